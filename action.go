@@ -0,0 +1,9 @@
+// Package action holds the definition of the GitHub Action.
+package action
+
+import _ "embed"
+
+// YAML is the action.yml of the action, which declares the inputs of the action.
+//
+//go:embed action.yml
+var YAML []byte
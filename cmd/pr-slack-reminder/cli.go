@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/reminderoptions"
+	"github.com/hellej/pr-slack-reminder-action/pkg/reminder"
+)
+
+// The defaults of action.yml that are not defaults of the config (the action runner applies them)
+var cliDefaultInputs = map[string]string{
+	config.InputPRListHeading:       "There are <pr_count> open PRs 👀",
+	config.InputOldPRThresholdHours: "96",
+}
+
+var errVersionRequested = errors.New("version requested")
+
+// inputFlags collects the repeated --input name=value flags
+type inputFlags map[string]string
+
+func (f inputFlags) String() string {
+	return fmt.Sprint(map[string]string(f))
+}
+
+func (f inputFlags) Set(value string) error {
+	name, inputValue, found := strings.Cut(value, "=")
+	if !found || name == "" {
+		return fmt.Errorf("invalid input %q, expected name=value", value)
+	}
+	f[name] = inputValue
+	return nil
+}
+
+// RunCLI runs the reminder with the inputs given as command line flags (e.g. locally or in
+// other CI systems than GitHub Actions) instead of INPUT_* environment variables.
+func RunCLI(
+	args []string,
+	getGitHubClient func(token, tokenForState string) githubclient.Client,
+	getSlackClient func(token string) slackclient.Client,
+) error {
+	opts, err := parseCLIFlags(args, getSlackClient)
+	if errors.Is(err, errVersionRequested) {
+		fmt.Printf(
+			"pr-slack-reminder %s (inputs schema version %d)\n", config.Version, config.InputsSchemaVersion,
+		)
+		return nil
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	r, err := reminder.New(append(
+		opts,
+		reminderoptions.WithGitHubClientGetter(getGitHubClient),
+		reminder.WithPrintedConfig(),
+	)...)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return r.Run(ctx)
+}
+
+func parseCLIFlags(
+	args []string, getSlackClient func(token string) slackclient.Client,
+) ([]reminder.Option, error) {
+	flags := flag.NewFlagSet("pr-slack-reminder", flag.ContinueOnError)
+	repos := flags.String("repos", "", "comma separated repositories (owner/repo) of which the PRs are included")
+	repository := flags.String(
+		"repository", "", "the current repository (owner/repo, GITHUB_REPOSITORY), the first of --repos by default",
+	)
+	channel := flags.String("channel", "", "name of the Slack channel")
+	channelID := flags.String("channel-id", "", "ID of the Slack channel (instead of --channel)")
+	mode := flags.String("mode", "", "run mode: post (default), update, event or sync")
+	githubToken := flags.String("github-token", os.Getenv("GITHUB_TOKEN"), "GitHub token ($GITHUB_TOKEN by default)")
+	slackBotToken := flags.String(
+		"slack-bot-token", os.Getenv("SLACK_BOT_TOKEN"), "Slack bot token ($SLACK_BOT_TOKEN by default)",
+	)
+	stateFile := flags.String("state-file", "", "path of the state file (STATE_FILE_PATH)")
+	dryRun := flags.Bool(
+		"dry-run", false, "log the Slack messages instead of sending them and save an HTML preview of them",
+	)
+	showVersion := flags.Bool("version", false, "print the version and the inputs schema version and exit")
+	inputs := inputFlags{}
+	flags.Var(&inputs, "input", "any input of action.yml as name=value (can be repeated)")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if *showVersion {
+		return nil, errVersionRequested
+	}
+	if flags.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+
+	opts := []reminder.Option{reminder.WithInputs(cliDefaultInputs), reminder.WithInputs(inputs)}
+	setInput := func(name, value string) {
+		if value != "" {
+			opts = append(opts, reminder.WithInput(name, value))
+		}
+	}
+	setInput(config.InputGithubToken, *githubToken)
+	setInput(config.InputSlackBotToken, *slackBotToken)
+	setInput(config.InputSlackChannelName, *channel)
+	setInput(config.InputSlackChannelID, *channelID)
+	setInput(config.InputRunMode, *mode)
+	if *dryRun {
+		setInput(config.InputDryRun, "true")
+	}
+
+	var repoList []string
+	for repo := range strings.SplitSeq(*repos, ",") {
+		if repo = strings.TrimSpace(repo); repo != "" {
+			repoList = append(repoList, repo)
+		}
+	}
+	if len(repoList) > 0 {
+		opts = append(opts, reminder.WithRepositories(repoList...))
+		if *repository == "" {
+			*repository = repoList[0]
+		}
+	}
+	if *repository != "" {
+		opts = append(opts, reminder.WithEnv(config.EnvGithubRepository, *repository))
+	}
+	if *stateFile != "" {
+		opts = append(opts, reminder.WithEnv(config.EnvStateFilePath, *stateFile))
+	}
+
+	return append(opts, reminderoptions.WithSlackClientGetter(getSlackClient)), nil
+}
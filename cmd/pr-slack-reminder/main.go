@@ -2,15 +2,23 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
 	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
 )
 
 func main() {
 	log.SetFlags(0)
-	log.Println("Starting PR Slack reminder action")
-	err := Run(githubclient.GetAuthenticatedClient, slackclient.GetAuthenticatedClient)
+	log.Printf("Starting PR Slack reminder action (version %s)", config.Version)
+	var err error
+	if len(os.Args) > 1 {
+		// flags are only given when run as a CLI, the action reads the inputs from the environment
+		err = RunCLI(os.Args[1:], githubclient.GetAuthenticatedClient, slackclient.GetAuthenticatedClient)
+	} else {
+		err = Run(githubclient.GetAuthenticatedClient, slackclient.GetAuthenticatedClient)
+	}
 	if err != nil {
 		log.Fatalf("%v", err)
 	}
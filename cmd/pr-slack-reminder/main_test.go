@@ -2,9 +2,14 @@ package main_test
 
 import (
 	"cmp"
+	"encoding/json"
 	"errors"
+	"maps"
 	"math"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
@@ -16,12 +21,19 @@ import (
 
 	"github.com/google/go-github/v78/github"
 	main "github.com/hellej/pr-slack-reminder-action/cmd/pr-slack-reminder"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/discordclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/googlechatclient"
 	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagecontent"
 	"github.com/hellej/pr-slack-reminder-action/internal/models"
 	"github.com/hellej/pr-slack-reminder-action/internal/state"
+	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
+	"github.com/hellej/pr-slack-reminder-action/pkg/blockids"
 	"github.com/hellej/pr-slack-reminder-action/testhelpers"
 	"github.com/hellej/pr-slack-reminder-action/testhelpers/mockgithubclient"
 	"github.com/hellej/pr-slack-reminder-action/testhelpers/mockslackclient"
+	"github.com/slack-go/slack"
 )
 
 type GetTestPROptions struct {
@@ -34,6 +46,11 @@ type GetTestPROptions struct {
 	Draft       *bool  // nil means unset, github.Ptr(true) means draft, github.Ptr(false) means not draft
 	State       string // "open", "closed"
 	Merged      bool   // true if PR is merged
+	// Teams and users from whom a review has been requested
+	RequestedTeams     []*github.Team
+	RequestedReviewers []string // logins
+	Milestone          string   // title of the milestone, unset if empty
+	NodeID             string   // GraphQL node ID, unset if empty
 }
 
 var now = time.Now()
@@ -63,6 +80,19 @@ func getTestPR(options GetTestPROptions) *github.PullRequest {
 
 	state := cmp.Or(options.State, "open")
 
+	var milestone *github.Milestone
+	if options.Milestone != "" {
+		milestone = &github.Milestone{Title: github.Ptr(options.Milestone)}
+	}
+	var nodeID *string
+	if options.NodeID != "" {
+		nodeID = github.Ptr(options.NodeID)
+	}
+	var requestedReviewers []*github.User
+	for _, login := range options.RequestedReviewers {
+		requestedReviewers = append(requestedReviewers, &github.User{Login: github.Ptr(login)})
+	}
+
 	return &github.PullRequest{
 		Number: &number,
 		Title:  &title,
@@ -70,11 +100,15 @@ func getTestPR(options GetTestPROptions) *github.PullRequest {
 			Login: &authorLogin,
 			Name:  &authorName,
 		},
-		Labels:    githubLabels,
-		CreatedAt: &github.Timestamp{Time: prTime},
-		Draft:     options.Draft,
-		State:     &state,
-		Merged:    &options.Merged,
+		Labels:             githubLabels,
+		CreatedAt:          &github.Timestamp{Time: prTime},
+		Draft:              options.Draft,
+		State:              &state,
+		Merged:             &options.Merged,
+		RequestedTeams:     options.RequestedTeams,
+		RequestedReviewers: requestedReviewers,
+		Milestone:          milestone,
+		NodeID:             nodeID,
 	}
 }
 
@@ -204,6 +238,7 @@ func TestScenarios(t *testing.T) {
 		prs                 []*github.PullRequest
 		prsByRepo           map[string][]*github.PullRequest
 		reviewsByPRNumber   map[int][]*github.PullRequestReview
+		teamMembersByTeam   map[string][]string
 		foundSlackChannels  []*mockslackclient.SlackChannel
 		findChannelError    error
 		sendMessageError    error
@@ -212,6 +247,8 @@ func TestScenarios(t *testing.T) {
 		expectedPRItemTexts []string
 		expectedSummary     string
 		expectedHeadings    []string // For group-by-repository mode to check repository headings
+		// For group-by-repository mode to check the repositories of the PR lists (by block IDs)
+		expectedPRListRepositories []string
 	}{
 		{
 			name:   "unset required inputs",
@@ -509,6 +546,121 @@ func TestScenarios(t *testing.T) {
 			},
 			expectedSummary: "2 open PRs are waiting for attention 👀",
 		},
+		{
+			name:   "old PR highlighting with a repository specific threshold",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputOldPRThresholdHours:       24,
+				config.InputRepositoryOldPRThresholds: "test-repo: 1",
+			},
+			prs: []*github.PullRequest{
+				getTestPR(GetTestPROptions{
+					Number:      1,
+					Title:       "Recent PR",
+					AuthorLogin: "alice",
+					AgeHours:    2,
+				}),
+				getTestPR(GetTestPROptions{
+					Number:      2,
+					Title:       "Very recent PR",
+					AuthorLogin: "bob",
+					AgeHours:    0.5,
+				}),
+			},
+			expectedPRNumbers: []int{1, 2},
+			expectedPRItemTexts: []string{
+				"Very recent PR 30 minutes ago by Bob",
+				"Recent PR 🚨 2 hours old by Alice",
+			},
+			expectedSummary: "2 open PRs are waiting for attention 👀",
+		},
+		{
+			name:   "PR ages at the reference time",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputOldPRThresholdHours: 24,
+				config.InputReferenceTime:       now.Add(48 * time.Hour).Format(time.RFC3339),
+			},
+			prs: []*github.PullRequest{
+				getTestPR(GetTestPROptions{Number: 1, Title: "Recent PR", AuthorLogin: "alice", AgeHours: 2}),
+			},
+			expectedPRNumbers:   []int{1},
+			expectedPRItemTexts: []string{"Recent PR 🚨 2 days old by Alice"},
+			expectedSummary:     "1 open PR is waiting for attention 👀",
+		},
+		{
+			name:   "only PRs of the team members",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputTeamMembers: "alice; test-org/backend",
+			},
+			teamMembersByTeam: map[string][]string{"test-org/backend": {"bob"}},
+			prs: []*github.PullRequest{
+				getTestPR(GetTestPROptions{Number: 1, Title: "PR by Alice", AuthorLogin: "alice"}),
+				getTestPR(GetTestPROptions{Number: 2, Title: "PR by Bob", AuthorLogin: "bob"}),
+				getTestPR(GetTestPROptions{
+					Number: 3, Title: "PR for backend", AuthorLogin: "carol",
+					RequestedTeams: []*github.Team{{Slug: github.Ptr("backend"), Name: github.Ptr("Backend")}},
+				}),
+				getTestPR(GetTestPROptions{
+					Number: 4, Title: "PR for web", AuthorLogin: "carol",
+					RequestedTeams: []*github.Team{{Slug: github.Ptr("web"), Name: github.Ptr("Web")}},
+				}),
+			},
+			expectedPRNumbers: []int{1, 2, 3},
+			expectedSummary:   "3 open PRs are waiting for attention 👀",
+		},
+		{
+			name:   "only PRs authored by the team members",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputTeamMembers:      "alice; test-org/backend",
+				config.InputTeamMembersMatch: "author",
+			},
+			teamMembersByTeam: map[string][]string{"test-org/backend": {"bob"}},
+			prs: []*github.PullRequest{
+				getTestPR(GetTestPROptions{Number: 1, Title: "PR by Alice", AuthorLogin: "alice"}),
+				getTestPR(GetTestPROptions{Number: 2, Title: "PR by Bob", AuthorLogin: "bob"}),
+				getTestPR(GetTestPROptions{
+					Number: 3, Title: "PR for backend", AuthorLogin: "carol",
+					RequestedTeams: []*github.Team{{Slug: github.Ptr("backend"), Name: github.Ptr("Backend")}},
+				}),
+			},
+			expectedPRNumbers: []int{1, 2},
+			expectedSummary:   "2 open PRs are waiting for attention 👀",
+		},
+		{
+			name:   "members of the team cannot be fetched",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputTeamMembers: "test-org/unknown",
+			},
+			prs: []*github.PullRequest{
+				getTestPR(GetTestPROptions{Number: 1, Title: "PR by Alice", AuthorLogin: "alice"}),
+			},
+			expectedErrorMsg: "error fetching members of team test-org/unknown",
+		},
+		{
+			name:   "old PR highlighting with escalating threshold tiers",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputOldPRThresholdHours: "24; 72; 168",
+			},
+			prs: []*github.PullRequest{
+				getTestPR(GetTestPROptions{Number: 1, Title: "Recent PR", AuthorLogin: "alice", AgeHours: 2}),
+				getTestPR(GetTestPROptions{Number: 2, Title: "Old PR", AuthorLogin: "bob", AgeHours: 48}),
+				getTestPR(GetTestPROptions{Number: 3, Title: "Older PR", AuthorLogin: "bob", AgeHours: 96}),
+				getTestPR(GetTestPROptions{Number: 4, Title: "Ancient PR", AuthorLogin: "alice", AgeHours: 240}),
+			},
+			expectedPRNumbers: []int{1, 2, 3, 4},
+			expectedPRItemTexts: []string{
+				"Recent PR 2 hours ago by Alice",
+				"Old PR ⚠️ 2 days old by Bob",
+				"Older PR 🚨 4 days old by Bob",
+				"Ancient PR 🔥 10 days old by Alice",
+			},
+			expectedSummary: "4 open PRs are waiting for attention 👀",
+		},
 		{
 			name:   "5 PRs of which some are approved and some are commented",
 			config: testhelpers.GetDefaultConfigMinimal(),
@@ -600,9 +752,10 @@ func TestScenarios(t *testing.T) {
 					getTestPR(GetTestPROptions{Number: 3, Title: "Another PR from repo2", AuthorLogin: "charlie"}),
 				},
 			},
-			expectedPRNumbers: []int{1, 2, 3},
-			expectedSummary:   "3 open PRs are waiting for attention 👀",
-			expectedHeadings:  []string{"Open PRs in org/repo1:", "Open PRs in org/repo2:"},
+			expectedPRNumbers:          []int{1, 2, 3},
+			expectedSummary:            "3 open PRs are waiting for attention 👀",
+			expectedHeadings:           []string{"Open PRs in org/repo1:", "Open PRs in org/repo2:"},
+			expectedPRListRepositories: []string{"org/repo1", "org/repo2"},
 		},
 		{
 			name:   "group by repository disabled with PR list heading required",
@@ -646,6 +799,30 @@ func TestScenarios(t *testing.T) {
 				"PR with bot and human reviewers 5 hours ago by Alice (💬 Human Reviewer)",
 			},
 		},
+		{
+			name:   "requested teams are mentioned with mapped Slack user groups",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputSlackGroupIdByGitHubTeam: "platform: S1234567890; test-org/mobile: S2234567890",
+			},
+			prs: []*github.PullRequest{
+				getTestPR(GetTestPROptions{
+					Number:      1,
+					Title:       "PR waiting for teams",
+					AuthorLogin: "alice",
+					RequestedTeams: []*github.Team{
+						{Slug: github.Ptr("platform"), Name: github.Ptr("Platform")},
+						{Slug: github.Ptr("mobile"), Name: github.Ptr("Mobile")},
+						{Slug: github.Ptr("web"), Name: github.Ptr("Web")},
+					},
+				}),
+			},
+			expectedPRNumbers: []int{1},
+			expectedSummary:   "1 open PR is waiting for attention 👀",
+			expectedPRItemTexts: []string{
+				"PR waiting for teams 5 hours ago by Alice (👥 S1234567890, S2234567890, Web)",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -659,6 +836,7 @@ func TestScenarios(t *testing.T) {
 				ReviewsByPRNumber:     tc.reviewsByPRNumber,
 				PRServiceError:        tc.prServiceError,
 				IssueServiceError:     tc.issueServiceError,
+				TeamMembersByTeam:     tc.teamMembersByTeam,
 			})
 			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{
 				SlackChannels:    tc.foundSlackChannels,
@@ -755,6 +933,14 @@ func TestScenarios(t *testing.T) {
 					}
 				}
 			}
+			if tc.expectedPRListRepositories != nil {
+				repositories := mockSlackAPI.SentMessage.Blocks.GetPRListRepositories()
+				if !slices.Equal(repositories, tc.expectedPRListRepositories) {
+					t.Errorf(
+						"Expected PR lists of repositories %v, got %v", tc.expectedPRListRepositories, repositories,
+					)
+				}
+			}
 		})
 	}
 }
@@ -820,110 +1006,3104 @@ func TestPostModeStateSaving(t *testing.T) {
 	}()
 }
 
-func TestScenariosUpdateMode(t *testing.T) {
+func TestPostModePRCountTrend(t *testing.T) {
 	testCases := []struct {
-		name                   string
-		config                 testhelpers.TestConfig
-		configOverrides        *map[string]any
-		mockState              *state.State
-		prByNumber             map[int]*github.PullRequest
-		fetchPRErrorByPRNumber map[int]error
-		reviewsByPRNumber      map[int][]*github.PullRequestReview
-		listArtifactsError     error
-		downloadArtifactError  error
-		updateMessageError     error
-		deleteMessageError     error
-		expectedErrorMsg       string
-		expectedPRItemTexts    []string
-		expectMessageDeleted   bool
+		name            string
+		mockState       *state.State
+		expectedSummary string
 	}{
 		{
-			name:   "unset required inputs",
-			config: testhelpers.GetDefaultConfigMinimal(),
-			configOverrides: &map[string]any{
-				config.InputSlackBotToken: nil,
-			},
-			expectedErrorMsg: "configuration error: required input slack-bot-token is not set",
+			name:            "no previous state",
+			expectedSummary: "2 open PRs are waiting for attention 👀",
 		},
 		{
-			name:   "update mode with empty state exits gracefully",
-			config: testhelpers.GetDefaultConfigMinimal(),
-			configOverrides: &map[string]any{
-				config.InputRunMode: config.RunModeUpdate,
-			},
-			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{}})),
+			name:            "PR count increased",
+			mockState:       testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1}})),
+			expectedSummary: "2 open PRs are waiting for attention 👀 (▲ 1 since last run)",
 		},
 		{
-			name:   "update mode with all PRs filtered out deletes message",
-			config: testhelpers.GetDefaultConfigMinimal(),
-			configOverrides: &map[string]any{
-				config.InputRunMode:       config.RunModeUpdate,
-				config.InputGlobalFilters: "{\"ignored-authors\": [\"alice\", \"bob\"]}",
-			},
-			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1, 2}})),
-			prByNumber: map[int]*github.PullRequest{
-				1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
-				2: getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob"}),
-			},
-			expectMessageDeleted: true,
+			name:            "PR count decreased",
+			mockState:       testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1, 2, 3, 4, 5}})),
+			expectedSummary: "2 open PRs are waiting for attention 👀 (▼ 3 since last run)",
 		},
 		{
-			name:   "update mode with all PRs filtered out and no-prs-message updates message",
-			config: testhelpers.GetDefaultConfigMinimal(),
-			configOverrides: &map[string]any{
-				config.InputRunMode:       config.RunModeUpdate,
-				config.InputGlobalFilters: "{\"ignored-authors\": [\"alice\", \"bob\"]}",
-				config.InputNoPRsMessage:  "All PRs have been resolved! 🎉",
-			},
-			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1, 2}})),
-			prByNumber: map[int]*github.PullRequest{
-				1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
-				2: getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob"}),
-			},
+			name:            "PR count unchanged",
+			mockState:       testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1, 2}})),
+			expectedSummary: "2 open PRs are waiting for attention 👀 (— no change since last run)",
 		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.EnvStateFilePath: filepath.Join(t.TempDir(), "pr-slack-reminder-state.json"),
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+					getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob"}),
+				},
+				MockStateForUpdateMode: tc.mockState,
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if mockSlackAPI.SentMessage.Text != tc.expectedSummary {
+				t.Errorf("Expected summary '%s', got '%s'", tc.expectedSummary, mockSlackAPI.SentMessage.Text)
+			}
+		})
+	}
+}
+
+func TestPostModeCountsReminders(t *testing.T) {
+	previousState := getTestState(GetTestStateOptions{PRNumbers: []int{1, 2}})
+	previousState.PullRequests[0].ReminderCount = 2
+
+	testCases := []struct {
+		name              string
+		showReminderCount bool
+		expectedPRItems   []string
+	}{
 		{
-			name:   "update mode with all PRs as drafts deletes message",
-			config: testhelpers.GetDefaultConfigMinimal(),
-			configOverrides: &map[string]any{
-				config.InputRunMode: config.RunModeUpdate,
-			},
-			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1, 2}})),
-			prByNumber: map[int]*github.PullRequest{
-				1: getTestPR(GetTestPROptions{Number: 1, Title: "Draft PR 1", AuthorLogin: "alice", Draft: github.Ptr(true)}),
-				2: getTestPR(GetTestPROptions{Number: 2, Title: "Draft PR 2", AuthorLogin: "bob", Draft: github.Ptr(true)}),
+			name: "reminder counts are not shown by default",
+			expectedPRItems: []string{
+				"Third PR 5 hours ago by Carol",
+				"Second PR 5 hours ago by Bob",
+				"First PR 5 hours ago by Alice",
 			},
-			expectMessageDeleted: true,
 		},
 		{
-			name:   "update mode handles delete failure gracefully",
-			config: testhelpers.GetDefaultConfigMinimal(),
-			configOverrides: &map[string]any{
-				config.InputRunMode:       config.RunModeUpdate,
-				config.InputGlobalFilters: "{\"ignored-authors\": [\"alice\"]}",
+			name:              "reminder counts of carried over PRs are shown if enabled",
+			showReminderCount: true,
+			expectedPRItems: []string{
+				"Third PR 5 hours ago by Carol",
+				"Second PR 5 hours ago (2nd reminder) by Bob",
+				"First PR 5 hours ago (3rd reminder) by Alice",
 			},
-			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1}})),
-			prByNumber: map[int]*github.PullRequest{
-				1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
-			},
-			deleteMessageError:   errors.New("slack delete failed"),
-			expectMessageDeleted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testStateFilePath := filepath.Join(t.TempDir(), "pr-slack-reminder-state.json")
+			configOverrides := map[string]any{
+				config.EnvStateFilePath:       testStateFilePath,
+				config.InputShowReminderCount: tc.showReminderCount,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5.2}),
+					getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob", AgeHours: 5.1}),
+					getTestPR(GetTestPROptions{Number: 3, Title: "Third PR", AuthorLogin: "carol", AgeHours: 5}),
+				},
+				MockStateForUpdateMode: &previousState,
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts()
+			if !slices.Equal(prItems, tc.expectedPRItems) {
+				t.Errorf("Expected PR items %v, got %v", tc.expectedPRItems, prItems)
+			}
+
+			var savedState state.State
+			if err := testhelpers.LoadJSONFromFile(testStateFilePath, &savedState); err != nil {
+				t.Fatalf("Failed to load saved state: %v", err)
+			}
+			var reminderCounts []int
+			for _, ref := range savedState.PullRequests {
+				reminderCounts = append(reminderCounts, ref.ReminderCount)
+			}
+			if !slices.Equal(reminderCounts, []int{1, 2, 3}) {
+				t.Errorf("Expected saved reminder counts [1 2 3], got %v", reminderCounts)
+			}
+		})
+	}
+}
+
+func TestPostModeSkipsArchivedRepositories(t *testing.T) {
+	testCases := []struct {
+		name                string
+		skipArchivedRepos   any
+		expectedPRNumbers   []int
+		expectedContextText []string
+	}{
+		{
+			name:                "archived repositories are skipped by default",
+			expectedPRNumbers:   []int{2},
+			expectedContextText: []string{"Skipped archived repositories: some-org/repo1"},
 		},
 		{
-			name:   "update mode with message_not_found error exits gracefully",
-			config: testhelpers.GetDefaultConfigMinimal(),
-			configOverrides: &map[string]any{
-				config.InputRunMode:       config.RunModeUpdate,
-				config.InputGlobalFilters: "{\"ignored-authors\": [\"alice\"]}",
+			name:              "archived repositories are included if skipping is disabled",
+			skipArchivedRepos: false,
+			expectedPRNumbers: []int{1, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputGithubRepositories: "some-org/repo1; some-org/repo2",
+				config.InputSkipArchivedRepos:  tc.skipArchivedRepos,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRsByRepo: map[string][]*github.PullRequest{
+					"repo1": {getTestPR(GetTestPROptions{Number: 1, Title: "PR in archived repo", AuthorLogin: "alice"})},
+					"repo2": {getTestPR(GetTestPROptions{Number: 2, Title: "PR in active repo", AuthorLogin: "bob"})},
+				},
+				ArchivedRepositories: []string{"repo1"},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			sentBlocks := mockSlackAPI.SentMessage.Blocks
+			if sentBlocks.GetPRCount() != len(tc.expectedPRNumbers) {
+				t.Errorf("Expected %d PRs in the message, got %d", len(tc.expectedPRNumbers), sentBlocks.GetPRCount())
+			}
+			if !slices.Equal(sentBlocks.GetContextTexts(), tc.expectedContextText) {
+				t.Errorf("Expected context texts %v, got %v", tc.expectedContextText, sentBlocks.GetContextTexts())
+			}
+		})
+	}
+}
+
+func TestPostModeShowsQuietRepositories(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		showQuietRepos       string
+		prsByRepo            map[string][]*github.PullRequest
+		expectedContextTexts []string
+		expectedQuietHeading string
+	}{
+		{
+			name:           "hidden",
+			showQuietRepos: "hide",
+			prsByRepo: map[string][]*github.PullRequest{
+				"repo1": {getTestPR(GetTestPROptions{Number: 1, Title: "PR in repo1"})},
 			},
-			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1}})),
-			prByNumber: map[int]*github.PullRequest{
-				1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+		},
+		{
+			name:           "in footer",
+			showQuietRepos: "footer",
+			prsByRepo: map[string][]*github.PullRequest{
+				"repo1": {getTestPR(GetTestPROptions{Number: 1, Title: "PR in repo1"})},
 			},
-			deleteMessageError:   errors.New("message_not_found"),
-			expectMessageDeleted: true,
+			expectedContextTexts: []string{"All clear in: some-org/repo2, some-org/repo3 ✅"},
 		},
 		{
-			name:   "update mode with two PRs with reviewers",
+			name:           "inline",
+			showQuietRepos: "inline",
+			prsByRepo: map[string][]*github.PullRequest{
+				"repo1": {getTestPR(GetTestPROptions{Number: 1, Title: "PR in repo1"})},
+			},
+			expectedQuietHeading: "Open PRs in some-org/repo2: All clear ✅",
+		},
+		{
+			name:           "not shown without any PRs",
+			showQuietRepos: "footer",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputGithubRepositories: "some-org/repo1; some-org/repo2; some-org/repo3",
+				config.InputGroupByRepository:  true,
+				config.InputShowQuietRepos:     tc.showQuietRepos,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRsByRepo: tc.prsByRepo,
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			blocks := mockSlackAPI.SentMessage.Blocks
+			if !slices.Equal(blocks.GetContextTexts(), tc.expectedContextTexts) {
+				t.Errorf("Expected context texts %v, got %v", tc.expectedContextTexts, blocks.GetContextTexts())
+			}
+			quietHeading := blocks.GetRichTextBlockText(blockids.RepositoryHeading("some-org/repo2"))
+			if quietHeading != tc.expectedQuietHeading {
+				t.Errorf("Expected heading of the quiet repository '%s', got '%s'", tc.expectedQuietHeading, quietHeading)
+			}
+			if repositories := blocks.GetPRListRepositories(); len(tc.prsByRepo) > 0 &&
+				!slices.Equal(repositories, []string{"some-org/repo1"}) {
+				t.Errorf("Expected only the PR list of some-org/repo1, got %v", repositories)
+			}
+		})
+	}
+}
+
+func TestPostModeShowsRepositoryHeadingPrefixes(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputGithubRepositories:  "some-org/repo1; some-org/repo2; some-org/repo3",
+		config.InputGroupByRepository:   true,
+		config.InputShowQuietRepos:      "inline",
+		config.InputRepoHeadingPrefixes: "repo1: 🛠; some-org/repo2: 📱",
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRsByRepo: map[string][]*github.PullRequest{
+			"repo1": {getTestPR(GetTestPROptions{Number: 1, Title: "PR in repo1"})},
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expectedHeadings := map[string]string{
+		"some-org/repo1": "🛠 Open PRs in some-org/repo1:",
+		"some-org/repo2": "📱 Open PRs in some-org/repo2: All clear ✅",
+		"some-org/repo3": "Open PRs in some-org/repo3: All clear ✅",
+	}
+	for repo, expectedHeading := range expectedHeadings {
+		heading := mockSlackAPI.SentMessage.Blocks.GetRichTextBlockText(blockids.RepositoryHeading(repo))
+		if heading != expectedHeading {
+			t.Errorf("Expected heading of %s '%s', got '%s'", repo, expectedHeading, heading)
+		}
+	}
+}
+
+func TestPostModeShowsFailingWorkflows(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		showFailingWorkflows bool
+		expectSection        bool
+	}{
+		{name: "failing workflows are not shown by default"},
+		{name: "failing workflows are shown if enabled", showFailingWorkflows: true, expectSection: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputShowFailingWorkflows: tc.showFailingWorkflows,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+				},
+				WorkflowRunsByRepo: map[string][]*github.WorkflowRun{
+					"test-repo": {{
+						WorkflowID: github.Ptr(int64(1)),
+						Name:       github.Ptr("Nightly build"),
+						Conclusion: github.Ptr("failure"),
+						HTMLURL:    github.Ptr("https://github.com/test-org/test-repo/actions/runs/1"),
+					}},
+				},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			hasSection := slices.ContainsFunc(mockSlackAPI.SentMessage.Blocks.Blocks, func(b mockslackclient.Block) bool {
+				return b.BlockID == "failing_workflows"
+			})
+			if hasSection != tc.expectSection {
+				t.Errorf("Expected failing workflows section: %v, got: %v", tc.expectSection, hasSection)
+			}
+			if mockSlackAPI.SentMessage.Blocks.GetPRCount() != 1 {
+				t.Errorf("Expected 1 PR in the message, got %d", mockSlackAPI.SentMessage.Blocks.GetPRCount())
+			}
+		})
+	}
+}
+
+func TestPostModeWarnsAboutRepositoriesWithoutRequiredReviews(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		auditBranchProtection bool
+		expectedContextText   []string
+	}{
+		{name: "branch protection is not audited by default"},
+		{
+			name:                  "repositories without required reviews are warned about if enabled",
+			auditBranchProtection: true,
+			expectedContextText:   []string{"⚠️ PR reviews are not required on the default branch of: some-org/repo2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputGithubRepositories:    "some-org/repo1; some-org/repo2",
+				config.InputAuditBranchProtection: tc.auditBranchProtection,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRsByRepo: map[string][]*github.PullRequest{
+					"repo1": {getTestPR(GetTestPROptions{Number: 1, AuthorLogin: "alice"})},
+					"repo2": {getTestPR(GetTestPROptions{Number: 2, AuthorLogin: "bob"})},
+				},
+				UnprotectedRepositories: []string{"repo2"},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			sentBlocks := mockSlackAPI.SentMessage.Blocks
+			if !slices.Equal(sentBlocks.GetContextTexts(), tc.expectedContextText) {
+				t.Errorf("Expected context texts %v, got %v", tc.expectedContextText, sentBlocks.GetContextTexts())
+			}
+			if sentBlocks.GetPRCount() != 2 {
+				t.Errorf("Expected 2 PRs in the message, got %d", sentBlocks.GetPRCount())
+			}
+		})
+	}
+}
+
+func TestPostModeShowsPRsBlockedByFailingChecks(t *testing.T) {
+	testCases := []struct {
+		name              string
+		showFailingChecks bool
+		expectedHeading   string
+	}{
+		{
+			name:            "failing checks are not shown by default",
+			expectedHeading: "Open PRs in test-org/test-repo:",
+		},
+		{
+			name:              "failing checks are shown in the repository heading if enabled",
+			showFailingChecks: true,
+			expectedHeading:   "Open PRs in test-org/test-repo: 2 PRs blocked by failing checks",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputGroupByRepository: true,
+				config.InputShowFailingChecks: tc.showFailingChecks,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			prs := []*github.PullRequest{
+				getTestPR(GetTestPROptions{Number: 1, AuthorLogin: "alice"}),
+				getTestPR(GetTestPROptions{Number: 2, AuthorLogin: "alice"}),
+				getTestPR(GetTestPROptions{Number: 3, AuthorLogin: "alice"}),
+			}
+			for _, pr := range prs {
+				pr.Head = &github.PullRequestBranch{SHA: github.Ptr("sha-" + strconv.Itoa(pr.GetNumber()))}
+			}
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: prs,
+				CheckRunsBySHA: map[string][]*github.CheckRun{
+					"sha-1": {{Conclusion: github.Ptr("success")}},
+					"sha-2": {{Conclusion: github.Ptr("success")}, {Conclusion: github.Ptr("failure")}},
+					"sha-3": {{Conclusion: github.Ptr("timed_out")}},
+				},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if !mockSlackAPI.SentMessage.Blocks.ContainsHeading(tc.expectedHeading) {
+				t.Errorf(
+					"Expected heading '%s', got PR lists: %v", tc.expectedHeading, mockSlackAPI.SentMessage.Blocks.GetPRLists(),
+				)
+			}
+		})
+	}
+}
+
+func TestPostModeIsReproducibleWithFixedTime(t *testing.T) {
+	fixedTime := now.Add(time.Hour).UTC().Truncate(time.Second)
+	getPRs := func(numbers ...int) []*github.PullRequest {
+		var prs []*github.PullRequest
+		for _, number := range numbers {
+			prs = append(prs, getTestPR(GetTestPROptions{
+				Number: number, Title: "PR " + strconv.Itoa(number), AuthorLogin: "alice", Labels: []string{"feature"},
+			}))
+		}
+		return prs
+	}
+	runWithPRs := func(prsByRepo map[string][]*github.PullRequest) ([]byte, []string) {
+		sentBlocksFilePath := filepath.Join(t.TempDir(), "sent-blocks.json")
+		configOverrides := map[string]any{
+			config.InputGithubRepositories:    "some-org/repo1; some-org/repo2",
+			config.EnvFixedTime:               fixedTime.Format(time.RFC3339),
+			config.EnvSentSlackBlocksFilePath: sentBlocksFilePath,
+		}
+		testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+		getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+			PRsByRepo: prsByRepo,
+		})
+		mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+		if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		sentBlocks, err := os.ReadFile(sentBlocksFilePath)
+		if err != nil {
+			t.Fatalf("Expected the sent blocks to be saved, got: %v", err)
+		}
+		return sentBlocks, mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts()
+	}
+
+	// PRs of the same age are fetched in a different order
+	sentBlocks, prItems := runWithPRs(map[string][]*github.PullRequest{"repo1": getPRs(1, 2), "repo2": getPRs(3)})
+	sentBlocksAgain, _ := runWithPRs(map[string][]*github.PullRequest{"repo1": getPRs(2, 1), "repo2": getPRs(3)})
+
+	if string(sentBlocks) != string(sentBlocksAgain) {
+		t.Errorf("Expected the same sent blocks in both runs, got:\n%s\nand:\n%s", sentBlocks, sentBlocksAgain)
+	}
+	expectedPRItems := []string{"PR 1 6 hours ago by Alice", "PR 2 6 hours ago by Alice", "PR 3 6 hours ago by Alice"}
+	if !slices.Equal(prItems, expectedPRItems) {
+		t.Errorf("Expected PR items %v, got %v", expectedPRItems, prItems)
+	}
+	var envelope state.SentBlocksEnvelope
+	if err := json.Unmarshal(sentBlocks, &envelope); err != nil {
+		t.Fatalf("Expected the sent blocks to be valid JSON, got: %v", err)
+	}
+	if !envelope.GeneratedAt.Equal(fixedTime) {
+		t.Errorf("Expected the fixed time %v as generatedAt, got %v", fixedTime, envelope.GeneratedAt)
+	}
+}
+
+func TestPostModeHighlightsAuthors(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputHighlightAuthors: "Alice;carol",
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{
+			getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5.2}),
+			getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob", AgeHours: 5.1}),
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expectedPRItems := []string{
+		"Second PR 5 hours ago by Bob",
+		"⭐ First PR 5 hours ago by Alice",
+	}
+	if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, expectedPRItems) {
+		t.Errorf("Expected PR items %v, got %v", expectedPRItems, prItems)
+	}
+}
+
+func TestPostModeAppliesSummaryTone(t *testing.T) {
+	summaryTones := `{
+		"ok": {"summary": "All good, <pr_count> open PRs"},
+		"warn": {"min-prs": 3, "summary": "<pr_count> PRs are piling up", "emoji": "⚠️"},
+		"critical": {"min-prs": 5, "summary": "<pr_count> PRs need attention now!", "emoji": "🔥"}
+	}`
+	testCases := []struct {
+		name            string
+		prCount         int
+		summaryTones    string
+		expectedSummary string
+		expectedHeading string
+	}{
+		{
+			name:            "default summary without tones",
+			prCount:         3,
+			expectedSummary: "3 open PRs are waiting for attention 👀",
+			expectedHeading: "There are 3 open PRs 🚀",
+		},
+		{
+			name:            "ok tone",
+			prCount:         2,
+			summaryTones:    summaryTones,
+			expectedSummary: "All good, 2 open PRs",
+			expectedHeading: "There are 2 open PRs 🚀",
+		},
+		{
+			name:            "warn tone",
+			prCount:         3,
+			summaryTones:    summaryTones,
+			expectedSummary: "3 PRs are piling up",
+			expectedHeading: "⚠️ There are 3 open PRs 🚀",
+		},
+		{
+			name:            "critical tone",
+			prCount:         5,
+			summaryTones:    summaryTones,
+			expectedSummary: "5 PRs need attention now!",
+			expectedHeading: "🔥 There are 5 open PRs 🚀",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputSummaryTones: tc.summaryTones,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			var prs []*github.PullRequest
+			for i := 1; i <= tc.prCount; i++ {
+				prs = append(prs, getTestPR(GetTestPROptions{Number: i, AuthorLogin: "alice"}))
+			}
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{PRs: prs})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if mockSlackAPI.SentMessage.Text != tc.expectedSummary {
+				t.Errorf("Expected summary '%s', got '%s'", tc.expectedSummary, mockSlackAPI.SentMessage.Text)
+			}
+			if !mockSlackAPI.SentMessage.Blocks.ContainsHeading(tc.expectedHeading) {
+				t.Errorf(
+					"Expected heading '%s', got PR lists: %v", tc.expectedHeading, mockSlackAPI.SentMessage.Blocks.GetPRLists(),
+				)
+			}
+		})
+	}
+}
+
+func TestPostModeShowsSeverityColor(t *testing.T) {
+	testCases := []struct {
+		name               string
+		severityThresholds string
+		summaryTones       string
+		oldPRCount         int
+		expectedColor      string
+	}{
+		{name: "no color bar by default", oldPRCount: 3, expectedColor: ""},
+		{name: "green without old PRs", severityThresholds: "1,3", oldPRCount: 0, expectedColor: config.SeverityColorOK},
+		{name: "yellow", severityThresholds: "1,3", oldPRCount: 2, expectedColor: config.SeverityColorWarn},
+		{name: "red", severityThresholds: "1,3", oldPRCount: 3, expectedColor: config.SeverityColorCritical},
+		{
+			name:               "color of the summary tone overrides the severity color",
+			severityThresholds: "1,3",
+			summaryTones:       `{"critical": {"min-old-prs": 3, "color": "#e01e5a"}}`,
+			oldPRCount:         3,
+			expectedColor:      "#e01e5a",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputOldPRThresholdHours: 24,
+				config.InputSeverityThresholds:  tc.severityThresholds,
+				config.InputSummaryTones:        tc.summaryTones,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			prs := []*github.PullRequest{
+				getTestPR(GetTestPROptions{Number: 1, AuthorLogin: "alice", AgeHours: 2}),
+			}
+			for i := 1; i <= tc.oldPRCount; i++ {
+				prs = append(prs, getTestPR(GetTestPROptions{Number: 1 + i, AuthorLogin: "bob", AgeHours: 48}))
+			}
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{PRs: prs})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if mockSlackAPI.SentMessage.Color != tc.expectedColor {
+				t.Errorf("Expected color '%s', got '%s'", tc.expectedColor, mockSlackAPI.SentMessage.Color)
+			}
+			if prCount := mockSlackAPI.SentMessage.Blocks.GetPRCount(); prCount != 1+tc.oldPRCount {
+				t.Errorf("Expected %d PRs in the sent blocks, got %d", 1+tc.oldPRCount, prCount)
+			}
+		})
+	}
+}
+
+func TestPostModeReportsReviewSLABreaches(t *testing.T) {
+	reviewedAfter := func(pr *github.PullRequest, hours int, login string) *github.PullRequestReview {
+		review := mockgithubclient.NewReview(int64(pr.GetNumber()), "APPROVED", login, "", "")
+		review.SubmittedAt = &github.Timestamp{Time: pr.GetCreatedAt().Add(time.Duration(hours) * time.Hour)}
+		return review
+	}
+	reviewedInTime := getTestPR(GetTestPROptions{Number: 1, AuthorLogin: "alice", AgeHours: 72})
+	reviewedLate := getTestPR(GetTestPROptions{Number: 2, AuthorLogin: "alice", AgeHours: 72})
+	onlySelfReviewed := getTestPR(GetTestPROptions{Number: 3, AuthorLogin: "alice", AgeHours: 48})
+	notReviewedYet := getTestPR(GetTestPROptions{Number: 4, AuthorLogin: "alice", AgeHours: 2})
+
+	configOverrides := map[string]any{config.InputReviewSLAHours: 24}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{reviewedInTime, reviewedLate, onlySelfReviewed, notReviewedYet},
+		ReviewsByPRNumber: map[int][]*github.PullRequestReview{
+			1: {reviewedAfter(reviewedInTime, 30, "bob"), reviewedAfter(reviewedInTime, 3, "carol")},
+			2: {reviewedAfter(reviewedLate, 30, "bob")},
+			3: {reviewedAfter(onlySelfReviewed, 1, "alice")},
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expectedSummary := "4 open PRs are waiting for attention 👀 (2 of 4 PRs breached the 24h review SLA)"
+	if mockSlackAPI.SentMessage.Text != expectedSummary {
+		t.Errorf("Expected summary '%s', got '%s'", expectedSummary, mockSlackAPI.SentMessage.Text)
+	}
+}
+
+func TestPostModeIgnoresReviewers(t *testing.T) {
+	testCases := []struct {
+		name             string
+		ignoredReviewers any
+		inputName        string
+		expectedPRItems  []string
+	}{
+		{
+			name:            "all reviewers are shown by default",
+			expectedPRItems: []string{"First PR 5 hours ago by Alice (✅ bob, lead / 💬 ci-account)"},
+		},
+		{
+			name:             "ignored reviewers are not shown",
+			ignoredReviewers: "Lead;ci-account",
+			expectedPRItems:  []string{"First PR 5 hours ago by Alice (✅ bob)"},
+		},
+		{
+			name:             "ignored reviewers are read from the deprecated reviewers-ignore",
+			ignoredReviewers: "Lead;ci-account",
+			inputName:        config.InputReviewersIgnore,
+			expectedPRItems:  []string{"First PR 5 hours ago by Alice (✅ bob)"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inputName := config.InputIgnoredReviewers
+			if tc.inputName != "" {
+				inputName = tc.inputName
+			}
+			configOverrides := map[string]any{inputName: tc.ignoredReviewers}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5}),
+				},
+				ReviewsByPRNumber: map[int][]*github.PullRequestReview{
+					1: {
+						mockgithubclient.NewReview(1, "APPROVED", "lead", "", ""),
+						mockgithubclient.NewReview(2, "APPROVED", "bob", "", ""),
+						mockgithubclient.NewReview(3, "COMMENTED", "ci-account", "", "LGTM"),
+					},
+				},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, tc.expectedPRItems) {
+				t.Errorf("Expected PR items %v, got %v", tc.expectedPRItems, prItems)
+			}
+		})
+	}
+}
+
+func TestPostModeShowsSilentReviewers(t *testing.T) {
+	testCases := []struct {
+		name                string
+		showSilentReviewers string
+		botAuthors          string
+		expectedPRItems     []string
+	}{
+		{
+			name:            "silent reviewers are not shown by default",
+			expectedPRItems: []string{"First PR 5 hours ago by Alice (✅ bob / 💬 carol)"},
+		},
+		{
+			name:                "requested reviewers without reviews or comments are shown if enabled",
+			showSilentReviewers: "true",
+			expectedPRItems:     []string{"First PR 5 hours ago by Alice (✅ bob / 💬 carol / ⏳ dave, erin)"},
+		},
+		{
+			name:                "requested reviewers configured as bots are not shown",
+			showSilentReviewers: "true",
+			botAuthors:          "dave",
+			expectedPRItems:     []string{"First PR 5 hours ago by Alice (✅ bob / 💬 carol / ⏳ erin)"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputShowSilentReviewers: tc.showSilentReviewers,
+				config.InputBotAuthors:          tc.botAuthors,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{
+						Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5,
+						// bob was re-requested after approving
+						RequestedReviewers: []string{"erin", "bob", "dave"},
+					}),
+				},
+				ReviewsByPRNumber: map[int][]*github.PullRequestReview{
+					1: {
+						mockgithubclient.NewReview(1, "APPROVED", "bob", "", ""),
+						mockgithubclient.NewReview(2, "COMMENTED", "carol", "", "Why?"),
+					},
+				},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, tc.expectedPRItems) {
+				t.Errorf("Expected PR items %v, got %v", tc.expectedPRItems, prItems)
+			}
+		})
+	}
+}
+
+func TestPostModeShowsCommentCount(t *testing.T) {
+	testCases := []struct {
+		name             string
+		showCommentCount string
+		expectedPRItems  []string
+	}{
+		{
+			name: "comment count is not shown by default",
+			expectedPRItems: []string{
+				"Second PR 3 hours ago by Alice",
+				"First PR 5 hours ago by Alice (💬 bob, carol)",
+			},
+		},
+		{
+			name:             "comments by users are counted if enabled",
+			showCommentCount: "true",
+			expectedPRItems: []string{
+				"Second PR 3 hours ago by Alice",
+				"First PR 5 hours ago by Alice (💬 bob, carol) (3 comments)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{config.InputShowCommentCount: tc.showCommentCount}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5}),
+					getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "alice", AgeHours: 3}),
+				},
+				CommentsByPRNumber: map[int][]*github.PullRequestComment{
+					1: {
+						mockgithubclient.NewComment(1, "bob", "", "Why?"),
+						mockgithubclient.NewComment(2, "alice", "", "Because"),
+						mockgithubclient.NewComment(3, "carol", "", "Makes sense"),
+						mockgithubclient.NewComment(4, "linter", "", "Lint failed", "Bot"),
+					},
+				},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, tc.expectedPRItems) {
+				t.Errorf("Expected PR items %v, got %v", tc.expectedPRItems, prItems)
+			}
+		})
+	}
+}
+
+func TestPostModeBotAccounts(t *testing.T) {
+	testCases := []struct {
+		name            string
+		botAuthors      any
+		humanBots       any
+		expectedPRItems []string
+	}{
+		{
+			name: "users of type Bot are ignored by default",
+			expectedPRItems: []string{
+				"Second PR 3 hours ago by Deploy-User",
+				"First PR 5 hours ago by Alice (✅ deploy-user)",
+			},
+		},
+		{
+			name:       "bot authors are ignored as reviewers and their PRs are not listed",
+			botAuthors: "Deploy-User",
+			expectedPRItems: []string{
+				"First PR 5 hours ago by Alice",
+			},
+		},
+		{
+			name:      "reviews of human bots are shown",
+			humanBots: "review-assistant[bot]",
+			expectedPRItems: []string{
+				"Second PR 3 hours ago by Deploy-User",
+				"First PR 5 hours ago by Alice (✅ deploy-user / 💬 review-assistant[bot])",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputBotAuthors: tc.botAuthors,
+				config.InputHumanBots:  tc.humanBots,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5}),
+					getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "deploy-user", AgeHours: 3}),
+				},
+				ReviewsByPRNumber: map[int][]*github.PullRequestReview{
+					1: {
+						mockgithubclient.NewReview(1, "APPROVED", "deploy-user", "", ""),
+						mockgithubclient.NewReview(2, "COMMENTED", "review-assistant[bot]", "", "Looks good", "Bot"),
+					},
+				},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, tc.expectedPRItems) {
+				t.Errorf("Expected PR items %v, got %v", tc.expectedPRItems, prItems)
+			}
+		})
+	}
+}
+
+func TestPostModeShowsCodeownerApproval(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		showCodeownerApproval any
+		expectedPRItems       []string
+	}{
+		{
+			name: "code owner approval is not shown by default",
+			expectedPRItems: []string{
+				"Second PR 3 hours ago by Bob (✅ alice)",
+				"First PR 5 hours ago by Alice (✅ lead)",
+			},
+		},
+		{
+			name:                  "code owner approval is shown if enabled",
+			showCodeownerApproval: "true",
+			expectedPRItems: []string{
+				"Second PR 3 hours ago by Bob (✅ alice)",
+				"First PR 5 hours ago by Alice (✅ lead) 👑 owner-approved",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{config.InputShowCodeownerApproval: tc.showCodeownerApproval}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5}),
+					getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob", AgeHours: 3}),
+				},
+				ReviewsByPRNumber: map[int][]*github.PullRequestReview{
+					1: {mockgithubclient.NewReview(1, "APPROVED", "lead", "", "")},
+					2: {mockgithubclient.NewReview(2, "APPROVED", "alice", "", "")},
+				},
+				CodeownersByRepo:       map[string]string{"test-repo": "*.go @lead\n/docs/ @alice\n"},
+				ChangedFilesByPRNumber: map[int][]string{1: {"main.go"}, 2: {"main.go"}},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, tc.expectedPRItems) {
+				t.Errorf("Expected PR items %v, got %v", tc.expectedPRItems, prItems)
+			}
+		})
+	}
+}
+
+func TestPostModeShowsReviewDecision(t *testing.T) {
+	testCases := []struct {
+		name               string
+		showReviewDecision any
+		expectedPRItems    []string
+	}{
+		{
+			name: "review decision is not shown by default",
+			expectedPRItems: []string{
+				"Third PR 2 hours ago by Carol",
+				"Second PR 3 hours ago by Bob (💬 alice)",
+				"First PR 5 hours ago by Alice (✅ lead)",
+			},
+		},
+		{
+			name:               "review decision is shown if enabled",
+			showReviewDecision: "true",
+			expectedPRItems: []string{
+				"Third PR 2 hours ago by Carol",
+				"Second PR 3 hours ago by Bob (💬 alice) 🔴 changes requested",
+				// the approval of lead has been dismissed
+				"First PR 5 hours ago by Alice (✅ lead) 🟡 review required",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{config.InputShowReviewDecision: tc.showReviewDecision}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5, NodeID: "PR_1"}),
+					getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob", AgeHours: 3, NodeID: "PR_2"}),
+					getTestPR(GetTestPROptions{Number: 3, Title: "Third PR", AuthorLogin: "carol", AgeHours: 2, NodeID: "PR_3"}),
+				},
+				ReviewsByPRNumber: map[int][]*github.PullRequestReview{
+					1: {mockgithubclient.NewReview(1, "APPROVED", "lead", "", "")},
+					2: {mockgithubclient.NewReview(2, "CHANGES_REQUESTED", "alice", "", "Please fix")},
+				},
+				ReviewDecisionByNodeID: map[string]string{"PR_1": "REVIEW_REQUIRED", "PR_2": "CHANGES_REQUESTED"},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, tc.expectedPRItems) {
+				t.Errorf("Expected PR items %v, got %v", tc.expectedPRItems, prItems)
+			}
+		})
+	}
+}
+
+func TestPostModeShowsReviewEffort(t *testing.T) {
+	testCases := []struct {
+		name             string
+		showReviewEffort string
+		expectedPRItems  []string
+	}{
+		{
+			name: "review effort is not shown by default",
+			expectedPRItems: []string{
+				"Third PR 2 hours ago by Carol",
+				"Second PR 3 hours ago by Bob",
+				"First PR 5 hours ago by Alice",
+			},
+		},
+		{
+			name:             "review effort is shown if enabled",
+			showReviewEffort: "true",
+			expectedPRItems: []string{
+				"Third PR 2 hours ago by Carol ● high effort",
+				"Second PR 3 hours ago by Bob ◑ medium effort",
+				"First PR 5 hours ago by Alice ◔ low effort",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{config.InputShowReviewEffort: tc.showReviewEffort}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5, NodeID: "PR_1"}),
+					getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob", AgeHours: 3, NodeID: "PR_2"}),
+					getTestPR(GetTestPROptions{Number: 3, Title: "Third PR", AuthorLogin: "carol", AgeHours: 2, NodeID: "PR_3"}),
+				},
+				PRStatsByNodeID: map[string]models.PullRequestStats{
+					"PR_1": {ChangedFiles: 1, Additions: 10, Deletions: 2},
+					"PR_2": {ChangedFiles: 4, Additions: 100, Deletions: 20, Comments: 3},
+					"PR_3": {ChangedFiles: 30, Additions: 500, Deletions: 100},
+				},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, tc.expectedPRItems) {
+				t.Errorf("Expected PR items %v, got %v", tc.expectedPRItems, prItems)
+			}
+		})
+	}
+}
+
+func TestPostModeShowsReviewResponseTime(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		showReviewResponseTime string
+		expectedPRItems        []string
+	}{
+		{
+			name: "review response time is not shown by default",
+			expectedPRItems: []string{
+				"Third PR 2 hours ago by Carol",
+				"Second PR 3 days ago by Bob",
+				"First PR 4 days ago by Alice",
+			},
+		},
+		{
+			name:                   "review response time is shown if enabled",
+			showReviewResponseTime: "true",
+			expectedPRItems: []string{
+				"Third PR 2 hours ago by Carol",
+				"Second PR 3 days ago by Bob ⏱ review requested 2 days ago, no response",
+				"First PR 4 days ago by Alice ⏱ first review in 5 hours",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{config.InputShowReviewResponseTime: tc.showReviewResponseTime}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 96, NodeID: "PR_1"}),
+					getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob", AgeHours: 72, NodeID: "PR_2"}),
+					// no review has been requested
+					getTestPR(GetTestPROptions{Number: 3, Title: "Third PR", AuthorLogin: "carol", AgeHours: 2, NodeID: "PR_3"}),
+				},
+				ReviewResponseByNodeID: map[string]models.PullRequestReviewResponse{
+					"PR_1": {RequestedAt: now.Add(-90 * time.Hour), RespondedAt: github.Ptr(now.Add(-85 * time.Hour))},
+					"PR_2": {RequestedAt: now.Add(-49 * time.Hour)},
+				},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, tc.expectedPRItems) {
+				t.Errorf("Expected PR items %v, got %v", tc.expectedPRItems, prItems)
+			}
+		})
+	}
+}
+
+func TestPostModeShowsQuickLinks(t *testing.T) {
+	testCases := []struct {
+		name           string
+		showQuickLinks string
+		expectedPRItem string
+	}{
+		{name: "quick links are not shown by default", expectedPRItem: "First PR 5 hours ago by Alice"},
+		{name: "quick links are shown if enabled", showQuickLinks: "true", expectedPRItem: "First PR 5 hours ago by Alice [files] [checks]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{config.InputShowQuickLinks: tc.showQuickLinks}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			pr := getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5})
+			pr.HTMLURL = github.Ptr("https://github.com/test-org/test-repo/pull/1")
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{pr},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, []string{tc.expectedPRItem}) {
+				t.Errorf("Expected PR items %v, got %v", []string{tc.expectedPRItem}, prItems)
+			}
+			sentBlocks, err := json.Marshal(mockSlackAPI.SentMessage.Blocks)
+			if err != nil {
+				t.Fatalf("Failed to marshal the sent blocks: %v", err)
+			}
+			hasFilesLink := strings.Contains(string(sentBlocks), "/pull/1/files")
+			if hasFilesLink != (tc.showQuickLinks != "") {
+				t.Errorf("Expected the files link to be sent: %v, got: %s", tc.showQuickLinks != "", sentBlocks)
+			}
+		})
+	}
+}
+
+func TestPostModeRedactsSecretsInTitles(t *testing.T) {
+	// the fake token is built at runtime so that it is not flagged by secret scanners
+	title := "Use token ghp_" + strings.Repeat("a1B2", 9) + " in CI"
+	testCases := []struct {
+		name           string
+		redactSecrets  string
+		expectedPRItem string
+	}{
+		{name: "titles are not redacted by default", expectedPRItem: title + " 5 hours ago by Alice"},
+		{name: "secrets are redacted if enabled", redactSecrets: "true", expectedPRItem: "Use token [redacted] in CI 5 hours ago by Alice"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{config.InputRedactSecretsInTitles: tc.redactSecrets}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: title, AuthorLogin: "alice", AgeHours: 5}),
+				},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, []string{tc.expectedPRItem}) {
+				t.Errorf("Expected PR items %v, got %v", []string{tc.expectedPRItem}, prItems)
+			}
+		})
+	}
+}
+
+func TestPostModeDigest(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputGithubRepositories:  "org/repo1; org/repo2",
+		config.InputMessageStyle:        "digest",
+		config.InputOldPRThresholdHours: 24,
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRsByRepo: map[string][]*github.PullRequest{
+			"repo1": {
+				getTestPR(GetTestPROptions{Number: 1, Title: "PR from repo1", AuthorLogin: "alice", AgeHours: 5}),
+			},
+			"repo2": {
+				getTestPR(GetTestPROptions{Number: 2, Title: "Old PR from repo2", AuthorLogin: "bob", AgeHours: 48}),
+				getTestPR(GetTestPROptions{Number: 3, Title: "PR from repo2", AuthorLogin: "carol", AgeHours: 5}),
+			},
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); len(prItems) != 0 {
+		t.Errorf("Expected no PRs to be listed, got %v", prItems)
+	}
+	expectedLines := []string{
+		"org/repo1: 1 open → pull requests",
+		"org/repo2: 2 open (1 stale) → pull requests",
+	}
+	if lines := mockSlackAPI.SentMessage.Blocks.GetListItemTexts(blockids.RepositoryDigest); !slices.Equal(lines, expectedLines) {
+		t.Errorf("Expected digest lines %v, got %v", expectedLines, lines)
+	}
+	if expectedSummary := "3 open PRs are waiting for attention 👀"; mockSlackAPI.SentMessage.Text != expectedSummary {
+		t.Errorf("Expected summary '%s', got '%s'", expectedSummary, mockSlackAPI.SentMessage.Text)
+	}
+}
+
+func TestPostModeNotifiesFailure(t *testing.T) {
+	testCases := []struct {
+		name             string
+		prServiceError   error
+		sendMessageError error
+		expectedCategory string // empty if no notification is expected
+	}{
+		{
+			name: "run succeeds",
+		},
+		{
+			name:             "sending the message fails",
+			sendMessageError: slack.SlackErrorResponse{Err: "not_in_channel"},
+			expectedCategory: "Slack API",
+		},
+		{
+			name:           "fetching the PRs fails before building the message",
+			prServiceError: errors.New("unable to fetch PRs"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var receivedNotification map[string]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&receivedNotification); err != nil {
+					t.Errorf("Failed to decode the failure notification: %v", err)
+				}
+			}))
+			defer server.Close()
+			configOverrides := map[string]any{
+				config.InputOnFailureWebhook: server.URL,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+				},
+				PRServiceError: tc.prServiceError,
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{
+				PostMessageError: tc.sendMessageError,
+			})
+			err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+
+			failureExpected := tc.prServiceError != nil || tc.sendMessageError != nil
+			if failureExpected != (err != nil) {
+				t.Fatalf("Expected error: %v, got: %v", failureExpected, err)
+			}
+			if tc.expectedCategory == "" {
+				if receivedNotification != nil {
+					t.Errorf("Expected no failure notification, got %v", receivedNotification)
+				}
+				return
+			}
+			if receivedNotification["category"] != tc.expectedCategory {
+				t.Errorf("Expected the error category '%s', got '%s'", tc.expectedCategory, receivedNotification["category"])
+			}
+			if !strings.Contains(receivedNotification["text"], "The PR reminder failed") {
+				t.Errorf("Expected the notification text to tell about the failure, got '%s'", receivedNotification["text"])
+			}
+			if strings.Contains(receivedNotification["text"], "not_in_channel") {
+				t.Errorf("Expected the error itself not to be posted, got '%s'", receivedNotification["text"])
+			}
+		})
+	}
+}
+
+func TestPostModeUpdatesMessageOfRerun(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		stateIdempotencyKey    string
+		expectedUpdate         bool
+		expectedReminderCounts []int
+	}{
+		{
+			name:                   "re-run of the run that posted the message",
+			stateIdempotencyKey:    "123456789-2025-01-02",
+			expectedUpdate:         true,
+			expectedReminderCounts: []int{1, 2},
+		},
+		{
+			name:                   "run on the next day",
+			stateIdempotencyKey:    "123456789-2025-01-01",
+			expectedReminderCounts: []int{1, 3},
+		},
+		{
+			name:                   "another run",
+			stateIdempotencyKey:    "987654321-2025-01-02",
+			expectedReminderCounts: []int{1, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			previousState := getTestState(GetTestStateOptions{PRNumbers: []int{1}})
+			previousState.SchemaVersion = state.CurrentSchemaVersion
+			previousState.PullRequests[0].ReminderCount = 2
+			previousState.IdempotencyKey = tc.stateIdempotencyKey
+			testStateFilePath := filepath.Join(t.TempDir(), "pr-slack-reminder-state.json")
+			configOverrides := map[string]any{
+				config.EnvStateFilePath: testStateFilePath,
+				config.EnvGithubRunID:   "123456789",
+				config.EnvFixedTime:     "2025-01-02T09:00:00Z",
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5.2}),
+					getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob", AgeHours: 5}),
+				},
+				MockStateForUpdateMode: &previousState,
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			posted := mockSlackAPI.SentMessage.Blocks.GetPRCount() > 0
+			updated := mockSlackAPI.UpdatedMessage.Blocks.GetPRCount() > 0
+			if tc.expectedUpdate && (posted || !updated) {
+				t.Errorf("Expected the message of the run to be updated instead of posting a new one")
+			}
+			if !tc.expectedUpdate && (!posted || updated) {
+				t.Errorf("Expected a new message to be posted")
+			}
+			if tc.expectedUpdate && strings.Contains(mockSlackAPI.UpdatedMessage.Text, "since last run") {
+				t.Errorf("Expected no PR count trend in the re-run, got '%s'", mockSlackAPI.UpdatedMessage.Text)
+			}
+
+			var savedState state.State
+			if err := testhelpers.LoadJSONFromFile(testStateFilePath, &savedState); err != nil {
+				t.Fatalf("Failed to load saved state: %v", err)
+			}
+			if savedState.IdempotencyKey != "123456789-2025-01-02" {
+				t.Errorf("Expected the idempotency key of the run to be saved, got '%s'", savedState.IdempotencyKey)
+			}
+			var reminderCounts []int
+			for _, ref := range savedState.PullRequests {
+				reminderCounts = append(reminderCounts, ref.ReminderCount)
+			}
+			if !slices.Equal(reminderCounts, tc.expectedReminderCounts) {
+				t.Errorf("Expected saved reminder counts %v, got %v", tc.expectedReminderCounts, reminderCounts)
+			}
+		})
+	}
+}
+
+func TestPostModeValidatesUserMapping(t *testing.T) {
+	testCases := []struct {
+		name                string
+		validateUserMapping bool
+		expectedRunReport   string
+	}{
+		{name: "user mapping is not validated by default"},
+		{
+			name:                "unknown GitHub and Slack users are reported if enabled",
+			validateUserMapping: true,
+			expectedRunReport:   "⚠️ 2 other issues",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputSlackUserIdByGitHubUsername: "alice: U1;alcie: U2;bob: U9",
+				config.InputValidateUserMapping:         tc.validateUserMapping,
+				config.InputShowRunReport:               true,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+				},
+				UnknownGitHubUsers: []string{"alcie"},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{
+				Users: []slack.User{{ID: "U1", Name: "alice"}, {ID: "U2", Name: "alice2"}},
+			})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			contextTexts := mockSlackAPI.SentMessage.Blocks.GetContextTexts()
+			hasRunReport := slices.ContainsFunc(contextTexts, func(text string) bool {
+				return strings.HasPrefix(text, "⚠️")
+			})
+			if tc.expectedRunReport == "" && hasRunReport {
+				t.Errorf("Expected no run report, got: %v", contextTexts)
+			}
+			if tc.expectedRunReport != "" && !slices.Contains(contextTexts, tc.expectedRunReport) {
+				t.Errorf("Expected the run report '%s' in the message, got: %v", tc.expectedRunReport, contextTexts)
+			}
+		})
+	}
+}
+
+func TestPostModeMapsGitHubLoginsCaseInsensitively(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputSlackUserIdByGitHubUsername: map[string]string{"Alice": "U2234567890"},
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{
+			getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5}),
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expectedPRItems := []string{"First PR 5 hours ago by U2234567890"}
+	if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, expectedPRItems) {
+		t.Errorf("Expected the author to be mentioned %v, got %v", expectedPRItems, prItems)
+	}
+}
+
+func TestPostModeHandlesMergeQueue(t *testing.T) {
+	testCases := []struct {
+		name            string
+		configOverrides map[string]any
+		expectedPRItems []string
+	}{
+		{
+			name: "merge queue is not checked by default",
+			expectedPRItems: []string{
+				"Second PR 3 hours ago by Bob",
+				"First PR 5 hours ago by Alice (✅ lead)",
+			},
+		},
+		{
+			name:            "queued PRs are marked",
+			configOverrides: map[string]any{config.InputShowMergeQueue: "true"},
+			expectedPRItems: []string{
+				"Second PR 3 hours ago by Bob",
+				"First PR 5 hours ago by Alice (✅ lead) 🕗 queued",
+			},
+		},
+		{
+			name:            "queued PRs are excluded by the filter",
+			configOverrides: map[string]any{config.InputGlobalFilters: `{"ignore-queued": true}`},
+			expectedPRItems: []string{"Second PR 3 hours ago by Bob"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &tc.configOverrides)
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5, NodeID: "PR_1"}),
+					getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob", AgeHours: 3, NodeID: "PR_2"}),
+				},
+				ReviewsByPRNumber: map[int][]*github.PullRequestReview{
+					1: {mockgithubclient.NewReview(1, "APPROVED", "lead", "", "")},
+				},
+				QueuedPRNodeIDs: []string{"PR_1"},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, tc.expectedPRItems) {
+				t.Errorf("Expected PR items %v, got %v", tc.expectedPRItems, prItems)
+			}
+		})
+	}
+}
+
+func TestPostModeCountsOnlyCurrentApprovals(t *testing.T) {
+	testCases := []struct {
+		name            string
+		strictApprovals any
+		expectedPRItems []string
+	}{
+		{
+			name: "approvals revoked by later reviews are not counted",
+			expectedPRItems: []string{
+				"Second PR 3 hours ago by Bob (💬 alice, lead)",
+				"First PR 5 hours ago by Alice (✅ lead)",
+			},
+		},
+		{
+			name:            "stale approvals are not counted if strict",
+			strictApprovals: "true",
+			expectedPRItems: []string{
+				"Second PR 3 hours ago by Bob (💬 alice, lead)",
+				"First PR 5 hours ago by Alice (💬 lead)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{config.InputStrictApprovals: tc.strictApprovals}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			prs := []*github.PullRequest{
+				getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5}),
+				getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob", AgeHours: 3}),
+			}
+			for _, pr := range prs {
+				pr.Head = &github.PullRequestBranch{SHA: github.Ptr("sha-new")}
+			}
+			reviewOfCommit := func(id int64, state, login, commitID string) *github.PullRequestReview {
+				review := mockgithubclient.NewReview(id, state, login, "", "")
+				review.CommitID = github.Ptr(commitID)
+				return review
+			}
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: prs,
+				ReviewsByPRNumber: map[int][]*github.PullRequestReview{
+					// approved before new commits were pushed
+					1: {reviewOfCommit(1, "APPROVED", "lead", "sha-old")},
+					2: {
+						reviewOfCommit(2, "APPROVED", "alice", "sha-new"),
+						reviewOfCommit(3, "CHANGES_REQUESTED", "alice", "sha-new"),
+						reviewOfCommit(4, "DISMISSED", "lead", "sha-new"),
+					},
+				},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, tc.expectedPRItems) {
+				t.Errorf("Expected PR items %v, got %v", tc.expectedPRItems, prItems)
+			}
+		})
+	}
+}
+
+func TestPostModeRepliesInPRThreads(t *testing.T) {
+	configOverrides := map[string]any{config.InputPRThreadMarker: "Build failed in PR #<pr_number>:"}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{
+			getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", AgeHours: 5}),
+			getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob", AgeHours: 3}),
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{
+		ChannelMessages: []slack.Message{
+			{Msg: slack.Msg{Text: "Deployed to staging", Timestamp: "1700000300.000100"}},
+			{Msg: slack.Msg{Text: "Build failed in PR #1: lint errors", Timestamp: "1700000200.000100"}},
+		},
+	})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expectedPRItems := []string{"Second PR 3 hours ago by Bob"}
+	if prItems := mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, expectedPRItems) {
+		t.Errorf("Expected PR items %v, got %v", expectedPRItems, prItems)
+	}
+	if len(mockSlackAPI.SentReplies) != 1 {
+		t.Fatalf("Expected one reply, got %v", mockSlackAPI.SentReplies)
+	}
+	reply := mockSlackAPI.SentReplies[0]
+	if reply.ThreadTS != "1700000200.000100" || !strings.Contains(reply.Text, "open for 5 hours") {
+		t.Errorf("Expected a reply about the first PR in the thread of the build failure, got %+v", reply)
+	}
+}
+
+func TestPostModeRepliesInReviewerThreads(t *testing.T) {
+	testStateFilePath := filepath.Join(t.TempDir(), "pr-slack-reminder-state.json")
+	configOverrides := map[string]any{
+		config.InputReviewerThreads:             true,
+		config.InputSlackUserIdByGitHubUsername: map[string]string{"alice": "U2234567890", "bob": "U3234567890"},
+		config.EnvStateFilePath:                 testStateFilePath,
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{
+			getTestPR(GetTestPROptions{
+				Number: 1, Title: "First PR", AuthorLogin: "dave", AgeHours: 5,
+				RequestedReviewers: []string{"bob", "alice", "carol"},
+			}),
+			getTestPR(GetTestPROptions{
+				Number: 2, Title: "Second PR", AuthorLogin: "erin", AgeHours: 3, RequestedReviewers: []string{"bob"},
+			}),
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expectedReplies := []struct {
+		text    string
+		prItems []string
+	}{
+		{text: "1 PR is waiting for your review 👀", prItems: []string{"First PR 5 hours ago by Dave"}},
+		{
+			text:    "2 PRs are waiting for your review 👀",
+			prItems: []string{"Second PR 3 hours ago by Erin", "First PR 5 hours ago by Dave"},
+		},
+	}
+	if len(mockSlackAPI.SentReplies) != len(expectedReplies) {
+		t.Fatalf("Expected a reply per mapped reviewer, got %+v", mockSlackAPI.SentReplies)
+	}
+	for i, expected := range expectedReplies {
+		reply := mockSlackAPI.SentReplies[i]
+		if reply.ThreadTS != "1234567890.123456" || reply.Text != expected.text {
+			t.Errorf("Expected reply '%s' in the thread of the message, got %+v", expected.text, reply)
+		}
+		if prItems := reply.Blocks.GetAllPRItemTexts(); !slices.Equal(prItems, expected.prItems) {
+			t.Errorf("Expected PR items %v in the reply, got %v", expected.prItems, prItems)
+		}
+	}
+
+	var savedState state.State
+	if err := testhelpers.LoadJSONFromFile(testStateFilePath, &savedState); err != nil {
+		t.Fatalf("Failed to load state file: %v", err)
+	}
+	reviewerLogins := utilities.Map(savedState.ReviewerThreads, func(ref state.ReviewerThreadRef) string {
+		return ref.ReviewerLogin
+	})
+	if !slices.Equal(reviewerLogins, []string{"alice", "bob"}) {
+		t.Errorf("Expected the reviewer threads of alice and bob in the state, got %+v", savedState.ReviewerThreads)
+	}
+}
+
+func TestPostModeGroupsPRsByLabel(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputGroupBy:         "label",
+		config.InputLabelGroupOrder: "bug;feature",
+		config.InputPRListHeading:   "",
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{
+			getTestPR(GetTestPROptions{Number: 1, Title: "Feature PR", AuthorLogin: "alice", Labels: []string{"feature"}}),
+			getTestPR(GetTestPROptions{
+				Number: 2, Title: "Bug fix PR", AuthorLogin: "bob", Labels: []string{"feature", "Bug"}, AgeHours: 3,
+			}),
+			getTestPR(GetTestPROptions{Number: 3, Title: "Docs PR", AuthorLogin: "carol", Labels: []string{"docs"}}),
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	prLists := mockSlackAPI.SentMessage.Blocks.GetPRLists()
+	expected := []struct {
+		heading string
+		prItems []string
+	}{
+		{heading: "Open PRs labeled bug:", prItems: []string{"Bug fix PR 3 hours ago by Bob"}},
+		{heading: "Open PRs labeled feature:", prItems: []string{"Feature PR 5 hours ago by Alice"}},
+		{heading: "Other open PRs:", prItems: []string{"Docs PR 5 hours ago by Carol"}},
+	}
+	if len(prLists) != len(expected) {
+		t.Fatalf("Expected %d PR lists, got %+v", len(expected), prLists)
+	}
+	for i, prList := range prLists {
+		if prList.Heading != expected[i].heading || !slices.Equal(prList.PRListItems, expected[i].prItems) {
+			t.Errorf("Expected PR list %d to be %+v, got %+v", i, expected[i], prList)
+		}
+	}
+}
+
+func TestPostModeLimitsPRsPerRepository(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputGroupByRepository: true,
+		config.InputMaxPRsPerRepo:     2,
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{
+			getTestPR(GetTestPROptions{Title: "Newest PR", AuthorLogin: "alice", AgeHours: 1}),
+			getTestPR(GetTestPROptions{Title: "Middle PR", AuthorLogin: "bob", AgeHours: 3}),
+			getTestPR(GetTestPROptions{Title: "Oldest PR", AuthorLogin: "carol", AgeHours: 5}),
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	blocks := mockSlackAPI.SentMessage.Blocks
+	expectedPRItems := []string{"Oldest PR 5 hours ago by Carol", "Middle PR 3 hours ago by Bob"}
+	if prItems := blocks.GetAllPRItemTexts(); !slices.Equal(prItems, expectedPRItems) {
+		t.Errorf("Expected the oldest PRs %v, got %v", expectedPRItems, prItems)
+	}
+	repositories := blocks.GetPRListRepositories()
+	if len(repositories) != 1 {
+		t.Fatalf("Expected one repository PR list, got %v", repositories)
+	}
+	if text := blocks.GetRichTextBlockText(blockids.RepositoryHiddenPRs(repositories[0])); text != "and 1 more…" {
+		t.Errorf("Expected the hidden PRs to be rolled up as 'and 1 more…', got '%s'", text)
+	}
+	if !strings.HasPrefix(mockSlackAPI.SentMessage.Text, "3 open PRs") {
+		t.Errorf("Expected the summary to count the hidden PRs, got '%s'", mockSlackAPI.SentMessage.Text)
+	}
+}
+
+func TestPostModeSavesSentBlocksEnvelope(t *testing.T) {
+	sentBlocksFilePath := filepath.Join(t.TempDir(), "sent-blocks.json")
+	configOverrides := map[string]any{config.EnvSentSlackBlocksFilePath: sentBlocksFilePath}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{getTestPR(GetTestPROptions{Number: 1, Title: "First PR"})},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var envelope state.SentBlocksEnvelope
+	if err := testhelpers.LoadJSONFromFile(sentBlocksFilePath, &envelope); err != nil {
+		t.Fatalf("Expected the sent blocks to be saved as an envelope: %v", err)
+	}
+	if envelope.RunMode != "post" {
+		t.Errorf("Expected run mode 'post', got '%s'", envelope.RunMode)
+	}
+	if envelope.Summary != "1 open PR is waiting for attention 👀" {
+		t.Errorf("Expected the summary of the message, got '%s'", envelope.Summary)
+	}
+	if envelope.ChannelID == "" || len(envelope.Blocks) == 0 {
+		t.Errorf("Expected the channel ID and blocks of the message, got %+v", envelope)
+	}
+}
+
+func TestPostModeShowsMilestoneProgress(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputGlobalFilters: "{\"milestones\": [\"2.4\"]}",
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{
+			getTestPR(GetTestPROptions{Number: 1, Title: "Planned PR", Milestone: "2.4"}),
+			getTestPR(GetTestPROptions{Number: 2, Title: "Old PR", Milestone: "2.3"}),
+			getTestPR(GetTestPROptions{Number: 3, Title: "Unplanned PR"}),
+		},
+		MilestonesByRepo: map[string][]*github.Milestone{
+			"test-repo": {
+				{Title: github.Ptr("2.3"), OpenIssues: github.Ptr(1), ClosedIssues: github.Ptr(30)},
+				{Title: github.Ptr("2.4"), OpenIssues: github.Ptr(8), ClosedIssues: github.Ptr(12)},
+			},
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if mockSlackAPI.SentMessage.Blocks.GetPRCount() != 1 {
+		t.Errorf("Expected 1 PR in the message, got %d", mockSlackAPI.SentMessage.Blocks.GetPRCount())
+	}
+	i := slices.IndexFunc(mockSlackAPI.SentMessage.Blocks.Blocks, func(b mockslackclient.Block) bool {
+		return b.BlockID == "milestone_progress"
+	})
+	if i == -1 {
+		t.Fatalf("Expected a milestone progress block in the message")
+	}
+	progressBlock := string(mockSlackAPI.SentMessage.Blocks.Blocks[i].Elements)
+	if !strings.Contains(progressBlock, "Milestone 2.4: 12/20 PRs merged") {
+		t.Errorf("Expected the progress of milestone 2.4 only, got %s", progressBlock)
+	}
+	if strings.Contains(progressBlock, "2.3") {
+		t.Errorf("Expected no progress of milestones not in the filters, got %s", progressBlock)
+	}
+}
+
+func TestPostModeListsReleasePRsFirst(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputReleasePRTitlePattern: "^Release v",
+		config.InputReleasePRLabels:       []string{"release"},
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{
+			getTestPR(GetTestPROptions{Number: 1, Title: "Feature PR", AuthorLogin: "alice"}),
+			getTestPR(GetTestPROptions{Number: 2, Title: "Release v2.4.0", AuthorLogin: "bob"}),
+			getTestPR(GetTestPROptions{Number: 3, Title: "Deploy to prod", AuthorLogin: "bob", Labels: []string{"release"}}),
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	prLists := mockSlackAPI.SentMessage.Blocks.GetPRLists()
+	if len(prLists) != 2 {
+		t.Fatalf("Expected 2 PR lists (releases and others), got %d", len(prLists))
+	}
+	if prLists[0].Heading != "🚢 Pending releases" || len(prLists[0].PRListItems) != 2 {
+		t.Errorf("Expected the 2 release PRs to be listed first, got %+v", prLists[0])
+	}
+	if prLists[1].Heading != "There are 1 open PRs 🚀" || len(prLists[1].PRListItems) != 1 {
+		t.Errorf("Expected the other PR to be listed after the releases, got %+v", prLists[1])
+	}
+	if mockSlackAPI.SentMessage.Text != "3 open PRs are waiting for attention 👀" {
+		t.Errorf("Expected the summary to count all PRs, got '%s'", mockSlackAPI.SentMessage.Text)
+	}
+}
+
+func TestPostModeGoogleChat(t *testing.T) {
+	var receivedMessage googlechatclient.Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&receivedMessage); err != nil {
+			t.Errorf("Failed to decode Google Chat message: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	testStateFilePath := filepath.Join(t.TempDir(), "pr-slack-reminder-state.json")
+	configOverrides := map[string]any{
+		config.InputMessenger:            config.MessengerGoogleChat,
+		config.InputGoogleChatWebhookURL: server.URL,
+		config.InputSlackBotToken:        nil,
+		config.InputSlackChannelName:     nil,
+		config.EnvStateFilePath:          testStateFilePath,
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{
+			getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+			getTestPR(GetTestPROptions{Number: 2, Title: "Second <PR>", AuthorLogin: "bob"}),
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(receivedMessage.CardsV2) != 1 {
+		t.Fatalf("Expected one card in the Google Chat message, got %d", len(receivedMessage.CardsV2))
+	}
+	sections := receivedMessage.CardsV2[0].Card.Sections
+	if len(sections) != 1 || len(sections[0].Widgets) != 2 {
+		t.Fatalf("Expected one section with 2 PRs, got %+v", sections)
+	}
+	if sections[0].Header != "There are 2 open PRs 🚀" {
+		t.Errorf("Expected the PR list heading as section header, got '%s'", sections[0].Header)
+	}
+	titleEscaped := slices.ContainsFunc(sections[0].Widgets, func(w googlechatclient.Widget) bool {
+		return strings.Contains(w.TextParagraph.Text, "Second &lt;PR&gt;")
+	})
+	if !titleEscaped {
+		t.Errorf("Expected the PR title to be HTML escaped, got %+v", sections[0].Widgets)
+	}
+	if mockSlackAPI.SentMessage.Blocks.GetPRCount() != 0 {
+		t.Errorf("Expected no Slack message to be sent")
+	}
+
+	var savedState state.State
+	if err := testhelpers.LoadJSONFromFile(testStateFilePath, &savedState); err != nil {
+		t.Fatalf("Failed to load state file: %v", err)
+	}
+	if len(savedState.PullRequests) != 2 {
+		t.Errorf("Expected 2 PRs in state, got %d", len(savedState.PullRequests))
+	}
+}
+
+func TestPostModeDiscord(t *testing.T) {
+	var receivedMessages []discordclient.Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message discordclient.Message
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("Failed to decode Discord message: %v", err)
+		}
+		receivedMessages = append(receivedMessages, message)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	configOverrides := map[string]any{
+		config.InputMessenger:         config.MessengerDiscord,
+		config.InputDiscordWebhookURL: server.URL,
+		config.InputSlackBotToken:     nil,
+		config.InputSlackChannelName:  nil,
+		config.EnvStateFilePath:       filepath.Join(t.TempDir(), "pr-slack-reminder-state.json"),
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	var prs []*github.PullRequest
+	for i := range 30 {
+		prs = append(prs, getTestPR(GetTestPROptions{Number: i + 1, Title: strings.Repeat("x", 250), AuthorLogin: "alice"}))
+	}
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{PRs: prs})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(receivedMessages) != 2 {
+		t.Fatalf("Expected the PRs to be paginated to 2 Discord messages, got %d", len(receivedMessages))
+	}
+	fieldCount := 0
+	for _, message := range receivedMessages {
+		for _, embed := range message.Embeds {
+			fieldCount += len(embed.Fields)
+		}
+	}
+	if fieldCount != 30 {
+		t.Errorf("Expected 30 PR fields in total, got %d", fieldCount)
+	}
+}
+
+func TestPostModeMultipleWorkspaces(t *testing.T) {
+	testStateFilePath := "/tmp/test-state-multiple-workspaces.json"
+
+	configOverrides := map[string]any{
+		config.InputSlackBotToken:    nil,
+		config.InputSlackChannelName: nil,
+		config.InputWorkspaceTargets: `[
+			{"slack-bot-token": "TOKEN_A", "slack-channel-name": "some-channel-name"},
+			{"slack-bot-token": "TOKEN_B", "slack-channel-id": "C87654321"}
+		]`,
+		config.EnvStateFilePath: testStateFilePath,
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	testPRs := getTestPRs(GetTestPRsOptions{})
+	mockGitHubClientGetter := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: testPRs.PRs,
+	})
+	mockSlackAPIByToken := map[string]*mockslackclient.MockSlackAPI{
+		"TOKEN_A": mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{}),
+		"TOKEN_B": mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{}),
+	}
+
+	err := main.Run(
+		mockGitHubClientGetter,
+		mockslackclient.MakeSlackClientGetterByToken(mockSlackAPIByToken),
+	)
+	if err != nil {
+		t.Fatalf("Expected Run to succeed, but got error: %v", err)
+	}
+
+	expectedChannelIDByToken := map[string]string{"TOKEN_A": "C12345678", "TOKEN_B": "C87654321"}
+	for token, expectedChannelID := range expectedChannelIDByToken {
+		sentMessage := mockSlackAPIByToken[token].SentMessage
+		if sentMessage.ChannelID != expectedChannelID {
+			t.Errorf("Expected message to be sent to channel %s with %s, got %s", expectedChannelID, token, sentMessage.ChannelID)
+		}
+		if sentMessage.Blocks.GetPRCount() != len(testPRs.PRs) {
+			t.Errorf("Expected %d PRs in the message sent with %s, got %d",
+				len(testPRs.PRs), token, sentMessage.Blocks.GetPRCount())
+		}
+	}
+
+	var loadedState state.State
+	if err := testhelpers.LoadJSONFromFile(testStateFilePath, &loadedState); err != nil {
+		t.Fatalf("Failed to load state file: %v", err)
+	}
+	slackRefs := loadedState.GetSlackRefs()
+	if len(slackRefs) != 2 {
+		t.Fatalf("Expected 2 Slack messages in state, got %d", len(slackRefs))
+	}
+	if slackRefs[0].ChannelID != "C12345678" || slackRefs[1].ChannelID != "C87654321" {
+		t.Errorf("Unexpected Slack message channels in state: %+v", slackRefs)
+	}
+}
+
+func TestPostModeChannelMatrix(t *testing.T) {
+	testStateFilePath := filepath.Join(t.TempDir(), "pr-slack-reminder-state.json")
+	configOverrides := map[string]any{
+		config.InputSlackChannelName: nil,
+		config.InputChannelMatrix: `[
+			{"slack-channel-name": "frontend", "filters": {"labels": ["frontend"]}},
+			{"slack-channel-id": "C2", "filters": {"authors": ["bob"]}},
+			{"slack-channel-name": "missing", "filters": {}}
+		]`,
+		config.EnvStateFilePath: testStateFilePath,
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	testPRs := []*github.PullRequest{
+		getTestPR(GetTestPROptions{Number: 1, Title: "Frontend PR", AuthorLogin: "alice", Labels: []string{"frontend"}}),
+		getTestPR(GetTestPROptions{Number: 2, Title: "Backend PR", AuthorLogin: "bob"}),
+		getTestPR(GetTestPROptions{Number: 3, Title: "Other PR", AuthorLogin: "carol"}),
+	}
+	mockGitHubClientGetter := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: testPRs,
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{
+		SlackChannels: []*mockslackclient.SlackChannel{{ID: "C1", Name: "frontend"}, {ID: "C2", Name: "backend"}},
+	})
+
+	err := main.Run(mockGitHubClientGetter, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+	if err == nil || !strings.Contains(err.Error(), "failed to post to channel missing") {
+		t.Fatalf("Expected an error about the missing channel, got: %v", err)
+	}
+
+	expectedPRItemsByChannel := map[string][]string{"C1": {"Frontend PR"}, "C2": {"Backend PR"}}
+	if len(mockSlackAPI.SentMessages) != len(expectedPRItemsByChannel) {
+		t.Fatalf("Expected a message to each found channel, got %+v", mockSlackAPI.SentMessages)
+	}
+	for _, sentMessage := range mockSlackAPI.SentMessages {
+		prItems := sentMessage.Blocks.GetAllPRItemTexts()
+		expected := expectedPRItemsByChannel[sentMessage.ChannelID]
+		if len(prItems) != len(expected) || !strings.HasPrefix(prItems[0], expected[0]) {
+			t.Errorf("Expected PRs %v in the message to channel %s, got %v", expected, sentMessage.ChannelID, prItems)
+		}
+	}
+
+	var loadedState state.State
+	if err := testhelpers.LoadJSONFromFile(testStateFilePath, &loadedState); err != nil {
+		t.Fatalf("Failed to load state file: %v", err)
+	}
+	if len(loadedState.GetSlackRefs()) != 2 || len(loadedState.PullRequests) != len(testPRs) {
+		t.Errorf("Expected the state of the sent messages and all PRs, got %+v", loadedState)
+	}
+}
+
+func TestPostModeEscalation(t *testing.T) {
+	testPRs := []*github.PullRequest{
+		getTestPR(GetTestPROptions{Number: 1, Title: "Old PR", AuthorLogin: "alice", AgeHours: 50}),
+		getTestPR(GetTestPROptions{Number: 2, Title: "Oldest PR", AuthorLogin: "bob", AgeHours: 100}),
+		getTestPR(GetTestPROptions{Number: 3, Title: "New PR", AuthorLogin: "carol", AgeHours: 2}),
+	}
+	testCases := []struct {
+		name                 string
+		escalationChannel    string
+		oldPRCount           string
+		expectedEscalatedPRs []string
+	}{
+		{
+			name:                 "old PRs exceed the count",
+			escalationChannel:    "team-leads",
+			oldPRCount:           "1",
+			expectedEscalatedPRs: []string{"Oldest PR", "Old PR"},
+		},
+		{
+			name:              "old PRs do not exceed the count",
+			escalationChannel: "team-leads",
+			oldPRCount:        "2",
+		},
+		{
+			name:              "escalation channel is not found",
+			escalationChannel: "missing",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputOldPRThresholdHours:  24,
+				config.InputEscalationChannel:    tc.escalationChannel,
+				config.InputEscalationOldPRCount: tc.oldPRCount,
+				config.EnvStateFilePath:          filepath.Join(t.TempDir(), "pr-slack-reminder-state.json"),
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			mockGitHubClientGetter := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: testPRs,
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{
+				SlackChannels: []*mockslackclient.SlackChannel{
+					{ID: "C12345678", Name: "some-channel-name"}, {ID: "C2", Name: "team-leads"},
+				},
+			})
+
+			err := main.Run(mockGitHubClientGetter, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			expectedMessageCount := 1
+			if len(tc.expectedEscalatedPRs) > 0 {
+				expectedMessageCount = 2
+			}
+			if len(mockSlackAPI.SentMessages) != expectedMessageCount {
+				t.Fatalf("Expected %d sent messages, got %+v", expectedMessageCount, mockSlackAPI.SentMessages)
+			}
+			if len(mockSlackAPI.SentMessages[0].Blocks.GetAllPRItemTexts()) != len(testPRs) {
+				t.Errorf("Expected all PRs in the reminder")
+			}
+			if len(tc.expectedEscalatedPRs) == 0 {
+				return
+			}
+			escalation := mockSlackAPI.SentMessages[1]
+			if escalation.ChannelID != "C2" {
+				t.Errorf("Expected the escalation message to channel C2, got %s", escalation.ChannelID)
+			}
+			prItems := escalation.Blocks.GetAllPRItemTexts()
+			if len(prItems) != len(tc.expectedEscalatedPRs) {
+				t.Fatalf("Expected escalated PRs %v, got %v", tc.expectedEscalatedPRs, prItems)
+			}
+			for i, title := range tc.expectedEscalatedPRs {
+				if !strings.HasPrefix(prItems[i], title) {
+					t.Errorf("Expected escalated PR %d to be '%s', got '%s'", i, title, prItems[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRespectDND(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputRespectDND:                  "true",
+		config.InputSlackUserIdByGitHubUsername: map[string]string{"alice": "U2234567890", "bob": "U3234567890"},
+		config.EnvStateFilePath:                 filepath.Join(t.TempDir(), "pr-slack-reminder-state.json"),
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	mockGitHubClientGetter := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{
+			getTestPR(GetTestPROptions{Number: 1, Title: "Alice's PR", AuthorLogin: "alice", AuthorName: "Alice"}),
+			getTestPR(GetTestPROptions{Number: 2, Title: "Bob's PR", AuthorLogin: "bob", AuthorName: "Bob"}),
+		},
+	})
+	now := int(time.Now().Unix())
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{
+		DNDStatuses: map[string]slack.DNDStatus{
+			"U2234567890": {SnoozeInfo: slack.SnoozeInfo{SnoozeEnabled: true, SnoozeEndTime: now + 3600}},
+			"U3234567890": {Enabled: true, NextStartTimestamp: now + 3600, NextEndTimestamp: now + 7200},
+		},
+	})
+
+	err := main.Run(mockGitHubClientGetter, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	blocks := mockSlackAPI.SentMessage.Blocks
+	if blocks.SomePRItemContainsText("U2234567890") || !blocks.SomePRItemContainsText("by Alice") {
+		t.Errorf("Expected the author on DND to be shown by name, got %v", blocks.GetAllPRItemTexts())
+	}
+	if !blocks.SomePRItemContainsText("by U3234567890") {
+		t.Errorf("Expected the author not on DND to be mentioned, got %v", blocks.GetAllPRItemTexts())
+	}
+}
+
+func TestShowFilteredCount(t *testing.T) {
+	testCases := []struct {
+		name            string
+		filters         string
+		expectedSummary string
+	}{
+		{
+			name:            "PRs hidden by filters",
+			filters:         `{"labels": ["frontend"]}`,
+			expectedSummary: "1 open PR is waiting for attention 👀 (2 PRs hidden by filters)",
+		},
+		{
+			name:            "no PRs hidden",
+			expectedSummary: "3 open PRs are waiting for attention 👀",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputShowFilteredCount: "true",
+				config.InputGlobalFilters:     tc.filters,
+				config.EnvStateFilePath:       filepath.Join(t.TempDir(), "pr-slack-reminder-state.json"),
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			mockGitHubClientGetter := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "Frontend PR", Labels: []string{"frontend"}}),
+					getTestPR(GetTestPROptions{Number: 2, Title: "Backend PR", Labels: []string{"backend"}}),
+					getTestPR(GetTestPROptions{Number: 3, Title: "Other PR"}),
+				},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			err := main.Run(mockGitHubClientGetter, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if mockSlackAPI.SentMessage.Text != tc.expectedSummary {
+				t.Errorf("Expected summary '%s', got '%s'", tc.expectedSummary, mockSlackAPI.SentMessage.Text)
+			}
+		})
+	}
+}
+
+func TestPRCountsOutput(t *testing.T) {
+	outputFilePath := filepath.Join(t.TempDir(), "github-output")
+	stepSummaryFilePath := filepath.Join(t.TempDir(), "step-summary.md")
+	configOverrides := map[string]any{
+		config.InputPRCountsStepSummary: "true",
+		config.EnvGithubOutput:          outputFilePath,
+		config.EnvGithubStepSummary:     stepSummaryFilePath,
+		config.EnvStateFilePath:         filepath.Join(t.TempDir(), "pr-slack-reminder-state.json"),
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	mockGitHubClientGetter := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{getTestPR(GetTestPROptions{Number: 1}), getTestPR(GetTestPROptions{Number: 2})},
+	})
+
+	err := main.Run(mockGitHubClientGetter, mockslackclient.MakeSlackClientGetter(nil))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	output, err := os.ReadFile(outputFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read the output file: %v", err)
+	}
+	if !strings.Contains(string(output), "pr-counts={\"test-org/test-repo\":2}\n") {
+		t.Errorf("Expected the PR counts of the repository in the output, got:\n%s", output)
+	}
+	stepSummary, err := os.ReadFile(stepSummaryFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read the step summary file: %v", err)
+	}
+	if !strings.Contains(string(stepSummary), "| test-org/test-repo | 2 |") {
+		t.Errorf("Expected the PR counts in the step summary, got:\n%s", stepSummary)
+	}
+}
+
+func TestPostModePRDataCache(t *testing.T) {
+	testPRs := []*github.PullRequest{
+		getTestPR(GetTestPROptions{Number: 1, Title: "Frontend PR", AuthorLogin: "alice", Labels: []string{"frontend"}}),
+		getTestPR(GetTestPROptions{Number: 2, Title: "Backend PR", AuthorLogin: "bob"}),
+	}
+	// the artifact of the mock has the file with the default name
+	cacheFilePath := filepath.Join(t.TempDir(), config.DefaultPRDataCacheFilePath)
+	runJob := func(configOverrides map[string]any, opts mockgithubclient.MockGitHubClientOptions) []string {
+		t.Helper()
+		configOverrides[config.EnvStateFilePath] = filepath.Join(t.TempDir(), "pr-slack-reminder-state.json")
+		testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+		mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+		if err := main.Run(
+			mockgithubclient.MakeMockGitHubClientGetter(opts), mockslackclient.MakeSlackClientGetter(mockSlackAPI),
+		); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		return mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts()
+	}
+
+	prItems := runJob(map[string]any{
+		config.InputPRDataCache:         "write",
+		config.InputPRDataCacheFilePath: cacheFilePath,
+	}, mockgithubclient.MockGitHubClientOptions{PRs: testPRs})
+	if len(prItems) != 2 {
+		t.Fatalf("Expected the writing job to list both PRs, got %v", prItems)
+	}
+	cacheFile, err := os.ReadFile(cacheFilePath)
+	if err != nil {
+		t.Fatalf("Expected the PR data cache file to be written, got: %v", err)
+	}
+
+	// the PRs are not available from GitHub, so they can only be listed from the cache
+	prItems = runJob(map[string]any{
+		config.InputPRDataCache:         "read",
+		config.InputPRDataCacheFilePath: cacheFilePath,
+		config.EnvGithubRunID:           strconv.FormatInt(mockgithubclient.PRDataCacheRunID, 10),
+		config.InputGlobalFilters:       `{"labels": ["frontend"]}`,
+	}, mockgithubclient.MockGitHubClientOptions{PRDataCacheFile: cacheFile})
+	if len(prItems) != 1 || !strings.HasPrefix(prItems[0], "Frontend PR") {
+		t.Errorf("Expected the reading job to list the cached PR matching its filters, got %v", prItems)
+	}
+
+	prItems = runJob(map[string]any{
+		config.InputPRDataCache:         "read",
+		config.InputPRDataCacheFilePath: cacheFilePath,
+		config.EnvGithubRunID:           "43",
+	}, mockgithubclient.MockGitHubClientOptions{PRs: testPRs, PRDataCacheFile: cacheFile})
+	if len(prItems) != 2 {
+		t.Errorf("Expected the PRs to be fetched if the cache of the run is not found, got %v", prItems)
+	}
+}
+
+func TestPostModeAllowedChannelPattern(t *testing.T) {
+	testCases := []struct {
+		name             string
+		channelName      any
+		channelID        any
+		pattern          string
+		expectedErrorMsg string
+	}{
+		{name: "any channel is allowed by default", channelName: "some-channel-name"},
+		{name: "channel name matches", channelName: "some-channel-name", pattern: "^some-.*-name$"},
+		{
+			name:             "channel name does not match",
+			channelName:      "some-channel-name",
+			pattern:          "^team-",
+			expectedErrorMsg: "channel 'some-channel-name' does not match allowed-channel-pattern '^team-', refusing to post",
+		},
+		{name: "name of the channel set by ID matches", channelName: nil, channelID: "C12345678", pattern: "^some-"},
+		{
+			name:             "name of the channel set by ID does not match",
+			channelName:      nil,
+			channelID:        "C12345678",
+			pattern:          "^team-",
+			expectedErrorMsg: "channel 'some-channel-name' does not match allowed-channel-pattern",
+		},
+		{
+			name:             "channel set by ID is not found",
+			channelName:      nil,
+			channelID:        "C00000000",
+			pattern:          "^some-",
+			expectedErrorMsg: "error checking channel against allowed-channel-pattern",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputSlackChannelName:      tc.channelName,
+				config.InputSlackChannelID:        tc.channelID,
+				config.InputAllowedChannelPattern: tc.pattern,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+				},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+			if tc.expectedErrorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErrorMsg) {
+					t.Fatalf("Expected error containing '%s', got: %v", tc.expectedErrorMsg, err)
+				}
+				if mockSlackAPI.SentMessage.ChannelID != "" {
+					t.Errorf("Expected no message to be sent, got one to %s", mockSlackAPI.SentMessage.ChannelID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if mockSlackAPI.SentMessage.ChannelID != "C12345678" {
+				t.Errorf("Expected message to be sent to C12345678, got '%s'", mockSlackAPI.SentMessage.ChannelID)
+			}
+		})
+	}
+}
+
+func TestPostModeAlwaysSaveState(t *testing.T) {
+	testCases := []struct {
+		name              string
+		alwaysSaveState   bool
+		expectStateToSave bool
+	}{
+		{name: "state is not saved by default if no message is posted", alwaysSaveState: false},
+		{name: "state is saved if configured", alwaysSaveState: true, expectStateToSave: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testStateFilePath := filepath.Join(t.TempDir(), "pr-slack-reminder-state.json")
+			configOverrides := map[string]any{
+				config.EnvStateFilePath:     testStateFilePath,
+				config.InputNoPRsMessage:    nil,
+				config.InputAlwaysSaveState: tc.alwaysSaveState,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if mockSlackAPI.SentMessage.Text != "" {
+				t.Errorf("Expected no message to be sent, got: %s", mockSlackAPI.SentMessage.Text)
+			}
+
+			var savedState state.State
+			err := testhelpers.LoadJSONFromFile(testStateFilePath, &savedState)
+			if !tc.expectStateToSave {
+				if err == nil {
+					t.Errorf("Expected no state to be saved, got: %+v", savedState)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to load saved state: %v", err)
+			}
+			if !savedState.NoMessagePosted || len(savedState.PullRequests) != 0 || len(savedState.GetSlackRefs()) != 0 {
+				t.Errorf("Expected a state without PRs and messages, got: %+v", savedState)
+			}
+		})
+	}
+}
+
+func TestUpdateModeFallsBackToPostModeWithoutState(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputRunMode:        config.RunModeUpdate,
+		config.InputOnMissingState: "post",
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{
+			getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !mockSlackAPI.SentMessage.Blocks.SomePRItemContainsText("First PR") {
+		t.Errorf("Expected a new message with the PR to be posted, got: %v", mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts())
+	}
+	if mockSlackAPI.UpdatedMessage.ChannelID != "" {
+		t.Errorf("Expected no message to be updated, got: %+v", mockSlackAPI.UpdatedMessage)
+	}
+}
+
+func TestUpdateModeWithoutPostedMessage(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputRunMode: config.RunModeUpdate,
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	mockState := state.State{SchemaVersion: state.CurrentSchemaVersion, NoMessagePosted: true}
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		MockStateForUpdateMode: &mockState,
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if mockSlackAPI.UpdatedMessage.ChannelID != "" || mockSlackAPI.DeletedMessage.ChannelID != "" {
+		t.Errorf("Expected no message to be updated or deleted, got: %+v", mockSlackAPI.UpdatedMessage)
+	}
+}
+
+func TestUpdateModeRejectsUnsignedState(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputRunMode:         config.RunModeUpdate,
+		config.InputStateSigningKey: "state-signing-secret",
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	mockState := getTestState(GetTestStateOptions{PRNumbers: []int{1}})
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRsByNumber: map[int]*github.PullRequest{
+			1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+		},
+		MockStateForUpdateMode: &mockState,
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+	if !errors.Is(err, state.ErrInvalidSignature) {
+		t.Fatalf("Expected an invalid signature error, got: %v", err)
+	}
+	if mockSlackAPI.UpdatedMessage.ChannelID != "" {
+		t.Errorf("Expected no message to be updated, got: %+v", mockSlackAPI.UpdatedMessage)
+	}
+}
+
+func TestUpdateModeIgnoresStateOfOtherBranches(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputRunMode:             config.RunModeUpdate,
+		config.InputStateArtifactBranch: "release",
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	mockState := getTestState(GetTestStateOptions{PRNumbers: []int{1}})
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRsByNumber: map[int]*github.PullRequest{
+			1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+		},
+		MockStateForUpdateMode: &mockState,
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+	if !errors.Is(err, githubclient.ErrArtifactNotFound) {
+		t.Fatalf("Expected the state of the main branch not to be found, got: %v", err)
+	}
+	if mockSlackAPI.UpdatedMessage.ChannelID != "" {
+		t.Errorf("Expected no message to be updated, got: %+v", mockSlackAPI.UpdatedMessage)
+	}
+}
+
+func TestUpdateModePrunesOldStateArtifacts(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputRunMode:             config.RunModeUpdate,
+		config.InputPruneStateArtifacts: 2,
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	mockState := getTestState(GetTestStateOptions{PRNumbers: []int{1}})
+	var deletedArtifactIDs []int64
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRsByNumber: map[int]*github.PullRequest{
+			1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+		},
+		MockStateForUpdateMode: &mockState,
+		OldStateArtifactCount:  3,
+		DeletedArtifactIDs:     &deletedArtifactIDs,
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	// the latest artifact (of the loaded state) and the next oldest one are kept
+	if !slices.Equal(deletedArtifactIDs, []int64{2, 3}) {
+		t.Errorf("Expected the two oldest state artifacts to be deleted, got: %v", deletedArtifactIDs)
+	}
+}
+
+func TestUpdateModeShowsRunReport(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputRunMode:        config.RunModeUpdate,
+		config.InputOnPRFetchError: string(config.PRFetchErrorSkip),
+		config.InputShowRunReport:  true,
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	mockState := getTestState(GetTestStateOptions{PRNumbers: []int{1, 2, 3}})
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRsByNumber: map[int]*github.PullRequest{
+			1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+		},
+		ErrByPRNumber: map[int]error{
+			2: errors.New("failed to fetch PR"),
+			3: errors.New("failed to fetch PR"),
+		},
+		MockStateForUpdateMode: &mockState,
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	contextTexts := mockSlackAPI.UpdatedMessage.Blocks.GetContextTexts()
+	if !slices.Contains(contextTexts, "⚠️ 2 PRs could not be fetched") {
+		t.Errorf("Expected the run report in the message, got: %v", contextTexts)
+	}
+}
+
+func TestUpdateModeRefreshesReviewerThreads(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputRunMode:                     config.RunModeUpdate,
+		config.InputReviewerThreads:             true,
+		config.InputSlackUserIdByGitHubUsername: map[string]string{"alice": "U2234567890", "bob": "U3234567890"},
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	mockState := getTestState(GetTestStateOptions{PRNumbers: []int{1}})
+	mockState.ReviewerThreads = []state.ReviewerThreadRef{
+		{ReviewerLogin: "alice", ChannelID: "C12345678", MessageTS: "1623850245.000300"},
+		{ReviewerLogin: "bob", ChannelID: "C12345678", MessageTS: "1623850245.000400"},
+	}
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRsByNumber: map[int]*github.PullRequest{
+			1: getTestPR(GetTestPROptions{
+				Number: 1, Title: "First PR", AuthorLogin: "dave", RequestedReviewers: []string{"bob"},
+			}),
+		},
+		MockStateForUpdateMode: &mockState,
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expectedUpdates := []struct {
+		timestamp string
+		text      string
+		prCount   int
+	}{
+		{timestamp: "1623850245.000200", text: "1 open PR is waiting for attention 👀", prCount: 1},
+		{timestamp: "1623850245.000300", text: messagecontent.NoPRsWaitingForReviewerText},
+		{timestamp: "1623850245.000400", text: "1 PR is waiting for your review 👀", prCount: 1},
+	}
+	if len(mockSlackAPI.UpdatedMessages) != len(expectedUpdates) {
+		t.Fatalf("Expected the message and the reviewer threads to be updated, got %+v", mockSlackAPI.UpdatedMessages)
+	}
+	for i, expected := range expectedUpdates {
+		updated := mockSlackAPI.UpdatedMessages[i]
+		if updated.Timestamp != expected.timestamp || updated.Text != expected.text {
+			t.Errorf("Expected message %s to be updated with '%s', got %+v", expected.timestamp, expected.text, updated)
+		}
+		if prCount := updated.Blocks.GetPRCount(); prCount != expected.prCount {
+			t.Errorf("Expected %d PRs in message %s, got %d", expected.prCount, expected.timestamp, prCount)
+		}
+	}
+	if len(mockSlackAPI.SentReplies) != 0 {
+		t.Errorf("Expected no new replies in update mode, got %+v", mockSlackAPI.SentReplies)
+	}
+}
+
+func TestUpdateModeLooksUpPRsByNodeID(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputRunMode: config.RunModeUpdate,
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	mockState := getTestState(GetTestStateOptions{PRNumbers: []int{1, 2}})
+	mockState.SchemaVersion = state.CurrentSchemaVersion
+	mockState.PullRequests[0].NodeID = "PR_node1"
+	mockState.PullRequests[1].NodeID = "PR_node2"
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{
+			getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice", NodeID: "PR_node1"}),
+			getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob", NodeID: "PR_node2"}),
+		},
+		// the PRs can only be found by their node IDs
+		ErrByPRNumber: map[int]error{
+			1: errors.New("failed to fetch PR"),
+			2: errors.New("failed to fetch PR"),
+		},
+		MockStateForUpdateMode: &mockState,
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if mockSlackAPI.UpdatedMessage.Text != "2 open PRs are waiting for attention 👀" {
+		t.Errorf("Expected both PRs in the updated message, got '%s'", mockSlackAPI.UpdatedMessage.Text)
+	}
+	for _, expected := range []string{"First PR 5 hours ago by Alice", "Second PR 5 hours ago by Bob"} {
+		if !mockSlackAPI.UpdatedMessage.Blocks.SomePRItemContainsText(expected) {
+			t.Errorf("Expected '%s' in the PR items, got: %v", expected, mockSlackAPI.UpdatedMessage.Blocks.GetAllPRItemTexts())
+		}
+	}
+}
+
+func TestUpdateModeTruncateKeep(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputRunMode:      config.RunModeUpdate,
+		config.InputTruncateKeep: string(config.TruncateKeepNewest),
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	prCount := githubclient.MaxPRsToFetch + 10
+	prNumbers := make([]int, 0, prCount)
+	prsByNumber := make(map[int]*github.PullRequest, prCount)
+	for number := 1; number <= prCount; number++ {
+		prNumbers = append(prNumbers, number)
+		prsByNumber[number] = getTestPR(GetTestPROptions{
+			Number: number, Title: "PR " + strconv.Itoa(number), AgeHours: float32(number), // PR 1 is the newest
+		})
+	}
+	mockState := getTestState(GetTestStateOptions{PRNumbers: prNumbers})
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRsByNumber:            prsByNumber,
+		MockStateForUpdateMode: &mockState,
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	blocks := mockSlackAPI.UpdatedMessage.Blocks
+	if count := blocks.GetPRCount(); count != githubclient.MaxPRsToFetch {
+		t.Fatalf("Expected %d PRs in the updated message, got %d", githubclient.MaxPRsToFetch, count)
+	}
+	if !blocks.SomePRItemContainsText("PR 1 ") || blocks.SomePRItemContainsText("PR 60 ") {
+		t.Errorf("Expected the newest PRs to be kept, got: %v", blocks.GetAllPRItemTexts())
+	}
+}
+
+func TestUpdateModeMultipleWorkspaces(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputRunMode:          config.RunModeUpdate,
+		config.InputSlackBotToken:    nil,
+		config.InputSlackChannelName: nil,
+		config.InputWorkspaceTargets: `[
+			{"slack-bot-token": "TOKEN_A", "slack-channel-id": "C12345678"},
+			{"slack-bot-token": "TOKEN_B", "slack-channel-id": "C87654321"},
+			{"slack-bot-token": "TOKEN_C", "slack-channel-id": "C99999999"}
+		]`,
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	mockState := getTestState(GetTestStateOptions{PRNumbers: []int{1}})
+	mockState.SlackMessages = []state.SlackRef{
+		{ChannelID: "C12345678", MessageTS: "1623850245.000200"},
+		{ChannelID: "C87654321", MessageTS: "1623850245.000300"},
+	}
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRsByNumber: map[int]*github.PullRequest{
+			1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+		},
+		MockStateForUpdateMode: &mockState,
+	})
+	mockSlackAPIByToken := map[string]*mockslackclient.MockSlackAPI{
+		"TOKEN_A": mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{}),
+		"TOKEN_B": mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{}),
+		"TOKEN_C": mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{}),
+	}
+
+	err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetterByToken(mockSlackAPIByToken))
+	if err != nil {
+		t.Fatalf("Expected Run to succeed, but got error: %v", err)
+	}
+
+	for token, expectedRef := range map[string]state.SlackRef{
+		"TOKEN_A": mockState.SlackMessages[0],
+		"TOKEN_B": mockState.SlackMessages[1],
+	} {
+		updatedMessage := mockSlackAPIByToken[token].UpdatedMessage
+		if updatedMessage.ChannelID != expectedRef.ChannelID || updatedMessage.Timestamp != expectedRef.MessageTS {
+			t.Errorf("Expected message %+v to be updated with %s, got %s/%s",
+				expectedRef, token, updatedMessage.ChannelID, updatedMessage.Timestamp)
+		}
+		if !updatedMessage.Blocks.SomePRItemContainsText("First PR") {
+			t.Errorf("Expected updated message of %s to contain the PR", token)
+		}
+	}
+	if mockSlackAPIByToken["TOKEN_C"].UpdatedMessage.ChannelID != "" {
+		t.Error("Expected no message to be updated in a workspace without a message in state")
+	}
+}
+
+func TestUpdateModePRsOfUnknownRepositories(t *testing.T) {
+	testCases := []struct {
+		name              string
+		onUnknownRepo     any
+		expectedPRNumbers []int
+	}{
+		{name: "kept by default without repository filters", expectedPRNumbers: []int{1, 2}},
+		{name: "dropped", onUnknownRepo: "drop", expectedPRNumbers: []int{1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputRunMode:       config.RunModeUpdate,
+				config.InputOnUnknownRepo: tc.onUnknownRepo,
+				// the filters of test-org/test-repo would exclude the PR of other-org/test-repo (same name)
+				config.InputRepositoryFilters: "test-repo: {\"ignored-authors\": [\"bob\"]}",
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			mockState := getTestState(GetTestStateOptions{PRNumbers: []int{1}})
+			mockState.PullRequests = append(mockState.PullRequests, models.PullRequestRef{
+				Repository: models.Repository{Owner: "other-org", Name: "test-repo"},
+				Number:     2,
+			})
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRsByNumber: map[int]*github.PullRequest{
+					1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+					2: getTestPR(GetTestPROptions{Number: 2, Title: "PR of removed repo", AuthorLogin: "bob"}),
+				},
+				MockStateForUpdateMode: &mockState,
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			updatedBlocks := mockSlackAPI.UpdatedMessage.Blocks
+			if updatedBlocks.GetPRCount() != len(tc.expectedPRNumbers) {
+				t.Errorf("Expected %d PRs in the updated message, got %d", len(tc.expectedPRNumbers), updatedBlocks.GetPRCount())
+			}
+			hasRemovedRepoPR := updatedBlocks.SomePRItemContainsText("PR of removed repo")
+			if hasRemovedRepoPR != slices.Contains(tc.expectedPRNumbers, 2) {
+				t.Errorf("Expected PR of the removed repository in the message: %v", !hasRemovedRepoPR)
+			}
+		})
+	}
+}
+
+func TestScenariosSyncMode(t *testing.T) {
+	recentState := getTestState(GetTestStateOptions{PRNumbers: []int{1}})
+	oldState := getTestState(GetTestStateOptions{PRNumbers: []int{1}})
+	oldState.CreatedAt = time.Now().Add(-25 * time.Hour)
+	unsupportedState := getTestState(GetTestStateOptions{PRNumbers: []int{1}})
+	unsupportedState.SchemaVersion = 0
+
+	testCases := []struct {
+		name               string
+		mockState          *state.State
+		slackOptions       mockslackclient.MockSlackClientOptions
+		expectUpdate       bool
+		expectPost         bool
+		expectedCreatedAt  *time.Time
+		expectedSummary    string
+		configOverrides    map[string]any
+		expectedPRsInState int
+	}{
+		{
+			name:               "posts a new message without previous state",
+			expectPost:         true,
+			expectedSummary:    "2 open PRs are waiting for attention 👀",
+			expectedPRsInState: 2,
+		},
+		{
+			name:               "updates a recent message",
+			mockState:          &recentState,
+			expectUpdate:       true,
+			expectedCreatedAt:  &recentState.CreatedAt,
+			expectedSummary:    "2 open PRs are waiting for attention 👀 (▲ 1 since last run)",
+			expectedPRsInState: 2,
+		},
+		{
+			name:               "posts a new message if the previous one is too old",
+			mockState:          &oldState,
+			expectPost:         true,
+			expectedSummary:    "2 open PRs are waiting for attention 👀 (▲ 1 since last run)",
+			expectedPRsInState: 2,
+		},
+		{
+			name:      "updates an older message with a longer max message age",
+			mockState: &oldState,
+			configOverrides: map[string]any{
+				config.InputSyncMaxMessageAgeHours: 48,
+			},
+			expectUpdate:       true,
+			expectedCreatedAt:  &oldState.CreatedAt,
+			expectedSummary:    "2 open PRs are waiting for attention 👀 (▲ 1 since last run)",
+			expectedPRsInState: 2,
+		},
+		{
+			name:               "posts a new message if the previous state is not valid",
+			mockState:          &unsupportedState,
+			expectPost:         true,
+			expectedSummary:    "2 open PRs are waiting for attention 👀 (▲ 1 since last run)",
+			expectedPRsInState: 2,
+		},
+		{
+			name:      "posts a new message if updating the previous one fails",
+			mockState: &recentState,
+			slackOptions: mockslackclient.MockSlackClientOptions{
+				UpdateMessageError: errors.New("message_not_found"),
+			},
+			expectPost:         true,
+			expectedSummary:    "2 open PRs are waiting for attention 👀 (▲ 1 since last run)",
+			expectedPRsInState: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testStateFilePath := filepath.Join(t.TempDir(), "pr-slack-reminder-state.json")
+			configOverrides := map[string]any{
+				config.InputRunMode:     config.RunModeSync,
+				config.EnvStateFilePath: testStateFilePath,
+			}
+			maps.Copy(configOverrides, tc.configOverrides)
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+					getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob"}),
+				},
+				MockStateForUpdateMode: tc.mockState,
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(tc.slackOptions)
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if tc.expectUpdate {
+				if mockSlackAPI.UpdatedMessage.Timestamp != tc.mockState.SlackMessage.MessageTS {
+					t.Errorf("Expected message %s to be updated, got '%s'",
+						tc.mockState.SlackMessage.MessageTS, mockSlackAPI.UpdatedMessage.Timestamp)
+				}
+				if mockSlackAPI.UpdatedMessage.Text != tc.expectedSummary {
+					t.Errorf("Expected summary '%s', got '%s'", tc.expectedSummary, mockSlackAPI.UpdatedMessage.Text)
+				}
+			}
+			if tc.expectPost {
+				if mockSlackAPI.SentMessage.Text != tc.expectedSummary {
+					t.Errorf("Expected summary '%s', got '%s'", tc.expectedSummary, mockSlackAPI.SentMessage.Text)
+				}
+			} else if mockSlackAPI.SentMessage.Text != "" {
+				t.Errorf("Expected no new message to be posted, got '%s'", mockSlackAPI.SentMessage.Text)
+			}
+
+			var savedState state.State
+			if err := testhelpers.LoadJSONFromFile(testStateFilePath, &savedState); err != nil {
+				t.Fatalf("Failed to load state file: %v", err)
+			}
+			if len(savedState.PullRequests) != tc.expectedPRsInState {
+				t.Errorf("Expected %d PRs in state, got %d", tc.expectedPRsInState, len(savedState.PullRequests))
+			}
+			if tc.expectedCreatedAt != nil && !savedState.CreatedAt.Equal(*tc.expectedCreatedAt) {
+				t.Errorf("Expected the creation time of the previous state to be kept, got %v", savedState.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestMessageTTL(t *testing.T) {
+	testCases := []struct {
+		name            string
+		runMode         config.RunMode
+		messageTTLHours int
+		expectReplaced  bool
+	}{
+		{name: "update mode replaces an expired message", runMode: config.RunModeUpdate, messageTTLHours: 24, expectReplaced: true},
+		{name: "update mode updates a message within TTL", runMode: config.RunModeUpdate, messageTTLHours: 48},
+		{name: "sync mode replaces an expired message", runMode: config.RunModeSync, messageTTLHours: 24, expectReplaced: true},
+		{name: "sync mode updates a message within TTL", runMode: config.RunModeSync, messageTTLHours: 48},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testStateFilePath := filepath.Join(t.TempDir(), "pr-slack-reminder-state.json")
+			configOverrides := map[string]any{
+				config.InputRunMode:                tc.runMode,
+				config.InputMessageTTLHours:        tc.messageTTLHours,
+				config.InputSyncMaxMessageAgeHours: 72,
+				config.EnvStateFilePath:            testStateFilePath,
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			mockState := getTestState(GetTestStateOptions{PRNumbers: []int{1}})
+			mockState.CreatedAt = time.Now().Add(-30 * time.Hour)
+			testPR := getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"})
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs:                    []*github.PullRequest{testPR},
+				PRsByNumber:            map[int]*github.PullRequest{1: testPR},
+				MockStateForUpdateMode: &mockState,
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if !tc.expectReplaced {
+				if mockSlackAPI.UpdatedMessage.Timestamp != mockState.SlackMessage.MessageTS {
+					t.Errorf("Expected the message to be updated, got '%s'", mockSlackAPI.UpdatedMessage.Timestamp)
+				}
+				if mockSlackAPI.DeletedMessage.Timestamp != "" || mockSlackAPI.SentMessage.Text != "" {
+					t.Error("Expected the message not to be deleted or posted again")
+				}
+				return
+			}
+			if mockSlackAPI.DeletedMessage.Timestamp != mockState.SlackMessage.MessageTS {
+				t.Errorf("Expected the expired message to be deleted, got '%s'", mockSlackAPI.DeletedMessage.Timestamp)
+			}
+			if mockSlackAPI.UpdatedMessage.Timestamp != "" {
+				t.Error("Expected the expired message not to be updated")
+			}
+			if !mockSlackAPI.SentMessage.Blocks.SomePRItemContainsText("First PR") {
+				t.Error("Expected a new message with the PR to be posted")
+			}
+			var savedState state.State
+			if err := testhelpers.LoadJSONFromFile(testStateFilePath, &savedState); err != nil {
+				t.Fatalf("Failed to load state file: %v", err)
+			}
+			if time.Since(savedState.CreatedAt) > time.Hour {
+				t.Errorf("Expected the state of the new message to be saved, got creation time %v", savedState.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestScenariosUpdateMode(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		config                 testhelpers.TestConfig
+		configOverrides        *map[string]any
+		mockState              *state.State
+		prByNumber             map[int]*github.PullRequest
+		fetchPRErrorByPRNumber map[int]error
+		reviewsByPRNumber      map[int][]*github.PullRequestReview
+		listArtifactsError     error
+		downloadArtifactError  error
+		updateMessageError     error
+		deleteMessageError     error
+		expectedErrorMsg       string
+		expectedPRItemTexts    []string
+		expectMessageDeleted   bool
+	}{
+		{
+			name:   "unset required inputs",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputSlackBotToken: nil,
+			},
+			expectedErrorMsg: "configuration error: required input slack-bot-token is not set",
+		},
+		{
+			name:   "update mode with empty state exits gracefully",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputRunMode: config.RunModeUpdate,
+			},
+			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{}})),
+		},
+		{
+			name:   "update mode with all PRs filtered out deletes message",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputRunMode:       config.RunModeUpdate,
+				config.InputGlobalFilters: "{\"ignored-authors\": [\"alice\", \"bob\"]}",
+			},
+			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1, 2}})),
+			prByNumber: map[int]*github.PullRequest{
+				1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+				2: getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob"}),
+			},
+			expectMessageDeleted: true,
+		},
+		{
+			name:   "update mode with all PRs filtered out and no-prs-message updates message",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputRunMode:       config.RunModeUpdate,
+				config.InputGlobalFilters: "{\"ignored-authors\": [\"alice\", \"bob\"]}",
+				config.InputNoPRsMessage:  "All PRs have been resolved! 🎉",
+			},
+			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1, 2}})),
+			prByNumber: map[int]*github.PullRequest{
+				1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+				2: getTestPR(GetTestPROptions{Number: 2, Title: "Second PR", AuthorLogin: "bob"}),
+			},
+		},
+		{
+			name:   "update mode with all PRs as drafts deletes message",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputRunMode: config.RunModeUpdate,
+			},
+			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1, 2}})),
+			prByNumber: map[int]*github.PullRequest{
+				1: getTestPR(GetTestPROptions{Number: 1, Title: "Draft PR 1", AuthorLogin: "alice", Draft: github.Ptr(true)}),
+				2: getTestPR(GetTestPROptions{Number: 2, Title: "Draft PR 2", AuthorLogin: "bob", Draft: github.Ptr(true)}),
+			},
+			expectMessageDeleted: true,
+		},
+		{
+			name:   "update mode handles delete failure gracefully",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputRunMode:       config.RunModeUpdate,
+				config.InputGlobalFilters: "{\"ignored-authors\": [\"alice\"]}",
+			},
+			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1}})),
+			prByNumber: map[int]*github.PullRequest{
+				1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+			},
+			deleteMessageError:   errors.New("slack delete failed"),
+			expectMessageDeleted: true,
+		},
+		{
+			name:   "update mode with message_not_found error exits gracefully",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputRunMode:       config.RunModeUpdate,
+				config.InputGlobalFilters: "{\"ignored-authors\": [\"alice\"]}",
+			},
+			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1}})),
+			prByNumber: map[int]*github.PullRequest{
+				1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+			},
+			deleteMessageError:   errors.New("message_not_found"),
+			expectMessageDeleted: true,
+		},
+		{
+			name:   "update mode with two PRs with reviewers",
 			config: testhelpers.GetDefaultConfigMinimal(),
 			configOverrides: &map[string]any{
 				config.InputRunMode: config.RunModeUpdate,
@@ -962,6 +4142,22 @@ func TestScenariosUpdateMode(t *testing.T) {
 			},
 			expectedErrorMsg: "failed to fetch PR",
 		},
+		{
+			name:   "update mode skips PR that fails to be fetched if on-pr-fetch-error is skip",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputRunMode:        config.RunModeUpdate,
+				config.InputOnPRFetchError: string(config.PRFetchErrorSkip),
+			},
+			mockState: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1, 2}})),
+			prByNumber: map[int]*github.PullRequest{
+				1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+			},
+			fetchPRErrorByPRNumber: map[int]error{
+				2: errors.New("failed to fetch PR"),
+			},
+			expectedPRItemTexts: []string{"First PR 5 hours ago by Alice"},
+		},
 		{
 			name:   "update mode fails when artifact listing fails",
 			config: testhelpers.GetDefaultConfigMinimal(),
@@ -991,6 +4187,24 @@ func TestScenariosUpdateMode(t *testing.T) {
 			mockState:        nil,
 			expectedErrorMsg: "no artifacts found with name",
 		},
+		{
+			name:   "update mode guides to on-missing-state when state artifact is not found",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputRunMode: config.RunModeUpdate,
+			},
+			mockState:        nil,
+			expectedErrorMsg: "set on-missing-state to 'post' or 'skip'",
+		},
+		{
+			name:   "update mode skips when state artifact is not found and on-missing-state is skip",
+			config: testhelpers.GetDefaultConfigMinimal(),
+			configOverrides: &map[string]any{
+				config.InputRunMode:        config.RunModeUpdate,
+				config.InputOnMissingState: "skip",
+			},
+			mockState: nil,
+		},
 		{
 			name:   "update mode fails when updating Slack message fails",
 			config: testhelpers.GetDefaultConfigMinimal(),
@@ -1140,3 +4354,425 @@ func TestScenariosUpdateMode(t *testing.T) {
 		})
 	}
 }
+
+func TestScenariosEventMode(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		configOverrides      map[string]any
+		eventPayload         string
+		mockState            *state.State
+		prByNumber           map[int]*github.PullRequest
+		expectedErrorMsg     string
+		expectedSentText     string
+		expectedUpdatedText  string
+		expectMessageDeleted bool
+	}{
+		{
+			name:         "opened PR without previous message posts a new message",
+			eventPayload: getTestPullRequestEventPayload("opened", 1),
+			prByNumber: map[int]*github.PullRequest{
+				1: getTestPR(GetTestPROptions{Number: 1, Title: "New PR", AuthorLogin: "alice"}),
+			},
+			expectedSentText: "New PR 5 hours ago by Alice",
+		},
+		{
+			name:         "PR with previous message updates the message",
+			eventPayload: getTestPullRequestEventPayload("closed", 1),
+			mockState:    testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1}})),
+			prByNumber: map[int]*github.PullRequest{
+				1: getTestPR(GetTestPROptions{Number: 1, Title: "Merged PR", AuthorLogin: "alice", State: "closed", Merged: true}),
+			},
+			expectedUpdatedText: "Merged PR 5 hours ago by Alice 🚀",
+		},
+		{
+			name:            "filtered out PR without previous message is not posted",
+			configOverrides: map[string]any{config.InputGlobalFilters: `{"ignored-authors": ["alice"]}`},
+			eventPayload:    getTestPullRequestEventPayload("opened", 1),
+			prByNumber: map[int]*github.PullRequest{
+				1: getTestPR(GetTestPROptions{Number: 1, Title: "Ignored PR", AuthorLogin: "alice"}),
+			},
+		},
+		{
+			name:            "filtered out PR with previous message deletes the message",
+			configOverrides: map[string]any{config.InputGlobalFilters: `{"ignored-authors": ["alice"]}`},
+			eventPayload:    getTestPullRequestEventPayload("edited", 1),
+			mockState:       testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: []int{1}})),
+			prByNumber: map[int]*github.PullRequest{
+				1: getTestPR(GetTestPROptions{Number: 1, Title: "Ignored PR", AuthorLogin: "alice"}),
+			},
+			expectMessageDeleted: true,
+		},
+		{
+			name:             "event without pull request fails",
+			eventPayload:     `{"ref": "refs/heads/main", "repository": {"name": "test-repo", "owner": {"login": "test-org"}}}`,
+			expectedErrorMsg: "failed to read pull request event: event payload does not contain a pull request",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputRunMode:     config.RunModeEvent,
+				config.EnvStateFilePath: filepath.Join(t.TempDir(), "pr-slack-reminder-state.json"),
+			}
+			maps.Copy(configOverrides, tc.configOverrides)
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+			eventPath := filepath.Join(t.TempDir(), "event.json")
+			if err := os.WriteFile(eventPath, []byte(tc.eventPayload), 0644); err != nil {
+				t.Fatalf("Failed to write event payload: %v", err)
+			}
+			t.Setenv(config.EnvGithubEventPath, eventPath)
+
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRsByNumber:            tc.prByNumber,
+				MockStateForUpdateMode: tc.mockState,
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+
+			if tc.expectedErrorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErrorMsg) {
+					t.Fatalf("Expected error '%v', got: %v", tc.expectedErrorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if tc.expectedSentText == "" && mockSlackAPI.SentMessage.ChannelID != "" {
+				t.Errorf("Expected no message to be sent, got: %v", mockSlackAPI.SentMessage.Text)
+			}
+			if tc.expectedSentText != "" && !mockSlackAPI.SentMessage.Blocks.SomePRItemTextIsEqualTo(tc.expectedSentText) {
+				t.Errorf("Expected sent message to contain '%s', got: %v",
+					tc.expectedSentText, mockSlackAPI.SentMessage.Blocks.GetAllPRItemTexts())
+			}
+			if tc.expectedUpdatedText == "" && mockSlackAPI.UpdatedMessage.ChannelID != "" {
+				t.Errorf("Expected no message to be updated, got: %v", mockSlackAPI.UpdatedMessage.Text)
+			}
+			if tc.expectedUpdatedText != "" && !mockSlackAPI.UpdatedMessage.Blocks.SomePRItemTextIsEqualTo(tc.expectedUpdatedText) {
+				t.Errorf("Expected updated message to contain '%s', got: %v",
+					tc.expectedUpdatedText, mockSlackAPI.UpdatedMessage.Blocks.GetAllPRItemTexts())
+			}
+			if tc.expectMessageDeleted != (mockSlackAPI.DeletedMessage.ChannelID != "") {
+				t.Errorf("Expected message deleted to be %v", tc.expectMessageDeleted)
+			}
+		})
+	}
+}
+
+func getTestPullRequestEventPayload(action string, prNumber int) string {
+	return `{
+		"action": "` + action + `",
+		"pull_request": {"number": ` + strconv.Itoa(prNumber) + `},
+		"repository": {"name": "test-repo", "owner": {"login": "test-org"}}
+	}`
+}
+
+func TestSuggestMappingMode(t *testing.T) {
+	suggestedMappingPath := filepath.Join(t.TempDir(), "suggestions", "mapping.txt")
+	configOverrides := map[string]any{
+		config.InputRunMode:                     "suggest-mapping",
+		config.InputSlackChannelName:            nil,
+		config.InputSuggestedMappingFilePath:    suggestedMappingPath,
+		config.InputSlackUserIdByGitHubUsername: map[string]string{"carol": "U3234567890"},
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		OrganizationMembers: map[string][]githubclient.OrganizationMember{
+			"test-org": {
+				{Login: "bob", Name: "Bob Brown"},
+				{Login: "alice", Email: "alice@example.com"},
+				{Login: "carol"},
+				{Login: "dave", Name: "Dave"},
+			},
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{
+		Users: []slack.User{
+			{ID: "U1234567890", Profile: slack.UserProfile{Email: "alice@example.com"}},
+			{ID: "U2234567890", RealName: "Bob Brown"},
+		},
+	})
+
+	if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	content, err := os.ReadFile(suggestedMappingPath)
+	if err != nil {
+		t.Fatalf("Expected the suggested mapping file to be written, got: %v", err)
+	}
+	for _, expected := range []string{"alice: U1234567890", "bob: U2234567890", "carol: U3234567890", "# dave"} {
+		if !strings.Contains(string(content), expected+"\n") {
+			t.Errorf("Expected '%s' in the suggested mapping, got:\n%s", expected, content)
+		}
+	}
+	if mockSlackAPI.SentMessage.Text != "" {
+		t.Errorf("Expected no message to be sent, got: %v", mockSlackAPI.SentMessage.Text)
+	}
+}
+
+func TestRunCLI(t *testing.T) {
+	testCases := []struct {
+		name             string
+		args             []string
+		expectedErrorMsg string
+		expectSent       bool
+		expectPreview    bool
+	}{
+		{
+			name: "message is sent with the inputs of the flags",
+			args: []string{
+				"--repos", "test-org/test-repo", "--channel", "some-channel-name",
+				"--github-token", "SOME_TOKEN", "--slack-bot-token", "SOME_TOKEN",
+				"--input", "old-pr-threshold-hours=2",
+			},
+			expectSent: true,
+		},
+		{
+			name: "message is not sent in dry run",
+			args: []string{
+				"--repos", "test-org/test-repo", "--channel", "some-channel-name",
+				"--github-token", "SOME_TOKEN", "--slack-bot-token", "SOME_TOKEN", "--dry-run",
+			},
+			expectPreview: true,
+		},
+		{
+			name: "version is printed without running",
+			args: []string{"--version"},
+		},
+		{
+			name:             "invalid input flag",
+			args:             []string{"--input", "pr-list-heading"},
+			expectedErrorMsg: "invalid input \"pr-list-heading\", expected name=value",
+		},
+		{
+			name: "missing token",
+			args: []string{
+				"--repos", "test-org/test-repo", "--channel", "some-channel-name", "--github-token", "SOME_TOKEN",
+			},
+			expectedErrorMsg: "configuration error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("SLACK_BOT_TOKEN", "")
+			t.Setenv(config.EnvStateFilePath, filepath.Join(t.TempDir(), "pr-slack-reminder-state.json"))
+			t.Setenv(config.EnvSentSlackBlocksFilePath, filepath.Join(t.TempDir(), "pr-slack-reminder-sent-blocks.json"))
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{getTestPR(GetTestPROptions{Number: 1})},
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			previewFilePath := filepath.Join(t.TempDir(), "preview.html")
+			args := append(slices.Clone(tc.args), "--input", "preview-file-path="+previewFilePath)
+
+			err := main.RunCLI(args, getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+
+			if tc.expectedErrorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErrorMsg) {
+					t.Fatalf("Expected error '%v', got: %v", tc.expectedErrorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if tc.expectSent != (mockSlackAPI.SentMessage.ChannelID != "") {
+				t.Errorf("Expected message sent to be %v", tc.expectSent)
+			}
+			if _, err := os.Stat(previewFilePath); tc.expectPreview != (err == nil) {
+				t.Errorf("Expected message preview to be saved to be %v, got: %v", tc.expectPreview, err)
+			}
+		})
+	}
+}
+
+func TestSplitMessagesByRepo(t *testing.T) {
+	var repositories []string
+	for i := 1; i <= 20; i++ {
+		repositories = append(repositories, "test-org/repo-"+strconv.Itoa(i))
+	}
+	stateFilePath := filepath.Join(t.TempDir(), "pr-slack-reminder-state.json")
+	configOverrides := map[string]any{
+		config.InputGithubRepositories:  strings.Join(repositories, "\n"),
+		config.InputGroupBy:             "repository",
+		config.InputSplitMessagesByRepo: "true",
+		config.EnvStateFilePath:         stateFilePath,
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	mockGitHubClientGetter := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"})},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	err := main.Run(mockGitHubClientGetter, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(mockSlackAPI.SentMessages) != len(repositories) {
+		t.Fatalf("Expected a message per repository, got %d messages", len(mockSlackAPI.SentMessages))
+	}
+	for i, message := range mockSlackAPI.SentMessages {
+		if !message.Blocks.SomePRItemContainsText("First PR") {
+			t.Errorf("Expected message %d to list the PR of its repository", i)
+		}
+	}
+	stateData, err := os.ReadFile(stateFilePath)
+	if err != nil {
+		t.Fatalf("Expected the state to be saved, got: %v", err)
+	}
+	var savedState state.State
+	if err := json.Unmarshal(stateData, &savedState); err != nil {
+		t.Fatalf("Failed to parse the saved state: %v", err)
+	}
+	if len(savedState.GetSlackRefs()) != len(repositories) {
+		t.Errorf("Expected all the messages in the state, got %+v", savedState.GetSlackRefs())
+	}
+}
+
+func TestUpdateModeSplitMessagesByRepo(t *testing.T) {
+	configOverrides := map[string]any{
+		config.InputRunMode:             config.RunModeUpdate,
+		config.InputGroupBy:             "repository",
+		config.InputSplitMessagesByRepo: "true",
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+	// the messages of the other repositories are no longer needed, as only the PR of one is left in the state
+	mockState := getTestState(GetTestStateOptions{PRNumbers: []int{1}})
+	mockState.SlackMessages = []state.SlackRef{
+		{ChannelID: "C12345678", MessageTS: "1623850245.000200"},
+		{ChannelID: "C12345678", MessageTS: "1623850245.000300"},
+		{ChannelID: "C12345678", MessageTS: "1623850245.000400"},
+	}
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRsByNumber: map[int]*github.PullRequest{
+			1: getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+		},
+		MockStateForUpdateMode: &mockState,
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+	if err != nil {
+		t.Fatalf("Expected Run to succeed, but got error: %v", err)
+	}
+
+	if len(mockSlackAPI.UpdatedMessages) != 1 || mockSlackAPI.UpdatedMessage.Timestamp != "1623850245.000200" {
+		t.Fatalf("Expected only the first message to be updated, got %+v", mockSlackAPI.UpdatedMessages)
+	}
+	if !mockSlackAPI.UpdatedMessage.Blocks.SomePRItemContainsText("First PR") {
+		t.Errorf("Expected the updated message to contain the PR")
+	}
+	if mockSlackAPI.DeletedMessage.Timestamp != "1623850245.000400" {
+		t.Errorf("Expected the messages left over to be deleted, got %+v", mockSlackAPI.DeletedMessage)
+	}
+}
+
+func TestShowRecentlyMerged(t *testing.T) {
+	mergedAt := &github.Timestamp{Time: time.Now().Add(-2 * time.Hour)}
+	configOverrides := map[string]any{
+		config.InputShowRecentlyMergedHours: "24",
+		config.EnvStateFilePath:             filepath.Join(t.TempDir(), "pr-slack-reminder-state.json"),
+	}
+	testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+	mockGitHubClientGetter := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{getTestPR(GetTestPROptions{Number: 1, Title: "Open PR", AuthorLogin: "alice"})},
+		ClosedPRs: []*github.PullRequest{
+			{
+				Number:   github.Ptr(2),
+				Title:    github.Ptr("Merged PR"),
+				HTMLURL:  github.Ptr("https://github.com/test-org/test-repo/pull/2"),
+				User:     &github.User{Login: github.Ptr("bob")},
+				MergedAt: mergedAt,
+			},
+		},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	err := main.Run(mockGitHubClientGetter, mockslackclient.MakeSlackClientGetter(mockSlackAPI))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	sentBlocks, err := json.Marshal(mockSlackAPI.SentMessage.Blocks)
+	if err != nil {
+		t.Fatalf("Failed to marshal the sent blocks: %v", err)
+	}
+	for _, expected := range []string{blockids.RecentlyMergedHeading, "Merged PR", "🚀 in test-org/test-repo"} {
+		if !strings.Contains(string(sentBlocks), expected) {
+			t.Errorf("Expected the sent message to contain %q, got: %s", expected, sentBlocks)
+		}
+	}
+	if mockSlackAPI.SentMessage.Blocks.SomePRItemContainsText("Merged PR") {
+		t.Errorf("Expected the merged PR not to be listed with the open PRs")
+	}
+}
+
+func TestPostModeResolvedPRs(t *testing.T) {
+	testCases := []struct {
+		name            string
+		previousPRs     []int
+		expectedSummary string
+	}{
+		{
+			name:            "PRs of the previous reminder were merged and closed",
+			previousPRs:     []int{1, 2, 3, 4, 5},
+			expectedSummary: "✅ 2 PRs from last reminder were merged, 1 closed",
+		},
+		{
+			name:            "a PR of the previous reminder was merged",
+			previousPRs:     []int{1, 2},
+			expectedSummary: "✅ 1 PR from last reminder was merged",
+		},
+		{
+			name:        "all PRs of the previous reminder are still listed",
+			previousPRs: []int{1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configOverrides := map[string]any{
+				config.InputShowResolvedPRs: "true",
+				config.EnvStateFilePath:     filepath.Join(t.TempDir(), "pr-slack-reminder-state.json"),
+			}
+			testhelpers.SetTestEnvironment(t, testhelpers.GetDefaultConfigMinimal(), &configOverrides)
+
+			getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+				PRs: []*github.PullRequest{
+					getTestPR(GetTestPROptions{Number: 1, Title: "First PR", AuthorLogin: "alice"}),
+				},
+				PRsByNumber: map[int]*github.PullRequest{
+					2: getTestPR(GetTestPROptions{Number: 2, Title: "Merged PR", State: "closed", Merged: true}),
+					3: getTestPR(GetTestPROptions{Number: 3, Title: "Closed PR", State: "closed"}),
+					4: getTestPR(GetTestPROptions{Number: 4, Title: "Another merged PR", State: "closed", Merged: true}),
+					// still open, but no longer listed (e.g. filtered out)
+					5: getTestPR(GetTestPROptions{Number: 5, Title: "Open PR"}),
+				},
+				MockStateForUpdateMode: testhelpers.AsPointer(getTestState(GetTestStateOptions{PRNumbers: tc.previousPRs})),
+			})
+			mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+			if err := main.Run(getGitHubClient, mockslackclient.MakeSlackClientGetter(mockSlackAPI)); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			sentBlocks, err := json.Marshal(mockSlackAPI.SentMessage.Blocks)
+			if err != nil {
+				t.Fatalf("Failed to marshal the sent blocks: %v", err)
+			}
+			hasResolvedPRs := strings.Contains(string(sentBlocks), blockids.ResolvedPRs)
+			if hasResolvedPRs != (tc.expectedSummary != "") {
+				t.Fatalf("Expected the resolved PRs block to be sent: %v, got: %s", tc.expectedSummary != "", sentBlocks)
+			}
+			if tc.expectedSummary != "" && !strings.Contains(string(sentBlocks), tc.expectedSummary) {
+				t.Errorf("Expected the summary '%s', got: %s", tc.expectedSummary, sentBlocks)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Package discordclient sends messages to a Discord channel via a webhook.
+// PR lists are sent as embeds, see https://discord.com/developers/docs/resources/message#embed-object
+package discordclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const RequestTimeout = 10 * time.Second
+
+// Limits of a single webhook message (embeds are paginated to multiple messages to fit them).
+const (
+	MaxEmbedsPerMessage  = 10
+	MaxFieldsPerEmbed    = 25
+	MaxEmbedCharacters   = 6000 // total of titles, descriptions, field names and values in a message
+	MaxFieldNameLength   = 256
+	MaxFieldValueLength  = 1024
+	MaxEmbedTitleLength  = 256
+	MaxDescriptionLength = 4096
+)
+
+type Message struct {
+	Content string  `json:"content,omitempty"`
+	Embeds  []Embed `json:"embeds,omitempty"`
+}
+
+type Embed struct {
+	Title       string  `json:"title,omitempty"`
+	URL         string  `json:"url,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Fields      []Field `json:"fields,omitempty"`
+	Color       int     `json:"color,omitempty"` // RGB value of the color of the left border
+}
+
+type Field struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CharacterCount returns the number of characters that count towards MaxEmbedCharacters.
+func (e Embed) CharacterCount() int {
+	count := len([]rune(e.Title)) + len([]rune(e.Description))
+	for _, field := range e.Fields {
+		count += field.CharacterCount()
+	}
+	return count
+}
+
+func (f Field) CharacterCount() int {
+	return len([]rune(f.Name)) + len([]rune(f.Value))
+}
+
+type Client interface {
+	SendMessage(message Message) error
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+func NewClient(httpClient HTTPClient, webhookURL string) Client {
+	return &client{httpClient: httpClient, webhookURL: webhookURL}
+}
+
+type client struct {
+	httpClient HTTPClient
+	webhookURL string
+}
+
+func (c *client) SendMessage(message Message) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode Discord message: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Discord request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Discord message: %w", err)
+	}
+	defer resp.Body.Close()
+	// Discord responds with 204 No Content unless the webhook is called with ?wait=true
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to send Discord message: unexpected status %d: %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package discordclient_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/discordclient"
+)
+
+func TestSendMessage(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		expectedErrMsg string
+	}{
+		{name: "sends the message", status: http.StatusNoContent},
+		{
+			name:           "returns error on unexpected status",
+			status:         http.StatusBadRequest,
+			expectedErrMsg: "failed to send Discord message: unexpected status 400: invalid embed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var received discordclient.Message
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected POST request, got %s", r.Method)
+				}
+				json.NewDecoder(r.Body).Decode(&received)
+				w.WriteHeader(tc.status)
+				if tc.status != http.StatusNoContent {
+					w.Write([]byte("invalid embed"))
+				}
+			}))
+			defer server.Close()
+
+			client := discordclient.NewClient(http.DefaultClient, server.URL)
+			err := client.SendMessage(discordclient.Message{Content: "2 open PRs"})
+
+			if tc.expectedErrMsg != "" {
+				if err == nil || err.Error() != tc.expectedErrMsg {
+					t.Fatalf("Expected error '%s', got: %v", tc.expectedErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if received.Content != "2 open PRs" {
+				t.Errorf("Expected message content '2 open PRs', got '%s'", received.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package githubclient
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/runreport"
+)
+
+var errAPICallBudgetExhausted = errors.New("the GitHub API call budget is exhausted")
+
+// apiCallBudget limits the number of GitHub API calls of a run (max-api-calls). The calls needed to
+// find the PRs are always made (and counted), but the optional enrichment of the PRs (e.g. fetching
+// their reviews or checks) is skipped when it would exceed the budget, so that the PRs are shown with
+// fewer details instead of the run failing or using up a rate limit shared with other workflows.
+// The calls for the state artifacts are not counted, as they may be made with another token.
+type apiCallBudget struct {
+	maxCalls int64 // 0 if the calls are not limited
+	calls    atomic.Int64
+}
+
+// count records calls that are made regardless of the budget.
+func (b *apiCallBudget) count(calls int) {
+	b.calls.Add(int64(calls))
+}
+
+// reserve records the calls and returns true if they fit in the budget. Otherwise nothing is
+// recorded and false is returned, i.e. the calls should not be made.
+func (b *apiCallBudget) reserve(calls int) bool {
+	for {
+		current := b.calls.Load()
+		if b.maxCalls > 0 && current+int64(calls) > b.maxCalls {
+			return false
+		}
+		if b.calls.CompareAndSwap(current, current+int64(calls)) {
+			return true
+		}
+	}
+}
+
+// Reports the enrichment (e.g. "reviews") that was skipped for the PRs as the budget was exhausted.
+func (b *apiCallBudget) reportSkipped(ctx context.Context, enrichment string, skippedPRCount int) {
+	if skippedPRCount == 0 {
+		return
+	}
+	log.Printf("The GitHub API call budget (%d) is exhausted, skipped fetching %s", b.maxCalls, enrichment)
+	runreport.FromContext(ctx).Add(
+		runreport.KindOther,
+		"GitHub API call budget of %d calls exhausted, %s of %d PRs not fetched", b.maxCalls, enrichment, skippedPRCount,
+	)
+}
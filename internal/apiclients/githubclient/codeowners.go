@@ -0,0 +1,100 @@
+package githubclient
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// The locations of the CODEOWNERS file in the order in which GitHub looks for it
+var codeownersFilePaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+type codeownersRule struct {
+	pattern *regexp.Regexp
+	owners  []string // e.g. "@alice", "@org/team" or "alice@example.com"
+}
+
+// codeowners are the rules of a CODEOWNERS file in the order of the file.
+type codeowners []codeownersRule
+
+// Parses the content of a CODEOWNERS file, skipping comments and invalid patterns.
+func parseCodeowners(content string) codeowners {
+	var rules codeowners
+	for line := range strings.Lines(content) {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		pattern, err := codeownersPatternToRegexp(fields[0])
+		if err != nil {
+			continue
+		}
+		rules = append(rules, codeownersRule{pattern: pattern, owners: fields[1:]})
+	}
+	return rules
+}
+
+// Returns the owners of the file, i.e. the owners of the last matching rule (as in GitHub).
+func (c codeowners) getOwners(filePath string) []string {
+	for _, rule := range slices.Backward(c) {
+		if rule.pattern.MatchString(filePath) {
+			return rule.owners
+		}
+	}
+	return nil
+}
+
+// Returns true if any of the users is an owner of any of the files. Teams are not resolved to their
+// members, so only users listed directly (as @login) in the CODEOWNERS file are recognized.
+func (c codeowners) isOwnedByAny(filePaths []string, logins []string) bool {
+	for _, filePath := range filePaths {
+		for _, owner := range c.getOwners(filePath) {
+			if slices.ContainsFunc(logins, func(login string) bool { return strings.EqualFold("@"+login, owner) }) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Converts a CODEOWNERS (gitignore style) pattern to a regular expression matching file paths
+// relative to the repository root.
+func codeownersPatternToRegexp(pattern string) (*regexp.Regexp, error) {
+	isDirectory := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	// patterns with a slash at the beginning or in the middle are relative to the root
+	isAnchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var b strings.Builder
+	if isAnchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+	if isDirectory {
+		// only the contents of matching directories are matched
+		b.WriteString("/.*$")
+	} else {
+		// matching directories own all of their contents
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
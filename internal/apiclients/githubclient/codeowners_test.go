@@ -0,0 +1,71 @@
+package githubclient
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCodeownersGetOwners(t *testing.T) {
+	rules := parseCodeowners(`# Default owners
+*       @default-owner
+
+*.js    @js-owner # inline comment
+/docs/  @docs-owner
+apps/   @apps-owner
+/build/logs/ @logs-owner
+**/generated/** @generator
+README.md @readme-owner
+/src/*.go @go-owner
+`)
+	tests := []struct {
+		filePath string
+		expected []string
+	}{
+		{filePath: "main.py", expected: []string{"@default-owner"}},
+		{filePath: "web/app.js", expected: []string{"@js-owner"}},
+		{filePath: "docs/guide.md", expected: []string{"@docs-owner"}},
+		{filePath: "other/docs/guide.md", expected: []string{"@default-owner"}},
+		{filePath: "apps/web/index.html", expected: []string{"@apps-owner"}},
+		{filePath: "nested/apps/index.html", expected: []string{"@apps-owner"}},
+		{filePath: "build/logs/out.log", expected: []string{"@logs-owner"}},
+		{filePath: "a/generated/b/c.ts", expected: []string{"@generator"}},
+		{filePath: "pkg/README.md", expected: []string{"@readme-owner"}},
+		{filePath: "src/main.go", expected: []string{"@go-owner"}},
+		{filePath: "src/nested/main.go", expected: []string{"@default-owner"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			if owners := rules.getOwners(tt.filePath); !slices.Equal(owners, tt.expected) {
+				t.Errorf("getOwners(%s) = %v, expected %v", tt.filePath, owners, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCodeownersIsOwnedByAny(t *testing.T) {
+	rules := parseCodeowners("/backend/ @Alice @org/backend-team\n/frontend/ @bob\n")
+	tests := []struct {
+		name      string
+		filePaths []string
+		logins    []string
+		expected  bool
+	}{
+		{name: "owner approved", filePaths: []string{"backend/api.go"}, logins: []string{"alice"}, expected: true},
+		{name: "owner of another file", filePaths: []string{"backend/api.go"}, logins: []string{"bob"}, expected: false},
+		{
+			name:      "owner of any of the files",
+			filePaths: []string{"backend/api.go", "frontend/app.ts"},
+			logins:    []string{"carol", "bob"},
+			expected:  true,
+		},
+		{name: "files without owners", filePaths: []string{"README.md"}, logins: []string{"alice"}, expected: false},
+		{name: "no approvers", filePaths: []string{"backend/api.go"}, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := rules.isOwnedByAny(tt.filePaths, tt.logins); result != tt.expected {
+				t.Errorf("isOwnedByAny() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -4,52 +4,94 @@ import (
 	"archive/zip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"time"
 
 	"github.com/google/go-github/v78/github"
+	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
 )
 
+var ErrArtifactNotFound = errors.New("no artifacts found")
+
+// MaxArtifactSizeBytes limits the size of the downloaded artifact zip and of the JSON file inside it.
+const MaxArtifactSizeBytes = 50 << 20
+
+// The download is retried on network errors and on server errors (e.g. 502 or 429),
+// resuming from the already downloaded bytes if the server supports range requests.
+const artifactDownloadAttempts = 3
+
+// The delay before the nth retry is n times this. Shortened in tests.
+var artifactDownloadRetryDelay = 2 * time.Second
+
+// AnyBranch as the branch of FetchLatestArtifactByName accepts artifacts of workflow runs on any branch.
+const AnyBranch = "*"
+
 // FetchLatestArtifactByName downloads the most recent GitHub Actions artifact by name,
 // extracts a JSON file from the zip archive, and unmarshals it into the provided struct.
+// Only artifacts of workflow runs on the branch are considered: the default branch of the
+// repository if the branch is empty, or any branch if it is AnyBranch.
 // The target parameter should be a pointer to the target struct for JSON deserialization.
 func (client *client) FetchLatestArtifactByName(
 	ctx context.Context,
-	owner, repo, artifactName, jsonFilePath string,
+	owner, repo, artifactName, branch, jsonFilePath string,
 	target any,
 ) error {
-	opts := &github.ListArtifactsOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
-		Name:        &artifactName,
-	}
-	res, resp, err := client.actionsService.ListArtifacts(ctx, owner, repo, opts)
+	artifacts, err := client.listArtifactsByName(ctx, owner, repo, artifactName, branch)
 	if err != nil {
-		statusText := ""
-		if resp != nil && resp.Status != "" {
-			statusText = " status=" + resp.Status
-		}
-		return fmt.Errorf("failed to list artifacts: %w%s", err, statusText)
+		return err
 	}
-	log.Printf("Found %d artifacts with name %q", res.GetTotalCount(), artifactName)
+	return client.downloadArtifactJSON(ctx, owner, repo, artifacts[0], jsonFilePath, target)
+}
 
-	artifacts := res.Artifacts
-	if len(artifacts) == 0 {
-		return fmt.Errorf("no artifacts found with name %q", artifactName)
+// FetchRunArtifactByName downloads the GitHub Actions artifact by name uploaded by the workflow run
+// (e.g. by an earlier job of the current run) and unmarshals the JSON file inside it into the target
+// like FetchLatestArtifactByName.
+func (client *client) FetchRunArtifactByName(
+	ctx context.Context,
+	owner, repo string,
+	runID int64,
+	artifactName, jsonFilePath string,
+	target any,
+) error {
+	artifacts, err := client.listArtifactsByName(ctx, owner, repo, artifactName, AnyBranch)
+	if err != nil {
+		return err
 	}
-	sort.Slice(artifacts, func(i, j int) bool {
-		return artifacts[i].GetCreatedAt().Time.After(artifacts[j].GetCreatedAt().Time)
+	artifact, found := utilities.Find(artifacts, func(artifact *github.Artifact) bool {
+		return artifact.GetWorkflowRun().GetID() == runID
 	})
+	if !found {
+		return fmt.Errorf("%w with name %q in workflow run %d", ErrArtifactNotFound, artifactName, runID)
+	}
+	return client.downloadArtifactJSON(ctx, owner, repo, artifact, jsonFilePath, target)
+}
 
-	latest := artifacts[0]
-	artifactID := latest.GetID()
+// Downloads the artifact and unmarshals the JSON file inside it into the target.
+func (client *client) downloadArtifactJSON(
+	ctx context.Context,
+	owner, repo string,
+	artifact *github.Artifact,
+	jsonFilePath string,
+	target any,
+) error {
+	artifactName := artifact.GetName()
+	artifactID := artifact.GetID()
+	if artifact.GetSizeInBytes() > MaxArtifactSizeBytes {
+		return fmt.Errorf(
+			"artifact %q is too large (%d bytes, maximum %d bytes)", artifactName, artifact.GetSizeInBytes(), MaxArtifactSizeBytes,
+		)
+	}
 	log.Printf(
 		"Downloading artifact %q (ID: %d) created at %s",
-		artifactName, artifactID, latest.GetCreatedAt(),
+		artifactName, artifactID, artifact.GetCreatedAt(),
 	)
 
 	downloadURL, _, err := client.actionsService.DownloadArtifact(ctx, owner, repo, artifactID, 1)
@@ -57,16 +99,6 @@ func (client *client) FetchLatestArtifactByName(
 		return fmt.Errorf("get artifact download URL: %w", err)
 	}
 
-	httpResp, err := client.http.Get(downloadURL.String())
-	if err != nil {
-		return fmt.Errorf("download artifact zip: %w", err)
-	}
-	defer httpResp.Body.Close()
-
-	if httpResp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code %d when downloading artifact", httpResp.StatusCode)
-	}
-
 	tmpFile, err := os.CreateTemp("", "artifact-*.zip")
 	if err != nil {
 		return fmt.Errorf("create temp file: %w", err)
@@ -77,8 +109,8 @@ func (client *client) FetchLatestArtifactByName(
 		_ = os.Remove(tmpPath)
 	}()
 
-	if _, err := io.Copy(tmpFile, httpResp.Body); err != nil {
-		return fmt.Errorf("write zip to temp file: %w", err)
+	if err := client.downloadArtifactZip(ctx, downloadURL.String(), tmpFile); err != nil {
+		return err
 	}
 	if err := tmpFile.Close(); err != nil {
 		return fmt.Errorf("close temp file: %w", err)
@@ -94,11 +126,15 @@ func (client *client) FetchLatestArtifactByName(
 	for _, f := range zr.File {
 		if filepath.Base(f.Name) == filepath.Base(jsonFilePath) {
 			found = true
+			if f.UncompressedSize64 > MaxArtifactSizeBytes {
+				return fmt.Errorf("json file %q inside artifact zip is too large (%d bytes)", jsonFilePath, f.UncompressedSize64)
+			}
 			rc, err := f.Open()
 			if err != nil {
 				return fmt.Errorf("open file inside zip: %w", err)
 			}
-			dec := json.NewDecoder(rc)
+			// the JSON is decoded as it is extracted, without reading the whole file in memory
+			dec := json.NewDecoder(io.LimitReader(rc, MaxArtifactSizeBytes))
 			if err := dec.Decode(target); err != nil {
 				_ = rc.Close()
 				return fmt.Errorf("decode json %q: %w", jsonFilePath, err)
@@ -114,3 +150,142 @@ func (client *client) FetchLatestArtifactByName(
 
 	return nil
 }
+
+// Downloads the artifact zip into the file, retrying on transient failures.
+func (client *client) downloadArtifactZip(ctx context.Context, downloadURL string, file *os.File) error {
+	var err error
+	for attempt := 1; attempt <= artifactDownloadAttempts; attempt++ {
+		if attempt > 1 {
+			log.Printf("Retrying artifact download (attempt %d/%d): %v", attempt, artifactDownloadAttempts, err)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("download artifact zip: %w", ctx.Err())
+			case <-time.After(time.Duration(attempt-1) * artifactDownloadRetryDelay):
+			}
+		}
+		var retryable bool
+		retryable, err = client.downloadArtifactZipAttempt(ctx, downloadURL, file)
+		if err == nil || !retryable {
+			return err
+		}
+	}
+	return err
+}
+
+// Downloads the (rest of the) artifact zip into the file. If the file already contains bytes from
+// a failed attempt, only the rest is requested, unless the server responds with the whole zip.
+// Returns true with the error if the attempt failed for a transient reason.
+func (client *client) downloadArtifactZipAttempt(
+	ctx context.Context, downloadURL string, file *os.File,
+) (bool, error) {
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return false, fmt.Errorf("seek temp file: %w", err)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, ArtifactDownloadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(attemptCtx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("create artifact download request: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	httpResp, err := client.http.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("download artifact zip: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	switch {
+	case httpResp.StatusCode == http.StatusPartialContent && offset > 0:
+		log.Printf("Resuming artifact download from byte %d", offset)
+	case httpResp.StatusCode == http.StatusOK:
+		if offset, err = restartFile(file); err != nil {
+			return false, err
+		}
+	default:
+		isTransient := httpResp.StatusCode >= 500 || httpResp.StatusCode == http.StatusTooManyRequests
+		return isTransient, fmt.Errorf("unexpected status code %d when downloading artifact", httpResp.StatusCode)
+	}
+
+	written, err := io.Copy(file, io.LimitReader(httpResp.Body, MaxArtifactSizeBytes-offset+1))
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("write zip to temp file: %w", err)
+	}
+	if offset+written > MaxArtifactSizeBytes {
+		return false, fmt.Errorf("artifact zip is larger than the maximum of %d bytes", MaxArtifactSizeBytes)
+	}
+	return false, nil
+}
+
+// Empties the file for downloading the whole zip again.
+func restartFile(file *os.File) (int64, error) {
+	if err := file.Truncate(0); err != nil {
+		return 0, fmt.Errorf("truncate temp file: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("seek temp file: %w", err)
+	}
+	return 0, nil
+}
+
+// Lists the artifacts with the name of workflow runs on the branch (see FetchLatestArtifactByName),
+// the most recent first. Only the first page of the artifacts is listed, i.e. the most recent ones.
+func (client *client) listArtifactsByName(
+	ctx context.Context,
+	owner, repo, artifactName, branch string,
+) ([]*github.Artifact, error) {
+	branch, err := client.getArtifactBranch(ctx, owner, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListArtifactsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+		Name:        &artifactName,
+	}
+	res, resp, err := client.actionsService.ListArtifacts(ctx, owner, repo, opts)
+	if err != nil {
+		statusText := ""
+		if resp != nil && resp.Status != "" {
+			statusText = " status=" + resp.Status
+		}
+		return nil, fmt.Errorf("failed to list artifacts: %w%s", err, statusText)
+	}
+	log.Printf("Found %d artifacts with name %q", res.GetTotalCount(), artifactName)
+
+	artifacts := res.Artifacts
+	if len(artifacts) == 0 {
+		return nil, fmt.Errorf("%w with name %q", ErrArtifactNotFound, artifactName)
+	}
+	if branch != AnyBranch {
+		// e.g. the artifacts of test runs on PR branches must not be used as the state of production runs
+		artifacts = slices.DeleteFunc(artifacts, func(artifact *github.Artifact) bool {
+			return artifact.GetWorkflowRun().GetHeadBranch() != branch
+		})
+		log.Printf("Found %d artifacts with name %q from workflow runs on branch %q", len(artifacts), artifactName, branch)
+		if len(artifacts) == 0 {
+			return nil, fmt.Errorf("%w with name %q on branch %q", ErrArtifactNotFound, artifactName, branch)
+		}
+	}
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].GetCreatedAt().Time.After(artifacts[j].GetCreatedAt().Time)
+	})
+	return artifacts, nil
+}
+
+// Returns the default branch of the repository if the branch is not set.
+func (client *client) getArtifactBranch(ctx context.Context, owner, repo, branch string) (string, error) {
+	if branch != "" {
+		return branch, nil
+	}
+	callCtx, cancel := context.WithTimeout(ctx, RepositoryFetchTimeout)
+	defer cancel()
+	repository, _, err := client.repoService.Get(callCtx, owner, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the default branch of %s/%s: %w", owner, repo, err)
+	}
+	return repository.GetDefaultBranch(), nil
+}
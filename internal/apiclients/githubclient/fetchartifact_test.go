@@ -5,10 +5,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"testing"
 	"time"
 
@@ -27,6 +29,10 @@ type mockActionsServiceWithArtifacts struct {
 	listError      error
 	downloadError  error
 	mockHTTPClient *mockHTTPClientWithZip
+	// The ID of the artifact of the last DownloadArtifact call
+	downloadedArtifactID int64
+	deleteErrorByID      map[int64]error
+	deletedArtifactIDs   []int64
 }
 
 func (m *mockActionsServiceWithArtifacts) ListArtifacts(
@@ -47,16 +53,27 @@ func (m *mockActionsServiceWithArtifacts) DownloadArtifact(
 	if m.downloadError != nil {
 		return nil, &github.Response{Response: &http.Response{StatusCode: 500}}, m.downloadError
 	}
+	m.downloadedArtifactID = artifactID
 	return m.downloadURL, &github.Response{Response: &http.Response{StatusCode: 200}}, nil
 }
 
+func (m *mockActionsServiceWithArtifacts) DeleteArtifact(
+	ctx context.Context, owner string, repo string, artifactID int64,
+) (*github.Response, error) {
+	if err, ok := m.deleteErrorByID[artifactID]; ok {
+		return &github.Response{Response: &http.Response{StatusCode: 500}}, err
+	}
+	m.deletedArtifactIDs = append(m.deletedArtifactIDs, artifactID)
+	return &github.Response{Response: &http.Response{StatusCode: 204}}, nil
+}
+
 type mockHTTPClientWithZip struct {
 	zipData    []byte
 	statusCode int
 	err        error
 }
 
-func (m *mockHTTPClientWithZip) Get(url string) (*http.Response, error) {
+func (m *mockHTTPClientWithZip) Do(req *http.Request) (*http.Response, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -295,7 +312,12 @@ func TestFetchLatestArtifactByName(t *testing.T) {
 				mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}},
 			}
 
-			client := githubclient.NewClient(mockHTTPClient, mockPRService, mockIssueService, mockActions)
+			client := githubclient.NewClient(githubclient.Services{
+				HTTP:         mockHTTPClient,
+				PullRequests: mockPRService,
+				Issues:       mockIssueService,
+				Actions:      mockActions,
+			})
 
 			var result testState
 			err = client.FetchLatestArtifactByName(
@@ -303,6 +325,7 @@ func TestFetchLatestArtifactByName(t *testing.T) {
 				"test-owner",
 				"test-repo",
 				tt.artifactName,
+				githubclient.AnyBranch,
 				tt.jsonFilePath,
 				&result,
 			)
@@ -330,6 +353,277 @@ func TestFetchLatestArtifactByName(t *testing.T) {
 	}
 }
 
+func TestFetchLatestArtifactByNameFiltersByBranch(t *testing.T) {
+	artifactOnBranch := func(id int64, branch string, age time.Duration) *github.Artifact {
+		return &github.Artifact{
+			ID:          github.Ptr(id),
+			Name:        github.Ptr("test-artifact"),
+			CreatedAt:   &github.Timestamp{Time: time.Now().Add(-age)},
+			WorkflowRun: &github.ArtifactWorkflowRun{HeadBranch: github.Ptr(branch)},
+		}
+	}
+	artifacts := []*github.Artifact{
+		artifactOnBranch(1, "main", 2*time.Hour),
+		artifactOnBranch(2, "feature", time.Hour),
+		artifactOnBranch(3, "release", 3*time.Hour),
+	}
+
+	tests := []struct {
+		name               string
+		branch             string
+		expectedArtifactID int64
+		errorContains      string
+	}{
+		{name: "default branch of the repository", branch: "", expectedArtifactID: 1},
+		{name: "given branch", branch: "release", expectedArtifactID: 3},
+		{name: "any branch", branch: githubclient.AnyBranch, expectedArtifactID: 2},
+		{
+			name:          "no artifacts on the branch",
+			branch:        "develop",
+			errorContains: `no artifacts found with name "test-artifact" on branch "develop"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonContent, _ := json.Marshal(testState{Version: 1, Message: "test"})
+			zipData, err := createTestZip("state.json", jsonContent)
+			if err != nil {
+				t.Fatalf("Failed to create test zip: %v", err)
+			}
+			downloadURL, _ := url.Parse("https://example.com/download")
+			mockActions := &mockActionsServiceWithArtifacts{artifacts: slices.Clone(artifacts), downloadURL: downloadURL}
+			httpClient := &mockHTTPClientWithZip{zipData: zipData, statusCode: 200}
+			repoService := &mockRepositoriesService{defaultBranch: "main"}
+			client := githubclient.NewClient(githubclient.Services{
+				HTTP:         httpClient,
+				Actions:      mockActions,
+				Repositories: repoService,
+			})
+
+			var result testState
+			err = client.FetchLatestArtifactByName(
+				context.Background(), "test-owner", "test-repo", "test-artifact", tt.branch, "state.json", &result,
+			)
+
+			if tt.errorContains != "" {
+				if !errors.Is(err, githubclient.ErrArtifactNotFound) || !contains(err.Error(), tt.errorContains) {
+					t.Fatalf("Expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if mockActions.downloadedArtifactID != tt.expectedArtifactID {
+				t.Errorf("Expected artifact %d to be downloaded, got %d", tt.expectedArtifactID, mockActions.downloadedArtifactID)
+			}
+		})
+	}
+}
+
+func TestFetchRunArtifactByName(t *testing.T) {
+	artifactOfRun := func(id, runID int64, branch string) *github.Artifact {
+		return &github.Artifact{
+			ID:          github.Ptr(id),
+			Name:        github.Ptr("test-artifact"),
+			CreatedAt:   &github.Timestamp{Time: time.Now().Add(-time.Duration(id) * time.Hour)},
+			WorkflowRun: &github.ArtifactWorkflowRun{ID: github.Ptr(runID), HeadBranch: github.Ptr(branch)},
+		}
+	}
+	artifacts := []*github.Artifact{
+		artifactOfRun(1, 100, "main"),
+		artifactOfRun(2, 200, "feature"),
+		artifactOfRun(3, 300, "main"),
+	}
+
+	tests := []struct {
+		name               string
+		runID              int64
+		expectedArtifactID int64
+		errorContains      string
+	}{
+		{name: "artifact of the run", runID: 300, expectedArtifactID: 3},
+		{name: "artifact of a run on any branch", runID: 200, expectedArtifactID: 2},
+		{
+			name:          "no artifact in the run",
+			runID:         400,
+			errorContains: `no artifacts found with name "test-artifact" in workflow run 400`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonContent, _ := json.Marshal(testState{Version: 1, Message: "test"})
+			zipData, err := createTestZip("data.json", jsonContent)
+			if err != nil {
+				t.Fatalf("Failed to create test zip: %v", err)
+			}
+			downloadURL, _ := url.Parse("https://example.com/download")
+			mockActions := &mockActionsServiceWithArtifacts{artifacts: slices.Clone(artifacts), downloadURL: downloadURL}
+			httpClient := &mockHTTPClientWithZip{zipData: zipData, statusCode: 200}
+			client := githubclient.NewClient(githubclient.Services{HTTP: httpClient, Actions: mockActions})
+
+			var result testState
+			err = client.FetchRunArtifactByName(
+				context.Background(), "test-owner", "test-repo", tt.runID, "test-artifact", "data.json", &result,
+			)
+
+			if tt.errorContains != "" {
+				if !errors.Is(err, githubclient.ErrArtifactNotFound) || !contains(err.Error(), tt.errorContains) {
+					t.Fatalf("Expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if mockActions.downloadedArtifactID != tt.expectedArtifactID {
+				t.Errorf("Expected artifact %d to be downloaded, got %d", tt.expectedArtifactID, mockActions.downloadedArtifactID)
+			}
+			if result.Message != "test" {
+				t.Errorf("Expected the JSON of the artifact, got %+v", result)
+			}
+		})
+	}
+}
+
+// Responds to the requests in order with the responses (the last one is repeated).
+type mockHTTPClientSequence struct {
+	responses []func(req *http.Request) (*http.Response, error)
+	requests  []*http.Request
+}
+
+func (m *mockHTTPClientSequence) Do(req *http.Request) (*http.Response, error) {
+	m.requests = append(m.requests, req)
+	return m.responses[min(len(m.requests), len(m.responses))-1](req)
+}
+
+func respondWith(statusCode int, body io.Reader) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: statusCode, Body: io.NopCloser(body)}, nil
+	}
+}
+
+// Reads the data and then fails as if the connection was lost.
+type interruptedReader struct{ data io.Reader }
+
+func (r interruptedReader) Read(p []byte) (int, error) {
+	n, err := r.data.Read(p)
+	if err == io.EOF {
+		return n, errors.New("connection reset by peer")
+	}
+	return n, err
+}
+
+func TestFetchLatestArtifactByNameRetries(t *testing.T) {
+	jsonContent, _ := json.Marshal(testState{Version: 5, Message: "retried"})
+	zipData, err := createTestZip("state.json", jsonContent)
+	if err != nil {
+		t.Fatalf("Failed to create test zip: %v", err)
+	}
+	half := len(zipData) / 2
+
+	tests := []struct {
+		name                 string
+		artifactSize         int64
+		responses            []func(req *http.Request) (*http.Response, error)
+		expectedRequestCount int
+		expectedRangeHeader  string // of the last request
+		errorContains        string
+	}{
+		{
+			name: "retries on server error",
+			responses: []func(req *http.Request) (*http.Response, error){
+				respondWith(http.StatusBadGateway, bytes.NewReader(nil)),
+				respondWith(http.StatusOK, bytes.NewReader(zipData)),
+			},
+			expectedRequestCount: 2,
+		},
+		{
+			name: "resumes an interrupted download",
+			responses: []func(req *http.Request) (*http.Response, error){
+				respondWith(http.StatusOK, interruptedReader{bytes.NewReader(zipData[:half])}),
+				respondWith(http.StatusPartialContent, bytes.NewReader(zipData[half:])),
+			},
+			expectedRequestCount: 2,
+			expectedRangeHeader:  fmt.Sprintf("bytes=%d-", half),
+		},
+		{
+			name: "starts over if the server does not support resuming",
+			responses: []func(req *http.Request) (*http.Response, error){
+				respondWith(http.StatusOK, interruptedReader{bytes.NewReader(zipData[:half])}),
+				respondWith(http.StatusOK, bytes.NewReader(zipData)),
+			},
+			expectedRequestCount: 2,
+			expectedRangeHeader:  fmt.Sprintf("bytes=%d-", half),
+		},
+		{
+			name: "gives up after the maximum attempts",
+			responses: []func(req *http.Request) (*http.Response, error){
+				respondWith(http.StatusServiceUnavailable, bytes.NewReader(nil)),
+			},
+			expectedRequestCount: 3,
+			errorContains:        "unexpected status code 503",
+		},
+		{
+			name: "does not retry on client error",
+			responses: []func(req *http.Request) (*http.Response, error){
+				respondWith(http.StatusForbidden, bytes.NewReader(nil)),
+			},
+			expectedRequestCount: 1,
+			errorContains:        "unexpected status code 403",
+		},
+		{
+			name:          "refuses to download a too large artifact",
+			artifactSize:  githubclient.MaxArtifactSizeBytes + 1,
+			errorContains: "is too large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := &mockHTTPClientSequence{responses: tt.responses}
+			downloadURL, _ := url.Parse("https://example.com/download")
+			mockActions := &mockActionsServiceWithArtifacts{
+				artifacts: []*github.Artifact{{
+					ID:          github.Ptr(int64(123)),
+					Name:        github.Ptr("test-artifact"),
+					CreatedAt:   &github.Timestamp{Time: time.Now()},
+					SizeInBytes: github.Ptr(tt.artifactSize),
+				}},
+				downloadURL: downloadURL,
+			}
+			client := githubclient.NewClient(githubclient.Services{HTTP: httpClient, Actions: mockActions})
+
+			var result testState
+			err := client.FetchLatestArtifactByName(
+				context.Background(), "test-owner", "test-repo", "test-artifact", githubclient.AnyBranch, "state.json", &result,
+			)
+
+			if len(httpClient.requests) != tt.expectedRequestCount {
+				t.Errorf("Expected %d download requests, got %d", tt.expectedRequestCount, len(httpClient.requests))
+			}
+			if tt.errorContains != "" {
+				if err == nil || !contains(err.Error(), tt.errorContains) {
+					t.Fatalf("Expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result.Version != 5 || result.Message != "retried" {
+				t.Errorf("Expected the data of the artifact, got %+v", result)
+			}
+			lastRequest := httpClient.requests[len(httpClient.requests)-1]
+			if rangeHeader := lastRequest.Header.Get("Range"); rangeHeader != tt.expectedRangeHeader {
+				t.Errorf("Expected Range header %q, got %q", tt.expectedRangeHeader, rangeHeader)
+			}
+		})
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && containsSubstring(s, substr))
 }
@@ -11,32 +11,89 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
+	"strings"
 
 	"time"
 
 	"github.com/google/go-github/v78/github"
 	"github.com/hellej/pr-slack-reminder-action/internal/config"
 	"github.com/hellej/pr-slack-reminder-action/internal/models"
+	"github.com/hellej/pr-slack-reminder-action/internal/runreport"
 	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
 	"golang.org/x/sync/errgroup"
 )
 
 type Client interface {
+	// Fetches the open PRs that pass the filters. Returns also the number of the PRs left out by the
+	// filters (drafts and the PRs of bots are not counted, as they are never listed).
 	FindOpenPRs(
 		ctx context.Context,
 		repositories []models.Repository,
 		getFiltersForRepository func(repo models.Repository) config.Filters,
-	) ([]PR, error)
+		opts FindOpenPRsOptions,
+	) (prs []PR, filteredPRCount int, err error)
+	// Fetches the referenced PRs. PRs that fail to be fetched are left out if onFetchError is skip.
 	GetPRs(
 		ctx context.Context,
 		references []models.PullRequestRef,
 		getFiltersForRepository func(repo models.Repository) config.Filters,
+		onFetchError config.PRFetchErrorPolicy,
+		opts FindOpenPRsOptions,
 	) ([]PR, error)
 	FetchLatestArtifactByName(
 		ctx context.Context,
-		owner, repo, artifactName, jsonFilePath string,
+		owner, repo, artifactName, branch, jsonFilePath string,
 		target any,
 	) error
+	FetchRunArtifactByName(
+		ctx context.Context,
+		owner, repo string,
+		runID int64,
+		artifactName, jsonFilePath string,
+		target any,
+	) error
+	DeleteOldArtifactsByName(ctx context.Context, owner, repo, artifactName, branch string, keep int) (int, error)
+	FindArchivedRepositories(ctx context.Context, repositories []models.Repository) []models.Repository
+	FindRepositoriesWithoutRequiredReviews(ctx context.Context, repositories []models.Repository) []models.Repository
+	FindFailingWorkflows(ctx context.Context, repositories []models.Repository) []FailingWorkflow
+	FindMilestones(
+		ctx context.Context,
+		repositories []models.Repository,
+		getFiltersForRepository func(repo models.Repository) config.Filters,
+	) []Milestone
+	// Fetches the PRs merged after the given time that pass the filters, the latest merged first
+	FindRecentlyMergedPRs(
+		ctx context.Context,
+		repositories []models.Repository,
+		since time.Time,
+		getFiltersForRepository func(repo models.Repository) config.Filters,
+	) []MergedPR
+	// Sets HasFailingChecks of the PRs from the check runs of their head commits
+	AddFailingChecksInfo(ctx context.Context, prs []PR) []PR
+	// Sets HasCodeownerApproval of the PRs from the CODEOWNERS files of their repositories
+	AddCodeownerApprovalInfo(ctx context.Context, prs []PR) []PR
+	// Sets ReviewDecision of the PRs with the GraphQL API
+	AddReviewDecisionInfo(ctx context.Context, prs []PR) []PR
+	// Sets IsInMergeQueue of the PRs with the GraphQL API
+	AddMergeQueueInfo(ctx context.Context, prs []PR) []PR
+	// Sets Stats of the PRs with the GraphQL API
+	AddReviewEffortInfo(ctx context.Context, prs []PR) []PR
+	// Sets ReviewResponse of the PRs from their timelines with the GraphQL API
+	AddReviewResponseInfo(ctx context.Context, prs []PR) []PR
+	// Refines which users are treated as bots when fetching PRs and reviews
+	SetBotAccounts(botAccounts BotAccounts)
+	// If strict, approvals of older commits than the head commits of the PRs are not counted
+	SetStrictApprovals(strict bool)
+	// Limits the GitHub API calls of the run, the enrichment of the PRs is skipped when the limit is
+	// reached (0 if the calls are not limited)
+	SetAPICallBudget(maxCalls int)
+	// Makes the API calls concerning the repositories with their own tokens instead of the main token
+	SetRepositoryTokens(tokens map[models.Repository]string)
+	// Returns the logins of the members of the teams (given as org/team-slug)
+	FindTeamMembers(ctx context.Context, teams []string) ([]string, error)
+	FindOrganizationMembers(ctx context.Context, org string) ([]OrganizationMember, error)
+	// Returns the logins that do not belong to any GitHub account (e.g. typos)
+	FindUnknownUsers(ctx context.Context, logins []string) ([]string, error)
 }
 
 type GithubPullRequestsService interface {
@@ -60,6 +117,11 @@ type GithubPullRequestsService interface {
 	) (
 		[]*github.PullRequestComment, *github.Response, error,
 	)
+	ListFiles(
+		ctx context.Context, owner string, repo string, number int, opts *github.ListOptions,
+	) (
+		[]*github.CommitFile, *github.Response, error,
+	)
 }
 
 type GithubIssuesService interface {
@@ -68,6 +130,11 @@ type GithubIssuesService interface {
 	) (
 		[]*github.IssueComment, *github.Response, error,
 	)
+	ListMilestones(
+		ctx context.Context, owner string, repo string, opts *github.MilestoneListOptions,
+	) (
+		[]*github.Milestone, *github.Response, error,
+	)
 }
 
 type GithubActionsService interface {
@@ -81,32 +148,97 @@ type GithubActionsService interface {
 	) (
 		*url.URL, *github.Response, error,
 	)
+	DeleteArtifact(ctx context.Context, owner, repo string, artifactID int64) (*github.Response, error)
 }
 
-type HTTPClient interface {
-	Get(url string) (resp *http.Response, err error)
+type GithubRepositoriesService interface {
+	Get(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error)
+	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error)
+	GetContents(
+		ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions,
+	) (
+		*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error,
+	)
 }
 
-type httpClient struct{}
+type GithubWorkflowRunsService interface {
+	ListRepositoryWorkflowRuns(
+		ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions,
+	) (
+		*github.WorkflowRuns, *github.Response, error,
+	)
+}
 
-func (h httpClient) Get(url string) (*http.Response, error) {
-	return http.Get(url)
+type GithubChecksService interface {
+	ListCheckRunsForRef(
+		ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions,
+	) (
+		*github.ListCheckRunsResults, *github.Response, error,
+	)
 }
 
-func NewClient(
-	httpClient HTTPClient,
-	prService GithubPullRequestsService,
-	issueService GithubIssuesService,
-	actionsService GithubActionsService,
-) Client {
+type GithubTeamsService interface {
+	ListTeamMembersBySlug(
+		ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions,
+	) (
+		[]*github.User, *github.Response, error,
+	)
+}
+
+type GithubGraphQLService interface {
+	// Decodes the data of the response to the query to result
+	Query(ctx context.Context, query string, variables map[string]any, result any) error
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Services are the GitHub API services of a client created with NewClient. The services that are
+// not needed (e.g. in tests) may be left out.
+type Services struct {
+	HTTP         HTTPClient
+	PullRequests GithubPullRequestsService
+	Issues       GithubIssuesService
+	Actions      GithubActionsService
+	Repositories GithubRepositoriesService
+	WorkflowRuns GithubWorkflowRunsService
+	Checks       GithubChecksService
+	Teams        GithubTeamsService
+	GraphQL      GithubGraphQLService
+}
+
+func NewClient(s Services) Client {
+	services := repositoryServices{
+		prService:           s.PullRequests,
+		issueService:        s.Issues,
+		repoService:         s.Repositories,
+		workflowRunsService: s.WorkflowRuns,
+		checksService:       s.Checks,
+		graphQLService:      s.GraphQL,
+	}
 	return &client{
-		http:           httpClient,
-		prService:      prService,
-		issueService:   issueService,
-		actionsService: actionsService,
+		http:               s.HTTP,
+		repositoryServices: services,
+		actionsService:     s.Actions,
+		teamsService:       s.Teams,
+		// the given services are used regardless of the tokens of the repositories
+		newRepositoryServices: func(string) repositoryServices { return services },
 	}
 }
 
+func (c *client) SetBotAccounts(botAccounts BotAccounts) {
+	c.botAccounts = botAccounts
+}
+
+func (c *client) SetStrictApprovals(strict bool) {
+	c.strictApprovals = strict
+}
+
+func (c *client) SetAPICallBudget(maxCalls int) {
+	c.apiCalls.maxCalls = int64(maxCalls)
+}
+
 // if the optional tokenForState arg is provided, that will be used for ListArtifacts & DownloadArtifact
 // (the main token may not have "actions: read" permission to the current repository, while that is
 // necessary for the "update" run-mode where the action first needs to fetch the "state" of the previous
@@ -119,19 +251,28 @@ func GetAuthenticatedClient(token, tokenForState string) Client {
 		ghClientForState = github.NewClient(nil).WithAuthToken(tokenForState)
 	}
 
-	return NewClient(
-		httpClient{},
-		ghClient.PullRequests,
-		ghClient.Issues,
-		ghClientForState.Actions,
-	)
+	return &client{
+		http:                  http.DefaultClient,
+		repositoryServices:    repositoryServicesOf(ghClient),
+		actionsService:        ghClientForState.Actions,
+		teamsService:          ghClient.Teams,
+		newRepositoryServices: newRepositoryServices,
+	}
 }
 
 type client struct {
-	http           HTTPClient
-	prService      GithubPullRequestsService
-	issueService   GithubIssuesService
-	actionsService GithubActionsService
+	http HTTPClient
+	// The services of the main token
+	repositoryServices
+	actionsService GithubActionsService // uses the token for state if provided
+	teamsService   GithubTeamsService
+	// Creates the services of the tokens of the repositories (repository-tokens)
+	newRepositoryServices func(token string) repositoryServices
+	tokenByRepository     map[models.Repository]string
+	servicesByToken       map[string]repositoryServices
+	botAccounts           BotAccounts
+	strictApprovals       bool
+	apiCalls              apiCallBudget
 }
 
 // DefaultGitHubAPIConcurrencyLimit caps concurrent repository fetches to avoid
@@ -145,13 +286,486 @@ const MaxPRsToFetch = 50
 const PullRequestListTimeout = 10 * time.Second
 const PullRequestFetchTimeout = 5 * time.Second
 const ReviewsFetchTimeout = 10 * time.Second
+const RepositoryFetchTimeout = 5 * time.Second
+const WorkflowRunsFetchTimeout = 10 * time.Second
+const MilestonesFetchTimeout = 5 * time.Second
+const CheckRunsFetchTimeout = 5 * time.Second
+const CodeownersFetchTimeout = 10 * time.Second
+const TeamMembersFetchTimeout = 10 * time.Second
+const ArtifactDownloadTimeout = 60 * time.Second // per attempt
+
+// Conclusions of workflow runs that are considered failed.
+var failedWorkflowRunConclusions = []string{"failure", "timed_out", "startup_failure"}
+
+// Conclusions of check runs that block merging a PR.
+var failedCheckRunConclusions = []string{"failure", "timed_out", "action_required"}
+
+// Returns the archived repositories of the given repositories. Repositories of which the metadata
+// cannot be fetched are not considered archived (errors with them are reported when fetching PRs).
+func (c *client) FindArchivedRepositories(
+	ctx context.Context, repositories []models.Repository,
+) []models.Repository {
+	fetchGroup, fetchCtx := errgroup.WithContext(ctx)
+	fetchGroup.SetLimit(DefaultGitHubAPIConcurrencyLimit)
+	isArchived := make([]bool, len(repositories))
+
+	for i, repo := range repositories {
+		i, repo := i, repo // https://golang.org/doc/faq#closures_and_goroutines
+		fetchGroup.Go(func() error {
+			callCtx, cancel := context.WithTimeout(fetchCtx, RepositoryFetchTimeout)
+			defer cancel()
+			c.apiCalls.count(1)
+			repository, _, err := c.forRepository(repo).repoService.Get(callCtx, repo.Owner, repo.Name)
+			if err != nil {
+				log.Printf("Unable to check if repository %s is archived: %v", repo.GetPath(), err)
+				return nil
+			}
+			isArchived[i] = repository.GetArchived()
+			return nil
+		})
+	}
+	fetchGroup.Wait()
+
+	var archived []models.Repository
+	for i, repo := range repositories {
+		if isArchived[i] {
+			archived = append(archived, repo)
+		}
+	}
+	return archived
+}
+
+// Returns the repositories of which the default branch does not require approving reviews for PRs
+// (i.e. the branch is not protected or the protection does not require reviews). Repositories that
+// cannot be checked (e.g. due to missing permissions to read the branch protection) are only logged.
+func (c *client) FindRepositoriesWithoutRequiredReviews(
+	ctx context.Context, repositories []models.Repository,
+) []models.Repository {
+	fetchGroup, fetchCtx := errgroup.WithContext(ctx)
+	fetchGroup.SetLimit(DefaultGitHubAPIConcurrencyLimit)
+	reviewsNotRequired := make([]bool, len(repositories))
+
+	for i, repo := range repositories {
+		i, repo := i, repo // https://golang.org/doc/faq#closures_and_goroutines
+		fetchGroup.Go(func() error {
+			required, err := c.requiresReviews(fetchCtx, repo)
+			if err != nil {
+				log.Printf("Unable to check the branch protection of repository %s: %v", repo.GetPath(), err)
+				return nil
+			}
+			reviewsNotRequired[i] = !required
+			return nil
+		})
+	}
+	fetchGroup.Wait()
+
+	var withoutRequiredReviews []models.Repository
+	for i, repo := range repositories {
+		if reviewsNotRequired[i] {
+			withoutRequiredReviews = append(withoutRequiredReviews, repo)
+		}
+	}
+	return withoutRequiredReviews
+}
+
+func (c *client) requiresReviews(ctx context.Context, repo models.Repository) (bool, error) {
+	callCtx, cancel := context.WithTimeout(ctx, RepositoryFetchTimeout)
+	defer cancel()
+	c.apiCalls.count(2)
+	repoService := c.forRepository(repo).repoService
+	repository, _, err := repoService.Get(callCtx, repo.Owner, repo.Name)
+	if err != nil {
+		return false, err
+	}
+	protection, _, err := repoService.GetBranchProtection(
+		callCtx, repo.Owner, repo.Name, repository.GetDefaultBranch(),
+	)
+	if errors.Is(err, github.ErrBranchNotProtected) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	reviews := protection.GetRequiredPullRequestReviews()
+	return reviews != nil && reviews.RequiredApprovingReviewCount > 0, nil
+}
+
+// Returns the workflows of which the latest completed run on the default branch of the repository
+// failed. Errors are only logged, as failing workflows are shown in the message for information only.
+func (c *client) FindFailingWorkflows(
+	ctx context.Context, repositories []models.Repository,
+) []FailingWorkflow {
+	fetchGroup, fetchCtx := errgroup.WithContext(ctx)
+	fetchGroup.SetLimit(DefaultGitHubAPIConcurrencyLimit)
+	failingWorkflowsByRepo := make([][]FailingWorkflow, len(repositories))
+
+	for i, repo := range repositories {
+		i, repo := i, repo // https://golang.org/doc/faq#closures_and_goroutines
+		fetchGroup.Go(func() error {
+			failing, err := c.findFailingWorkflowsOfRepository(fetchCtx, repo)
+			if err != nil {
+				log.Printf("Unable to check failing workflows of repository %s: %v", repo.GetPath(), err)
+				return nil
+			}
+			failingWorkflowsByRepo[i] = failing
+			return nil
+		})
+	}
+	fetchGroup.Wait()
+	return slices.Concat(failingWorkflowsByRepo...)
+}
+
+func (c *client) findFailingWorkflowsOfRepository(
+	ctx context.Context, repo models.Repository,
+) ([]FailingWorkflow, error) {
+	repoCtx, cancelRepo := context.WithTimeout(ctx, RepositoryFetchTimeout)
+	defer cancelRepo()
+	c.apiCalls.count(2) // the repository and its workflow runs
+	repository, _, err := c.forRepository(repo).repoService.Get(repoCtx, repo.Owner, repo.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	runsCtx, cancelRuns := context.WithTimeout(ctx, WorkflowRunsFetchTimeout)
+	defer cancelRuns()
+	runs, _, err := c.forRepository(repo).workflowRunsService.ListRepositoryWorkflowRuns(
+		runsCtx, repo.Owner, repo.Name, &github.ListWorkflowRunsOptions{
+			Branch:      repository.GetDefaultBranch(),
+			Status:      "completed",
+			ListOptions: github.ListOptions{PerPage: 100},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Runs are listed newest first, so the first run of each workflow is the latest one
+	var failing []FailingWorkflow
+	seenWorkflowIDs := map[int64]bool{}
+	for _, run := range runs.WorkflowRuns {
+		if seenWorkflowIDs[run.GetWorkflowID()] {
+			continue
+		}
+		seenWorkflowIDs[run.GetWorkflowID()] = true
+		if slices.Contains(failedWorkflowRunConclusions, run.GetConclusion()) {
+			failing = append(failing, FailingWorkflow{
+				Repository: repo,
+				Name:       run.GetName(),
+				HTMLURL:    run.GetHTMLURL(),
+				Event:      run.GetEvent(),
+			})
+		}
+	}
+	return failing, nil
+}
+
+// Returns the milestones used in the filters of the repositories. Errors are only logged,
+// as milestone progress is shown in the message for information only.
+func (c *client) FindMilestones(
+	ctx context.Context,
+	repositories []models.Repository,
+	getFiltersForRepository func(repo models.Repository) config.Filters,
+) []Milestone {
+	fetchGroup, fetchCtx := errgroup.WithContext(ctx)
+	fetchGroup.SetLimit(DefaultGitHubAPIConcurrencyLimit)
+	milestonesByRepo := make([][]Milestone, len(repositories))
+
+	for i, repo := range repositories {
+		i, repo := i, repo // https://golang.org/doc/faq#closures_and_goroutines
+		titles := getFiltersForRepository(repo).Milestones
+		if len(titles) == 0 {
+			continue
+		}
+		fetchGroup.Go(func() error {
+			callCtx, cancel := context.WithTimeout(fetchCtx, MilestonesFetchTimeout)
+			defer cancel()
+			c.apiCalls.count(1)
+			milestones, _, err := c.forRepository(repo).issueService.ListMilestones(
+				callCtx, repo.Owner, repo.Name, &github.MilestoneListOptions{
+					State:       "all",
+					ListOptions: github.ListOptions{PerPage: 100},
+				},
+			)
+			if err != nil {
+				log.Printf("Unable to fetch milestones of repository %s: %v", repo.GetPath(), err)
+				return nil
+			}
+			for _, milestone := range milestones {
+				if slices.Contains(titles, milestone.GetTitle()) {
+					milestonesByRepo[i] = append(milestonesByRepo[i], Milestone{
+						Repository:   repo,
+						Title:        milestone.GetTitle(),
+						OpenIssues:   milestone.GetOpenIssues(),
+						ClosedIssues: milestone.GetClosedIssues(),
+					})
+				}
+			}
+			return nil
+		})
+	}
+	fetchGroup.Wait()
+	return slices.Concat(milestonesByRepo...)
+}
+
+// The closed PRs are listed by the latest update, so the first page (of 100 PRs) has the PRs merged
+// recently unless a lot of PRs have been updated since. Failing to list the PRs of a repository is
+// not an error, as the recently merged PRs are only shown to celebrate progress.
+func (c *client) FindRecentlyMergedPRs(
+	ctx context.Context,
+	repositories []models.Repository,
+	since time.Time,
+	getFiltersForRepository func(repo models.Repository) config.Filters,
+) []MergedPR {
+	fetchGroup, fetchCtx := errgroup.WithContext(ctx)
+	fetchGroup.SetLimit(DefaultGitHubAPIConcurrencyLimit)
+	mergedPRsByRepo := make([][]MergedPR, len(repositories))
+
+	for i, repo := range repositories {
+		i, repo := i, repo // https://golang.org/doc/faq#closures_and_goroutines
+		fetchGroup.Go(func() error {
+			callCtx, cancel := context.WithTimeout(fetchCtx, PullRequestListTimeout)
+			defer cancel()
+			c.apiCalls.count(1)
+			prs, _, err := c.forRepository(repo).prService.List(
+				callCtx, repo.Owner, repo.Name, &github.PullRequestListOptions{
+					State:       "closed",
+					Sort:        "updated",
+					Direction:   "desc",
+					ListOptions: github.ListOptions{PerPage: 100},
+				},
+			)
+			if err != nil {
+				log.Printf("Unable to list recently merged PRs of repository %s: %v", repo.GetPath(), err)
+				return nil
+			}
+			filters := getFiltersForRepository(repo)
+			filters.MinAgeHours = 0 // only concerns the open PRs
+			for _, pr := range prs {
+				if !pr.GetMergedAt().After(since) || c.botAccounts.isBotAuthor(pr.GetUser().GetLogin()) ||
+					!includePR(newPRResult(pr, repo, c.botAccounts).pr, filters) {
+					continue
+				}
+				mergedPRsByRepo[i] = append(mergedPRsByRepo[i], MergedPR{
+					Repository: repo,
+					Title:      pr.GetTitle(),
+					HTMLURL:    pr.GetHTMLURL(),
+					MergedAt:   pr.GetMergedAt().Time,
+				})
+			}
+			return nil
+		})
+	}
+	fetchGroup.Wait()
+	mergedPRs := slices.Concat(mergedPRsByRepo...)
+	slices.SortStableFunc(mergedPRs, func(a, b MergedPR) int { return b.MergedAt.Compare(a.MergedAt) })
+	return mergedPRs
+}
+
+// Checking the PRs is best effort: PRs of which the check runs cannot be fetched are considered passing.
+func (c *client) AddFailingChecksInfo(ctx context.Context, prs []PR) []PR {
+	fetchGroup, fetchCtx := errgroup.WithContext(ctx)
+	fetchGroup.SetLimit(DefaultGitHubAPIConcurrencyLimit)
+	skippedPRCount := 0
+
+	for i, pr := range prs {
+		i, pr := i, pr // https://golang.org/doc/faq#closures_and_goroutines
+		if !c.apiCalls.reserve(1) {
+			skippedPRCount++
+			continue
+		}
+		fetchGroup.Go(func() error {
+			hasFailingChecks, err := c.hasFailingChecks(fetchCtx, pr)
+			if err != nil {
+				log.Printf(
+					"Unable to check the check runs of PR %s/%d: %v", pr.Repository.GetPath(), pr.Number, err,
+				)
+				return nil
+			}
+			prs[i].HasFailingChecks = hasFailingChecks
+			return nil
+		})
+	}
+	fetchGroup.Wait()
+	c.apiCalls.reportSkipped(ctx, "check runs", skippedPRCount)
+	return prs
+}
+
+func (c *client) hasFailingChecks(ctx context.Context, pr PR) (bool, error) {
+	callCtx, cancel := context.WithTimeout(ctx, CheckRunsFetchTimeout)
+	defer cancel()
+	result, _, err := c.forRepository(pr.Repository).checksService.ListCheckRunsForRef(
+		callCtx, pr.Repository.Owner, pr.Repository.Name, pr.HeadSHA,
+		&github.ListCheckRunsOptions{Filter: github.Ptr("latest"), ListOptions: github.ListOptions{PerPage: 100}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(result.CheckRuns, func(run *github.CheckRun) bool {
+		return slices.Contains(failedCheckRunConclusions, run.GetConclusion())
+	}), nil
+}
+
+func (c *client) AddCodeownerApprovalInfo(ctx context.Context, prs []PR) []PR {
+	repositories := []models.Repository{}
+	for _, pr := range prs {
+		if !slices.Contains(repositories, pr.Repository) {
+			repositories = append(repositories, pr.Repository)
+		}
+	}
+
+	fetchGroup, fetchCtx := errgroup.WithContext(ctx)
+	fetchGroup.SetLimit(DefaultGitHubAPIConcurrencyLimit)
+	codeownersByRepo := make([]codeowners, len(repositories))
+	for i, repo := range repositories {
+		i, repo := i, repo // https://golang.org/doc/faq#closures_and_goroutines
+		fetchGroup.Go(func() error {
+			rules, err := c.fetchCodeowners(fetchCtx, repo)
+			if err != nil {
+				log.Printf("Unable to fetch the CODEOWNERS file of repository %s: %v", repo.GetPath(), err)
+				return nil
+			}
+			codeownersByRepo[i] = rules
+			return nil
+		})
+	}
+	fetchGroup.Wait()
+
+	fetchGroup, fetchCtx = errgroup.WithContext(ctx)
+	fetchGroup.SetLimit(DefaultGitHubAPIConcurrencyLimit)
+	skippedPRCount := 0
+	for i, pr := range prs {
+		i, pr := i, pr // https://golang.org/doc/faq#closures_and_goroutines
+		rules := codeownersByRepo[slices.Index(repositories, pr.Repository)]
+		if len(rules) == 0 || len(pr.ApprovedByUsers) == 0 {
+			continue
+		}
+		if !c.apiCalls.reserve(1) {
+			skippedPRCount++
+			continue
+		}
+		fetchGroup.Go(func() error {
+			filePaths, err := c.fetchChangedFilePaths(fetchCtx, pr)
+			if err != nil {
+				log.Printf(
+					"Unable to fetch the changed files of PR %s/%d: %v", pr.Repository.GetPath(), pr.Number, err,
+				)
+				return nil
+			}
+			approverLogins := utilities.Map(pr.ApprovedByUsers, func(approver Collaborator) string { return approver.Login })
+			prs[i].HasCodeownerApproval = rules.isOwnedByAny(filePaths, approverLogins)
+			return nil
+		})
+	}
+	fetchGroup.Wait()
+	c.apiCalls.reportSkipped(ctx, "changed files", skippedPRCount)
+	return prs
+}
+
+// Returns the rules of the CODEOWNERS file of the repository (nil if the repository has no CODEOWNERS file).
+func (c *client) fetchCodeowners(ctx context.Context, repo models.Repository) (codeowners, error) {
+	callCtx, cancel := context.WithTimeout(ctx, CodeownersFetchTimeout)
+	defer cancel()
+	for _, filePath := range codeownersFilePaths {
+		if !c.apiCalls.reserve(1) {
+			return nil, errAPICallBudgetExhausted
+		}
+		file, _, response, err := c.forRepository(repo).repoService.GetContents(
+			callCtx, repo.Owner, repo.Name, filePath, nil,
+		)
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			return nil, err
+		}
+		return parseCodeowners(content), nil
+	}
+	return nil, nil
+}
+
+const changedFilesMaximumPages = 3
+
+func (c *client) fetchChangedFilePaths(ctx context.Context, pr PR) ([]string, error) {
+	callCtx, cancel := context.WithTimeout(ctx, CodeownersFetchTimeout)
+	defer cancel()
+	filePaths := []string{}
+	opts := &github.ListOptions{PerPage: 100}
+	for pagesFetched := 1; ; pagesFetched++ {
+		files, response, err := c.forRepository(pr.Repository).prService.ListFiles(
+			callCtx, pr.Repository.Owner, pr.Repository.Name, pr.Number, opts,
+		)
+		if err != nil {
+			return nil, err
+		}
+		filePaths = append(filePaths, utilities.Map(files, (*github.CommitFile).GetFilename)...)
+		if response == nil || response.NextPage == 0 || pagesFetched >= changedFilesMaximumPages {
+			return filePaths, nil
+		}
+		c.apiCalls.count(1) // the first page is reserved by the caller
+		opts.Page = response.NextPage
+	}
+}
+
+const teamMembersMaximumPages = 10
+
+// Returns an error if the members of any team cannot be fetched, as the PRs of the team could
+// otherwise be silently left out of the message (the token needs the "members: read" permission
+// of the organization).
+func (c *client) FindTeamMembers(ctx context.Context, teams []string) ([]string, error) {
+	var logins []string
+	for _, team := range teams {
+		org, slug, _ := strings.Cut(team, "/")
+		teamLogins, err := c.fetchTeamMembers(ctx, org, slug)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching members of team %s: %w", team, err)
+		}
+		log.Printf("Found %d members in team %s", len(teamLogins), team)
+		logins = append(logins, teamLogins...)
+	}
+	return logins, nil
+}
+
+func (c *client) fetchTeamMembers(ctx context.Context, org, slug string) ([]string, error) {
+	callCtx, cancel := context.WithTimeout(ctx, TeamMembersFetchTimeout)
+	defer cancel()
+	var logins []string
+	opts := &github.TeamListTeamMembersOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for pagesFetched := 1; ; pagesFetched++ {
+		c.apiCalls.count(1)
+		members, response, err := c.teamsService.ListTeamMembersBySlug(callCtx, org, slug, opts)
+		if err != nil {
+			return nil, err
+		}
+		logins = append(logins, utilities.Map(members, (*github.User).GetLogin)...)
+		if response == nil || response.NextPage == 0 || pagesFetched >= teamMembersMaximumPages {
+			return logins, nil
+		}
+		opts.Page = response.NextPage
+	}
+}
+
+// FindOpenPRsOptions are the options of FindOpenPRs and GetPRs. The zero value fetches the reviews and
+// comments of all PRs and keeps the oldest PRs if there are too many of them.
+type FindOpenPRsOptions struct {
+	// If positive, reviews and comments are fetched only for the EnrichTopN oldest PRs
+	EnrichTopN int
+	// If more than MaxPRsToFetch PRs are found, the oldest or newest of them are kept, but at least
+	// MinPRsPerRepository of each repository (see truncatePRsIfExceedsLimit)
+	TruncateKeep        config.TruncateKeep
+	MinPRsPerRepository int
+}
 
 // Returns an error if fetching PRs from any repository fails (and cancels the other requests).
 func (c *client) FindOpenPRs(
 	ctx context.Context,
 	repositories []models.Repository,
 	getFiltersForRepository func(repo models.Repository) config.Filters,
-) ([]PR, error) {
+	opts FindOpenPRsOptions,
+) ([]PR, int, error) {
 	log.Printf("Fetching open pull requests for repositories: %v", repositories)
 
 	listGroup, listCtx := errgroup.WithContext(ctx)
@@ -169,89 +783,163 @@ func (c *client) FindOpenPRs(
 		})
 	}
 	if err := listGroup.Wait(); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	prResults := utilities.Filter(
-		utilities.FlatMap(prResultSlices),
-		getPRFilterFunc(getFiltersForRepository),
-	)
-	prResults = includeLatestPRsOnlyIfExceedsLimit(prResults)
+	listablePRResults := utilities.Filter(utilities.FlatMap(prResultSlices), getListablePRFunc(c.botAccounts))
+	prResults := utilities.Filter(listablePRResults, func(result PRResult) bool {
+		return includePR(result.pr, getFiltersForRepository(result.pr.Repository))
+	})
+	filteredPRCount := len(listablePRResults) - len(prResults)
+	prResults = truncatePRsIfExceedsLimit(prResults, opts.TruncateKeep, opts.MinPRsPerRepository)
 	logFoundPRs(prResults)
 
-	return c.addReviewerInfoToPRs(ctx, prResults)
+	prs, err := c.addReviewerInfoToPRs(ctx, prResults, opts.EnrichTopN)
+	return prs, filteredPRCount, err
 }
 
 func (c *client) GetPRs(
 	ctx context.Context,
 	references []models.PullRequestRef,
 	getFiltersForRepository func(repo models.Repository) config.Filters,
+	onFetchError config.PRFetchErrorPolicy,
+	opts FindOpenPRsOptions,
 ) ([]PR, error) {
-	if len(references) > MaxPRsToFetch {
-		log.Printf(
-			"More than %d PRs requested (%d), fetching only the first %d",
-			MaxPRsToFetch, len(references), MaxPRsToFetch,
-		)
-		references = references[:MaxPRsToFetch]
-	} else {
-		log.Printf("Fetching %d pull requests", len(references))
-	}
+	// all the referenced PRs are fetched, so that the PRs to keep are chosen by age (as with FindOpenPRs)
+	log.Printf("Fetching %d pull requests", len(references))
 
-	listGroup, listCtx := errgroup.WithContext(ctx)
-	listGroup.SetLimit(DefaultGitHubAPIConcurrencyLimit)
+	foundPRs := c.lookUpPRsByNodeID(ctx, references)
+
+	fetchGroup, fetchCtx := errgroup.WithContext(ctx)
+	fetchGroup.SetLimit(DefaultGitHubAPIConcurrencyLimit)
 	prResultSlices := make([]PRResult, len(references))
 
 	for i, prRef := range references {
+		if result, found := foundPRs[prRef.NodeID]; found {
+			prResultSlices[i] = result
+			continue
+		}
 		i, prRef := i, prRef // https://golang.org/doc/faq#closures_and_goroutines
-		listGroup.Go(func() error {
-			res, err := c.fetchPR(listCtx, prRef)
+		fetchGroup.Go(func() error {
+			res, err := c.fetchPR(fetchCtx, prRef)
+			// a deleted PR should not fail the run, but the PRs are not skipped if the run is canceled
+			if err != nil && onFetchError == config.PRFetchErrorSkip && fetchCtx.Err() == nil {
+				runreport.FromContext(ctx).Add(runreport.KindSkippedPR, "skipping a PR that could not be fetched: %v", err)
+				return nil
+			}
 			if err == nil {
 				prResultSlices[i] = res
 			}
 			return err
 		})
 	}
-	if err := listGroup.Wait(); err != nil {
+	if err := fetchGroup.Wait(); err != nil {
 		return nil, err
 	}
+	// the results of the skipped PRs are empty
+	prResultSlices = slices.DeleteFunc(prResultSlices, func(result PRResult) bool { return result.pr.Number == 0 })
 
 	prResults := utilities.Filter(
 		prResultSlices,
-		getPRFilterFunc(getFiltersForRepository),
+		getPRFilterFunc(getFiltersForRepository, c.botAccounts),
 	)
-	prResults = includeLatestPRsOnlyIfExceedsLimit(prResults)
+	prResults = truncatePRsIfExceedsLimit(prResults, opts.TruncateKeep, opts.MinPRsPerRepository)
 	logFoundPRs(prResults)
 
-	return c.addReviewerInfoToPRs(ctx, prResults)
+	return c.addReviewerInfoToPRs(ctx, prResults, opts.EnrichTopN)
+}
+
+// Looks up the referenced PRs by their node IDs with the GraphQL API (in batches), so that they don't
+// need to be fetched one by one. Returns the found PRs by node ID. The references without a node ID
+// (saved before schema version 2 of the state) need to be fetched separately, as do the PRs that are
+// not found (e.g. deleted PRs, so that they fail as the other PRs that cannot be fetched).
+func (c *client) lookUpPRsByNodeID(
+	ctx context.Context, references []models.PullRequestRef,
+) map[string]PRResult {
+	prs := utilities.Map(references, func(prRef models.PullRequestRef) PR {
+		return PR{PullRequest: models.PullRequest{
+			Repository: prRef.Repository, Number: prRef.Number, NodeID: prRef.NodeID,
+		}}
+	})
+	foundPRs := make(map[string]PRResult)
+	c.addPullRequestNodeInfo(ctx, prs, pullRequestFields, func(pr *PR, node pullRequestNode) {
+		foundPRs[node.ID] = node.asPRResult(pr.Repository, c.botAccounts)
+	})
+	log.Printf("Found %d of %d pull requests by their node IDs", len(foundPRs), len(references))
+	return foundPRs
 }
 
 func getPRFilterFunc(
 	getFiltersForRepository func(repo models.Repository) config.Filters,
+	botAccounts BotAccounts,
 ) func(result PRResult) bool {
+	isListablePR := getListablePRFunc(botAccounts)
 	return func(result PRResult) bool {
-		return !result.pr.GetDraft() && includePR(result.pr, getFiltersForRepository(result.repository))
+		return isListablePR(result) && includePR(result.pr, getFiltersForRepository(result.pr.Repository))
 	}
 }
 
+// Drafts and the PRs of bots are never listed, regardless of the filters.
+func getListablePRFunc(botAccounts BotAccounts) func(result PRResult) bool {
+	return func(result PRResult) bool {
+		return !result.isDraft && !botAccounts.isBotAuthor(result.pr.Author.Login)
+	}
+}
+
+// The maximum number of pages (of 100 PRs) of open PRs fetched per repository, so that a huge
+// backlog cannot exhaust the API rate limit. The oldest PRs are fetched first, as they are the
+// ones most in need of reminders.
+const pullRequestsMaximumPages = 10
+
 func (c *client) fetchOpenPRsForRepository(
 	ctx context.Context, repo models.Repository,
 ) ([]PRResult, error) {
+	opts := &github.PullRequestListOptions{
+		Sort:        "created",
+		Direction:   "asc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	var prs []*github.PullRequest
+	for pagesFetched := 1; ; pagesFetched++ {
+		page, response, err := c.listOpenPRsPage(ctx, repo, opts)
+		if err != nil {
+			return nil, getListPRsError(repo, response, err)
+		}
+		prs = append(prs, page...)
+		if response == nil || response.NextPage == 0 {
+			break
+		}
+		if pagesFetched >= pullRequestsMaximumPages {
+			runreport.FromContext(ctx).Add(
+				runreport.KindOther, "only the oldest %d open PRs of %s were fetched", len(prs), repo.GetPath(),
+			)
+			break
+		}
+		opts.Page = response.NextPage
+	}
+	return utilities.Map(prs, func(pr *github.PullRequest) PRResult {
+		return newPRResult(pr, repo, c.botAccounts)
+	}), nil
+}
+
+func (c *client) listOpenPRsPage(
+	ctx context.Context, repo models.Repository, opts *github.PullRequestListOptions,
+) ([]*github.PullRequest, *github.Response, error) {
 	callCtx, cancel := context.WithTimeout(ctx, PullRequestListTimeout)
 	defer cancel()
-	prs, response, err := c.prService.List(
-		callCtx, repo.Owner, repo.Name, &github.PullRequestListOptions{ListOptions: github.ListOptions{PerPage: 100}},
-	)
-	if err == nil {
-		return utilities.Map(prs, getPRResultMapper(repo)), nil
-	}
+	c.apiCalls.count(1)
+	return c.forRepository(repo).prService.List(callCtx, repo.Owner, repo.Name, opts)
+}
+
+func getListPRsError(repo models.Repository, response *github.Response, err error) error {
 	if response != nil && response.StatusCode == 404 {
-		return nil, fmt.Errorf(
+		return fmt.Errorf(
 			"repository %s/%s not found - check the repository name and permissions",
 			repo.Owner,
 			repo.Name,
 		)
 	}
-	return nil, fmt.Errorf(
+	return fmt.Errorf(
 		"error fetching pull requests from %s/%s: %w", repo.Owner, repo.Name, err,
 	)
 }
@@ -261,11 +949,12 @@ func (c *client) fetchPR(
 ) (PRResult, error) {
 	callCtx, cancel := context.WithTimeout(ctx, PullRequestFetchTimeout)
 	defer cancel()
-	pr, response, err := c.prService.Get(
+	c.apiCalls.count(1)
+	pr, response, err := c.forRepository(prRef.Repository).prService.Get(
 		callCtx, prRef.Repository.Owner, prRef.Repository.Name, prRef.Number,
 	)
 	if err == nil {
-		return getPRResultMapper(prRef.Repository)(pr), nil
+		return newPRResult(pr, prRef.Repository, c.botAccounts), nil
 	}
 	if response != nil && response.StatusCode == 404 {
 		return PRResult{}, fmt.Errorf(
@@ -281,51 +970,93 @@ func (c *client) fetchPR(
 	)
 }
 
-func getPRResultMapper(repo models.Repository) func(pr *github.PullRequest) PRResult {
-	return func(pr *github.PullRequest) PRResult {
-		return PRResult{
-			pr:         pr,
-			repository: repo,
-		}
-	}
-}
-
 func logFoundPRs(prResults []PRResult) {
 	log.Printf("Found %d open pull requests:", len(prResults))
 	for _, result := range prResults {
-		log.Printf("%s/%v", result.repository.GetPath(), *result.pr.Number)
+		log.Printf("%s/%v", result.pr.Repository.GetPath(), result.pr.Number)
 	}
 }
 
-func includeLatestPRsOnlyIfExceedsLimit(prs []PRResult) []PRResult {
+// Keeps the oldest or newest MaxPRsToFetch PRs if there are more. So that a busy repository cannot
+// crowd out the others, the oldest or newest minPRsPerRepository PRs of each repository are kept
+// first (fewer if they would not fit for all repositories), and the rest is filled by age.
+// Either way, the kept PRs are ordered newest first (as the PRs are listed in the message).
+func truncatePRsIfExceedsLimit(
+	prs []PRResult, truncateKeep config.TruncateKeep, minPRsPerRepository int,
+) []PRResult {
 	if len(prs) <= MaxPRsToFetch {
 		return prs
 	}
 	log.Printf(
-		"More than %d pull requests found (%d), including only the latest %d",
-		MaxPRsToFetch, len(prs), MaxPRsToFetch,
+		"More than %d pull requests found (%d), including only the %s %d",
+		MaxPRsToFetch, len(prs), truncateKeep, MaxPRsToFetch,
 	)
-	slices.SortStableFunc(prs, func(a, b PRResult) int {
-		if !a.pr.GetCreatedAt().Time.Equal(b.pr.GetCreatedAt().Time) {
-			return b.pr.GetCreatedAt().Time.Compare(a.pr.GetCreatedAt().Time)
+	newestFirst := func(a, b PRResult) int {
+		if !a.pr.CreatedAt.Equal(b.pr.CreatedAt) {
+			return b.pr.CreatedAt.Compare(a.pr.CreatedAt)
 		}
-		return b.pr.GetUpdatedAt().Time.Compare(a.pr.GetUpdatedAt().Time)
-	})
-	return prs[:MaxPRsToFetch]
+		return b.pr.UpdatedAt.Compare(a.pr.UpdatedAt)
+	}
+	// the PRs in the order in which they are kept
+	slices.SortStableFunc(prs, newestFirst)
+	if truncateKeep != config.TruncateKeepNewest {
+		slices.Reverse(prs)
+	}
+
+	repositoryCount := len(utilities.Unique(
+		utilities.Map(prs, func(result PRResult) string { return result.pr.Repository.GetPath() }),
+	))
+	guaranteedPerRepository := min(minPRsPerRepository, MaxPRsToFetch/repositoryCount)
+	kept := make([]bool, len(prs))
+	keptCount := 0
+	keptByRepository := map[string]int{}
+	for i, result := range prs {
+		if keptByRepository[result.pr.Repository.GetPath()] < guaranteedPerRepository {
+			kept[i] = true
+			keptCount++
+			keptByRepository[result.pr.Repository.GetPath()]++
+		}
+	}
+	for i := range prs {
+		if keptCount >= MaxPRsToFetch {
+			break
+		}
+		if !kept[i] {
+			kept[i] = true
+			keptCount++
+		}
+	}
+
+	var keptPRs []PRResult
+	for i, result := range prs {
+		if kept[i] {
+			keptPRs = append(keptPRs, result)
+		}
+	}
+	slices.SortStableFunc(keptPRs, newestFirst)
+	return keptPRs
 }
 
 // Fetches review and comment data for the given PRs and returns enriched PR data.
 // Returns all PRs even if fetching review data for some PRs fails (those will just be missing reviewer info then).
-func (c *client) addReviewerInfoToPRs(ctx context.Context, prResults []PRResult) ([]PR, error) {
+// If enrichTopN is positive, review data is fetched only for the enrichTopN oldest PRs.
+// Review data is not fetched for the PRs that would exceed the API call budget.
+func (c *client) addReviewerInfoToPRs(ctx context.Context, prResults []PRResult, enrichTopN int) ([]PR, error) {
+	prResults, notEnrichedPRResults := splitPRsToEnrich(prResults, enrichTopN)
 	log.Printf("\nFetching pull request reviews and comments for PRs")
 
 	prProcessingGroup, prProcessingCtx := errgroup.WithContext(ctx)
 	prProcessingGroup.SetLimit(DefaultGitHubAPIConcurrencyLimit)
 	resultChannel := make(chan FetchReviewsResult, len(prResults))
+	var overBudgetPRResults []PRResult
 
 	for _, result := range prResults {
-		repo := result.repository
+		if !c.apiCalls.reserve(reviewerInfoCallsPerPR) {
+			overBudgetPRResults = append(overBudgetPRResults, result)
+			continue
+		}
 		pr := result.pr
+		repo := pr.Repository
 		prProcessingGroup.Go(func() error {
 			callCtx, cancel := context.WithTimeout(prProcessingCtx, ReviewsFetchTimeout)
 			defer cancel()
@@ -340,21 +1071,21 @@ func (c *client) addReviewerInfoToPRs(ctx context.Context, prResults []PRResult)
 
 			dataFetchGroup.Go(func() error {
 				reviews, reviewsErr = fetchPRReviews(
-					dataFetchCtx, c.prService, repo.Owner, repo.Name, *pr.Number,
+					dataFetchCtx, c.forRepository(repo).prService, &c.apiCalls, repo.Owner, repo.Name, pr.Number,
 				)
 				return nil // capture error in reviewsErr
 			})
 
 			dataFetchGroup.Go(func() error {
 				comments, commentsErr = fetchPRComments(
-					dataFetchCtx, c.prService, repo.Owner, repo.Name, *pr.Number,
+					dataFetchCtx, c.forRepository(repo).prService, repo.Owner, repo.Name, pr.Number,
 				)
 				return nil // capture error in commentsErr
 			})
 
 			dataFetchGroup.Go(func() error {
 				timelineComments, timelineCommentsErr = fetchPRTimelineComments(
-					dataFetchCtx, c.issueService, repo.Owner, repo.Name, *pr.Number,
+					dataFetchCtx, c.forRepository(repo).issueService, repo.Owner, repo.Name, pr.Number,
 				)
 				return nil // capture error in timelineCommentsErr
 			})
@@ -366,7 +1097,6 @@ func (c *client) addReviewerInfoToPRs(ctx context.Context, prResults []PRResult)
 				reviews:          reviews,
 				comments:         comments,
 				timelineComments: timelineComments,
-				repository:       repo,
 				err:              errors.Join(reviewsErr, commentsErr, timelineCommentsErr),
 			}
 
@@ -382,17 +1112,51 @@ func (c *client) addReviewerInfoToPRs(ctx context.Context, prResults []PRResult)
 
 	allPRs := []PR{}
 	for result := range resultChannel {
-		result.printResult()
-		allPRs = append(allPRs, result.asPR())
+		if result.err != nil {
+			runreport.FromContext(ctx).Add(
+				runreport.KindMissingReviewerInfo,
+				"unable to fetch reviews/comments for PR %s/%d: %v", result.pr.Repository.GetPath(), result.pr.Number, result.err,
+			)
+		} else {
+			result.printResult()
+		}
+		allPRs = append(allPRs, result.asPR(c.botAccounts, c.strictApprovals))
+	}
+	c.apiCalls.reportSkipped(ctx, "reviews and comments", len(overBudgetPRResults))
+	for _, result := range slices.Concat(notEnrichedPRResults, overBudgetPRResults) {
+		allPRs = append(allPRs, FetchReviewsResult{pr: result.pr}.asPR(
+			c.botAccounts, c.strictApprovals,
+		))
 	}
 	return allPRs, nil
 }
 
+// Splits the PRs into the enrichTopN oldest ones and the rest (all PRs are enriched if enrichTopN is not positive).
+func splitPRsToEnrich(prs []PRResult, enrichTopN int) (toEnrich, rest []PRResult) {
+	if enrichTopN <= 0 || len(prs) <= enrichTopN {
+		return prs, nil
+	}
+	log.Printf(
+		"More than %d pull requests found (%d), fetching reviews and comments only for the oldest %d",
+		enrichTopN, len(prs), enrichTopN,
+	)
+	sorted := slices.Clone(prs)
+	slices.SortStableFunc(sorted, func(a, b PRResult) int {
+		return a.pr.CreatedAt.Compare(b.pr.CreatedAt)
+	})
+	return sorted[:enrichTopN], sorted[enrichTopN:]
+}
+
 const reviewsMaximumPages = 2
 
+// The first pages of the reviews, comments and timeline comments of a PR
+const reviewerInfoCallsPerPR = 3
+
+// The first page of the reviews is counted in reviewerInfoCallsPerPR.
 func fetchPRReviews(
 	ctx context.Context,
 	prService GithubPullRequestsService,
+	apiCalls *apiCallBudget,
 	owner, repo string,
 	number int,
 ) ([]*github.PullRequestReview, error) {
@@ -424,6 +1188,7 @@ func fetchPRReviews(
 		if response == nil || response.NextPage == 0 || pagesFetched >= reviewsMaximumPages {
 			break
 		}
+		apiCalls.count(1)
 		opts.Page = response.NextPage
 	}
 	return reviews, nil
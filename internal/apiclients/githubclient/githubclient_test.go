@@ -1,6 +1,11 @@
 package githubclient_test
 
 import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,6 +18,8 @@ import (
 	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
 	"github.com/hellej/pr-slack-reminder-action/internal/config"
 	"github.com/hellej/pr-slack-reminder-action/internal/models"
+	"github.com/hellej/pr-slack-reminder-action/internal/runreport"
+	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
 )
 
 type mockPullRequestService struct {
@@ -20,6 +27,7 @@ type mockPullRequestService struct {
 	mockPRsByNumber        map[int]*github.PullRequest
 	mockReviewsByPRNumber  map[int][]*github.PullRequestReview
 	mockCommentsByPRNumber map[int][]*github.PullRequestComment
+	mockFilesByPRNumber    map[int][]*github.CommitFile
 	mockResponse           *github.Response
 	mockError              error
 }
@@ -53,8 +61,16 @@ func (m *mockPullRequestService) ListComments(
 	return comments, m.mockResponse, m.mockError
 }
 
+func (m *mockPullRequestService) ListFiles(
+	ctx context.Context, owner string, repo string, number int, opts *github.ListOptions,
+) ([]*github.CommitFile, *github.Response, error) {
+	files := m.mockFilesByPRNumber[number]
+	return files, m.mockResponse, m.mockError
+}
+
 type mockIssueService struct {
 	mockTimelineCommentsByPRNumber map[int][]*github.IssueComment
+	mockMilestones                 []*github.Milestone
 	mockResponse                   *github.Response
 	mockError                      error
 }
@@ -66,6 +82,12 @@ func (m *mockIssueService) ListComments(
 	return comments, m.mockResponse, m.mockError
 }
 
+func (m *mockIssueService) ListMilestones(
+	ctx context.Context, owner string, repo string, opts *github.MilestoneListOptions,
+) ([]*github.Milestone, *github.Response, error) {
+	return m.mockMilestones, m.mockResponse, m.mockError
+}
+
 type mockActionsService struct {
 	mockResponse *github.Response
 	mockError    error
@@ -83,13 +105,18 @@ func (m *mockActionsService) DownloadArtifact(
 ) {
 	return &url.URL{}, m.mockResponse, m.mockError
 }
+func (m *mockActionsService) DeleteArtifact(
+	ctx context.Context, owner string, repo string, artifactID int64,
+) (*github.Response, error) {
+	return m.mockResponse, m.mockError
+}
 
 type mockHTTPClient struct {
 	mockResponse *http.Response
 	mockError    error
 }
 
-func (m *mockHTTPClient) Get(url string) (*http.Response, error) {
+func (m *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	return m.mockResponse, m.mockError
 }
 
@@ -181,6 +208,16 @@ func (m *multiRepoPRService) ListComments(
 	return nil, &github.Response{Response: &http.Response{StatusCode: 404}}, fmt.Errorf("unknown repo")
 }
 
+func (m *multiRepoPRService) ListFiles(
+	ctx context.Context, owner string, repo string, number int, opts *github.ListOptions,
+) ([]*github.CommitFile, *github.Response, error) {
+	if svc, ok := m.services[repo]; ok {
+		files := svc.mockFilesByPRNumber[number]
+		return files, svc.mockResponse, svc.mockError
+	}
+	return nil, &github.Response{Response: &http.Response{StatusCode: 404}}, fmt.Errorf("unknown repo")
+}
+
 // multiRepoIssuesService routes ListComments calls to different mock services based on repo name
 type multiRepoIssuesService struct {
 	services map[string]*mockIssueService
@@ -196,6 +233,15 @@ func (m *multiRepoIssuesService) ListComments(
 	return nil, &github.Response{Response: &http.Response{StatusCode: 404}}, fmt.Errorf("unknown repo")
 }
 
+func (m *multiRepoIssuesService) ListMilestones(
+	ctx context.Context, owner string, repo string, opts *github.MilestoneListOptions,
+) ([]*github.Milestone, *github.Response, error) {
+	if svc, ok := m.services[repo]; ok {
+		return svc.ListMilestones(ctx, owner, repo, opts)
+	}
+	return nil, &github.Response{Response: &http.Response{StatusCode: 404}}, fmt.Errorf("unknown repo")
+}
+
 func TestGetAuthenticatedClient(t *testing.T) {
 	client := githubclient.GetAuthenticatedClient("test-token", "another-token")
 	if client == nil {
@@ -488,6 +534,166 @@ func TestFindOneOrNoPRs(t *testing.T) {
 			expectedApproverLogins:  []string{},
 			expectedCommenterLogins: []string{},
 		},
+		{
+			name: "PR with head branch matching branches-ignore should be filtered out",
+			mockPRs: []*github.PullRequest{
+				{
+					Number:  github.Ptr(142),
+					Title:   github.Ptr("Update dependency react to v19"),
+					Draft:   github.Ptr(false),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/pull/142"),
+					Head:    &github.PullRequestBranch{Ref: github.Ptr("renovate/react-19.x")},
+					User: &github.User{
+						Login: github.Ptr("author"),
+						Name:  github.Ptr("PR Author"),
+					},
+				},
+				{
+					Number:  github.Ptr(143),
+					Title:   github.Ptr("Update renovate config"),
+					Draft:   github.Ptr(false),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/pull/143"),
+					Head:    &github.PullRequestBranch{Ref: github.Ptr("feature/renovate/config")},
+					User: &github.User{
+						Login: github.Ptr("author"),
+						Name:  github.Ptr("PR Author"),
+					},
+				},
+			},
+			mockReviews:             map[int][]*github.PullRequestReview{},
+			mockComments:            map[int][]*github.PullRequestComment{},
+			mockTimelineComments:    map[int][]*github.IssueComment{},
+			filters:                 config.Filters{BranchesIgnore: []string{"renovate/*", "release-please--*"}},
+			expectedPRCount:         1,
+			expectedPRNumber:        143,
+			expectedApproverLogins:  []string{},
+			expectedCommenterLogins: []string{},
+		},
+		{
+			name: "PR without the milestone of the milestones filter should be filtered out",
+			mockPRs: []*github.PullRequest{
+				{
+					Number:    github.Ptr(134),
+					Title:     github.Ptr("Old Feature"),
+					Draft:     github.Ptr(false),
+					HTMLURL:   github.Ptr("https://github.com/owner/repo/pull/134"),
+					Milestone: &github.Milestone{Title: github.Ptr("2.3")},
+					User: &github.User{
+						Login: github.Ptr("author"),
+						Name:  github.Ptr("PR Author"),
+					},
+				},
+				{
+					Number:    github.Ptr(135),
+					Title:     github.Ptr("New Feature"),
+					Draft:     github.Ptr(false),
+					HTMLURL:   github.Ptr("https://github.com/owner/repo/pull/135"),
+					Milestone: &github.Milestone{Title: github.Ptr("2.4")},
+					User: &github.User{
+						Login: github.Ptr("author"),
+						Name:  github.Ptr("PR Author"),
+					},
+				},
+				{
+					Number:  github.Ptr(136),
+					Title:   github.Ptr("Unplanned Feature"),
+					Draft:   github.Ptr(false),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/pull/136"),
+					User: &github.User{
+						Login: github.Ptr("author"),
+						Name:  github.Ptr("PR Author"),
+					},
+				},
+			},
+			mockReviews:             map[int][]*github.PullRequestReview{},
+			mockComments:            map[int][]*github.PullRequestComment{},
+			mockTimelineComments:    map[int][]*github.IssueComment{},
+			filters:                 config.Filters{Milestones: []string{"2.4"}},
+			expectedPRCount:         1,
+			expectedPRNumber:        135,
+			expectedApproverLogins:  []string{},
+			expectedCommenterLogins: []string{},
+		},
+		{
+			name: "PR younger than min-age-hours should be filtered out",
+			mockPRs: []*github.PullRequest{
+				{
+					Number:    github.Ptr(137),
+					Title:     github.Ptr("Just opened"),
+					Draft:     github.Ptr(false),
+					HTMLURL:   github.Ptr("https://github.com/owner/repo/pull/137"),
+					CreatedAt: &github.Timestamp{Time: time.Now().Add(-30 * time.Minute)},
+					User: &github.User{
+						Login: github.Ptr("author"),
+						Name:  github.Ptr("PR Author"),
+					},
+				},
+				{
+					Number:    github.Ptr(138),
+					Title:     github.Ptr("Waiting for review"),
+					Draft:     github.Ptr(false),
+					HTMLURL:   github.Ptr("https://github.com/owner/repo/pull/138"),
+					CreatedAt: &github.Timestamp{Time: time.Now().Add(-3 * time.Hour)},
+					User: &github.User{
+						Login: github.Ptr("author"),
+						Name:  github.Ptr("PR Author"),
+					},
+				},
+			},
+			mockReviews:             map[int][]*github.PullRequestReview{},
+			mockComments:            map[int][]*github.PullRequestComment{},
+			mockTimelineComments:    map[int][]*github.IssueComment{},
+			filters:                 config.Filters{MinAgeHours: 1},
+			expectedPRCount:         1,
+			expectedPRNumber:        138,
+			expectedApproverLogins:  []string{},
+			expectedCommenterLogins: []string{},
+		},
+		{
+			name: "PR not requesting a review from the teams of the requested-teams filter should be filtered out",
+			mockPRs: []*github.PullRequest{
+				{
+					Number:         github.Ptr(139),
+					Title:          github.Ptr("Frontend change"),
+					Draft:          github.Ptr(false),
+					HTMLURL:        github.Ptr("https://github.com/owner/repo/pull/139"),
+					RequestedTeams: []*github.Team{{Slug: github.Ptr("frontend")}},
+					User: &github.User{
+						Login: github.Ptr("author"),
+						Name:  github.Ptr("PR Author"),
+					},
+				},
+				{
+					Number:         github.Ptr(140),
+					Title:          github.Ptr("Infra change"),
+					Draft:          github.Ptr(false),
+					HTMLURL:        github.Ptr("https://github.com/owner/repo/pull/140"),
+					RequestedTeams: []*github.Team{{Slug: github.Ptr("frontend")}, {Slug: github.Ptr("platform")}},
+					User: &github.User{
+						Login: github.Ptr("author"),
+						Name:  github.Ptr("PR Author"),
+					},
+				},
+				{
+					Number:  github.Ptr(141),
+					Title:   github.Ptr("No team requested"),
+					Draft:   github.Ptr(false),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/pull/141"),
+					User: &github.User{
+						Login: github.Ptr("author"),
+						Name:  github.Ptr("PR Author"),
+					},
+				},
+			},
+			mockReviews:             map[int][]*github.PullRequestReview{},
+			mockComments:            map[int][]*github.PullRequestComment{},
+			mockTimelineComments:    map[int][]*github.IssueComment{},
+			filters:                 config.Filters{RequestedTeams: []string{"owner/Platform"}},
+			expectedPRCount:         1,
+			expectedPRNumber:        140,
+			expectedApproverLogins:  []string{},
+			expectedCommenterLogins: []string{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -520,7 +726,12 @@ func TestFindOneOrNoPRs(t *testing.T) {
 				mockResponse: &http.Response{StatusCode: 200},
 				mockError:    nil,
 			}
-			client := githubclient.NewClient(mockHTTPClient, mockPRService, mockIssueService, mockActionsService)
+			client := githubclient.NewClient(githubclient.Services{
+				HTTP:         mockHTTPClient,
+				PullRequests: mockPRService,
+				Issues:       mockIssueService,
+				Actions:      mockActionsService,
+			})
 
 			repos := []models.Repository{
 				{Owner: "testowner", Name: "testrepo"},
@@ -530,7 +741,7 @@ func TestFindOneOrNoPRs(t *testing.T) {
 				return tt.filters
 			}
 
-			result, err := client.FindOpenPRs(context.Background(), repos, getFilters)
+			result, _, err := client.FindOpenPRs(context.Background(), repos, getFilters, githubclient.FindOpenPRsOptions{})
 
 			if err != nil {
 				t.Fatalf("FindOpenPRs() returned error: %v", err)
@@ -548,8 +759,8 @@ func TestFindOneOrNoPRs(t *testing.T) {
 				if tt.expectedPRNumber > 0 {
 					expectedNumber = tt.expectedPRNumber
 				}
-				if pr.GetNumber() != expectedNumber {
-					t.Errorf("Expected PR number %d, got %d", expectedNumber, pr.GetNumber())
+				if pr.Number != expectedNumber {
+					t.Errorf("Expected PR number %d, got %d", expectedNumber, pr.Number)
 				}
 
 				var expectedPR *github.PullRequest
@@ -644,14 +855,21 @@ func TestFetchManyPRs(t *testing.T) {
 				mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}},
 				mockError:    nil,
 			}
-			client := githubclient.NewClient(mockHTTPClient, mockPRService, mockIssueService, mockActionsService)
+			client := githubclient.NewClient(githubclient.Services{
+				HTTP:         mockHTTPClient,
+				PullRequests: mockPRService,
+				Issues:       mockIssueService,
+				Actions:      mockActionsService,
+			})
 			repos := []models.Repository{{Owner: "testowner", Name: "testrepo"}}
 
-			result, err := client.FindOpenPRs(
+			result, _, err := client.FindOpenPRs(
 				context.Background(),
-				repos, func(models.Repository) config.Filters {
+				repos,
+				func(models.Repository) config.Filters {
 					return config.Filters{}
 				},
+				githubclient.FindOpenPRsOptions{},
 			)
 
 			if err != nil {
@@ -665,123 +883,674 @@ func TestFetchManyPRs(t *testing.T) {
 	}
 }
 
-func TestFindOpenPRs_MultipleRepositories(t *testing.T) {
-	mockPRService1 := &mockPullRequestService{
-		mockPRs: []*github.PullRequest{
-			{
-				Number:  github.Ptr(1),
-				Title:   github.Ptr("Repo1 PR"),
-				Draft:   github.Ptr(false),
-				HTMLURL: github.Ptr("https://example.com/r1/1"),
-				User:    &github.User{Login: github.Ptr("author1")},
-			},
-		},
-		mockReviewsByPRNumber:  map[int][]*github.PullRequestReview{},
-		mockCommentsByPRNumber: map[int][]*github.PullRequestComment{},
-		mockResponse:           &github.Response{Response: &http.Response{StatusCode: 200}},
-		mockError:              nil,
-	}
-	mockPRService2 := &mockPullRequestService{
-		mockPRs: []*github.PullRequest{
-			{
-				Number:  github.Ptr(2),
-				Title:   github.Ptr("Repo2 PR"),
-				Draft:   github.Ptr(false),
-				HTMLURL: github.Ptr("https://example.com/r2/2"),
-				User:    &github.User{Login: github.Ptr("author2")},
-			},
-		},
-		mockReviewsByPRNumber:  map[int][]*github.PullRequestReview{},
-		mockCommentsByPRNumber: map[int][]*github.PullRequestComment{},
-		mockResponse:           &github.Response{Response: &http.Response{StatusCode: 200}},
-		mockError:              nil,
-	}
+// countingPRService counts Get calls of the wrapped mock service
+type countingPRService struct {
+	*mockPullRequestService
+	mu       sync.Mutex
+	getCalls int
+}
 
-	mockIssueService1 := &mockIssueService{
-		mockTimelineCommentsByPRNumber: map[int][]*github.IssueComment{},
-		mockResponse:                   &github.Response{Response: &http.Response{StatusCode: 200}},
-		mockError:                      nil,
-	}
-	mockIssueService2 := &mockIssueService{
-		mockTimelineCommentsByPRNumber: map[int][]*github.IssueComment{},
-		mockResponse:                   &github.Response{Response: &http.Response{StatusCode: 200}},
-		mockError:                      nil,
+func (m *countingPRService) Get(
+	ctx context.Context, owner string, repo string, number int,
+) (*github.PullRequest, *github.Response, error) {
+	m.mu.Lock()
+	m.getCalls++
+	m.mu.Unlock()
+	return m.mockPullRequestService.Get(ctx, owner, repo, number)
+}
+
+func TestGetPRs_LooksUpPRsByNodeID(t *testing.T) {
+	repo := models.Repository{Owner: "testowner", Name: "testrepo"}
+	newPR := func(number int) *github.PullRequest {
+		return &github.PullRequest{
+			Number: github.Ptr(number),
+			Title:  github.Ptr(fmt.Sprintf("PR %d", number)),
+			State:  github.Ptr("open"),
+			User:   &github.User{Login: github.Ptr("author")},
+		}
 	}
-	mockHTTPClient := &mockHTTPClient{
-		mockResponse: &http.Response{StatusCode: 200},
-		mockError:    nil,
+	newNode := func(number int, state string) map[string]any {
+		return map[string]any{
+			"number": number,
+			"title":  fmt.Sprintf("PR %d", number),
+			"url":    fmt.Sprintf("https://github.com/testowner/testrepo/pull/%d", number),
+			"state":  state,
+			"merged": state == "MERGED",
+			"author": map[string]any{"__typename": "User", "login": "author"},
+		}
 	}
-	mockActionsService := &mockActionsService{
-		mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}},
-		mockError:    nil,
+	ref := func(number int, nodeID string) models.PullRequestRef {
+		return models.PullRequestRef{Repository: repo, Number: number, NodeID: nodeID}
 	}
-	client := githubclient.NewClient(
-		mockHTTPClient,
-		&multiRepoPRService{
-			services: map[string]*mockPullRequestService{"repo1": mockPRService1, "repo2": mockPRService2},
+
+	tests := []struct {
+		name               string
+		references         []models.PullRequestRef
+		lookupErr          error
+		expectedBatchSizes []int
+		expectedGetCalls   int
+	}{
+		{
+			name:               "PRs are looked up in one query",
+			references:         []models.PullRequestRef{ref(1, "PR_1"), ref(2, "PR_2")},
+			expectedBatchSizes: []int{2},
 		},
-		&multiRepoIssuesService{
-			services: map[string]*mockIssueService{"repo1": mockIssueService1, "repo2": mockIssueService2},
+		{
+			name:               "PRs without a node ID are fetched one by one",
+			references:         []models.PullRequestRef{ref(1, "PR_1"), ref(3, "")},
+			expectedBatchSizes: []int{1},
+			expectedGetCalls:   1,
 		},
-		mockActionsService,
-	)
-	repos := []models.Repository{{Owner: "o", Name: "repo1"}, {Owner: "o", Name: "repo2"}}
-	result, err := client.FindOpenPRs(
-		context.Background(),
-		repos, func(models.Repository) config.Filters {
-			return config.Filters{}
+		{
+			name:               "PRs that are not found are fetched one by one",
+			references:         []models.PullRequestRef{ref(1, "PR_1"), ref(3, "PR_3")},
+			expectedBatchSizes: []int{2},
+			expectedGetCalls:   1,
 		},
-	)
+		{
+			name:             "all PRs are fetched one by one if the lookup fails",
+			references:       []models.PullRequestRef{ref(1, "PR_1"), ref(2, "PR_2")},
+			lookupErr:        fmt.Errorf("lookup failed"),
+			expectedGetCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mergedPR := newPR(2)
+			mergedPR.State = github.Ptr("closed")
+			mergedPR.Merged = github.Ptr(true)
+			prService := &countingPRService{
+				mockPullRequestService: &mockPullRequestService{
+					mockPRsByNumber: map[int]*github.PullRequest{1: newPR(1), 2: mergedPR, 3: newPR(3)},
+					mockResponse:    &github.Response{Response: &http.Response{StatusCode: 200}},
+				},
+			}
+			graphQLService := &mockGraphQLService{
+				pullRequestByNodeID: map[string]map[string]any{
+					"PR_1": newNode(1, "OPEN"),
+					"PR_2": newNode(2, "MERGED"),
+				},
+				err: tt.lookupErr,
+			}
+			client := githubclient.NewClient(githubclient.Services{
+				PullRequests: prService,
+				Issues:       &mockIssueService{mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}}},
+				GraphQL:      graphQLService,
+			})
+
+			result, err := client.GetPRs(
+				context.Background(), tt.references, func(models.Repository) config.Filters {
+					return config.Filters{}
+				},
+				config.PRFetchErrorFail,
+				githubclient.FindOpenPRsOptions{},
+			)
+			if err != nil {
+				t.Fatalf("GetPRs() returned error: %v", err)
+			}
+			if len(result) != len(tt.references) {
+				t.Errorf("Expected %d PRs, got %d", len(tt.references), len(result))
+			}
+			if !slices.Equal(graphQLService.batchSizes, tt.expectedBatchSizes) {
+				t.Errorf("Expected lookups of %v node IDs, got %v", tt.expectedBatchSizes, graphQLService.batchSizes)
+			}
+			if prService.getCalls != tt.expectedGetCalls {
+				t.Errorf("Expected %d Get calls, got %d", tt.expectedGetCalls, prService.getCalls)
+			}
+			for _, pr := range result {
+				if pr.Title != fmt.Sprintf("PR %d", pr.Number) || pr.Author.Login != "author" {
+					t.Errorf("Expected PR %d to have its title and author, got %+v", pr.Number, pr)
+				}
+				if pr.Number == 2 && (!pr.Merged || pr.State != "closed") {
+					t.Errorf("Expected PR 2 to be merged, got state %q (merged: %v)", pr.State, pr.Merged)
+				}
+				if pr.Number == 1 && (pr.Merged || pr.State != "open") {
+					t.Errorf("Expected PR 1 to be open, got state %q (merged: %v)", pr.State, pr.Merged)
+				}
+			}
+		})
+	}
+}
 
+func TestGetPRs_LookedUpPRsMatchFetchedPRs(t *testing.T) {
+	repo := models.Repository{Owner: "testowner", Name: "testrepo"}
+	graphQLService := &mockGraphQLService{
+		pullRequestByNodeID: map[string]map[string]any{"PR_1": {
+			"number":      1,
+			"title":       "PR 1",
+			"url":         "https://github.com/testowner/testrepo/pull/1",
+			"state":       "OPEN",
+			"createdAt":   "2025-01-02T03:04:05Z",
+			"updatedAt":   "2025-01-03T03:04:05Z",
+			"author":      map[string]any{"__typename": "User", "login": "author", "name": "Author"},
+			"labels":      map[string]any{"nodes": []map[string]any{{"name": "frontend"}}},
+			"milestone":   map[string]any{"title": "v1"},
+			"headRefName": "feature",
+			"headRefOid":  "abc123",
+			"reviewRequests": map[string]any{"nodes": []map[string]any{
+				{"requestedReviewer": map[string]any{"__typename": "User", "login": "bob"}},
+				{"requestedReviewer": map[string]any{"__typename": "Bot", "login": "reviewer-bot"}},
+				{"requestedReviewer": map[string]any{"__typename": "User", "login": "automation"}},
+				{"requestedReviewer": map[string]any{"__typename": "Team", "slug": "backend", "name": "Backend"}},
+			}},
+			"baseRepository": map[string]any{"nameWithOwner": "neworg/testrepo"},
+		}},
+	}
+	client := githubclient.NewClient(githubclient.Services{
+		PullRequests: &mockPullRequestService{mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}}},
+		Issues:       &mockIssueService{mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}}},
+		GraphQL:      graphQLService,
+	})
+	client.SetBotAccounts(githubclient.BotAccounts{Bots: []string{"automation"}})
+
+	result, err := client.GetPRs(
+		context.Background(),
+		[]models.PullRequestRef{{Repository: repo, Number: 1, NodeID: "PR_1"}},
+		func(models.Repository) config.Filters { return config.Filters{} },
+		config.PRFetchErrorFail,
+		githubclient.FindOpenPRsOptions{},
+	)
 	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+		t.Fatalf("GetPRs() returned error: %v", err)
 	}
-	if len(result) != 2 {
-		t.Fatalf("expected 2 PRs, got %d", len(result))
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 PR, got %d", len(result))
 	}
-	numbers := []int{result[0].GetNumber(), result[1].GetNumber()}
-	if !((numbers[0] == 1 && numbers[1] == 2) || (numbers[0] == 2 && numbers[1] == 1)) {
-		t.Errorf("expected PR numbers 1 and 2, got %v", numbers)
+
+	expected := models.PullRequest{
+		Repository: repo,
+		Number:     1,
+		NodeID:     "PR_1",
+		Title:      "PR 1",
+		HTMLURL:    "https://github.com/testowner/testrepo/pull/1",
+		State:      "open",
+		CreatedAt:  time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		UpdatedAt:  time.Date(2025, 1, 3, 3, 4, 5, 0, time.UTC),
+		Labels:     []string{"frontend"},
+		Milestone:  "v1",
+		HeadBranch: "feature",
+		HeadSHA:    "abc123",
+		RequestedReviewers: []models.User{
+			{Login: "bob"},
+			{Login: "reviewer-bot", IsBot: true},
+			{Login: "automation", IsBot: true},
+		},
+		RequestedTeams:    []models.Team{{Slug: "backend", Name: "Backend"}},
+		MovedToRepository: &models.Repository{Owner: "neworg", Name: "testrepo"},
+	}
+	if !reflect.DeepEqual(result[0].PullRequest, expected) {
+		t.Errorf("Expected the PR\n%+v\ngot\n%+v", expected, result[0].PullRequest)
+	}
+	if expected := (githubclient.Collaborator{Login: "author", Name: "Author"}); result[0].Author != expected {
+		t.Errorf("Expected the author %+v, got %+v", expected, result[0].Author)
 	}
 }
 
-func TestFindOpenPRs_ErrorShortCircuits(t *testing.T) {
-	mockPRService404 := &mockPullRequestService{
-		mockPRs: nil, mockReviewsByPRNumber: map[int][]*github.PullRequestReview{},
-		mockCommentsByPRNumber: map[int][]*github.PullRequestComment{},
-		mockResponse:           &github.Response{Response: &http.Response{StatusCode: 404}},
-		mockError:              fmt.Errorf("not found"),
+type mockRepositoriesService struct {
+	archivedByRepo        map[string]bool
+	errorByRepo           map[string]error
+	protectionByRepo      map[string]*github.Protection
+	protectionErrorByRepo map[string]error
+	// Contents of files by repository name and file path
+	filesByRepo   map[string]map[string]string
+	defaultBranch string
+}
+
+func (m *mockRepositoriesService) Get(
+	ctx context.Context, owner string, repo string,
+) (*github.Repository, *github.Response, error) {
+	if err, ok := m.errorByRepo[repo]; ok {
+		return nil, &github.Response{Response: &http.Response{StatusCode: 404}}, err
 	}
-	mockPRServiceOK := &mockPullRequestService{
-		mockPRs: []*github.PullRequest{
-			{
-				Number:  github.Ptr(3),
-				Title:   github.Ptr("Ok PR"),
-				Draft:   github.Ptr(false),
-				HTMLURL: github.Ptr("https://example.com/r/3"),
-				User:    &github.User{Login: github.Ptr("author")},
-			},
-		},
-		mockReviewsByPRNumber:  map[int][]*github.PullRequestReview{},
-		mockCommentsByPRNumber: map[int][]*github.PullRequestComment{},
-		mockResponse:           &github.Response{Response: &http.Response{StatusCode: 200}},
-		mockError:              nil,
+	repository := &github.Repository{
+		Name:          github.Ptr(repo),
+		Archived:      github.Ptr(m.archivedByRepo[repo]),
+		DefaultBranch: github.Ptr(m.defaultBranch),
 	}
+	return repository, &github.Response{Response: &http.Response{StatusCode: 200}}, nil
+}
 
-	mockHTTPClient := &mockHTTPClient{
-		mockResponse: &http.Response{StatusCode: 200},
-		mockError:    nil,
+func (m *mockRepositoriesService) GetBranchProtection(
+	ctx context.Context, owner, repo, branch string,
+) (*github.Protection, *github.Response, error) {
+	if err, ok := m.protectionErrorByRepo[repo]; ok {
+		return nil, &github.Response{Response: &http.Response{StatusCode: 404}}, err
+	}
+	return m.protectionByRepo[repo], &github.Response{Response: &http.Response{StatusCode: 200}}, nil
+}
+
+func (m *mockRepositoriesService) GetContents(
+	ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions,
+) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	content, ok := m.filesByRepo[repo][path]
+	if !ok {
+		return nil, nil, &github.Response{Response: &http.Response{StatusCode: 404}}, fmt.Errorf("not found")
+	}
+	file := &github.RepositoryContent{Path: github.Ptr(path), Content: github.Ptr(content)}
+	return file, nil, &github.Response{Response: &http.Response{StatusCode: 200}}, nil
+}
+
+func TestFindArchivedRepositories(t *testing.T) {
+	repoService := &mockRepositoriesService{
+		archivedByRepo: map[string]bool{"archived1": true, "active": false, "archived2": true},
+		errorByRepo:    map[string]error{"missing": fmt.Errorf("not found")},
+	}
+	client := githubclient.NewClient(githubclient.Services{Repositories: repoService})
+	repos := []models.Repository{
+		{Owner: "o", Name: "archived1"},
+		{Owner: "o", Name: "active"},
+		{Owner: "o", Name: "missing"},
+		{Owner: "o", Name: "archived2"},
+	}
+
+	archived := client.FindArchivedRepositories(context.Background(), repos)
+
+	expected := []models.Repository{{Owner: "o", Name: "archived1"}, {Owner: "o", Name: "archived2"}}
+	if len(archived) != len(expected) || archived[0] != expected[0] || archived[1] != expected[1] {
+		t.Errorf("Expected archived repositories %v, got %v", expected, archived)
+	}
+}
+
+func TestFindRepositoriesWithoutRequiredReviews(t *testing.T) {
+	requiringReviews := func(count int) *github.Protection {
+		return &github.Protection{
+			RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: count},
+		}
+	}
+	repoService := &mockRepositoriesService{
+		errorByRepo: map[string]error{"missing": fmt.Errorf("not found")},
+		protectionByRepo: map[string]*github.Protection{
+			"protected":            requiringReviews(1),
+			"zero-reviews":         requiringReviews(0),
+			"only-status-checks":   {RequiredStatusChecks: &github.RequiredStatusChecks{Strict: true}},
+			"protection-forbidden": nil,
+		},
+		protectionErrorByRepo: map[string]error{
+			"unprotected":          github.ErrBranchNotProtected,
+			"protection-forbidden": fmt.Errorf("resource not accessible by integration"),
+		},
+	}
+	client := githubclient.NewClient(githubclient.Services{Repositories: repoService})
+	var repos []models.Repository
+	for _, name := range []string{
+		"protected", "zero-reviews", "only-status-checks", "unprotected", "protection-forbidden", "missing",
+	} {
+		repos = append(repos, models.Repository{Owner: "o", Name: name})
+	}
+
+	withoutRequiredReviews := client.FindRepositoriesWithoutRequiredReviews(context.Background(), repos)
+
+	var names []string
+	for _, repo := range withoutRequiredReviews {
+		names = append(names, repo.Name)
+	}
+	expected := []string{"zero-reviews", "only-status-checks", "unprotected"}
+	if !slices.Equal(names, expected) {
+		t.Errorf("Expected repositories without required reviews %v, got %v", expected, names)
+	}
+}
+
+type mockWorkflowRunsService struct {
+	runsByRepo    map[string][]*github.WorkflowRun
+	requestedOpts *github.ListWorkflowRunsOptions
+}
+
+func (m *mockWorkflowRunsService) ListRepositoryWorkflowRuns(
+	ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions,
+) (*github.WorkflowRuns, *github.Response, error) {
+	m.requestedOpts = opts
+	return &github.WorkflowRuns{WorkflowRuns: m.runsByRepo[repo]},
+		&github.Response{Response: &http.Response{StatusCode: 200}}, nil
+}
+
+func newWorkflowRun(workflowID int64, name, conclusion string) *github.WorkflowRun {
+	return &github.WorkflowRun{
+		WorkflowID: github.Ptr(workflowID),
+		Name:       github.Ptr(name),
+		Conclusion: github.Ptr(conclusion),
+		HTMLURL:    github.Ptr("https://github.com/o/repo/actions/runs/" + name),
+		Event:      github.Ptr("schedule"),
+	}
+}
+
+func TestFindFailingWorkflows(t *testing.T) {
+	repoService := &mockRepositoriesService{errorByRepo: map[string]error{"missing": fmt.Errorf("not found")}}
+	workflowRunsService := &mockWorkflowRunsService{
+		runsByRepo: map[string][]*github.WorkflowRun{
+			"repo": {
+				newWorkflowRun(1, "nightly", "failure"),
+				newWorkflowRun(2, "ci", "success"),
+				newWorkflowRun(1, "nightly-previous", "success"),
+				newWorkflowRun(2, "ci-previous", "failure"),
+				newWorkflowRun(3, "e2e", "timed_out"),
+			},
+		},
+	}
+	client := githubclient.NewClient(githubclient.Services{
+		Repositories: repoService,
+		WorkflowRuns: workflowRunsService,
+	})
+	repos := []models.Repository{{Owner: "o", Name: "repo"}, {Owner: "o", Name: "missing"}}
+
+	failing := client.FindFailingWorkflows(context.Background(), repos)
+
+	var names []string
+	for _, workflow := range failing {
+		names = append(names, workflow.Name)
+	}
+	if !slices.Equal(names, []string{"nightly", "e2e"}) {
+		t.Errorf("Expected the latest runs of nightly and e2e to be failing, got %v", names)
+	}
+	if workflowRunsService.requestedOpts.Status != "completed" {
+		t.Errorf("Expected only completed runs to be listed, got status '%s'", workflowRunsService.requestedOpts.Status)
+	}
+}
+
+type mockChecksService struct {
+	conclusionsBySHA map[string][]string
+	errorBySHA       map[string]error
+}
+
+func (m *mockChecksService) ListCheckRunsForRef(
+	ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions,
+) (*github.ListCheckRunsResults, *github.Response, error) {
+	if err, ok := m.errorBySHA[ref]; ok {
+		return nil, &github.Response{Response: &http.Response{StatusCode: 500}}, err
+	}
+	var runs []*github.CheckRun
+	for _, conclusion := range m.conclusionsBySHA[ref] {
+		runs = append(runs, &github.CheckRun{Conclusion: github.Ptr(conclusion)})
+	}
+	return &github.ListCheckRunsResults{CheckRuns: runs}, &github.Response{Response: &http.Response{StatusCode: 200}}, nil
+}
+
+func TestAddFailingChecksInfo(t *testing.T) {
+	checksService := &mockChecksService{
+		conclusionsBySHA: map[string][]string{
+			"sha-passing":         {"success", "skipped", "neutral"},
+			"sha-failing":         {"success", "failure"},
+			"sha-timed-out":       {"timed_out"},
+			"sha-action-required": {"action_required"},
+		},
+		errorBySHA: map[string]error{"sha-error": fmt.Errorf("server error")},
+	}
+	client := githubclient.NewClient(githubclient.Services{Checks: checksService})
+	getPR := func(number int, sha string) githubclient.PR {
+		return githubclient.PR{PullRequest: models.PullRequest{
+			Repository: models.Repository{Owner: "o", Name: "repo"}, Number: number, HeadSHA: sha,
+		}}
+	}
+	prs := []githubclient.PR{
+		getPR(1, "sha-passing"),
+		getPR(2, "sha-failing"),
+		getPR(3, "sha-timed-out"),
+		getPR(4, "sha-action-required"),
+		getPR(5, "sha-error"),
+		getPR(6, "sha-without-checks"),
+	}
+
+	prs = client.AddFailingChecksInfo(context.Background(), prs)
+
+	var failing []int
+	for _, pr := range prs {
+		if pr.HasFailingChecks {
+			failing = append(failing, pr.Number)
+		}
+	}
+	if !slices.Equal(failing, []int{2, 3, 4}) {
+		t.Errorf("Expected PRs 2, 3 and 4 to have failing checks, got %v", failing)
+	}
+}
+
+func TestAddCodeownerApprovalInfo(t *testing.T) {
+	repoService := &mockRepositoriesService{
+		filesByRepo: map[string]map[string]string{
+			"with-codeowners":      {".github/CODEOWNERS": "* @lead\n/docs/ @writer\n"},
+			"with-root-codeowners": {"CODEOWNERS": "* @lead\n"},
+		},
+	}
+	prService := &multiRepoPRService{
+		services: map[string]*mockPullRequestService{
+			"with-codeowners": {mockFilesByPRNumber: map[int][]*github.CommitFile{
+				1: {{Filename: github.Ptr("main.go")}},
+				2: {{Filename: github.Ptr("docs/guide.md")}},
+				3: {{Filename: github.Ptr("main.go")}},
+			}},
+			"with-root-codeowners": {mockFilesByPRNumber: map[int][]*github.CommitFile{
+				4: {{Filename: github.Ptr("main.go")}},
+			}},
+			"without-codeowners": {mockFilesByPRNumber: map[int][]*github.CommitFile{
+				5: {{Filename: github.Ptr("main.go")}},
+			}},
+		},
+	}
+	client := githubclient.NewClient(githubclient.Services{PullRequests: prService, Repositories: repoService})
+	getPR := func(number int, repo string, approvers ...string) githubclient.PR {
+		pr := githubclient.PR{PullRequest: models.PullRequest{
+			Repository: models.Repository{Owner: "o", Name: repo}, Number: number,
+		}}
+		for _, login := range approvers {
+			pr.ApprovedByUsers = append(pr.ApprovedByUsers, githubclient.Collaborator{Login: login})
+		}
+		return pr
+	}
+	prs := []githubclient.PR{
+		getPR(1, "with-codeowners", "lead"),
+		getPR(2, "with-codeowners", "lead"),
+		getPR(3, "with-codeowners", "someone"),
+		getPR(4, "with-root-codeowners", "someone", "Lead"),
+		getPR(5, "without-codeowners", "lead"),
+	}
+
+	prs = client.AddCodeownerApprovalInfo(context.Background(), prs)
+
+	var ownerApproved []int
+	for _, pr := range prs {
+		if pr.HasCodeownerApproval {
+			ownerApproved = append(ownerApproved, pr.Number)
+		}
+	}
+	if !slices.Equal(ownerApproved, []int{1, 4}) {
+		t.Errorf("Expected PRs 1 and 4 to have code owner approval, got %v", ownerApproved)
+	}
+}
+
+func TestFindMilestones(t *testing.T) {
+	issuesService := &multiRepoIssuesService{
+		services: map[string]*mockIssueService{
+			"repo": {
+				mockMilestones: []*github.Milestone{
+					{Title: github.Ptr("2.3"), OpenIssues: github.Ptr(0), ClosedIssues: github.Ptr(15)},
+					{Title: github.Ptr("2.4"), OpenIssues: github.Ptr(8), ClosedIssues: github.Ptr(12)},
+				},
+				mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}},
+			},
+		},
+	}
+	client := githubclient.NewClient(githubclient.Services{Issues: issuesService})
+	repos := []models.Repository{{Owner: "o", Name: "repo"}, {Owner: "o", Name: "missing"}, {Owner: "o", Name: "unfiltered"}}
+	getFilters := func(repo models.Repository) config.Filters {
+		if repo.Name == "unfiltered" {
+			return config.Filters{}
+		}
+		return config.Filters{Milestones: []string{"2.4"}}
+	}
+
+	milestones := client.FindMilestones(context.Background(), repos, getFilters)
+
+	expected := []githubclient.Milestone{
+		{Repository: repos[0], Title: "2.4", OpenIssues: 8, ClosedIssues: 12},
+	}
+	if !slices.Equal(milestones, expected) {
+		t.Errorf("Expected milestones %v, got %v", expected, milestones)
+	}
+}
+
+func TestFindRecentlyMergedPRs(t *testing.T) {
+	now := time.Now()
+	mergedPR := func(number int, author string, mergedHoursAgo int) *github.PullRequest {
+		return &github.PullRequest{
+			Number:   github.Ptr(number),
+			Title:    github.Ptr(fmt.Sprintf("PR %d", number)),
+			HTMLURL:  github.Ptr(fmt.Sprintf("https://github.com/o/repo/pull/%d", number)),
+			User:     &github.User{Login: github.Ptr(author)},
+			MergedAt: &github.Timestamp{Time: now.Add(-time.Duration(mergedHoursAgo) * time.Hour)},
+		}
+	}
+	prService := &multiRepoPRService{
+		services: map[string]*mockPullRequestService{
+			"repo": {
+				mockPRs: []*github.PullRequest{
+					mergedPR(1, "alice", 2),
+					mergedPR(2, "alice", 30), // merged before the time
+					{Number: github.Ptr(3), User: &github.User{Login: github.Ptr("alice")}}, // closed without merging
+					mergedPR(4, "bob", 1), // left out by the filters
+				},
+				mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}},
+			},
+			"other-repo": {
+				mockPRs:      []*github.PullRequest{mergedPR(5, "alice", 1)},
+				mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}},
+			},
+		},
+	}
+	client := githubclient.NewClient(githubclient.Services{PullRequests: prService})
+	repos := []models.Repository{{Owner: "o", Name: "repo"}, {Owner: "o", Name: "other-repo"}, {Owner: "o", Name: "missing"}}
+	getFilters := func(repo models.Repository) config.Filters {
+		return config.Filters{IgnoredAuthors: []string{"bob"}, MinAgeHours: 48}
+	}
+
+	mergedPRs := client.FindRecentlyMergedPRs(context.Background(), repos, now.Add(-24*time.Hour), getFilters)
+
+	titles := utilities.Map(mergedPRs, func(pr githubclient.MergedPR) string { return pr.Title })
+	if !slices.Equal(titles, []string{"PR 5", "PR 1"}) {
+		t.Errorf("Expected the PRs merged within the time, the latest merged first, got %v", titles)
+	}
+	if mergedPRs[1].Repository != repos[0] || mergedPRs[1].HTMLURL != "https://github.com/o/repo/pull/1" {
+		t.Errorf("Expected the repository and URL of the merged PR, got %+v", mergedPRs[1])
+	}
+}
+
+func TestFindOpenPRs_MultipleRepositories(t *testing.T) {
+	mockPRService1 := &mockPullRequestService{
+		mockPRs: []*github.PullRequest{
+			{
+				Number:  github.Ptr(1),
+				Title:   github.Ptr("Repo1 PR"),
+				Draft:   github.Ptr(false),
+				HTMLURL: github.Ptr("https://example.com/r1/1"),
+				User:    &github.User{Login: github.Ptr("author1")},
+			},
+		},
+		mockReviewsByPRNumber:  map[int][]*github.PullRequestReview{},
+		mockCommentsByPRNumber: map[int][]*github.PullRequestComment{},
+		mockResponse:           &github.Response{Response: &http.Response{StatusCode: 200}},
+		mockError:              nil,
+	}
+	mockPRService2 := &mockPullRequestService{
+		mockPRs: []*github.PullRequest{
+			{
+				Number:  github.Ptr(2),
+				Title:   github.Ptr("Repo2 PR"),
+				Draft:   github.Ptr(false),
+				HTMLURL: github.Ptr("https://example.com/r2/2"),
+				User:    &github.User{Login: github.Ptr("author2")},
+			},
+		},
+		mockReviewsByPRNumber:  map[int][]*github.PullRequestReview{},
+		mockCommentsByPRNumber: map[int][]*github.PullRequestComment{},
+		mockResponse:           &github.Response{Response: &http.Response{StatusCode: 200}},
+		mockError:              nil,
+	}
+
+	mockIssueService1 := &mockIssueService{
+		mockTimelineCommentsByPRNumber: map[int][]*github.IssueComment{},
+		mockResponse:                   &github.Response{Response: &http.Response{StatusCode: 200}},
+		mockError:                      nil,
+	}
+	mockIssueService2 := &mockIssueService{
+		mockTimelineCommentsByPRNumber: map[int][]*github.IssueComment{},
+		mockResponse:                   &github.Response{Response: &http.Response{StatusCode: 200}},
+		mockError:                      nil,
+	}
+	mockHTTPClient := &mockHTTPClient{
+		mockResponse: &http.Response{StatusCode: 200},
+		mockError:    nil,
+	}
+	mockActionsService := &mockActionsService{
+		mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}},
+		mockError:    nil,
+	}
+	client := githubclient.NewClient(githubclient.Services{
+		HTTP: mockHTTPClient,
+		PullRequests: &multiRepoPRService{
+			services: map[string]*mockPullRequestService{"repo1": mockPRService1, "repo2": mockPRService2},
+		},
+		Issues: &multiRepoIssuesService{
+			services: map[string]*mockIssueService{"repo1": mockIssueService1, "repo2": mockIssueService2},
+		},
+		Actions: mockActionsService,
+	})
+	repos := []models.Repository{{Owner: "o", Name: "repo1"}, {Owner: "o", Name: "repo2"}}
+	result, _, err := client.FindOpenPRs(
+		context.Background(),
+		repos,
+		func(models.Repository) config.Filters {
+			return config.Filters{}
+		},
+		githubclient.FindOpenPRsOptions{},
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("expected 2 PRs, got %d", len(result))
+	}
+	numbers := []int{result[0].Number, result[1].Number}
+	if !((numbers[0] == 1 && numbers[1] == 2) || (numbers[0] == 2 && numbers[1] == 1)) {
+		t.Errorf("expected PR numbers 1 and 2, got %v", numbers)
+	}
+}
+
+func TestFindOpenPRs_ErrorShortCircuits(t *testing.T) {
+	mockPRService404 := &mockPullRequestService{
+		mockPRs: nil, mockReviewsByPRNumber: map[int][]*github.PullRequestReview{},
+		mockCommentsByPRNumber: map[int][]*github.PullRequestComment{},
+		mockResponse:           &github.Response{Response: &http.Response{StatusCode: 404}},
+		mockError:              fmt.Errorf("not found"),
+	}
+	mockPRServiceOK := &mockPullRequestService{
+		mockPRs: []*github.PullRequest{
+			{
+				Number:  github.Ptr(3),
+				Title:   github.Ptr("Ok PR"),
+				Draft:   github.Ptr(false),
+				HTMLURL: github.Ptr("https://example.com/r/3"),
+				User:    &github.User{Login: github.Ptr("author")},
+			},
+		},
+		mockReviewsByPRNumber:  map[int][]*github.PullRequestReview{},
+		mockCommentsByPRNumber: map[int][]*github.PullRequestComment{},
+		mockResponse:           &github.Response{Response: &http.Response{StatusCode: 200}},
+		mockError:              nil,
+	}
+
+	mockHTTPClient := &mockHTTPClient{
+		mockResponse: &http.Response{StatusCode: 200},
+		mockError:    nil,
 	}
 	mockActionsService := &mockActionsService{
 		mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}},
 		mockError:    nil,
 	}
-	client := githubclient.NewClient(
-		mockHTTPClient,
-		&multiRepoPRService{
+	client := githubclient.NewClient(githubclient.Services{
+		HTTP: mockHTTPClient,
+		PullRequests: &multiRepoPRService{
 			services: map[string]*mockPullRequestService{"bad": mockPRService404, "good": mockPRServiceOK},
 		},
-		&multiRepoIssuesService{
+		Issues: &multiRepoIssuesService{
 			services: map[string]*mockIssueService{
 				"bad": {
 					mockTimelineCommentsByPRNumber: map[int][]*github.IssueComment{},
@@ -795,13 +1564,14 @@ func TestFindOpenPRs_ErrorShortCircuits(t *testing.T) {
 				},
 			},
 		},
-		mockActionsService,
-	)
+		Actions: mockActionsService,
+	})
 	repos := []models.Repository{{Owner: "o", Name: "bad"}, {Owner: "o", Name: "good"}}
-	_, err := client.FindOpenPRs(
+	_, _, err := client.FindOpenPRs(
 		context.Background(),
 		repos,
 		func(models.Repository) config.Filters { return config.Filters{} },
+		githubclient.FindOpenPRsOptions{},
 	)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
@@ -846,16 +1616,17 @@ func TestFindOpenPRs_ConcurrencyLimit(t *testing.T) {
 		mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}},
 		mockError:    nil,
 	}
-	client := githubclient.NewClient(
-		mockHTTPClient,
-		&multiRepoPRService{services: services},
-		&multiRepoIssuesService{services: issueServices},
-		mockActionsService,
-	)
-	prs, err := client.FindOpenPRs(
+	client := githubclient.NewClient(githubclient.Services{
+		HTTP:         mockHTTPClient,
+		PullRequests: &multiRepoPRService{services: services},
+		Issues:       &multiRepoIssuesService{services: issueServices},
+		Actions:      mockActionsService,
+	})
+	prs, _, err := client.FindOpenPRs(
 		context.Background(),
 		repos,
 		func(models.Repository) config.Filters { return config.Filters{} },
+		githubclient.FindOpenPRsOptions{},
 	)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -865,6 +1636,210 @@ func TestFindOpenPRs_ConcurrencyLimit(t *testing.T) {
 	}
 }
 
+func TestFindOpenPRs_FilteredPRCount(t *testing.T) {
+	getPR := func(number int, label string, draft bool) *github.PullRequest {
+		return &github.PullRequest{
+			Number:  github.Ptr(number),
+			Title:   github.Ptr(fmt.Sprintf("PR %d", number)),
+			Draft:   github.Ptr(draft),
+			HTMLURL: github.Ptr(fmt.Sprintf("https://example.com/pr/%d", number)),
+			User:    &github.User{Login: github.Ptr("author")},
+			Labels:  []*github.Label{{Name: github.Ptr(label)}},
+		}
+	}
+	mockPRService := &mockPullRequestService{
+		mockPRs: []*github.PullRequest{
+			getPR(1, "frontend", false),
+			getPR(2, "backend", false),
+			getPR(3, "backend", false),
+			getPR(4, "backend", true), // drafts are never listed, so they are not counted
+		},
+		mockReviewsByPRNumber:  map[int][]*github.PullRequestReview{},
+		mockCommentsByPRNumber: map[int][]*github.PullRequestComment{},
+		mockResponse:           &github.Response{Response: &http.Response{StatusCode: 200}},
+	}
+	mockIssueService := &mockIssueService{
+		mockTimelineCommentsByPRNumber: map[int][]*github.IssueComment{},
+		mockResponse:                   &github.Response{Response: &http.Response{StatusCode: 200}},
+	}
+	client := githubclient.NewClient(githubclient.Services{
+		HTTP:         &mockHTTPClient{mockResponse: &http.Response{StatusCode: 200}},
+		PullRequests: mockPRService,
+		Issues:       mockIssueService,
+		Actions:      &mockActionsService{mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}}},
+	})
+
+	prs, filteredPRCount, err := client.FindOpenPRs(
+		context.Background(),
+		[]models.Repository{{Owner: "testowner", Name: "testrepo"}},
+		func(models.Repository) config.Filters { return config.Filters{Labels: []string{"frontend"}} },
+		githubclient.FindOpenPRsOptions{},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 1 || prs[0].Number != 1 {
+		t.Errorf("expected only PR 1, got %d PRs", len(prs))
+	}
+	if filteredPRCount != 2 {
+		t.Errorf("expected 2 PRs left out by the filters, got %d", filteredPRCount)
+	}
+}
+
+func TestFindOpenPRs_EnrichTopN(t *testing.T) {
+	now := time.Now()
+	getPR := func(number int, ageHours int) *github.PullRequest {
+		return &github.PullRequest{
+			Number:    github.Ptr(number),
+			Title:     github.Ptr(fmt.Sprintf("PR %d", number)),
+			Draft:     github.Ptr(false),
+			HTMLURL:   github.Ptr(fmt.Sprintf("https://example.com/pr/%d", number)),
+			User:      &github.User{Login: github.Ptr("author")},
+			CreatedAt: &github.Timestamp{Time: now.Add(-time.Duration(ageHours) * time.Hour)},
+		}
+	}
+	approvingReview := []*github.PullRequestReview{
+		{State: github.Ptr("APPROVED"), User: &github.User{Login: github.Ptr("reviewer")}},
+	}
+	mockPRService := &mockPullRequestService{
+		mockPRs: []*github.PullRequest{getPR(1, 5), getPR(2, 30), getPR(3, 1), getPR(4, 10)},
+		mockReviewsByPRNumber: map[int][]*github.PullRequestReview{
+			1: approvingReview, 2: approvingReview, 3: approvingReview, 4: approvingReview,
+		},
+		mockCommentsByPRNumber: map[int][]*github.PullRequestComment{},
+		mockResponse:           &github.Response{Response: &http.Response{StatusCode: 200}},
+	}
+	mockIssueService := &mockIssueService{
+		mockTimelineCommentsByPRNumber: map[int][]*github.IssueComment{},
+		mockResponse:                   &github.Response{Response: &http.Response{StatusCode: 200}},
+	}
+
+	testCases := []struct {
+		name             string
+		enrichTopN       int
+		expectedEnriched []int
+	}{
+		{name: "all PRs enriched by default", enrichTopN: 0, expectedEnriched: []int{1, 2, 3, 4}},
+		{name: "only oldest PRs enriched", enrichTopN: 2, expectedEnriched: []int{2, 4}},
+		{name: "limit exceeding PR count", enrichTopN: 10, expectedEnriched: []int{1, 2, 3, 4}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubclient.NewClient(githubclient.Services{
+				PullRequests: mockPRService,
+				Issues:       mockIssueService,
+			})
+			prs, _, err := client.FindOpenPRs(
+				context.Background(),
+				[]models.Repository{{Owner: "o", Name: "repo"}},
+				func(models.Repository) config.Filters { return config.Filters{} },
+				githubclient.FindOpenPRsOptions{EnrichTopN: tc.enrichTopN},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(prs) != 4 {
+				t.Fatalf("expected all 4 PRs to be returned, got %d", len(prs))
+			}
+			var enriched []int
+			for _, pr := range prs {
+				if len(pr.ApprovedByUsers) > 0 {
+					enriched = append(enriched, pr.Number)
+				}
+			}
+			slices.Sort(enriched)
+			if !slices.Equal(enriched, tc.expectedEnriched) {
+				t.Errorf("expected PRs %v to be enriched, got %v", tc.expectedEnriched, enriched)
+			}
+		})
+	}
+}
+
+func TestFindOpenPRs_APICallBudget(t *testing.T) {
+	getPR := func(number int) *github.PullRequest {
+		return &github.PullRequest{
+			Number:    github.Ptr(number),
+			Title:     github.Ptr(fmt.Sprintf("PR %d", number)),
+			Draft:     github.Ptr(false),
+			User:      &github.User{Login: github.Ptr("author")},
+			CreatedAt: &github.Timestamp{Time: time.Now().Add(-time.Duration(number) * time.Hour)},
+		}
+	}
+	approvingReview := []*github.PullRequestReview{
+		{State: github.Ptr("APPROVED"), User: &github.User{Login: github.Ptr("reviewer")}},
+	}
+	mockPRService := &mockPullRequestService{
+		mockPRs: []*github.PullRequest{getPR(1), getPR(2), getPR(3), getPR(4)},
+		mockReviewsByPRNumber: map[int][]*github.PullRequestReview{
+			1: approvingReview, 2: approvingReview, 3: approvingReview, 4: approvingReview,
+		},
+		mockCommentsByPRNumber: map[int][]*github.PullRequestComment{},
+		mockResponse:           &github.Response{Response: &http.Response{StatusCode: 200}},
+	}
+	mockIssueService := &mockIssueService{
+		mockTimelineCommentsByPRNumber: map[int][]*github.IssueComment{},
+		mockResponse:                   &github.Response{Response: &http.Response{StatusCode: 200}},
+	}
+
+	testCases := []struct {
+		name                  string
+		maxAPICalls           int
+		expectedEnrichedCount int
+		expectedReportSummary string
+	}{
+		{name: "all PRs enriched without a budget", maxAPICalls: 0, expectedEnrichedCount: 4},
+		{name: "budget covering all calls", maxAPICalls: 13, expectedEnrichedCount: 4},
+		{
+			// listing the PRs takes one call and enriching each PR three
+			name:                  "budget covering the enrichment of two PRs",
+			maxAPICalls:           7,
+			expectedEnrichedCount: 2,
+			expectedReportSummary: "⚠️ 1 other issue",
+		},
+		{
+			name:                  "PRs are listed even if the budget is exceeded",
+			maxAPICalls:           1,
+			expectedEnrichedCount: 0,
+			expectedReportSummary: "⚠️ 1 other issue",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubclient.NewClient(githubclient.Services{
+				PullRequests: mockPRService,
+				Issues:       mockIssueService,
+			})
+			client.SetAPICallBudget(tc.maxAPICalls)
+			report := runreport.New()
+
+			prs, _, err := client.FindOpenPRs(
+				runreport.NewContext(context.Background(), report),
+				[]models.Repository{{Owner: "o", Name: "repo"}},
+				func(models.Repository) config.Filters { return config.Filters{} },
+				githubclient.FindOpenPRsOptions{},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(prs) != 4 {
+				t.Fatalf("expected all 4 PRs to be returned, got %d", len(prs))
+			}
+			enrichedCount := 0
+			for _, pr := range prs {
+				if len(pr.ApprovedByUsers) > 0 {
+					enrichedCount++
+				}
+			}
+			if enrichedCount != tc.expectedEnrichedCount {
+				t.Errorf("expected %d PRs to be enriched, got %d", tc.expectedEnrichedCount, enrichedCount)
+			}
+			if summary := report.Summary(); summary != tc.expectedReportSummary {
+				t.Errorf("expected report summary %q, got %q", tc.expectedReportSummary, summary)
+			}
+		})
+	}
+}
+
 // selectivePRService allows per-PR errors to test best-effort reviewer info enrichment.
 type selectivePRService struct {
 	mockPRs            []*github.PullRequest
@@ -911,6 +1886,12 @@ func (s *selectivePRService) ListComments(
 	return comments, s.reviewsResponse, err
 }
 
+func (s *selectivePRService) ListFiles(
+	ctx context.Context, owner string, repo string, number int, opts *github.ListOptions,
+) ([]*github.CommitFile, *github.Response, error) {
+	return nil, s.reviewsResponse, s.errByPRNumber[number]
+}
+
 // selectiveIssuesService allows per-PR errors to test best-effort issue comment info enrichment.
 type selectiveIssuesService struct {
 	timelineCommentsByPRNumber map[int][]*github.IssueComment
@@ -926,6 +1907,12 @@ func (s *selectiveIssuesService) ListComments(
 	return comments, s.response, err
 }
 
+func (s *selectiveIssuesService) ListMilestones(
+	ctx context.Context, owner string, repo string, opts *github.MilestoneListOptions,
+) ([]*github.Milestone, *github.Response, error) {
+	return nil, s.response, nil
+}
+
 func TestFindOpenPRs_ReviewsPartialErrors(t *testing.T) {
 	// Two PRs: first reviews fetch fails, second succeeds.
 	prService := &selectivePRService{
@@ -961,12 +1948,18 @@ func TestFindOpenPRs_ReviewsPartialErrors(t *testing.T) {
 		mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}},
 		mockError:    nil,
 	}
-	client := githubclient.NewClient(mockHTTPClient, prService, issueService, mockActionsService)
+	client := githubclient.NewClient(githubclient.Services{
+		HTTP:         mockHTTPClient,
+		PullRequests: prService,
+		Issues:       issueService,
+		Actions:      mockActionsService,
+	})
 	repos := []models.Repository{{Owner: "o", Name: "repo"}}
-	prs, err := client.FindOpenPRs(
+	prs, _, err := client.FindOpenPRs(
 		context.Background(),
 		repos,
 		func(models.Repository) config.Filters { return config.Filters{} },
+		githubclient.FindOpenPRsOptions{},
 	)
 	if err != nil {
 		t.Fatalf("did not expect error, got %v", err)
@@ -976,7 +1969,7 @@ func TestFindOpenPRs_ReviewsPartialErrors(t *testing.T) {
 	}
 	var pr1, pr2 *githubclient.PR
 	for i := range prs {
-		switch prs[i].GetNumber() {
+		switch prs[i].Number {
 		case 101:
 			pr1 = &prs[i]
 		case 102:
@@ -1014,3 +2007,518 @@ func slicesEqualIgnoreOrder(a, b []string) bool {
 	}
 	return true
 }
+
+type pagedPRService struct {
+	*mockPullRequestService
+	pages      [][]*github.PullRequest
+	listedOpts []github.PullRequestListOptions
+}
+
+func (m *pagedPRService) List(
+	ctx context.Context, owner string, repo string, opts *github.PullRequestListOptions,
+) ([]*github.PullRequest, *github.Response, error) {
+	m.listedOpts = append(m.listedOpts, *opts)
+	pageIndex := max(opts.Page, 1) - 1
+	response := &github.Response{Response: &http.Response{StatusCode: 200}}
+	if pageIndex+1 < len(m.pages) {
+		response.NextPage = pageIndex + 2
+	}
+	return m.pages[pageIndex], response, nil
+}
+
+func TestFindOpenPRs_Paginates(t *testing.T) {
+	getPages := func(count int) [][]*github.PullRequest {
+		pages := make([][]*github.PullRequest, count)
+		for i := range pages {
+			for j := 1; j <= 2; j++ {
+				number := i*2 + j
+				pages[i] = append(pages[i], &github.PullRequest{
+					Number:    github.Ptr(number),
+					Title:     github.Ptr(fmt.Sprintf("PR %d", number)),
+					User:      &github.User{Login: github.Ptr("author")},
+					CreatedAt: &github.Timestamp{Time: time.Now().Add(-time.Duration(100-number) * time.Hour)},
+				})
+			}
+		}
+		return pages
+	}
+
+	tests := []struct {
+		name              string
+		pageCount         int
+		expectedListCalls int
+		expectedPRCount   int
+	}{
+		{name: "single page", pageCount: 1, expectedListCalls: 1, expectedPRCount: 2},
+		{name: "all pages are fetched", pageCount: 3, expectedListCalls: 3, expectedPRCount: 6},
+		{name: "pages are fetched up to the limit", pageCount: 12, expectedListCalls: 10, expectedPRCount: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prService := &pagedPRService{
+				mockPullRequestService: &mockPullRequestService{
+					mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}},
+				},
+				pages: getPages(tt.pageCount),
+			}
+			client := githubclient.NewClient(githubclient.Services{
+				HTTP:         &mockHTTPClient{mockResponse: &http.Response{StatusCode: 200}},
+				PullRequests: prService,
+				Issues:       &mockIssueService{mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}}},
+				Actions:      &mockActionsService{mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}}},
+			})
+
+			prs, _, err := client.FindOpenPRs(
+				context.Background(),
+				[]models.Repository{{Owner: "testowner", Name: "testrepo"}},
+				func(models.Repository) config.Filters { return config.Filters{} },
+				githubclient.FindOpenPRsOptions{},
+			)
+			if err != nil {
+				t.Fatalf("FindOpenPRs() returned error: %v", err)
+			}
+			if len(prService.listedOpts) != tt.expectedListCalls {
+				t.Errorf("Expected %d list calls, got %d", tt.expectedListCalls, len(prService.listedOpts))
+			}
+			if len(prs) != tt.expectedPRCount {
+				t.Errorf("Expected %d PRs, got %d", tt.expectedPRCount, len(prs))
+			}
+			if opts := prService.listedOpts[0]; opts.Sort != "created" || opts.Direction != "asc" {
+				t.Errorf("Expected the oldest PRs to be listed first, got sort %q and direction %q", opts.Sort, opts.Direction)
+			}
+		})
+	}
+}
+
+func TestFindOpenPRs_TruncateKeep(t *testing.T) {
+	var mockPRs []*github.PullRequest
+	for number := 1; number <= githubclient.MaxPRsToFetch+10; number++ {
+		mockPRs = append(mockPRs, &github.PullRequest{
+			Number:    github.Ptr(number),
+			Title:     github.Ptr(fmt.Sprintf("PR %d", number)),
+			User:      &github.User{Login: github.Ptr("author")},
+			CreatedAt: &github.Timestamp{Time: time.Now().Add(-time.Duration(number) * time.Hour)}, // PR 1 is the newest
+		})
+	}
+
+	tests := []struct {
+		name                string
+		truncateKeep        config.TruncateKeep
+		expectedFirstNumber int
+		expectedLastNumber  int
+	}{
+		{name: "oldest PRs are kept", truncateKeep: config.TruncateKeepOldest, expectedFirstNumber: 11, expectedLastNumber: 60},
+		{name: "newest PRs are kept", truncateKeep: config.TruncateKeepNewest, expectedFirstNumber: 1, expectedLastNumber: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := githubclient.NewClient(githubclient.Services{
+				HTTP: &mockHTTPClient{mockResponse: &http.Response{StatusCode: 200}},
+				PullRequests: &mockPullRequestService{
+					mockPRs:      slices.Clone(mockPRs),
+					mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}},
+				},
+				Issues:  &mockIssueService{mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}}},
+				Actions: &mockActionsService{mockResponse: &github.Response{Response: &http.Response{StatusCode: 200}}},
+			})
+
+			prs, _, err := client.FindOpenPRs(
+				context.Background(),
+				[]models.Repository{{Owner: "testowner", Name: "testrepo"}},
+				func(models.Repository) config.Filters { return config.Filters{} },
+				githubclient.FindOpenPRsOptions{TruncateKeep: tt.truncateKeep},
+			)
+			if err != nil {
+				t.Fatalf("FindOpenPRs() returned error: %v", err)
+			}
+			if len(prs) != githubclient.MaxPRsToFetch {
+				t.Fatalf("Expected %d PRs, got %d", githubclient.MaxPRsToFetch, len(prs))
+			}
+			numbers := utilities.Map(prs, func(pr githubclient.PR) int { return pr.Number })
+			if slices.Min(numbers) != tt.expectedFirstNumber || slices.Max(numbers) != tt.expectedLastNumber {
+				t.Errorf(
+					"Expected PRs %d..%d, got %d..%d",
+					tt.expectedFirstNumber, tt.expectedLastNumber, slices.Min(numbers), slices.Max(numbers),
+				)
+			}
+		})
+	}
+}
+
+func TestFindOpenPRs_MinPRsPerRepository(t *testing.T) {
+	getPRs := func(count int, maxAge time.Duration) []*github.PullRequest {
+		var prs []*github.PullRequest
+		for number := 1; number <= count; number++ {
+			prs = append(prs, &github.PullRequest{
+				Number:    github.Ptr(number),
+				Title:     github.Ptr(fmt.Sprintf("PR %d", number)),
+				User:      &github.User{Login: github.Ptr("author")},
+				CreatedAt: &github.Timestamp{Time: time.Now().Add(-maxAge + time.Duration(number)*time.Minute)},
+			})
+		}
+		return prs
+	}
+	okResponse := &github.Response{Response: &http.Response{StatusCode: 200}}
+
+	tests := []struct {
+		name                string
+		minPRsPerRepository int
+		expectedCountByRepo map[string]int
+	}{
+		{
+			name:                "busy repository crowds out the others without a minimum",
+			expectedCountByRepo: map[string]int{"busy": 50},
+		},
+		{
+			name:                "minimum PRs of each repository are kept",
+			minPRsPerRepository: 3,
+			expectedCountByRepo: map[string]int{"busy": 44, "quiet": 3, "other": 3},
+		},
+		{
+			name:                "minimum is limited to a fair share",
+			minPRsPerRepository: 40,
+			expectedCountByRepo: map[string]int{"busy": 29, "quiet": 5, "other": 16}, // 16 of each, the rest by age
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prService := &multiRepoPRService{services: map[string]*mockPullRequestService{
+				// the PRs of the busy repository are the oldest
+				"busy":  {mockPRs: getPRs(60, 100*time.Hour), mockResponse: okResponse},
+				"quiet": {mockPRs: getPRs(5, 10*time.Hour), mockResponse: okResponse},
+				"other": {mockPRs: getPRs(20, 20*time.Hour), mockResponse: okResponse},
+			}}
+			client := githubclient.NewClient(githubclient.Services{
+				HTTP:         &mockHTTPClient{mockResponse: &http.Response{StatusCode: 200}},
+				PullRequests: prService,
+				Issues:       &mockIssueService{mockResponse: okResponse},
+				Actions:      &mockActionsService{mockResponse: okResponse},
+			})
+
+			prs, _, err := client.FindOpenPRs(
+				context.Background(),
+				[]models.Repository{{Owner: "o", Name: "busy"}, {Owner: "o", Name: "quiet"}, {Owner: "o", Name: "other"}},
+				func(models.Repository) config.Filters { return config.Filters{} },
+				githubclient.FindOpenPRsOptions{
+					TruncateKeep:        config.TruncateKeepOldest,
+					MinPRsPerRepository: tt.minPRsPerRepository,
+				},
+			)
+			if err != nil {
+				t.Fatalf("FindOpenPRs() returned error: %v", err)
+			}
+			countByRepo := map[string]int{}
+			for _, pr := range prs {
+				countByRepo[pr.Repository.Name]++
+			}
+			if !maps.Equal(countByRepo, tt.expectedCountByRepo) {
+				t.Errorf("Expected PR counts by repository %v, got %v", tt.expectedCountByRepo, countByRepo)
+			}
+		})
+	}
+}
+
+type mockTeamsService struct {
+	// pages of member logins by org/team-slug
+	memberPagesByTeam map[string][][]string
+}
+
+func (m *mockTeamsService) ListTeamMembersBySlug(
+	ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions,
+) ([]*github.User, *github.Response, error) {
+	pages, ok := m.memberPagesByTeam[org+"/"+slug]
+	if !ok {
+		return nil, &github.Response{Response: &http.Response{StatusCode: 404}}, fmt.Errorf("404 Not Found")
+	}
+	page := max(opts.Page, 1)
+	response := &github.Response{Response: &http.Response{StatusCode: 200}}
+	if page < len(pages) {
+		response.NextPage = page + 1
+	}
+	var members []*github.User
+	for _, login := range pages[page-1] {
+		members = append(members, &github.User{Login: github.Ptr(login)})
+	}
+	return members, response, nil
+}
+
+func TestFindTeamMembers(t *testing.T) {
+	teamsService := &mockTeamsService{
+		memberPagesByTeam: map[string][][]string{
+			"org/backend":  {{"alice", "bob"}, {"carol"}},
+			"org/frontend": {{"dave"}},
+		},
+	}
+	client := githubclient.NewClient(githubclient.Services{Teams: teamsService})
+
+	tests := []struct {
+		name           string
+		teams          []string
+		expectedLogins []string
+		expectError    bool
+	}{
+		{
+			name:           "members of all pages of the teams",
+			teams:          []string{"org/backend", "org/frontend"},
+			expectedLogins: []string{"alice", "bob", "carol", "dave"},
+		},
+		{
+			name:        "error if the members of a team cannot be fetched",
+			teams:       []string{"org/backend", "org/unknown"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logins, err := client.FindTeamMembers(context.Background(), tt.teams)
+
+			if tt.expectError != (err != nil) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectError, err)
+			}
+			if !slices.Equal(logins, tt.expectedLogins) {
+				t.Errorf("Expected members %v, got %v", tt.expectedLogins, logins)
+			}
+		})
+	}
+}
+
+type mockGraphQLService struct {
+	// The fields of the PRs looked up by node ID, the other nodes are not found if set
+	pullRequestByNodeID    map[string]map[string]any
+	reviewDecisionByNodeID map[string]string
+	statsByNodeID          map[string]models.PullRequestStats
+	timelineByNodeID       map[string][]map[string]any
+	err                    error
+	batchSizes             []int
+}
+
+func (m *mockGraphQLService) Query(ctx context.Context, query string, variables map[string]any, result any) error {
+	if m.err != nil {
+		return m.err
+	}
+	ids := variables["ids"].([]string)
+	m.batchSizes = append(m.batchSizes, len(ids))
+	var nodes []map[string]any
+	for _, id := range ids {
+		if m.pullRequestByNodeID != nil {
+			fields, found := m.pullRequestByNodeID[id]
+			if !found {
+				nodes = append(nodes, nil)
+				continue
+			}
+			node := maps.Clone(fields)
+			node["id"] = id
+			nodes = append(nodes, node)
+			continue
+		}
+		node := map[string]any{"id": id, "reviewDecision": m.reviewDecisionByNodeID[id]}
+		if stats, ok := m.statsByNodeID[id]; ok {
+			node["changedFiles"] = stats.ChangedFiles
+			node["additions"] = stats.Additions
+			node["deletions"] = stats.Deletions
+			node["comments"] = map[string]int{"totalCount": stats.Comments}
+		}
+		if timeline, ok := m.timelineByNodeID[id]; ok {
+			node["timelineItems"] = map[string]any{"nodes": timeline}
+		}
+		nodes = append(nodes, node)
+	}
+	data, err := json.Marshal(map[string]any{"nodes": nodes})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func TestAddReviewEffortInfo(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedStats map[int]*models.PullRequestStats
+	}{
+		{
+			name: "stats of the PRs",
+			expectedStats: map[int]*models.PullRequestStats{
+				1: {ChangedFiles: 3, Additions: 120, Deletions: 40, Comments: 2},
+				2: {ChangedFiles: 1, Additions: 5},
+			},
+		},
+		{
+			name:          "stats are left unset if the query fails",
+			err:           fmt.Errorf("Resource not accessible by integration"),
+			expectedStats: map[int]*models.PullRequestStats{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graphQLService := &mockGraphQLService{
+				statsByNodeID: map[string]models.PullRequestStats{
+					"PR_1": {ChangedFiles: 3, Additions: 120, Deletions: 40, Comments: 2},
+					"PR_2": {ChangedFiles: 1, Additions: 5},
+				},
+				err: tt.err,
+			}
+			client := githubclient.NewClient(githubclient.Services{GraphQL: graphQLService})
+			prs := []githubclient.PR{
+				{PullRequest: models.PullRequest{Number: 1, NodeID: "PR_1"}},
+				{PullRequest: models.PullRequest{Number: 2, NodeID: "PR_2"}},
+				// PRs without a node ID are not queried
+				{PullRequest: models.PullRequest{Number: 3}},
+			}
+
+			prs = client.AddReviewEffortInfo(context.Background(), prs)
+
+			for _, pr := range prs {
+				if !reflect.DeepEqual(pr.Stats, tt.expectedStats[pr.Number]) {
+					t.Errorf("Expected stats %+v of PR %d, got %+v", tt.expectedStats[pr.Number], pr.Number, pr.Stats)
+				}
+			}
+		})
+	}
+}
+
+func TestAddReviewResponseInfo(t *testing.T) {
+	requestedAt := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	reviewedAt := requestedAt.Add(5 * time.Hour)
+	reviewRequest := func(at time.Time) map[string]any {
+		return map[string]any{"__typename": "ReviewRequestedEvent", "createdAt": at}
+	}
+	review := func(authorType, login string, submittedAt *time.Time) map[string]any {
+		return map[string]any{
+			"__typename":  "PullRequestReview",
+			"submittedAt": submittedAt,
+			"author":      map[string]string{"__typename": authorType, "login": login},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		timeline         []map[string]any
+		expectedResponse *models.PullRequestReviewResponse
+	}{
+		{
+			name:     "no review requested",
+			timeline: []map[string]any{review("User", "bob", &reviewedAt)},
+		},
+		{
+			name:             "review requested without a response",
+			timeline:         []map[string]any{reviewRequest(requestedAt)},
+			expectedResponse: &models.PullRequestReviewResponse{RequestedAt: requestedAt},
+		},
+		{
+			name: "first review after the first request",
+			timeline: []map[string]any{
+				reviewRequest(requestedAt),
+				reviewRequest(requestedAt.Add(time.Hour)),
+				review("User", "bob", &reviewedAt),
+				review("User", "carol", github.Ptr(reviewedAt.Add(time.Hour))),
+			},
+			expectedResponse: &models.PullRequestReviewResponse{RequestedAt: requestedAt, RespondedAt: &reviewedAt},
+		},
+		{
+			name: "reviews by the author and bots, pending reviews and earlier reviews are not responses",
+			timeline: []map[string]any{
+				review("User", "bob", github.Ptr(requestedAt.Add(-time.Hour))),
+				reviewRequest(requestedAt),
+				review("User", "alice", github.Ptr(requestedAt.Add(time.Hour))),
+				review("Bot", "copilot-pull-request-reviewer", github.Ptr(requestedAt.Add(time.Hour))),
+				review("User", "carol", nil),
+			},
+			expectedResponse: &models.PullRequestReviewResponse{RequestedAt: requestedAt},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graphQLService := &mockGraphQLService{timelineByNodeID: map[string][]map[string]any{"PR_1": tt.timeline}}
+			client := githubclient.NewClient(githubclient.Services{GraphQL: graphQLService})
+			prs := []githubclient.PR{{
+				PullRequest: models.PullRequest{Number: 1, NodeID: "PR_1"},
+				Author:      githubclient.Collaborator{Login: "alice"},
+			}}
+
+			prs = client.AddReviewResponseInfo(context.Background(), prs)
+
+			if !reflect.DeepEqual(prs[0].ReviewResponse, tt.expectedResponse) {
+				t.Errorf("Expected review response %+v, got %+v", tt.expectedResponse, prs[0].ReviewResponse)
+			}
+		})
+	}
+}
+
+func TestAddReviewDecisionInfo(t *testing.T) {
+	getPRs := func(count int) []githubclient.PR {
+		var prs []githubclient.PR
+		for i := 1; i <= count; i++ {
+			prs = append(prs, githubclient.PR{
+				PullRequest: models.PullRequest{Number: i, NodeID: fmt.Sprintf("PR_%d", i)},
+			})
+		}
+		return prs
+	}
+
+	tests := []struct {
+		name               string
+		prCount            int
+		err                error
+		expectedDecisions  map[int]string
+		expectedBatchSizes []int
+	}{
+		{
+			name:    "review decisions of the PRs",
+			prCount: 3,
+			expectedDecisions: map[int]string{
+				1: githubclient.ReviewDecisionApproved, 2: githubclient.ReviewDecisionChangesRequested,
+			},
+			expectedBatchSizes: []int{3},
+		},
+		{
+			name:    "PRs are queried in batches of 100",
+			prCount: 150,
+			expectedDecisions: map[int]string{
+				1: githubclient.ReviewDecisionApproved, 2: githubclient.ReviewDecisionChangesRequested,
+				150: githubclient.ReviewDecisionReviewRequired,
+			},
+			expectedBatchSizes: []int{100, 50},
+		},
+		{
+			name:              "decisions are left empty if the query fails",
+			prCount:           3,
+			err:               fmt.Errorf("Resource not accessible by integration"),
+			expectedDecisions: map[int]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graphQLService := &mockGraphQLService{
+				reviewDecisionByNodeID: map[string]string{
+					"PR_1":   githubclient.ReviewDecisionApproved,
+					"PR_2":   githubclient.ReviewDecisionChangesRequested,
+					"PR_150": githubclient.ReviewDecisionReviewRequired,
+				},
+				err: tt.err,
+			}
+			client := githubclient.NewClient(githubclient.Services{GraphQL: graphQLService})
+
+			prs := client.AddReviewDecisionInfo(context.Background(), getPRs(tt.prCount))
+
+			for _, pr := range prs {
+				if pr.ReviewDecision != tt.expectedDecisions[pr.Number] {
+					t.Errorf(
+						"Expected review decision %q of PR %d, got %q",
+						tt.expectedDecisions[pr.Number], pr.Number, pr.ReviewDecision,
+					)
+				}
+			}
+			if !slices.Equal(graphQLService.batchSizes, tt.expectedBatchSizes) {
+				t.Errorf("Expected batches of %v PRs, got %v", tt.expectedBatchSizes, graphQLService.batchSizes)
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package githubclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v78/github"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+)
+
+const PullRequestNodesFetchTimeout = 10 * time.Second
+
+// The maximum number of node IDs that can be queried at once with the GraphQL API
+const pullRequestNodesBatchSize = 100
+
+// The fields of the PRs (e.g. "reviewDecision") are added to the query.
+const pullRequestNodesQuery = `query($ids: [ID!]!) {
+  nodes(ids: $ids) {
+    ... on PullRequest {
+      id
+      %s
+    }
+  }
+}`
+
+// pullRequestNode has the fields of PRs queried with the GraphQL API: the fields that are only
+// available in the GraphQL API, and the fields of the PRs looked up by their node IDs (see GetPRs).
+type pullRequestNode struct {
+	ID        string        `json:"id"`
+	Number    int           `json:"number"`
+	Title     string        `json:"title"`
+	URL       string        `json:"url"`
+	State     string        `json:"state"` // "OPEN", "CLOSED" or "MERGED"
+	Merged    bool          `json:"merged"`
+	IsDraft   bool          `json:"isDraft"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+	Author    *graphQLActor `json:"author"` // nil if the account has been deleted
+	Labels    struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Milestone *struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+	HeadRefName    string `json:"headRefName"`
+	HeadRefOid     string `json:"headRefOid"`
+	BaseRepository *struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"baseRepository"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer *graphQLActor `json:"requestedReviewer"` // nil if not visible to the token
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	ReviewDecision string `json:"reviewDecision"`
+	IsInMergeQueue bool   `json:"isInMergeQueue"`
+	ChangedFiles   int    `json:"changedFiles"`
+	Additions      int    `json:"additions"`
+	Deletions      int    `json:"deletions"`
+	Comments       struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"comments"`
+	TimelineItems struct {
+		Nodes []timelineItem `json:"nodes"`
+	} `json:"timelineItems"`
+}
+
+// timelineItem is a review request (ReviewRequestedEvent) or a review (PullRequestReview) of a PR.
+type timelineItem struct {
+	Typename    string     `json:"__typename"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	SubmittedAt *time.Time `json:"submittedAt"` // nil if the review is pending
+	Author      *struct {
+		Typename string `json:"__typename"` // e.g. "User" or "Bot"
+		Login    string `json:"login"`
+	} `json:"author"` // nil if the account has been deleted
+}
+
+// graphQLActor is the author or a requested reviewer of a PR: a user, a bot or a team.
+type graphQLActor struct {
+	Typename string `json:"__typename"` // e.g. "User", "Bot" or "Team"
+	Login    string `json:"login"`      // not set for teams
+	Name     string `json:"name"`       // of users and teams
+	Slug     string `json:"slug"`       // of teams
+}
+
+// Returns the actor as a user of the REST API, so that it is treated the same as in the fetched PRs
+// (e.g. classified as a bot by the bot accounts).
+func (a *graphQLActor) asUser() *github.User {
+	if a == nil {
+		return nil
+	}
+	return &github.User{Login: github.Ptr(a.Login), Name: github.Ptr(a.Name), Type: github.Ptr(a.Typename)}
+}
+
+// The fields of the PRs looked up by their node IDs, i.e. the fields of the PRs fetched with the REST API
+// that are used by the action.
+const pullRequestFields = "number title url state merged isDraft createdAt updatedAt " +
+	"author { __typename login ... on User { name } } labels(first: 100) { nodes { name } } " +
+	"milestone { title } headRefName headRefOid baseRepository { nameWithOwner } " +
+	"reviewRequests(first: 100) { nodes { requestedReviewer { __typename " +
+	"... on User { login name } ... on Bot { login } ... on Mannequin { login } ... on Team { slug name } } } }"
+
+// Returns the PR looked up from the (saved) repository without its reviewer info, as newPRResult does
+// for the PRs fetched with the REST API.
+func (node pullRequestNode) asPRResult(repository models.Repository, botAccounts BotAccounts) PRResult {
+	state := "closed"
+	if node.State == "OPEN" {
+		state = "open"
+	}
+	var labels []string
+	for _, label := range node.Labels.Nodes {
+		labels = append(labels, label.Name)
+	}
+	var milestone, currentPath string
+	if node.Milestone != nil {
+		milestone = node.Milestone.Title
+	}
+	if node.BaseRepository != nil {
+		currentPath = node.BaseRepository.NameWithOwner
+	}
+	var requestedReviewers []models.User
+	var requestedTeams []models.Team
+	for _, request := range node.ReviewRequests.Nodes {
+		switch reviewer := request.RequestedReviewer; {
+		case reviewer == nil:
+			continue
+		case reviewer.Typename == "Team":
+			requestedTeams = append(requestedTeams, models.Team{Slug: reviewer.Slug, Name: reviewer.Name})
+		default:
+			requestedReviewers = append(requestedReviewers, botAccounts.newUser(reviewer.asUser()))
+		}
+	}
+	return PRResult{
+		pr: PR{
+			PullRequest: models.PullRequest{
+				Repository:         repository,
+				Number:             node.Number,
+				NodeID:             node.ID,
+				Title:              node.Title,
+				HTMLURL:            node.URL,
+				State:              state,
+				Merged:             node.Merged,
+				CreatedAt:          node.CreatedAt,
+				UpdatedAt:          node.UpdatedAt,
+				Labels:             labels,
+				Milestone:          milestone,
+				HeadBranch:         node.HeadRefName,
+				HeadSHA:            node.HeadRefOid,
+				RequestedReviewers: requestedReviewers,
+				RequestedTeams:     requestedTeams,
+				MovedToRepository:  getMovedToRepository(currentPath, repository),
+			},
+			Author: newCollaboratorFromUser(node.Author.asUser()),
+		},
+		isDraft: node.IsDraft,
+	}
+}
+
+// The timeline is in chronological order, so the first items contain the first review request and
+// (typically) the first review after it.
+const reviewResponseFields = "timelineItems(itemTypes: [REVIEW_REQUESTED_EVENT, PULL_REQUEST_REVIEW], first: 50) { " +
+	"nodes { __typename ... on ReviewRequestedEvent { createdAt } " +
+	"... on PullRequestReview { submittedAt author { __typename login } } } }"
+
+// Review decisions of PRs (reviewDecision of the GraphQL API). The decision is empty if the
+// repository does not require reviews.
+const (
+	ReviewDecisionApproved         = "APPROVED"
+	ReviewDecisionChangesRequested = "CHANGES_REQUESTED"
+	ReviewDecisionReviewRequired   = "REVIEW_REQUIRED"
+)
+
+// graphQLService sends GraphQL queries with the (authenticated) client of the REST API.
+type graphQLService struct {
+	client *github.Client
+}
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (s *graphQLService) Query(ctx context.Context, query string, variables map[string]any, result any) error {
+	request, err := s.client.NewRequest(http.MethodPost, "graphql", graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+	var response graphQLResponse
+	if _, err := s.client.Do(ctx, request, &response); err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 {
+		var errs []error
+		for _, e := range response.Errors {
+			errs = append(errs, errors.New(e.Message))
+		}
+		return errors.Join(errs...)
+	}
+	return json.Unmarshal(response.Data, result)
+}
+
+// Setting the review decisions is best effort: the decisions of PRs that cannot be fetched are left
+// empty (as if reviews were not required), so the message falls back to the approvers of the PRs.
+func (c *client) AddReviewDecisionInfo(ctx context.Context, prs []PR) []PR {
+	return c.addPullRequestNodeInfo(ctx, prs, "reviewDecision", func(pr *PR, node pullRequestNode) {
+		pr.ReviewDecision = node.ReviewDecision
+	})
+}
+
+// PRs of which the merge queue state cannot be fetched are considered not queued.
+func (c *client) AddMergeQueueInfo(ctx context.Context, prs []PR) []PR {
+	return c.addPullRequestNodeInfo(ctx, prs, "isInMergeQueue", func(pr *PR, node pullRequestNode) {
+		pr.IsInMergeQueue = node.IsInMergeQueue
+	})
+}
+
+// The stats of PRs that cannot be fetched are left unset, so no review effort is shown for them.
+func (c *client) AddReviewEffortInfo(ctx context.Context, prs []PR) []PR {
+	fields := "changedFiles additions deletions comments { totalCount }"
+	return c.addPullRequestNodeInfo(ctx, prs, fields, func(pr *PR, node pullRequestNode) {
+		pr.Stats = &models.PullRequestStats{
+			ChangedFiles: node.ChangedFiles,
+			Additions:    node.Additions,
+			Deletions:    node.Deletions,
+			Comments:     node.Comments.TotalCount,
+		}
+	})
+}
+
+// The review responses of PRs that cannot be fetched are left unset, so no response time is shown for them.
+func (c *client) AddReviewResponseInfo(ctx context.Context, prs []PR) []PR {
+	return c.addPullRequestNodeInfo(ctx, prs, reviewResponseFields, func(pr *PR, node pullRequestNode) {
+		pr.ReviewResponse = getReviewResponse(node.TimelineItems.Nodes, pr.Author.Login)
+	})
+}
+
+// Returns the first review request and the first review submitted after it by another user than the
+// author (reviews by bots are ignored), nil if no review has been requested.
+func getReviewResponse(items []timelineItem, authorLogin string) *models.PullRequestReviewResponse {
+	var response *models.PullRequestReviewResponse
+	for _, item := range items {
+		if item.Typename == "ReviewRequestedEvent" && (response == nil || item.CreatedAt.Before(response.RequestedAt)) {
+			response = &models.PullRequestReviewResponse{RequestedAt: item.CreatedAt}
+		}
+	}
+	if response == nil {
+		return nil
+	}
+	for _, item := range items {
+		if item.Typename != "PullRequestReview" || item.SubmittedAt == nil || item.SubmittedAt.Before(response.RequestedAt) {
+			continue
+		}
+		if item.Author != nil && (item.Author.Typename == "Bot" || item.Author.Login == authorLogin) {
+			continue
+		}
+		if response.RespondedAt == nil || item.SubmittedAt.Before(*response.RespondedAt) {
+			response.RespondedAt = item.SubmittedAt
+		}
+	}
+	return response
+}
+
+// Fetches the fields of the PRs with the GraphQL API (in batches) and sets them with setFields.
+// The PRs are fetched with the tokens of their repositories, so the batches are formed per token.
+// Errors are only logged, as the fields are shown in the message for information only.
+func (c *client) addPullRequestNodeInfo(
+	ctx context.Context, prs []PR, fields string, setFields func(pr *PR, node pullRequestNode),
+) []PR {
+	indexByNodeID := map[string]int{}
+	var tokens []string // in the order of the first PRs of the tokens, empty for the main token
+	nodeIDsByToken := map[string][]string{}
+	for i, pr := range prs {
+		if pr.NodeID == "" {
+			continue
+		}
+		indexByNodeID[pr.NodeID] = i
+		token := c.tokenByRepository[pr.Repository]
+		if _, exists := nodeIDsByToken[token]; !exists {
+			tokens = append(tokens, token)
+		}
+		nodeIDsByToken[token] = append(nodeIDsByToken[token], pr.NodeID)
+	}
+
+	skippedPRCount := 0
+	for _, token := range tokens {
+		nodeIDs := nodeIDsByToken[token]
+		for start := 0; start < len(nodeIDs); start += pullRequestNodesBatchSize {
+			batch := nodeIDs[start:min(start+pullRequestNodesBatchSize, len(nodeIDs))]
+			if !c.apiCalls.reserve(1) {
+				skippedPRCount += len(nodeIDs) - start
+				break
+			}
+			nodes, err := fetchPullRequestNodes(ctx, c.forToken(token).graphQLService, batch, fields)
+			if err != nil {
+				log.Printf("Unable to fetch %s of %d PRs: %v", fields, len(batch), err)
+				continue
+			}
+			for _, node := range nodes {
+				if i, ok := indexByNodeID[node.ID]; ok {
+					setFields(&prs[i], node)
+				}
+			}
+		}
+	}
+	c.apiCalls.reportSkipped(ctx, fields, skippedPRCount)
+	return prs
+}
+
+// Returns the PRs (with the fields) by their node IDs. Nodes that are not found are left out.
+func fetchPullRequestNodes(
+	ctx context.Context, graphQLService GithubGraphQLService, nodeIDs []string, fields string,
+) ([]pullRequestNode, error) {
+	callCtx, cancel := context.WithTimeout(ctx, PullRequestNodesFetchTimeout)
+	defer cancel()
+	var result struct {
+		Nodes []*pullRequestNode `json:"nodes"`
+	}
+	query := fmt.Sprintf(pullRequestNodesQuery, fields)
+	if err := graphQLService.Query(callCtx, query, map[string]any{"ids": nodeIDs}, &result); err != nil {
+		return nil, err
+	}
+	var nodes []pullRequestNode
+	for _, node := range result.Nodes {
+		if node != nil && node.ID != "" {
+			nodes = append(nodes, *node)
+		}
+	}
+	return nodes, nil
+}
@@ -0,0 +1,79 @@
+package githubclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v78/github"
+)
+
+func TestGraphQLServiceQuery(t *testing.T) {
+	tests := []struct {
+		name             string
+		responseBody     string
+		responseStatus   int
+		expectedDecision string
+		expectedErrMsg   string
+	}{
+		{
+			name:             "data of the response",
+			responseBody:     `{"data": {"nodes": [{"id": "PR_1", "reviewDecision": "APPROVED"}]}}`,
+			responseStatus:   http.StatusOK,
+			expectedDecision: "APPROVED",
+		},
+		{
+			name:           "errors of the response",
+			responseBody:   `{"data": null, "errors": [{"message": "Could not resolve to a node with the global id of 'PR_1'"}]}`,
+			responseStatus: http.StatusOK,
+			expectedErrMsg: "Could not resolve to a node",
+		},
+		{
+			name:           "failed request",
+			responseBody:   `{"message": "Bad credentials"}`,
+			responseStatus: http.StatusUnauthorized,
+			expectedErrMsg: "401 Bad credentials",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request graphQLRequest
+				if err := json.NewDecoder(r.Body).Decode(&request); err != nil || r.URL.Path != "/graphql" {
+					t.Errorf("Unexpected request to %s: %v", r.URL.Path, err)
+				}
+				if !strings.Contains(request.Query, "reviewDecision") {
+					t.Errorf("Expected the query to contain reviewDecision, got: %s", request.Query)
+				}
+				w.WriteHeader(tt.responseStatus)
+				w.Write([]byte(tt.responseBody))
+			}))
+			defer server.Close()
+			ghClient := github.NewClient(nil)
+			ghClient.BaseURL, _ = url.Parse(server.URL + "/")
+			service := &graphQLService{client: ghClient}
+
+			nodes, err := fetchPullRequestNodes(
+				context.Background(), service, []string{"PR_1"}, "reviewDecision",
+			)
+
+			if tt.expectedErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg) {
+					t.Fatalf("Expected error containing '%s', got: %v", tt.expectedErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(nodes) != 1 || nodes[0].ReviewDecision != tt.expectedDecision {
+				t.Errorf("Expected review decision %q of PR_1, got %+v", tt.expectedDecision, nodes)
+			}
+		})
+	}
+}
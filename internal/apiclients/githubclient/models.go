@@ -4,39 +4,94 @@ import (
 	"cmp"
 	"log"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/go-github/v78/github"
 	"github.com/hellej/pr-slack-reminder-action/internal/models"
 	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
 )
 
+// PR is a fetched PR with its reviewer info. It is built from the GitHub SDK types by the client, so
+// that the rest of the action only depends on the models.
 type PR struct {
-	*github.PullRequest
-	Repository       models.Repository
+	models.PullRequest
 	Author           Collaborator
 	ApprovedByUsers  []Collaborator
-	CommentedByUsers []Collaborator // reviewers who commented the PR but did not approve it
+	CommentedByUsers []Collaborator             // reviewers who commented the PR but did not approve it
+	Reviews          []models.PullRequestReview // reviews by users (not bots), including the author's own
+	// Review and issue comments by users (not bots), including the author's own (up to 100 of each kind)
+	CommentCount int
 }
 
+// FailingWorkflow is a workflow of which the latest run on the default branch failed.
+type FailingWorkflow struct {
+	Repository models.Repository
+	Name       string
+	HTMLURL    string // of the failed run
+	Event      string // event that triggered the run, e.g. "schedule" or "push"
+}
+
+// MergedPR is a PR merged recently (listed after the open PRs with show-recently-merged-hours).
+type MergedPR struct {
+	Repository models.Repository
+	Title      string
+	HTMLURL    string
+	MergedAt   time.Time
+}
+
+// Milestone is a milestone used in the filters of a repository.
+type Milestone struct {
+	Repository   models.Repository
+	Title        string
+	OpenIssues   int // includes PRs
+	ClosedIssues int // includes PRs
+}
+
+// PRResult is a fetched PR before its reviewer info is fetched.
 type PRResult struct {
-	pr         *github.PullRequest
-	repository models.Repository
+	pr      PR
+	isDraft bool
 }
 
 type FetchReviewsResult struct {
-	pr               *github.PullRequest
+	pr               PR
 	reviews          []*github.PullRequestReview
 	comments         []*github.PullRequestComment
 	timelineComments []*github.IssueComment
-	repository       models.Repository
 	err              error
 }
 
 func (r FetchReviewsResult) printResult() {
-	if r.err != nil {
-		log.Printf("Unable to fetch reviews/comments for PR #%d: %v", r.pr.GetNumber(), r.err)
-	} else {
-		log.Printf("Found %d reviews, %d PR comments, and %d timeline comments for PR %v/%d", len(r.reviews), len(r.comments), len(r.timelineComments), r.repository, r.pr.GetNumber())
+	log.Printf("Found %d reviews, %d PR comments, and %d timeline comments for PR %v/%d", len(r.reviews), len(r.comments), len(r.timelineComments), r.pr.Repository, r.pr.Number)
+}
+
+// Returns the PR fetched from the (configured or saved) repository without its reviewer info.
+// The requested reviewers are classified as bots by the bot accounts.
+func newPRResult(pr *github.PullRequest, repository models.Repository, botAccounts BotAccounts) PRResult {
+	return PRResult{
+		pr: PR{
+			PullRequest: models.PullRequest{
+				Repository:         repository,
+				Number:             pr.GetNumber(),
+				NodeID:             pr.GetNodeID(),
+				Title:              pr.GetTitle(),
+				HTMLURL:            pr.GetHTMLURL(),
+				State:              pr.GetState(),
+				Merged:             pr.GetMerged(),
+				CreatedAt:          pr.GetCreatedAt().Time,
+				UpdatedAt:          pr.GetUpdatedAt().Time,
+				Labels:             utilities.Map(pr.Labels, (*github.Label).GetName),
+				Milestone:          pr.GetMilestone().GetTitle(),
+				HeadBranch:         pr.GetHead().GetRef(),
+				HeadSHA:            pr.GetHead().GetSHA(),
+				RequestedReviewers: utilities.Map(pr.RequestedReviewers, botAccounts.newUser),
+				RequestedTeams:     utilities.Map(pr.RequestedTeams, newTeam),
+				MovedToRepository:  getMovedToRepository(pr.GetBase().GetRepo().GetFullName(), repository),
+			},
+			Author: newCollaboratorFromUser(pr.GetUser()),
+		},
+		isDraft: pr.GetDraft(),
 	}
 }
 
@@ -52,9 +107,42 @@ func newCollaboratorFromUser(user *github.User) Collaborator {
 	}
 }
 
-func isBot(user *github.User) bool {
-	userType := user.GetType()
-	return userType == "Bot"
+// BotAccounts refine which GitHub users are treated as bots, whose reviews and comments are ignored.
+// By default, the users of type Bot are treated as bots.
+type BotAccounts struct {
+	Bots   []string // logins of users treated as bots regardless of their type (e.g. automation accounts of type User)
+	Humans []string // logins of users of type Bot that are treated as humans
+}
+
+func (b BotAccounts) isBot(user *github.User) bool {
+	if slices.ContainsFunc(b.Bots, func(login string) bool { return strings.EqualFold(login, user.GetLogin()) }) {
+		return true
+	}
+	if slices.ContainsFunc(b.Humans, func(login string) bool { return strings.EqualFold(login, user.GetLogin()) }) {
+		return false
+	}
+	return user.GetType() == "Bot"
+}
+
+// Returns true if the user is explicitly configured as a bot (the PRs of such users are not listed).
+func (b BotAccounts) isBotAuthor(login string) bool {
+	return slices.ContainsFunc(b.Bots, func(bot string) bool { return strings.EqualFold(bot, login) })
+}
+
+func (b BotAccounts) newUser(user *github.User) models.User {
+	return models.User{Login: user.GetLogin(), Name: user.GetName(), IsBot: b.isBot(user)}
+}
+
+func newTeam(team *github.Team) models.Team {
+	return models.Team{Slug: team.GetSlug(), Name: team.GetName()}
+}
+
+func newReview(review *github.PullRequestReview) models.PullRequestReview {
+	return models.PullRequestReview{
+		AuthorLogin: review.GetUser().GetLogin(),
+		State:       review.GetState(),
+		SubmittedAt: review.GetSubmittedAt().Time,
+	}
 }
 
 type GitHubUserProvider interface {
@@ -66,15 +154,16 @@ func (c Collaborator) GetGitHubName() string {
 	return cmp.Or(c.Name, c.Login)
 }
 
-func (r FetchReviewsResult) asPR() PR {
-	authorLogin := r.pr.GetUser().GetLogin()
+func (r FetchReviewsResult) asPR(botAccounts BotAccounts, strictApprovals bool) PR {
+	authorLogin := r.pr.Author.Login
 
-	reviewsWithValidUser := utilities.Filter(r.reviews, hasValidUserData)
-	commentsWithValidUser := utilities.Filter(r.comments, hasValidUserData)
-	timelineCommentsWithValidUser := utilities.Filter(r.timelineComments, hasValidUserData)
+	reviewsWithValidUser := utilities.Filter(r.reviews, hasValidUserData[*github.PullRequestReview](botAccounts))
+	commentsWithValidUser := utilities.Filter(r.comments, hasValidUserData[*github.PullRequestComment](botAccounts))
+	timelineCommentsWithValidUser := utilities.Filter(
+		r.timelineComments, hasValidUserData[*github.IssueComment](botAccounts),
+	)
 
-	approvingReviews := utilities.Filter(reviewsWithValidUser, isApprovingReview)
-	approvedByUsers := extractUniqueCollaborators(approvingReviews)
+	approvedByUsers := getApprovedByUsers(reviewsWithValidUser, r.pr.HeadSHA, strictApprovals)
 
 	reviewCommenters := extractUniqueCollaborators(reviewsWithValidUser)
 	standaloneCommenters := extractUniqueCollaborators(commentsWithValidUser)
@@ -86,18 +175,34 @@ func (r FetchReviewsResult) asPR() PR {
 		getFilterForCommenters(authorLogin, approvedByUsers),
 	)
 
-	return PR{
-		PullRequest:      r.pr,
-		Repository:       r.repository,
-		Author:           newCollaboratorFromUser(r.pr.GetUser()),
-		ApprovedByUsers:  approvedByUsers,
-		CommentedByUsers: commentedByUsers,
+	pr := r.pr
+	pr.ApprovedByUsers = approvedByUsers
+	pr.CommentedByUsers = commentedByUsers
+	pr.Reviews = utilities.Map(reviewsWithValidUser, newReview)
+	pr.CommentCount = len(commentsWithValidUser) + len(timelineCommentsWithValidUser)
+	return pr
+}
+
+// Returns the current repository of the PR (by the full name of its base repository, empty if not
+// available) if it differs from the (configured or saved) repository that was used for fetching it,
+// i.e. if the repository has been renamed or transferred.
+func getMovedToRepository(currentPath string, repository models.Repository) *models.Repository {
+	if currentPath == "" || strings.EqualFold(currentPath, repository.GetPath()) {
+		return nil
 	}
+	movedTo, err := models.ParseRepository(currentPath)
+	if err != nil {
+		return nil
+	}
+	log.Printf("Repository %s has been renamed or transferred to %s", repository.GetPath(), movedTo.GetPath())
+	return &movedTo
 }
 
-func hasValidUserData[T GitHubUserProvider](item T) bool {
-	user := item.GetUser()
-	return user != nil && user.GetLogin() != "" && !isBot(user)
+func hasValidUserData[T GitHubUserProvider](botAccounts BotAccounts) func(item T) bool {
+	return func(item T) bool {
+		user := item.GetUser()
+		return user != nil && user.GetLogin() != "" && !botAccounts.isBot(user)
+	}
 }
 
 func extractUniqueCollaborators[T GitHubUserProvider](items []T) []Collaborator {
@@ -118,6 +223,37 @@ func isApprovingReview(review *github.PullRequestReview) bool {
 	return review.GetState() == "APPROVED"
 }
 
+// Returns true if the review approves the PR, requests changes to it or was dismissed, i.e. it
+// replaces the earlier approval (or request for changes) of the reviewer. Comments do not.
+func isDecisiveReview(review *github.PullRequestReview) bool {
+	switch review.GetState() {
+	case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+		return true
+	default:
+		return false
+	}
+}
+
+// Returns the users whose latest decisive review (in the chronological order of the reviews)
+// approves the PR, so that approvals are revoked by later requests for changes and by dismissals.
+// If strict, approvals of other commits than the head commit of the PR are ignored too (as with
+// the "dismiss stale pull request approvals" rule of branch protection).
+func getApprovedByUsers(reviews []*github.PullRequestReview, headSHA string, strict bool) []Collaborator {
+	latestReviewByLogin := map[string]*github.PullRequestReview{}
+	for _, review := range reviews {
+		if isDecisiveReview(review) {
+			latestReviewByLogin[review.GetUser().GetLogin()] = review
+		}
+	}
+	approvingReviews := utilities.Filter(reviews, func(review *github.PullRequestReview) bool {
+		if review != latestReviewByLogin[review.GetUser().GetLogin()] || !isApprovingReview(review) {
+			return false
+		}
+		return !strict || headSHA == "" || review.GetCommitID() == headSHA
+	})
+	return extractUniqueCollaborators(approvingReviews)
+}
+
 func getFilterForCommenters(authorLogin string, approvedByUsers []Collaborator) func(c Collaborator) bool {
 	return func(c Collaborator) bool {
 		return c.Login != authorLogin &&
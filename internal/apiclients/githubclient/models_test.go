@@ -1,7 +1,11 @@
 package githubclient
 
 import (
+	"slices"
 	"testing"
+
+	"github.com/google/go-github/v78/github"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
 )
 
 func TestCollaboratorGetGitHubName(t *testing.T) {
@@ -36,3 +40,109 @@ func TestCollaboratorGetGitHubName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMovedToRepository(t *testing.T) {
+	repository := models.NewRepository("old-org", "old-name")
+
+	tests := []struct {
+		name        string
+		currentPath string
+		expected    *models.Repository
+	}{
+		{name: "same repository", currentPath: "old-org/old-name"},
+		{name: "same repository with different casing", currentPath: "Old-Org/Old-Name"},
+		{name: "base repository not available", currentPath: ""},
+		{
+			name:        "renamed repository",
+			currentPath: "old-org/new-name",
+			expected:    &models.Repository{Owner: "old-org", Name: "new-name"},
+		},
+		{
+			name:        "transferred repository",
+			currentPath: "new-org/new-name",
+			expected:    &models.Repository{Owner: "new-org", Name: "new-name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getMovedToRepository(tt.currentPath, repository)
+			if (result == nil) != (tt.expected == nil) || (result != nil && *result != *tt.expected) {
+				t.Errorf("getMovedToRepository() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetApprovedByUsers(t *testing.T) {
+	review := func(login, state, commitID string) *github.PullRequestReview {
+		return &github.PullRequestReview{
+			User:     &github.User{Login: github.Ptr(login)},
+			State:    github.Ptr(state),
+			CommitID: github.Ptr(commitID),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		reviews        []*github.PullRequestReview
+		headSHA        string
+		strict         bool
+		expectedLogins []string
+	}{
+		{
+			name:           "approvals",
+			reviews:        []*github.PullRequestReview{review("alice", "APPROVED", "sha1"), review("bob", "APPROVED", "sha2")},
+			headSHA:        "sha2",
+			expectedLogins: []string{"alice", "bob"},
+		},
+		{
+			name: "later comments do not revoke approvals",
+			reviews: []*github.PullRequestReview{
+				review("alice", "APPROVED", "sha1"), review("alice", "COMMENTED", "sha1"),
+			},
+			expectedLogins: []string{"alice"},
+		},
+		{
+			name: "later requests for changes and dismissals revoke approvals",
+			reviews: []*github.PullRequestReview{
+				review("alice", "APPROVED", "sha1"), review("alice", "CHANGES_REQUESTED", "sha1"),
+				review("bob", "APPROVED", "sha1"), review("bob", "DISMISSED", "sha1"),
+			},
+		},
+		{
+			name: "approvals after requests for changes",
+			reviews: []*github.PullRequestReview{
+				review("alice", "CHANGES_REQUESTED", "sha1"), review("alice", "APPROVED", "sha2"),
+			},
+			expectedLogins: []string{"alice"},
+		},
+		{
+			name:           "stale approvals if strict",
+			reviews:        []*github.PullRequestReview{review("alice", "APPROVED", "sha1"), review("bob", "APPROVED", "sha2")},
+			headSHA:        "sha2",
+			strict:         true,
+			expectedLogins: []string{"bob"},
+		},
+		{
+			name:           "all approvals if strict but the head commit is unknown",
+			reviews:        []*github.PullRequestReview{review("alice", "APPROVED", "sha1"), review("bob", "APPROVED", "sha2")},
+			strict:         true,
+			expectedLogins: []string{"alice", "bob"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approvers := getApprovedByUsers(tt.reviews, tt.headSHA, tt.strict)
+
+			var logins []string
+			for _, approver := range approvers {
+				logins = append(logins, approver.Login)
+			}
+			if !slices.Equal(logins, tt.expectedLogins) {
+				t.Errorf("Expected approvers %v, got %v", tt.expectedLogins, logins)
+			}
+		})
+	}
+}
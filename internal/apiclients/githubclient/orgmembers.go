@@ -0,0 +1,72 @@
+package githubclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const OrganizationMembersFetchTimeout = 60 * time.Second
+
+// Limits the fetched members to 2000 per organization (100 per page)
+const organizationMembersMaximumPages = 20
+
+const organizationMembersQuery = `query($org: String!, $cursor: String) {
+  organization(login: $org) {
+    membersWithRole(first: 100, after: $cursor) {
+      nodes {
+        login
+        name
+        email
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+// OrganizationMember is a member of an organization with the public name and email of the profile
+// (empty if not set).
+type OrganizationMember struct {
+	Login string `json:"login"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// FindOrganizationMembers returns the members of the organization with the GraphQL API.
+// Returns an error if the owner is not an organization (e.g. a user account).
+func (c *client) FindOrganizationMembers(ctx context.Context, org string) ([]OrganizationMember, error) {
+	callCtx, cancel := context.WithTimeout(ctx, OrganizationMembersFetchTimeout)
+	defer cancel()
+	var members []OrganizationMember
+	var cursor *string
+	for pagesFetched := 1; ; pagesFetched++ {
+		var result struct {
+			Organization *struct {
+				MembersWithRole struct {
+					Nodes    []OrganizationMember `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"membersWithRole"`
+			} `json:"organization"`
+		}
+		variables := map[string]any{"org": org, "cursor": cursor}
+		c.apiCalls.count(1)
+		if err := c.graphQLService.Query(callCtx, organizationMembersQuery, variables, &result); err != nil {
+			return nil, fmt.Errorf("error fetching members of organization %s: %w", org, err)
+		}
+		if result.Organization == nil {
+			return nil, fmt.Errorf("organization %s not found", org)
+		}
+		page := result.Organization.MembersWithRole
+		members = append(members, page.Nodes...)
+		if !page.PageInfo.HasNextPage || pagesFetched >= organizationMembersMaximumPages {
+			return members, nil
+		}
+		cursor = &page.PageInfo.EndCursor
+	}
+}
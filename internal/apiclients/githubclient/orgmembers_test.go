@@ -0,0 +1,102 @@
+package githubclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
+)
+
+// Returns the pages of the members of the organizations, the cursor of a page is its index.
+type mockOrganizationMembersService struct {
+	memberPagesByOrg map[string][][]githubclient.OrganizationMember
+	err              error
+}
+
+func (m *mockOrganizationMembersService) Query(
+	ctx context.Context, query string, variables map[string]any, result any,
+) error {
+	if m.err != nil {
+		return m.err
+	}
+	var organization any
+	if pages, ok := m.memberPagesByOrg[variables["org"].(string)]; ok {
+		page := 0
+		if cursor, ok := variables["cursor"].(*string); ok && cursor != nil {
+			page, _ = strconv.Atoi(*cursor)
+		}
+		organization = map[string]any{
+			"membersWithRole": map[string]any{
+				"nodes": pages[page],
+				"pageInfo": map[string]any{
+					"hasNextPage": page < len(pages)-1,
+					"endCursor":   strconv.Itoa(page + 1),
+				},
+			},
+		}
+	}
+	data, err := json.Marshal(map[string]any{"organization": organization})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func TestFindOrganizationMembers(t *testing.T) {
+	alice := githubclient.OrganizationMember{Login: "alice", Name: "Alice A", Email: "alice@example.com"}
+	bob := githubclient.OrganizationMember{Login: "bob"}
+	carol := githubclient.OrganizationMember{Login: "carol", Name: "Carol C"}
+
+	tests := []struct {
+		name           string
+		org            string
+		err            error
+		expectedLogins []string
+		expectError    bool
+	}{
+		{
+			name:           "members of all pages",
+			org:            "org",
+			expectedLogins: []string{"alice", "bob", "carol"},
+		},
+		{
+			name:        "error if the owner is not an organization",
+			org:         "user",
+			expectError: true,
+		},
+		{
+			name:        "error if the query fails",
+			org:         "org",
+			err:         errors.New("Bad credentials"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graphQLService := &mockOrganizationMembersService{
+				memberPagesByOrg: map[string][][]githubclient.OrganizationMember{"org": {{alice, bob}, {carol}}},
+				err:              tt.err,
+			}
+			client := githubclient.NewClient(githubclient.Services{GraphQL: graphQLService})
+
+			members, err := client.FindOrganizationMembers(context.Background(), tt.org)
+
+			if tt.expectError != (err != nil) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectError, err)
+			}
+			logins := utilities.Map(members, func(m githubclient.OrganizationMember) string { return m.Login })
+			if !slices.Equal(logins, tt.expectedLogins) {
+				t.Errorf("Expected members %v, got %v", tt.expectedLogins, logins)
+			}
+			if len(members) > 0 && members[0] != alice {
+				t.Errorf("Expected the name and email of the members, got %+v", members[0])
+			}
+		})
+	}
+}
@@ -1,48 +1,132 @@
 package githubclient
 
 import (
+	"path"
 	"slices"
 	"strings"
+	"time"
 
-	"github.com/google/go-github/v78/github"
+	"github.com/hellej/pr-slack-reminder-action/internal/clock"
 	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
 )
 
-func includePR(pr *github.PullRequest, filters config.Filters) bool {
-	title := pr.GetTitle()
+// MatchesFilters returns true if the fetched PR passes the filters, e.g. to divide the PRs between
+// several messages. Excluding queued PRs requires the merge queue state of the PR.
+func (pr PR) MatchesFilters(filters config.Filters) bool {
+	if filters.IgnoreQueued && pr.IsInMergeQueue {
+		return false
+	}
+	return includePR(pr, filters)
+}
+
+func includePR(pr PR, filters config.Filters) bool {
+	title := pr.Title
 	for _, ignoredTerm := range filters.IgnoredTerms {
 		if strings.Contains(title, ignoredTerm) {
 			return false
 		}
 	}
 
+	if len(filters.BranchesIgnore) > 0 {
+		branch := pr.HeadBranch
+		if slices.ContainsFunc(filters.BranchesIgnore, func(pattern string) bool {
+			matched, _ := path.Match(pattern, branch)
+			return matched
+		}) {
+			return false
+		}
+	}
+
 	if len(filters.IgnoredLabels) > 0 {
-		if slices.ContainsFunc(pr.Labels, func(l *github.Label) bool {
-			return slices.Contains(filters.IgnoredLabels, l.GetName())
+		if slices.ContainsFunc(pr.Labels, func(label string) bool {
+			return slices.Contains(filters.IgnoredLabels, label)
 		}) {
 			return false
 		}
 	}
 
 	if len(filters.IgnoredAuthors) > 0 {
-		if slices.Contains(filters.IgnoredAuthors, pr.GetUser().GetLogin()) {
+		if slices.Contains(filters.IgnoredAuthors, pr.Author.Login) {
 			return false
 		}
 	}
 
 	if len(filters.Labels) > 0 {
-		if !slices.ContainsFunc(pr.Labels, func(l *github.Label) bool {
-			return slices.Contains(filters.Labels, l.GetName())
+		if !slices.ContainsFunc(pr.Labels, func(label string) bool {
+			return slices.Contains(filters.Labels, label)
 		}) {
 			return false
 		}
 	}
 
 	if len(filters.Authors) > 0 {
-		if !slices.Contains(filters.Authors, pr.GetUser().GetLogin()) {
+		if !slices.Contains(filters.Authors, pr.Author.Login) {
+			return false
+		}
+	}
+
+	if len(filters.Milestones) > 0 {
+		if !slices.Contains(filters.Milestones, pr.Milestone) {
+			return false
+		}
+	}
+
+	if len(filters.RequestedTeams) > 0 && !isReviewRequestedFromTeams(pr, filters.RequestedTeams) {
+		return false
+	}
+
+	if filters.TeamMembers != nil && !isTeamPR(pr, *filters.TeamMembers) {
+		return false
+	}
+
+	if filters.MinAgeHours > 0 && !pr.CreatedAt.IsZero() {
+		if pr.CreatedAt.After(clock.Now().Add(-time.Duration(filters.MinAgeHours) * time.Hour)) {
 			return false
 		}
 	}
 
 	return true
 }
+
+// Returns true if a review is requested from any of the teams (given as "slug" or "org/slug"). GitHub
+// removes a team from the requested reviewers once a member of the team has reviewed the PR.
+func isReviewRequestedFromTeams(pr PR, teams []string) bool {
+	return slices.ContainsFunc(pr.RequestedTeams, func(t models.Team) bool {
+		return slices.ContainsFunc(teams, func(team string) bool {
+			slug := team
+			if _, s, found := strings.Cut(team, "/"); found {
+				slug = s
+			}
+			return strings.EqualFold(slug, t.Slug)
+		})
+	})
+}
+
+// Returns true if the PR is authored by the team members and/or requests a review from them
+// or from their teams, depending on the match of the team members.
+func isTeamPR(pr PR, teamMembers config.TeamMembers) bool {
+	isTeamMember := func(login string) bool {
+		return slices.ContainsFunc(teamMembers.Logins, func(member string) bool {
+			return strings.EqualFold(member, login)
+		})
+	}
+	isAuthor := isTeamMember(pr.Author.Login)
+	isReviewer := slices.ContainsFunc(pr.RequestedReviewers, func(u models.User) bool {
+		return isTeamMember(u.Login)
+	}) || slices.ContainsFunc(pr.RequestedTeams, func(t models.Team) bool {
+		return slices.ContainsFunc(teamMembers.Teams, func(team string) bool {
+			_, slug, _ := strings.Cut(team, "/")
+			return strings.EqualFold(slug, t.Slug)
+		})
+	})
+
+	switch teamMembers.Match {
+	case config.TeamMembersMatchAuthor:
+		return isAuthor
+	case config.TeamMembersMatchReviewer:
+		return isReviewer
+	default:
+		return isAuthor || isReviewer
+	}
+}
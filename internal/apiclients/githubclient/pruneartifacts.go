@@ -0,0 +1,40 @@
+package githubclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// DeleteOldArtifactsByName deletes the artifacts with the name of workflow runs on the branch
+// (see FetchLatestArtifactByName) except for the keep most recent ones. Returns the number of
+// deleted artifacts, and the errors of the failed deletions (the others are deleted anyway).
+func (client *client) DeleteOldArtifactsByName(
+	ctx context.Context,
+	owner, repo, artifactName, branch string,
+	keep int,
+) (int, error) {
+	artifacts, err := client.listArtifactsByName(ctx, owner, repo, artifactName, branch)
+	if errors.Is(err, ErrArtifactNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(artifacts) <= keep {
+		return 0, nil
+	}
+
+	deleted := 0
+	var errs []error
+	for _, artifact := range artifacts[keep:] {
+		if _, err := client.actionsService.DeleteArtifact(ctx, owner, repo, artifact.GetID()); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete artifact %d: %w", artifact.GetID(), err))
+			continue
+		}
+		deleted++
+	}
+	log.Printf("Deleted %d old artifacts with name %q (keeping the latest %d)", deleted, artifactName, keep)
+	return deleted, errors.Join(errs...)
+}
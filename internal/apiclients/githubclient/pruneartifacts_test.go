@@ -0,0 +1,90 @@
+package githubclient_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v78/github"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+)
+
+func TestDeleteOldArtifactsByName(t *testing.T) {
+	artifactOnBranch := func(id int64, branch string, age time.Duration) *github.Artifact {
+		return &github.Artifact{
+			ID:          github.Ptr(id),
+			Name:        github.Ptr("test-artifact"),
+			CreatedAt:   &github.Timestamp{Time: time.Now().Add(-age)},
+			WorkflowRun: &github.ArtifactWorkflowRun{HeadBranch: github.Ptr(branch)},
+		}
+	}
+	artifacts := []*github.Artifact{
+		artifactOnBranch(1, "main", 4*time.Hour),
+		artifactOnBranch(2, "main", time.Hour),
+		artifactOnBranch(3, "feature", 2*time.Hour),
+		artifactOnBranch(4, "main", 3*time.Hour),
+	}
+
+	tests := []struct {
+		name          string
+		artifacts     []*github.Artifact
+		keep          int
+		deleteErrors  map[int64]error
+		expectedIDs   []int64
+		expectedCount int
+		expectError   bool
+	}{
+		{
+			name:          "older artifacts of the branch are deleted",
+			artifacts:     artifacts,
+			keep:          1,
+			expectedIDs:   []int64{4, 1},
+			expectedCount: 2,
+		},
+		{
+			name:      "nothing is deleted if there are no more artifacts than are kept",
+			artifacts: artifacts,
+			keep:      3,
+		},
+		{
+			name:      "nothing is deleted if there are no artifacts",
+			artifacts: []*github.Artifact{},
+			keep:      1,
+		},
+		{
+			name:          "other artifacts are deleted if deleting one fails",
+			artifacts:     artifacts,
+			keep:          1,
+			deleteErrors:  map[int64]error{4: fmt.Errorf("forbidden")},
+			expectedIDs:   []int64{1},
+			expectedCount: 1,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockActions := &mockActionsServiceWithArtifacts{
+				artifacts:       slices.Clone(tt.artifacts),
+				deleteErrorByID: tt.deleteErrors,
+			}
+			client := githubclient.NewClient(githubclient.Services{Actions: mockActions})
+
+			count, err := client.DeleteOldArtifactsByName(
+				context.Background(), "test-owner", "test-repo", "test-artifact", "main", tt.keep,
+			)
+
+			if tt.expectError != (err != nil) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectError, err)
+			}
+			if count != tt.expectedCount {
+				t.Errorf("Expected %d deleted artifacts, got %d", tt.expectedCount, count)
+			}
+			if !slices.Equal(mockActions.deletedArtifactIDs, tt.expectedIDs) {
+				t.Errorf("Expected artifacts %v to be deleted, got %v", tt.expectedIDs, mockActions.deletedArtifactIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package githubclient
+
+import (
+	"github.com/google/go-github/v78/github"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+)
+
+// repositoryServices are the services of the API calls concerning a single repository. They are
+// authenticated with the token of the repository if one is given (repository-tokens), so that the
+// repositories of organizations requiring their own fine-grained tokens can be fetched in one run.
+// The artifacts and the members of the organizations and teams are always fetched with the main
+// token (or the token for state).
+type repositoryServices struct {
+	prService           GithubPullRequestsService
+	issueService        GithubIssuesService
+	repoService         GithubRepositoriesService
+	workflowRunsService GithubWorkflowRunsService
+	checksService       GithubChecksService
+	graphQLService      GithubGraphQLService
+}
+
+func newRepositoryServices(token string) repositoryServices {
+	return repositoryServicesOf(github.NewClient(nil).WithAuthToken(token))
+}
+
+func repositoryServicesOf(ghClient *github.Client) repositoryServices {
+	return repositoryServices{
+		prService:           ghClient.PullRequests,
+		issueService:        ghClient.Issues,
+		repoService:         ghClient.Repositories,
+		workflowRunsService: ghClient.Actions,
+		checksService:       ghClient.Checks,
+		graphQLService:      &graphQLService{client: ghClient},
+	}
+}
+
+func (c *client) SetRepositoryTokens(tokens map[models.Repository]string) {
+	c.tokenByRepository = tokens
+	c.servicesByToken = make(map[string]repositoryServices, len(tokens))
+	for _, token := range tokens {
+		if _, exists := c.servicesByToken[token]; !exists {
+			// the repositories of the same token share the services
+			c.servicesByToken[token] = c.newRepositoryServices(token)
+		}
+	}
+}
+
+// Returns the services authenticated with the token of the repository, or with the main token if the
+// repository has no token of its own.
+func (c *client) forRepository(repo models.Repository) repositoryServices {
+	return c.forToken(c.tokenByRepository[repo])
+}
+
+// Returns the services of the token, or of the main token if the token is empty.
+func (c *client) forToken(token string) repositoryServices {
+	if services, exists := c.servicesByToken[token]; exists {
+		return services
+	}
+	return c.repositoryServices
+}
@@ -0,0 +1,71 @@
+package githubclient
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+)
+
+// Records the node IDs of the queries and returns the PRs as approved.
+type recordingGraphQLService struct {
+	queriedNodeIDs [][]string
+}
+
+func (s *recordingGraphQLService) Query(ctx context.Context, query string, variables map[string]any, result any) error {
+	nodeIDs := variables["ids"].([]string)
+	s.queriedNodeIDs = append(s.queriedNodeIDs, nodeIDs)
+	var nodes []pullRequestNode
+	for _, nodeID := range nodeIDs {
+		nodes = append(nodes, pullRequestNode{ID: nodeID, ReviewDecision: ReviewDecisionApproved})
+	}
+	data, err := json.Marshal(map[string]any{"nodes": nodes})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func TestSetRepositoryTokens(t *testing.T) {
+	mainRepo := models.Repository{Owner: "main-org", Name: "repo"}
+	otherRepo := models.Repository{Owner: "other-org", Name: "repo"}
+	otherRepo2 := models.Repository{Owner: "other-org", Name: "repo2"}
+	mainGraphQLService := &recordingGraphQLService{}
+	otherGraphQLService := &recordingGraphQLService{}
+
+	c := NewClient(Services{GraphQL: mainGraphQLService}).(*client)
+	var createdTokens []string
+	c.newRepositoryServices = func(token string) repositoryServices {
+		createdTokens = append(createdTokens, token)
+		return repositoryServices{graphQLService: otherGraphQLService}
+	}
+	c.SetRepositoryTokens(map[models.Repository]string{otherRepo: "other-token", otherRepo2: "other-token"})
+
+	if !reflect.DeepEqual(createdTokens, []string{"other-token"}) {
+		t.Errorf("Expected the services of the shared token to be created once, got %v", createdTokens)
+	}
+	if c.forRepository(mainRepo).graphQLService != mainGraphQLService {
+		t.Errorf("Expected the repository without a token to use the main token")
+	}
+
+	prs := c.AddReviewDecisionInfo(context.Background(), []PR{
+		{PullRequest: models.PullRequest{NodeID: "PR_1", Repository: mainRepo}},
+		{PullRequest: models.PullRequest{NodeID: "PR_2", Repository: otherRepo}},
+		{PullRequest: models.PullRequest{NodeID: "PR_3", Repository: mainRepo}},
+		{PullRequest: models.PullRequest{NodeID: "PR_4", Repository: otherRepo2}},
+	})
+
+	if expected := [][]string{{"PR_1", "PR_3"}}; !reflect.DeepEqual(mainGraphQLService.queriedNodeIDs, expected) {
+		t.Errorf("Expected the main token to query %v, got %v", expected, mainGraphQLService.queriedNodeIDs)
+	}
+	if expected := [][]string{{"PR_2", "PR_4"}}; !reflect.DeepEqual(otherGraphQLService.queriedNodeIDs, expected) {
+		t.Errorf("Expected the repository token to query %v, got %v", expected, otherGraphQLService.queriedNodeIDs)
+	}
+	for _, pr := range prs {
+		if pr.ReviewDecision != ReviewDecisionApproved {
+			t.Errorf("Expected the review decision of %s to be set, got %q", pr.NodeID, pr.ReviewDecision)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package githubclient
+
+import "time"
+
+func init() {
+	// no need to wait between the artifact download attempts in tests
+	artifactDownloadRetryDelay = time.Millisecond
+}
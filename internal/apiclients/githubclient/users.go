@@ -0,0 +1,52 @@
+package githubclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const UsersFetchTimeout = 30 * time.Second
+
+// The maximum number of users looked up with one query
+const usersBatchSize = 100
+
+// FindUnknownUsers returns the logins (of the given ones) that do not belong to any GitHub account,
+// e.g. typos in the user mapping. The accounts are looked up in batches with the GraphQL API, in
+// which repositoryOwner is null (instead of an error) if the account does not exist.
+func (c *client) FindUnknownUsers(ctx context.Context, logins []string) ([]string, error) {
+	callCtx, cancel := context.WithTimeout(ctx, UsersFetchTimeout)
+	defer cancel()
+	var unknown []string
+	for start := 0; start < len(logins); start += usersBatchSize {
+		batch := logins[start:min(start+usersBatchSize, len(logins))]
+		query, variables := getUsersQuery(batch)
+		var result map[string]*struct {
+			Login string `json:"login"`
+		}
+		c.apiCalls.count(1)
+		if err := c.graphQLService.Query(callCtx, query, variables, &result); err != nil {
+			return nil, fmt.Errorf("error looking up %d GitHub users: %w", len(batch), err)
+		}
+		for i, login := range batch {
+			if result[fmt.Sprintf("u%d", i)] == nil {
+				unknown = append(unknown, login)
+			}
+		}
+	}
+	return unknown, nil
+}
+
+// Returns e.g. `query($l0: String!) { u0: repositoryOwner(login: $l0) { login } }` for one login.
+func getUsersQuery(logins []string) (string, map[string]any) {
+	var parameters, fields []string
+	variables := make(map[string]any, len(logins))
+	for i, login := range logins {
+		parameters = append(parameters, fmt.Sprintf("$l%d: String!", i))
+		fields = append(fields, fmt.Sprintf("u%d: repositoryOwner(login: $l%d) { login }", i, i))
+		variables[fmt.Sprintf("l%d", i)] = login
+	}
+	query := fmt.Sprintf("query(%s) {\n  %s\n}", strings.Join(parameters, ", "), strings.Join(fields, "\n  "))
+	return query, variables
+}
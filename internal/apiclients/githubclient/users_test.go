@@ -0,0 +1,97 @@
+package githubclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+)
+
+// Responds to the lookups of users (u0, u1, ... by the logins l0, l1, ...) with null for unknown users.
+type mockUsersService struct {
+	knownLogins []string
+	err         error
+	batchSizes  []int
+}
+
+func (m *mockUsersService) Query(ctx context.Context, query string, variables map[string]any, result any) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.batchSizes = append(m.batchSizes, len(variables))
+	owners := map[string]any{}
+	for name, login := range variables {
+		if !strings.Contains(query, fmt.Sprintf("repositoryOwner(login: $%s)", name)) {
+			return fmt.Errorf("variable %s is not used in the query", name)
+		}
+		var owner any
+		if slices.Contains(m.knownLogins, login.(string)) {
+			owner = map[string]any{"login": login}
+		}
+		owners["u"+strings.TrimPrefix(name, "l")] = owner
+	}
+	data, err := json.Marshal(owners)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func TestFindUnknownUsers(t *testing.T) {
+	var manyLogins []string
+	for i := range 150 {
+		manyLogins = append(manyLogins, fmt.Sprintf("user%d", i))
+	}
+
+	tests := []struct {
+		name               string
+		logins             []string
+		err                error
+		expectedUnknown    []string
+		expectedBatchSizes []int
+		expectError        bool
+	}{
+		{
+			name:               "unknown logins",
+			logins:             []string{"alice", "alcie", "bob"},
+			expectedUnknown:    []string{"alcie"},
+			expectedBatchSizes: []int{3},
+		},
+		{
+			name:               "logins are looked up in batches",
+			logins:             append(slices.Clone(manyLogins), "alice"),
+			expectedUnknown:    manyLogins,
+			expectedBatchSizes: []int{100, 51},
+		},
+		{
+			name:        "error if the query fails",
+			logins:      []string{"alice"},
+			err:         errors.New("Bad credentials"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graphQLService := &mockUsersService{knownLogins: []string{"alice", "bob"}, err: tt.err}
+			client := githubclient.NewClient(githubclient.Services{GraphQL: graphQLService})
+
+			unknown, err := client.FindUnknownUsers(context.Background(), tt.logins)
+
+			if tt.expectError != (err != nil) {
+				t.Errorf("Expected error: %v, got: %v", tt.expectError, err)
+			}
+			if !slices.Equal(unknown, tt.expectedUnknown) {
+				t.Errorf("Expected unknown users %v, got %v", tt.expectedUnknown, unknown)
+			}
+			if !tt.expectError && !slices.Equal(graphQLService.batchSizes, tt.expectedBatchSizes) {
+				t.Errorf("Expected batches of %v users, got %v", tt.expectedBatchSizes, graphQLService.batchSizes)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Package googlechatclient sends messages to a Google Chat space via an incoming webhook.
+// Messages are composed of cards (cards v2), see
+// https://developers.google.com/workspace/chat/api/reference/rest/v1/cards
+package googlechatclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const RequestTimeout = 10 * time.Second
+
+type Message struct {
+	Text    string       `json:"text,omitempty"`
+	CardsV2 []CardWithID `json:"cardsV2,omitempty"`
+}
+
+type CardWithID struct {
+	CardID string `json:"cardId"`
+	Card   Card   `json:"card"`
+}
+
+type Card struct {
+	Header   *CardHeader `json:"header,omitempty"`
+	Sections []Section   `json:"sections"`
+}
+
+type CardHeader struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+type Section struct {
+	Header  string   `json:"header,omitempty"`
+	Widgets []Widget `json:"widgets"`
+}
+
+// Widget is a text paragraph widget. The text supports a subset of HTML
+// (e.g. <b>, <i>, <s> and <a href="...">).
+type Widget struct {
+	TextParagraph TextParagraph `json:"textParagraph"`
+}
+
+type TextParagraph struct {
+	Text string `json:"text"`
+}
+
+func NewTextWidget(text string) Widget {
+	return Widget{TextParagraph: TextParagraph{Text: text}}
+}
+
+type Client interface {
+	SendMessage(message Message) error
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+func NewClient(httpClient HTTPClient, webhookURL string) Client {
+	return &client{httpClient: httpClient, webhookURL: webhookURL}
+}
+
+type client struct {
+	httpClient HTTPClient
+	webhookURL string
+}
+
+func (c *client) SendMessage(message Message) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode Google Chat message: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Google Chat request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Google Chat message: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to send Google Chat message: unexpected status %d: %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package googlechatclient_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/googlechatclient"
+)
+
+func TestSendMessage(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		expectedErrMsg string
+	}{
+		{name: "sends the message", status: http.StatusOK},
+		{
+			name:           "returns error on unexpected status",
+			status:         http.StatusBadRequest,
+			expectedErrMsg: "failed to send Google Chat message: unexpected status 400: invalid card",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var received googlechatclient.Message
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected POST request, got %s", r.Method)
+				}
+				json.NewDecoder(r.Body).Decode(&received)
+				w.WriteHeader(tc.status)
+				if tc.status != http.StatusOK {
+					w.Write([]byte("invalid card"))
+				}
+			}))
+			defer server.Close()
+
+			client := googlechatclient.NewClient(http.DefaultClient, server.URL)
+			err := client.SendMessage(googlechatclient.Message{Text: "2 open PRs"})
+
+			if tc.expectedErrMsg != "" {
+				if err == nil || err.Error() != tc.expectedErrMsg {
+					t.Fatalf("Expected error '%s', got: %v", tc.expectedErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if received.Text != "2 open PRs" {
+				t.Errorf("Expected message text '2 open PRs', got '%s'", received.Text)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package matrixclient sends messages to a Matrix room via the client-server API, see
+// https://spec.matrix.org/latest/client-server-api/#put_matrixclientv3roomsroomidsendeventtypetxnid
+package matrixclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const RequestTimeout = 10 * time.Second
+
+// Message is the content of an m.room.message event with an HTML formatted body.
+// Body is the plain text fallback for clients that do not render HTML.
+type Message struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+func NewHTMLMessage(body, formattedBody string) Message {
+	return Message{
+		MsgType:       "m.text",
+		Body:          body,
+		Format:        "org.matrix.custom.html",
+		FormattedBody: formattedBody,
+	}
+}
+
+type Client interface {
+	SendMessage(roomID string, message Message) error
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+func NewClient(httpClient HTTPClient, homeserverURL, accessToken string) Client {
+	return &client{
+		httpClient:    httpClient,
+		homeserverURL: strings.TrimSuffix(homeserverURL, "/"),
+		accessToken:   accessToken,
+	}
+}
+
+type client struct {
+	httpClient    HTTPClient
+	homeserverURL string
+	accessToken   string
+}
+
+func (c *client) SendMessage(roomID string, message Message) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode Matrix message: %w", err)
+	}
+	// The transaction ID makes retries of the same request idempotent
+	txnID := "pr-reminder-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	requestURL := fmt.Sprintf(
+		"%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		c.homeserverURL, url.PathEscape(roomID), txnID,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Matrix request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Matrix message: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to send Matrix message: unexpected status %d: %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package matrixclient_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/matrixclient"
+)
+
+func TestSendMessage(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		expectedErrMsg string
+	}{
+		{name: "sends the message", status: http.StatusOK},
+		{
+			name:           "returns error on unexpected status",
+			status:         http.StatusForbidden,
+			expectedErrMsg: `failed to send Matrix message: unexpected status 403: {"errcode":"M_FORBIDDEN"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var received matrixclient.Message
+			var path, authorization string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.EscapedPath()
+				authorization = r.Header.Get("Authorization")
+				json.NewDecoder(r.Body).Decode(&received)
+				w.WriteHeader(tc.status)
+				if tc.status != http.StatusOK {
+					w.Write([]byte(`{"errcode":"M_FORBIDDEN"}`))
+				}
+			}))
+			defer server.Close()
+
+			client := matrixclient.NewClient(http.DefaultClient, server.URL+"/", "syt_token")
+			err := client.SendMessage("!room:example.org", matrixclient.NewHTMLMessage("2 open PRs", "<b>2 open PRs</b>"))
+
+			if tc.expectedErrMsg != "" {
+				if err == nil || err.Error() != tc.expectedErrMsg {
+					t.Fatalf("Expected error '%s', got: %v", tc.expectedErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			expectedPathPrefix := "/_matrix/client/v3/rooms/%21room:example.org/send/m.room.message/"
+			if !strings.HasPrefix(path, expectedPathPrefix) {
+				t.Errorf("Expected path to start with '%s', got '%s'", expectedPathPrefix, path)
+			}
+			if authorization != "Bearer syt_token" {
+				t.Errorf("Expected bearer authorization, got '%s'", authorization)
+			}
+			if received.FormattedBody != "<b>2 open PRs</b>" || received.Format != "org.matrix.custom.html" {
+				t.Errorf("Expected HTML formatted message, got %+v", received)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Package oncallclient fetches the current on-call user of a PagerDuty or Opsgenie schedule.
+// The on-call user is mentioned as the review captain of the day in the reminder message.
+package oncallclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+)
+
+const (
+	PagerDutyAPIURL = "https://api.pagerduty.com"
+	OpsgenieAPIURL  = "https://api.opsgenie.com"
+)
+
+const RequestTimeout = 10 * time.Second
+
+type User struct {
+	Name  string
+	Email string // empty string if not available
+}
+
+type Client interface {
+	GetOnCallUser(ctx context.Context, scheduleID string) (User, error)
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+func GetAuthenticatedClient(provider config.OnCallProvider, token string) (Client, error) {
+	switch provider {
+	case config.OnCallProviderPagerDuty:
+		return NewPagerDutyClient(http.DefaultClient, PagerDutyAPIURL, token), nil
+	case config.OnCallProviderOpsgenie:
+		return NewOpsgenieClient(http.DefaultClient, OpsgenieAPIURL, token), nil
+	default:
+		return nil, fmt.Errorf("unsupported on-call provider: %s", provider)
+	}
+}
+
+// Sends a GET request with the given headers and decodes the JSON response body into target.
+func getJSON(
+	ctx context.Context, httpClient HTTPClient, url string, headers map[string]string, target any,
+) error {
+	callCtx, cancel := context.WithTimeout(ctx, RequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(callCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
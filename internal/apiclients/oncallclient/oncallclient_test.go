@@ -0,0 +1,166 @@
+package oncallclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/oncallclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+)
+
+type recordedRequest struct {
+	path          string
+	query         string
+	authorization string
+}
+
+func newTestServer(t *testing.T, status int, body string) (*httptest.Server, *recordedRequest) {
+	t.Helper()
+	recorded := &recordedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorded.path = r.URL.Path
+		recorded.query = r.URL.RawQuery
+		recorded.authorization = r.Header.Get("Authorization")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, recorded
+}
+
+func TestPagerDutyGetOnCallUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		body           string
+		expectedUser   oncallclient.User
+		expectedErrMsg string
+	}{
+		{
+			name:   "returns the user with the lowest escalation level",
+			status: http.StatusOK,
+			body: `{"oncalls": [
+				{"escalation_level": 2, "user": {"name": "Bob", "email": "bob@example.com"}},
+				{"escalation_level": 1, "user": {"name": "Alice", "email": "alice@example.com"}}
+			]}`,
+			expectedUser: oncallclient.User{Name: "Alice", Email: "alice@example.com"},
+		},
+		{
+			name:         "falls back to user summary without name",
+			status:       http.StatusOK,
+			body:         `{"oncalls": [{"escalation_level": 1, "user": {"summary": "Alice"}}]}`,
+			expectedUser: oncallclient.User{Name: "Alice"},
+		},
+		{
+			name:           "no one on call",
+			status:         http.StatusOK,
+			body:           `{"oncalls": []}`,
+			expectedErrMsg: "no one is on call in PagerDuty schedule PSCHED1",
+		},
+		{
+			name:           "unauthorized",
+			status:         http.StatusUnauthorized,
+			body:           `{"error": {"message": "Unauthorized"}}`,
+			expectedErrMsg: "unexpected status 401",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, recorded := newTestServer(t, tt.status, tt.body)
+			client := oncallclient.NewPagerDutyClient(server.Client(), server.URL, "PD_TOKEN")
+
+			user, err := client.GetOnCallUser(context.Background(), "PSCHED1")
+
+			if recorded.path != "/oncalls" || !strings.Contains(recorded.query, "schedule_ids%5B%5D=PSCHED1") {
+				t.Errorf("Unexpected request: %s?%s", recorded.path, recorded.query)
+			}
+			if recorded.authorization != "Token token=PD_TOKEN" {
+				t.Errorf("Unexpected Authorization header: %s", recorded.authorization)
+			}
+			if tt.expectedErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg) {
+					t.Fatalf("Expected error containing '%s', got: %v", tt.expectedErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if user != tt.expectedUser {
+				t.Errorf("Expected user %+v, got %+v", tt.expectedUser, user)
+			}
+		})
+	}
+}
+
+func TestOpsgenieGetOnCallUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		body           string
+		expectedUser   oncallclient.User
+		expectedErrMsg string
+	}{
+		{
+			name:         "returns the first on-call recipient",
+			status:       http.StatusOK,
+			body:         `{"data": {"onCallRecipients": ["alice@example.com", "bob@example.com"]}}`,
+			expectedUser: oncallclient.User{Name: "alice@example.com", Email: "alice@example.com"},
+		},
+		{
+			name:           "no one on call",
+			status:         http.StatusOK,
+			body:           `{"data": {"onCallRecipients": []}}`,
+			expectedErrMsg: "no one is on call in Opsgenie schedule schedule-1",
+		},
+		{
+			name:           "invalid response",
+			status:         http.StatusOK,
+			body:           `not json`,
+			expectedErrMsg: "failed to decode response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, recorded := newTestServer(t, tt.status, tt.body)
+			client := oncallclient.NewOpsgenieClient(server.Client(), server.URL, "OG_KEY")
+
+			user, err := client.GetOnCallUser(context.Background(), "schedule-1")
+
+			if recorded.path != "/v2/schedules/schedule-1/on-calls" {
+				t.Errorf("Unexpected request path: %s", recorded.path)
+			}
+			if recorded.authorization != "GenieKey OG_KEY" {
+				t.Errorf("Unexpected Authorization header: %s", recorded.authorization)
+			}
+			if tt.expectedErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErrMsg) {
+					t.Fatalf("Expected error containing '%s', got: %v", tt.expectedErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if user != tt.expectedUser {
+				t.Errorf("Expected user %+v, got %+v", tt.expectedUser, user)
+			}
+		})
+	}
+}
+
+func TestGetAuthenticatedClient(t *testing.T) {
+	for _, provider := range []config.OnCallProvider{config.OnCallProviderPagerDuty, config.OnCallProviderOpsgenie} {
+		if client, err := oncallclient.GetAuthenticatedClient(provider, "token"); err != nil || client == nil {
+			t.Errorf("Expected a client for provider %s, got error: %v", provider, err)
+		}
+	}
+	if _, err := oncallclient.GetAuthenticatedClient(config.OnCallProviderNone, "token"); err == nil {
+		t.Errorf("Expected an error without a provider")
+	}
+}
@@ -0,0 +1,46 @@
+package oncallclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type opsgenieClient struct {
+	httpClient HTTPClient
+	apiURL     string
+	token      string
+}
+
+func NewOpsgenieClient(httpClient HTTPClient, apiURL, token string) Client {
+	return &opsgenieClient{httpClient: httpClient, apiURL: apiURL, token: token}
+}
+
+type opsgenieOnCallsResponse struct {
+	Data struct {
+		OnCallRecipients []string `json:"onCallRecipients"`
+	} `json:"data"`
+}
+
+// Returns the user who is currently on call in the schedule. Opsgenie identifies
+// the on-call recipients by their usernames, which are email addresses.
+func (c *opsgenieClient) GetOnCallUser(ctx context.Context, scheduleID string) (User, error) {
+	query := url.Values{}
+	query.Set("scheduleIdentifierType", "id")
+	query.Set("flat", "true")
+
+	var response opsgenieOnCallsResponse
+	if err := getJSON(ctx, c.httpClient,
+		c.apiURL+"/v2/schedules/"+url.PathEscape(scheduleID)+"/on-calls?"+query.Encode(),
+		map[string]string{"Authorization": "GenieKey " + c.token},
+		&response,
+	); err != nil {
+		return User{}, fmt.Errorf("failed to fetch on-calls of Opsgenie schedule %s: %w", scheduleID, err)
+	}
+	if len(response.Data.OnCallRecipients) == 0 {
+		return User{}, fmt.Errorf("no one is on call in Opsgenie schedule %s", scheduleID)
+	}
+
+	username := response.Data.OnCallRecipients[0]
+	return User{Name: username, Email: username}, nil
+}
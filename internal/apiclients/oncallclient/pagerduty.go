@@ -0,0 +1,59 @@
+package oncallclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type pagerDutyClient struct {
+	httpClient HTTPClient
+	apiURL     string
+	token      string
+}
+
+func NewPagerDutyClient(httpClient HTTPClient, apiURL, token string) Client {
+	return &pagerDutyClient{httpClient: httpClient, apiURL: apiURL, token: token}
+}
+
+type pagerDutyOnCallsResponse struct {
+	OnCalls []struct {
+		EscalationLevel int `json:"escalation_level"`
+		User            struct {
+			Name    string `json:"name"`
+			Summary string `json:"summary"`
+			Email   string `json:"email"`
+		} `json:"user"`
+	} `json:"oncalls"`
+}
+
+// Returns the user who is currently on call in the schedule (the first escalation level if many).
+func (c *pagerDutyClient) GetOnCallUser(ctx context.Context, scheduleID string) (User, error) {
+	query := url.Values{}
+	query.Set("schedule_ids[]", scheduleID)
+	query.Set("include[]", "users")
+	query.Set("earliest", "true")
+
+	var response pagerDutyOnCallsResponse
+	if err := getJSON(ctx, c.httpClient, c.apiURL+"/oncalls?"+query.Encode(), map[string]string{
+		"Authorization": "Token token=" + c.token,
+		"Accept":        "application/vnd.pagerduty+json;version=2",
+	}, &response); err != nil {
+		return User{}, fmt.Errorf("failed to fetch on-calls of PagerDuty schedule %s: %w", scheduleID, err)
+	}
+	if len(response.OnCalls) == 0 {
+		return User{}, fmt.Errorf("no one is on call in PagerDuty schedule %s", scheduleID)
+	}
+
+	onCall := response.OnCalls[0]
+	for _, candidate := range response.OnCalls[1:] {
+		if candidate.EscalationLevel < onCall.EscalationLevel {
+			onCall = candidate
+		}
+	}
+	name := onCall.User.Name
+	if name == "" {
+		name = onCall.User.Summary
+	}
+	return User{Name: name, Email: onCall.User.Email}, nil
+}
@@ -0,0 +1,92 @@
+package slackclient
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/messagepreview"
+	"github.com/slack-go/slack"
+)
+
+const dryRunTimestamp = "0000000000.000000"
+
+type dryRunClient struct {
+	Client
+	preview *messagepreview.Preview
+}
+
+// NewDryRunClient wraps the client so that the messages are logged instead of sent, updated,
+// or deleted. The channels and recent messages are still read with the client.
+// If a preview is given, the messages that would be sent or updated are also added to it.
+func NewDryRunClient(c Client, preview *messagepreview.Preview) Client {
+	return &dryRunClient{Client: c, preview: preview}
+}
+
+func (c *dryRunClient) addToPreview(channelID string, message slack.Message, summaryText string) {
+	if c.preview == nil {
+		return
+	}
+	if err := c.preview.Add(channelID, message, summaryText); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
+
+func (c *dryRunClient) SendMessage(
+	_ context.Context, channelID string, message slack.Message, summaryText string,
+) (SentMessageInfo, error) {
+	jsonBlocks := parseSentJSONBlocks(message)
+	log.Printf("\n[dry run] Would send message to channel %s with summary: %s", channelID, summaryText)
+	log.Printf("[dry run] Blocks:\n%s", strings.Join(jsonBlocks, "\n"))
+	c.addToPreview(channelID, message, summaryText)
+	return SentMessageInfo{
+		ChannelID:   channelID,
+		Timestamp:   dryRunTimestamp,
+		SummaryText: summaryText,
+		JSONBlocks:  jsonBlocks,
+	}, nil
+}
+
+func (c *dryRunClient) UpdateMessage(
+	_ context.Context, channelID string, messageTS string, message slack.Message, summaryText string,
+) (SentMessageInfo, error) {
+	jsonBlocks := parseSentJSONBlocks(message)
+	log.Printf(
+		"\n[dry run] Would update message %s in channel %s with summary: %s", messageTS, channelID, summaryText,
+	)
+	log.Printf("[dry run] Blocks:\n%s", strings.Join(jsonBlocks, "\n"))
+	c.addToPreview(channelID, message, summaryText)
+	return SentMessageInfo{
+		ChannelID:   channelID,
+		Timestamp:   messageTS,
+		SummaryText: summaryText,
+		JSONBlocks:  jsonBlocks,
+	}, nil
+}
+
+func (c *dryRunClient) DeleteMessage(_ context.Context, channelID string, messageTS string) error {
+	log.Printf("[dry run] Would delete message %s from channel %s", messageTS, channelID)
+	return nil
+}
+
+func (c *dryRunClient) SendReply(_ context.Context, channelID string, threadTS string, text string) error {
+	log.Printf("[dry run] Would reply to message %s in channel %s: %s", threadTS, channelID, text)
+	return nil
+}
+
+func (c *dryRunClient) SendThreadReply(
+	_ context.Context, channelID string, threadTS string, message slack.Message, summaryText string,
+) (SentMessageInfo, error) {
+	jsonBlocks := parseSentJSONBlocks(message)
+	log.Printf(
+		"\n[dry run] Would reply to message %s in channel %s with summary: %s", threadTS, channelID, summaryText,
+	)
+	log.Printf("[dry run] Blocks:\n%s", strings.Join(jsonBlocks, "\n"))
+	c.addToPreview(channelID, message, summaryText)
+	return SentMessageInfo{
+		ChannelID:   channelID,
+		Timestamp:   dryRunTimestamp,
+		SummaryText: summaryText,
+		JSONBlocks:  jsonBlocks,
+	}, nil
+}
@@ -1,31 +1,55 @@
 // Package slackclient provides Slack API integration for sending messages.
 // It handles channel resolution by name and message posting with Block Kit formatting.
+// Each Slack API call is limited by the request timeout of the client, so a hanging
+// call cannot stall the whole run.
 package slackclient
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
 	"github.com/slack-go/slack"
 )
 
 type SentMessageInfo struct {
-	ChannelID  string
-	Timestamp  string
-	JSONBlocks []string
+	ChannelID   string
+	Timestamp   string
+	SummaryText string
+	JSONBlocks  []string
 }
 
 type Client interface {
-	GetChannelIDByName(channelName string) (string, error)
-	SendMessage(channelID string, message slack.Message, summaryText string,
+	// Sets the timeout of each Slack API call (DefaultRequestTimeout by default)
+	SetRequestTimeout(timeout time.Duration)
+	GetChannelIDByName(ctx context.Context, channelName string) (string, error)
+	GetChannelNameByID(ctx context.Context, channelID string) (string, error)
+	SendMessage(ctx context.Context, channelID string, message slack.Message, summaryText string,
 	) (SentMessageInfo, error)
 	UpdateMessage(
-		channelID string, messageTS string, message slack.Message, summaryText string,
+		ctx context.Context, channelID string, messageTS string, message slack.Message, summaryText string,
 	) (SentMessageInfo, error)
-	DeleteMessage(channelID string, messageTS string) error
+	DeleteMessage(ctx context.Context, channelID string, messageTS string) error
+	// Returns up to RecentMessagesLimit of the latest messages of the channel (newest first). The messages
+	// are paged through, as search.messages cannot be used with bot tokens.
+	GetRecentMessages(ctx context.Context, channelID string) ([]slack.Message, error)
+	SendReply(ctx context.Context, channelID string, threadTS string, text string) error
+	// Sends the message as a reply in the thread of the message with the timestamp threadTS
+	SendThreadReply(
+		ctx context.Context, channelID string, threadTS string, message slack.Message, summaryText string,
+	) (SentMessageInfo, error)
+	// Returns the active users of the workspace (deleted users and bots are left out)
+	ListUsers(ctx context.Context) ([]slack.User, error)
+	// Returns the IDs of the given users who currently have Do Not Disturb on (snoozed notifications
+	// or within their scheduled DND hours)
+	GetUsersOnDND(ctx context.Context, userIDs []string) ([]string, error)
+	// Returns the IDs of the given users that are not found or are deactivated in the workspace
+	FindInvalidUserIDs(ctx context.Context, userIDs []string) ([]string, error)
 }
 
 func GetAuthenticatedClient(token string) Client {
@@ -33,27 +57,56 @@ func GetAuthenticatedClient(token string) Client {
 }
 
 func NewClient(slackAPI SlackAPI) Client {
-	return &client{slackAPI: slackAPI}
+	return &client{slackAPI: slackAPI, requestTimeout: DefaultRequestTimeout}
 }
 
 // represents the Slack API methods relevant to us from github.com/slack-go/slack
 type SlackAPI interface {
-	GetConversations(params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
-	PostMessage(channelID string, options ...slack.MsgOption) (string, string, error)
-	UpdateMessage(channelID string, timestamp string, options ...slack.MsgOption) (string, string, string, error)
-	DeleteMessage(channelID string, timestamp string) (string, string, error)
+	GetConversationsContext(
+		ctx context.Context, params *slack.GetConversationsParameters,
+	) ([]slack.Channel, string, error)
+	GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error)
+	PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error)
+	UpdateMessageContext(
+		ctx context.Context, channelID string, timestamp string, options ...slack.MsgOption,
+	) (string, string, string, error)
+	DeleteMessageContext(ctx context.Context, channelID string, timestamp string) (string, string, error)
+	GetConversationHistoryContext(
+		ctx context.Context, params *slack.GetConversationHistoryParameters,
+	) (*slack.GetConversationHistoryResponse, error)
+	GetUsersContext(ctx context.Context, options ...slack.GetUsersOption) ([]slack.User, error)
+	GetDNDTeamInfoContext(ctx context.Context, users []string) (map[string]slack.DNDStatus, error)
+	GetUserInfoContext(ctx context.Context, user string) (*slack.User, error)
 }
 
+const RecentMessagesLimit = 1000
+
+// The number of messages fetched per call of conversations.history (at most 1000, but less is recommended)
+const recentMessagesPageSize = 200
+
+const DefaultRequestTimeout = 30 * time.Second
+
+// Listing the users of a large workspace takes many (rate limited) calls
+const UsersFetchTimeout = 2 * time.Minute
+
+// The maximum number of users of which dnd.teamInfo returns the Do Not Disturb statuses at once
+const DNDUsersBatchSize = 50
+
 type client struct {
-	slackAPI SlackAPI
+	slackAPI       SlackAPI
+	requestTimeout time.Duration
+}
+
+func (c *client) SetRequestTimeout(timeout time.Duration) {
+	c.requestTimeout = timeout
 }
 
-func (c *client) GetChannelIDByName(channelName string) (string, error) {
+func (c *client) GetChannelIDByName(ctx context.Context, channelName string) (string, error) {
 	var publicChannelsError error
 	var privateChannelsError error
 
 	for _, channelType := range []string{"public_channel", "private_channel"} {
-		channels, fetchError := c.fetchChannels([]string{channelType})
+		channels, fetchError := c.fetchChannels(ctx, []string{channelType})
 		if fetchError != nil {
 			if channelType == "public_channel" {
 				publicChannelsError = fetchError
@@ -72,21 +125,21 @@ func (c *client) GetChannelIDByName(channelName string) (string, error) {
 
 	if publicChannelsError == nil && privateChannelsError != nil {
 		return "", fmt.Errorf(
-			"%v (unable to fetch private channels, channel not found from public channels, "+
+			"%w (unable to fetch private channels, channel not found from public channels, "+
 				"check channel name, token and permissions or use channel ID input instead)",
 			privateChannelsError,
 		)
 	}
 	if publicChannelsError != nil && privateChannelsError == nil {
 		return "", fmt.Errorf(
-			"%v (unable to fetch public channels, channel not found from private channels, "+
+			"%w (unable to fetch public channels, channel not found from private channels, "+
 				"check channel name, token and permissions or use channel ID input instead)",
 			publicChannelsError,
 		)
 	}
 	if publicChannelsError != nil && privateChannelsError != nil {
 		return "", fmt.Errorf(
-			"%v, %v (unable to fetch channels, check token and permissions or use channel ID input instead)",
+			"%w, %w (unable to fetch channels, check token and permissions or use channel ID input instead)",
 			publicChannelsError,
 			privateChannelsError,
 		)
@@ -95,8 +148,19 @@ func (c *client) GetChannelIDByName(channelName string) (string, error) {
 	return "", errors.New("channel not found (check channel name)")
 }
 
+func (c *client) GetChannelNameByID(ctx context.Context, channelID string) (string, error) {
+	callCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	channel, err := c.slackAPI.GetConversationInfoContext(callCtx, &slack.GetConversationInfoInput{ChannelID: channelID})
+	if err != nil {
+		return "", fmt.Errorf("failed to get channel info (check channel ID, token and permissions): %w", err)
+	}
+	return channel.Name, nil
+}
+
 // The message must not have more than 50 blocks
 func (c *client) SendMessage(
+	ctx context.Context,
 	channelID string,
 	message slack.Message,
 	summaryText string,
@@ -109,62 +173,200 @@ func (c *client) SendMessage(
 	}
 
 	log.Printf("\nSending message with summary: %s", summaryText)
-	responseChannelID, timestamp, err := c.slackAPI.PostMessage(
-		channelID,
-		slack.MsgOptionBlocks(message.Blocks.BlockSet...),
-		slack.MsgOptionText(summaryText, false),
+	callCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	responseChannelID, timestamp, err := c.slackAPI.PostMessageContext(
+		callCtx, channelID, getMessageOptions(message, summaryText)...,
 	)
 	if err != nil {
-		return SentMessageInfo{}, fmt.Errorf("failed to send Slack message: %v", err)
+		return SentMessageInfo{}, fmt.Errorf("failed to send Slack message: %w", err)
 	}
 	log.Printf("Sent message to Slack channel: %s", channelID)
 
 	return SentMessageInfo{
-		ChannelID:  responseChannelID,
-		Timestamp:  timestamp,
-		JSONBlocks: parseSentJSONBlocks(message),
+		ChannelID:   responseChannelID,
+		Timestamp:   timestamp,
+		SummaryText: summaryText,
+		JSONBlocks:  parseSentJSONBlocks(message),
 	}, nil
 }
 
 func (c *client) UpdateMessage(
+	ctx context.Context,
 	channelID string,
 	messageTS string,
 	message slack.Message,
 	summaryText string,
 ) (SentMessageInfo, error) {
 	log.Printf("Updating message with timestamp %s and summary: %s", messageTS, summaryText)
-	_, _, _, err := c.slackAPI.UpdateMessage(
-		channelID,
-		messageTS,
-		slack.MsgOptionBlocks(message.Blocks.BlockSet...),
-		slack.MsgOptionText(summaryText, false),
+	callCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	_, _, _, err := c.slackAPI.UpdateMessageContext(
+		callCtx, channelID, messageTS, getMessageOptions(message, summaryText)...,
 	)
 	if err != nil {
-		return SentMessageInfo{}, fmt.Errorf("failed to update Slack message: %v", err)
+		return SentMessageInfo{}, fmt.Errorf("failed to update Slack message: %w", err)
 	}
 	log.Printf("Updated message in Slack channel: %s", channelID)
 
 	return SentMessageInfo{
-		ChannelID:  channelID,
-		Timestamp:  messageTS,
-		JSONBlocks: parseSentJSONBlocks(message),
+		ChannelID:   channelID,
+		Timestamp:   messageTS,
+		SummaryText: summaryText,
+		JSONBlocks:  parseSentJSONBlocks(message),
 	}, nil
 }
 
-func (c *client) DeleteMessage(channelID string, messageTS string) error {
+func (c *client) DeleteMessage(ctx context.Context, channelID string, messageTS string) error {
 	log.Printf("Deleting message with timestamp %s from channel %s", messageTS, channelID)
-	_, _, err := c.slackAPI.DeleteMessage(channelID, messageTS)
+	callCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	_, _, err := c.slackAPI.DeleteMessageContext(callCtx, channelID, messageTS)
 	if err != nil {
 		if strings.Contains(err.Error(), "message_not_found") {
 			log.Printf("Message already deleted or not found, ignoring error")
 			return nil
 		}
-		return fmt.Errorf("failed to delete Slack message: %v", err)
+		return fmt.Errorf("failed to delete Slack message: %w", err)
 	}
 	log.Printf("Deleted message from Slack channel: %s", channelID)
 	return nil
 }
 
+func (c *client) GetRecentMessages(ctx context.Context, channelID string) ([]slack.Message, error) {
+	var messages []slack.Message
+	params := &slack.GetConversationHistoryParameters{ChannelID: channelID, Limit: recentMessagesPageSize}
+	for len(messages) < RecentMessagesLimit {
+		response, err := c.getConversationHistory(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the messages of the channel (check channels:history scope): %w", err)
+		}
+		messages = append(messages, response.Messages...)
+		if !response.HasMore || response.ResponseMetaData.NextCursor == "" {
+			break
+		}
+		params.Cursor = response.ResponseMetaData.NextCursor
+	}
+	return messages[:min(len(messages), RecentMessagesLimit)], nil
+}
+
+func (c *client) getConversationHistory(
+	ctx context.Context, params *slack.GetConversationHistoryParameters,
+) (*slack.GetConversationHistoryResponse, error) {
+	callCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	return c.slackAPI.GetConversationHistoryContext(callCtx, params)
+}
+
+func (c *client) ListUsers(ctx context.Context) ([]slack.User, error) {
+	callCtx, cancel := context.WithTimeout(ctx, UsersFetchTimeout)
+	defer cancel()
+	users, err := c.slackAPI.GetUsersContext(callCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the users of the workspace (check users:read scope): %w", err)
+	}
+	return utilities.Filter(users, func(user slack.User) bool {
+		return !user.Deleted && !user.IsBot && !user.IsAppUser && user.ID != "USLACKBOT"
+	}), nil
+}
+
+func (c *client) GetUsersOnDND(ctx context.Context, userIDs []string) ([]string, error) {
+	now := time.Now().Unix()
+	var usersOnDND []string
+	for batch := range slices.Chunk(userIDs, DNDUsersBatchSize) {
+		callCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+		statuses, err := c.slackAPI.GetDNDTeamInfoContext(callCtx, batch)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the Do Not Disturb statuses of the users (check dnd:read scope): %w", err)
+		}
+		for _, userID := range batch {
+			if status, ok := statuses[userID]; ok && isOnDND(status, now) {
+				usersOnDND = append(usersOnDND, userID)
+			}
+		}
+	}
+	return usersOnDND, nil
+}
+
+// Looks up the users one by one (users.info), as listing all users of a large workspace takes many calls.
+func (c *client) FindInvalidUserIDs(ctx context.Context, userIDs []string) ([]string, error) {
+	var invalid []string
+	for _, userID := range userIDs {
+		callCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+		user, err := c.slackAPI.GetUserInfoContext(callCtx, userID)
+		cancel()
+		var slackErr slack.SlackErrorResponse
+		switch {
+		case errors.As(err, &slackErr) && slackErr.Err == "user_not_found":
+			invalid = append(invalid, userID)
+		case err != nil:
+			return nil, fmt.Errorf("failed to look up the Slack user %s (check users:read scope): %w", userID, err)
+		case user.Deleted:
+			invalid = append(invalid, userID)
+		}
+	}
+	return invalid, nil
+}
+
+func isOnDND(status slack.DNDStatus, now int64) bool {
+	if status.SnoozeEnabled && int64(status.SnoozeEndTime) > now {
+		return true
+	}
+	return status.Enabled && int64(status.NextStartTimestamp) <= now && now < int64(status.NextEndTimestamp)
+}
+
+func (c *client) SendReply(ctx context.Context, channelID string, threadTS string, text string) error {
+	callCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	_, _, err := c.slackAPI.PostMessageContext(
+		callCtx, channelID, slack.MsgOptionText(text, false), slack.MsgOptionTS(threadTS),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to send Slack reply: %w", err)
+	}
+	log.Printf("Sent reply to thread %s in Slack channel: %s", threadTS, channelID)
+	return nil
+}
+
+func (c *client) SendThreadReply(
+	ctx context.Context,
+	channelID string,
+	threadTS string,
+	message slack.Message,
+	summaryText string,
+) (SentMessageInfo, error) {
+	callCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	options := append(getMessageOptions(message, summaryText), slack.MsgOptionTS(threadTS))
+	_, timestamp, err := c.slackAPI.PostMessageContext(callCtx, channelID, options...)
+	if err != nil {
+		return SentMessageInfo{}, fmt.Errorf("failed to send Slack reply: %w", err)
+	}
+	log.Printf("Sent reply to thread %s in Slack channel: %s", threadTS, channelID)
+
+	return SentMessageInfo{
+		ChannelID:   channelID,
+		Timestamp:   timestamp,
+		SummaryText: summaryText,
+		JSONBlocks:  parseSentJSONBlocks(message),
+	}, nil
+}
+
+// If the message has (colored) attachments, the blocks are sent in them instead of the message itself.
+func getMessageOptions(message slack.Message, summaryText string) []slack.MsgOption {
+	if len(message.Attachments) > 0 {
+		return []slack.MsgOption{
+			slack.MsgOptionAttachments(message.Attachments...),
+			slack.MsgOptionText(summaryText, false),
+		}
+	}
+	return []slack.MsgOption{
+		slack.MsgOptionBlocks(message.Blocks.BlockSet...),
+		slack.MsgOptionText(summaryText, false),
+	}
+}
+
 func parseSentJSONBlocks(message slack.Message) []string {
 	var sentJSONBlocks []string
 	_, values, err := slack.UnsafeApplyMsgOptions(
@@ -180,16 +382,19 @@ func parseSentJSONBlocks(message slack.Message) []string {
 	return sentJSONBlocks
 }
 
-func (c *client) fetchChannels(types []string) ([]slack.Channel, error) {
+// Each page of channels is fetched within the request timeout.
+func (c *client) fetchChannels(ctx context.Context, types []string) ([]slack.Channel, error) {
 	channels, cursor := []slack.Channel{}, ""
 
 	for {
-		result, nextCursor, err := c.slackAPI.GetConversations(&slack.GetConversationsParameters{
+		callCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+		result, nextCursor, err := c.slackAPI.GetConversationsContext(callCtx, &slack.GetConversationsParameters{
 			Limit:           999,
 			Cursor:          cursor,
 			Types:           types,
 			ExcludeArchived: true,
 		})
+		cancel()
 		if err != nil {
 			return nil, err
 		}
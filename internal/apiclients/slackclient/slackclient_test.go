@@ -1,9 +1,14 @@
 package slackclient_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/slack-go/slack"
 
@@ -105,7 +110,7 @@ func TestGetChannelIDByName(t *testing.T) {
 			}
 			client := slackclient.NewClient(mockAPI)
 
-			channelID, err := client.GetChannelIDByName(tt.channelName)
+			channelID, err := client.GetChannelIDByName(context.Background(), tt.channelName)
 
 			if tt.expectedError != "" {
 				if err == nil {
@@ -136,9 +141,19 @@ type mockSlackAPI struct {
 	publicChannelsError  error
 	privateChannelsError error
 	deleteMessageError   error
+	messages             []slack.Message
+	historyPageSize      int // if set, the messages are returned in pages of this size
+	historyCalls         int
+	historyError         error
+	users                []slack.User
+	dndStatuses          map[string]slack.DNDStatus
+	dndRequests          [][]string
+	hangUntilCanceled    bool // PostMessageContext blocks until the context is done
 }
 
-func (m *mockSlackAPI) GetConversations(params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+func (m *mockSlackAPI) GetConversationsContext(
+	_ context.Context, params *slack.GetConversationsParameters,
+) ([]slack.Channel, string, error) {
 	if len(params.Types) == 1 {
 		switch params.Types[0] {
 		case "public_channel":
@@ -157,21 +172,222 @@ func (m *mockSlackAPI) GetConversations(params *slack.GetConversationsParameters
 	return nil, "", errors.New("unexpected channel types requested")
 }
 
-func (m *mockSlackAPI) PostMessage(channelID string, options ...slack.MsgOption) (string, string, error) {
+func (m *mockSlackAPI) GetConversationInfoContext(
+	_ context.Context, input *slack.GetConversationInfoInput,
+) (*slack.Channel, error) {
+	for _, channel := range append(m.publicChannels, m.privateChannels...) {
+		if channel.ID == input.ChannelID {
+			return &channel, nil
+		}
+	}
+	return nil, errors.New("channel_not_found")
+}
+
+func (m *mockSlackAPI) PostMessageContext(
+	ctx context.Context, channelID string, options ...slack.MsgOption,
+) (string, string, error) {
+	if m.hangUntilCanceled {
+		<-ctx.Done()
+		return "", "", ctx.Err()
+	}
 	return "timestamp", channelID, nil
 }
 
-func (m *mockSlackAPI) UpdateMessage(channelID string, timestamp string, options ...slack.MsgOption) (string, string, string, error) {
+func (m *mockSlackAPI) UpdateMessageContext(_ context.Context, channelID string, timestamp string, options ...slack.MsgOption) (string, string, string, error) {
 	return channelID, timestamp, "updated_timestamp", nil
 }
 
-func (m *mockSlackAPI) DeleteMessage(channelID string, timestamp string) (string, string, error) {
+func (m *mockSlackAPI) DeleteMessageContext(_ context.Context, channelID string, timestamp string) (string, string, error) {
 	if m.deleteMessageError != nil {
 		return "", "", m.deleteMessageError
 	}
 	return channelID, timestamp, nil
 }
 
+func (m *mockSlackAPI) GetConversationHistoryContext(
+	_ context.Context, params *slack.GetConversationHistoryParameters,
+) (*slack.GetConversationHistoryResponse, error) {
+	m.historyCalls++
+	if m.historyError != nil {
+		return nil, m.historyError
+	}
+	if m.historyPageSize == 0 {
+		return &slack.GetConversationHistoryResponse{Messages: m.messages}, nil
+	}
+	start, _ := strconv.Atoi(params.Cursor)
+	end := min(start+m.historyPageSize, len(m.messages))
+	response := &slack.GetConversationHistoryResponse{Messages: m.messages[start:end], HasMore: end < len(m.messages)}
+	if response.HasMore {
+		response.ResponseMetaData.NextCursor = strconv.Itoa(end)
+	}
+	return response, nil
+}
+
+func (m *mockSlackAPI) GetUsersContext(_ context.Context, _ ...slack.GetUsersOption) ([]slack.User, error) {
+	return m.users, nil
+}
+
+func (m *mockSlackAPI) GetDNDTeamInfoContext(_ context.Context, users []string) (map[string]slack.DNDStatus, error) {
+	m.dndRequests = append(m.dndRequests, users)
+	return m.dndStatuses, nil
+}
+
+func (m *mockSlackAPI) GetUserInfoContext(_ context.Context, user string) (*slack.User, error) {
+	for _, u := range m.users {
+		if u.ID == user {
+			return &u, nil
+		}
+	}
+	return nil, slack.SlackErrorResponse{Err: "user_not_found"}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	client := slackclient.NewClient(&mockSlackAPI{hangUntilCanceled: true})
+	client.SetRequestTimeout(10 * time.Millisecond)
+
+	_, err := client.SendMessage(context.Background(), "C123", slack.NewBlockMessage(), "summary")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the request to time out, got: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.SetRequestTimeout(slackclient.DefaultRequestTimeout)
+	err = client.SendReply(ctx, "C123", "1700000000.000100", "reply")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the request to be canceled, got: %v", err)
+	}
+}
+
+func TestGetRecentMessages(t *testing.T) {
+	messages := []slack.Message{{Msg: slack.Msg{Text: "CI failed", Timestamp: "1700000000.000100"}}}
+	client := slackclient.NewClient(&mockSlackAPI{messages: messages})
+	result, err := client.GetRecentMessages(context.Background(), "C123")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(result) != 1 || result[0].Text != "CI failed" {
+		t.Errorf("Expected the messages of the channel, got %v", result)
+	}
+
+	client = slackclient.NewClient(&mockSlackAPI{historyError: errors.New("missing_scope")})
+	if _, err := client.GetRecentMessages(context.Background(), "C123"); err == nil || !strings.Contains(err.Error(), "missing_scope") {
+		t.Errorf("Expected an error containing missing_scope, got: %v", err)
+	}
+}
+
+func TestGetRecentMessages_Pages(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		messageCount         int
+		expectedMessageCount int
+		expectedHistoryCalls int
+	}{
+		{name: "all pages", messageCount: 450, expectedMessageCount: 450, expectedHistoryCalls: 3},
+		{
+			name:                 "up to the limit",
+			messageCount:         slackclient.RecentMessagesLimit + 300,
+			expectedMessageCount: slackclient.RecentMessagesLimit,
+			expectedHistoryCalls: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			messages := make([]slack.Message, tc.messageCount)
+			for i := range messages {
+				messages[i] = slack.Message{Msg: slack.Msg{Text: "message " + strconv.Itoa(i)}}
+			}
+			mockAPI := &mockSlackAPI{messages: messages, historyPageSize: 200}
+			result, err := slackclient.NewClient(mockAPI).GetRecentMessages(context.Background(), "C123")
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(result) != tc.expectedMessageCount {
+				t.Errorf("Expected %d messages, got %d", tc.expectedMessageCount, len(result))
+			}
+			if result[len(result)-1].Text != "message "+strconv.Itoa(tc.expectedMessageCount-1) {
+				t.Errorf("Expected the messages in order, got '%s' last", result[len(result)-1].Text)
+			}
+			if mockAPI.historyCalls != tc.expectedHistoryCalls {
+				t.Errorf("Expected %d calls, got %d", tc.expectedHistoryCalls, mockAPI.historyCalls)
+			}
+		})
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	users := []slack.User{
+		{ID: "U1", Name: "alice"},
+		{ID: "U2", Name: "deleted", Deleted: true},
+		{ID: "B1", Name: "bot", IsBot: true},
+		{ID: "A1", Name: "app", IsAppUser: true},
+		{ID: "USLACKBOT", Name: "slackbot"},
+	}
+	client := slackclient.NewClient(&mockSlackAPI{users: users})
+	result, err := client.ListUsers(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(result) != 1 || result[0].ID != "U1" {
+		t.Errorf("Expected only the active human users, got %v", result)
+	}
+}
+
+func TestGetUsersOnDND(t *testing.T) {
+	now := int(time.Now().Unix())
+	mockAPI := &mockSlackAPI{dndStatuses: map[string]slack.DNDStatus{
+		"U1": {SnoozeInfo: slack.SnoozeInfo{SnoozeEnabled: true, SnoozeEndTime: now + 3600}},
+		"U2": {SnoozeInfo: slack.SnoozeInfo{SnoozeEnabled: true, SnoozeEndTime: now - 3600}},
+		"U3": {Enabled: true, NextStartTimestamp: now - 3600, NextEndTimestamp: now + 3600},
+		"U4": {Enabled: true, NextStartTimestamp: now + 3600, NextEndTimestamp: now + 7200},
+		"U5": {},
+	}}
+	userIDs := []string{"U1", "U2", "U3", "U4", "U5"}
+	for i := range slackclient.DNDUsersBatchSize {
+		userIDs = append(userIDs, fmt.Sprintf("U%d", 100+i))
+	}
+	client := slackclient.NewClient(mockAPI)
+
+	result, err := client.GetUsersOnDND(context.Background(), userIDs)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !slices.Equal(result, []string{"U1", "U3"}) {
+		t.Errorf("Expected the snoozed user and the user within DND hours, got %v", result)
+	}
+	if len(mockAPI.dndRequests) != 2 {
+		t.Errorf("Expected the statuses to be fetched in 2 batches, got %d", len(mockAPI.dndRequests))
+	}
+}
+
+func TestGetChannelNameByID(t *testing.T) {
+	mockAPI := &mockSlackAPI{
+		publicChannels: []slack.Channel{
+			{GroupConversation: slack.GroupConversation{Name: "general", Conversation: slack.Conversation{ID: "C12345"}}},
+		},
+		privateChannels: []slack.Channel{
+			{GroupConversation: slack.GroupConversation{Name: "private-reviews", Conversation: slack.Conversation{ID: "C67890"}}},
+		},
+	}
+	client := slackclient.NewClient(mockAPI)
+
+	for channelID, expectedName := range map[string]string{"C12345": "general", "C67890": "private-reviews"} {
+		name, err := client.GetChannelNameByID(context.Background(), channelID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if name != expectedName {
+			t.Errorf("Expected channel name %q, got %q", expectedName, name)
+		}
+	}
+
+	_, err := client.GetChannelNameByID(context.Background(), "C00000")
+	if err == nil || !strings.Contains(err.Error(), "failed to get channel info") {
+		t.Errorf("Expected error about failing to get channel info, got %v", err)
+	}
+}
+
 func TestSendMessage(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -203,7 +419,7 @@ func TestSendMessage(t *testing.T) {
 			blocks := make([]slack.Block, tt.blocksCount)
 			message := slack.NewBlockMessage(blocks...)
 
-			_, err := client.SendMessage(tt.channelID, message, tt.summaryText)
+			_, err := client.SendMessage(context.Background(), tt.channelID, message, tt.summaryText)
 
 			if tt.expectedError != "" {
 				if err == nil {
@@ -244,7 +460,7 @@ func TestUpdateMessage(t *testing.T) {
 
 			blocks := slack.NewBlockMessage()
 
-			_, err := client.UpdateMessage(tt.channelID, tt.messageTS, blocks, tt.summaryText)
+			_, err := client.UpdateMessage(context.Background(), tt.channelID, tt.messageTS, blocks, tt.summaryText)
 
 			if tt.expectedError != "" {
 				if err == nil {
@@ -298,7 +514,7 @@ func TestDeleteMessage(t *testing.T) {
 			}
 			client := slackclient.NewClient(mockAPI)
 
-			err := client.DeleteMessage(tt.channelID, tt.messageTS)
+			err := client.DeleteMessage(context.Background(), tt.channelID, tt.messageTS)
 
 			if tt.expectedError != "" {
 				if err == nil {
@@ -315,3 +531,56 @@ func TestDeleteMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestDryRunClient(t *testing.T) {
+	// the API would hang on posting and fail on deleting, so the calls must not reach it
+	mockAPI := &mockSlackAPI{
+		hangUntilCanceled:  true,
+		deleteMessageError: errors.New("should not be called"),
+		publicChannels: []slack.Channel{{
+			GroupConversation: slack.GroupConversation{Name: "general", Conversation: slack.Conversation{ID: "C1"}},
+		}},
+	}
+	client := slackclient.NewDryRunClient(slackclient.NewClient(mockAPI), nil)
+	message := slack.NewBlockMessage(slack.NewDividerBlock())
+
+	channelID, err := client.GetChannelIDByName(context.Background(), "general")
+	if err != nil || channelID != "C1" {
+		t.Fatalf("Expected the channel to be found with the wrapped client, got %q, %v", channelID, err)
+	}
+	info, err := client.SendMessage(context.Background(), "C1", message, "Test summary")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if info.ChannelID != "C1" || info.SummaryText != "Test summary" || len(info.JSONBlocks) != 1 {
+		t.Errorf("Expected the info of the message that would be sent, got %+v", info)
+	}
+	if _, err := client.UpdateMessage(context.Background(), "C1", "123.456", message, "Test summary"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := client.DeleteMessage(context.Background(), "C1", "123.456"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := client.SendReply(context.Background(), "C1", "123.456", "reply"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if _, err := client.SendThreadReply(context.Background(), "C1", "123.456", message, "Test summary"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestFindInvalidUserIDs(t *testing.T) {
+	users := []slack.User{
+		{ID: "U1", Name: "alice"},
+		{ID: "U2", Name: "bob", Deleted: true},
+	}
+	client := slackclient.NewClient(&mockSlackAPI{users: users})
+
+	result, err := client.FindInvalidUserIDs(context.Background(), []string{"U1", "U2", "U3"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !slices.Equal(result, []string{"U2", "U3"}) {
+		t.Errorf("Expected the deactivated and the unknown user, got %v", result)
+	}
+}
@@ -0,0 +1,68 @@
+// Package clock provides the current time of the run. The time can be fixed (see Fix), so that
+// the content of the messages (e.g. the ages of the PRs) and the sent blocks are reproducible,
+// e.g. in golden-file tests or when comparing the outputs of runs.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time, e.g. for computing the ages of the PRs.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+type fixedClock struct {
+	t time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.t
+}
+
+// Fixed returns a clock that always returns t as the current time.
+func Fixed(t time.Time) Clock {
+	return fixedClock{t: t}
+}
+
+var (
+	mu      sync.RWMutex
+	current Clock = systemClock{}
+)
+
+// Default returns the clock of the run: the system clock, or a fixed clock if the time is fixed.
+func Default() Clock {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Now returns the current time of the default clock.
+func Now() time.Time {
+	return Default().Now()
+}
+
+// Since returns the time elapsed since t, i.e. Now().Sub(t).
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+// Fix fixes the time of the default clock to t until the returned function is called.
+func Fix(t time.Time) (restore func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	previous := current
+	current = Fixed(t)
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		current = previous
+	}
+}
@@ -0,0 +1,37 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/clock"
+)
+
+func TestFix(t *testing.T) {
+	fixedAt := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	restore := clock.Fix(fixedAt)
+	if now := clock.Now(); !now.Equal(fixedAt) {
+		t.Errorf("Expected the fixed time %v, got %v", fixedAt, now)
+	}
+	if since := clock.Since(fixedAt.Add(-time.Hour)); since != time.Hour {
+		t.Errorf("Expected an hour since the fixed time, got %v", since)
+	}
+
+	restore()
+	if since := clock.Since(time.Now()); since < 0 || since > time.Minute {
+		t.Errorf("Expected the current time after restoring, got %v since now", since)
+	}
+}
+
+func TestFixed(t *testing.T) {
+	fixedAt := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	clk := clock.Fixed(fixedAt)
+
+	if now := clk.Now(); !now.Equal(fixedAt) {
+		t.Errorf("Expected the fixed time %v, got %v", fixedAt, now)
+	}
+	if now := clock.Default().Now(); now.Equal(fixedAt) {
+		t.Errorf("Expected the default clock not to be affected by a fixed clock, got %v", now)
+	}
+}
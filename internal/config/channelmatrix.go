@@ -0,0 +1,65 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// ChannelMatrixEntry is a Slack channel and the filters of the PRs to post to it. The filters
+// are applied in addition to the filters and repository-filters inputs.
+type ChannelMatrixEntry struct {
+	SlackChannelID   string  `json:"slack-channel-id,omitempty"`
+	SlackChannelName string  `json:"slack-channel-name,omitempty"`
+	Filters          Filters `json:"filters"`
+}
+
+// GetChannel returns the ID or (if the ID is not set) the name of the channel, e.g. for logging.
+func (e ChannelMatrixEntry) GetChannel() string {
+	if e.SlackChannelID != "" {
+		return e.SlackChannelID
+	}
+	return e.SlackChannelName
+}
+
+func GetChannelMatrixFromInput(input string) ([]ChannelMatrixEntry, error) {
+	entries, err := parseChannelMatrix(inputhelpers.GetInput(input))
+	if err != nil {
+		return nil, fmt.Errorf("error reading input %s: %w", input, err)
+	}
+	return entries, nil
+}
+
+func parseChannelMatrix(rawMatrix string) ([]ChannelMatrixEntry, error) {
+	if rawMatrix == "" {
+		return []ChannelMatrixEntry{}, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(rawMatrix)))
+	dec.DisallowUnknownFields()
+	var entries []ChannelMatrixEntry
+	if err := dec.Decode(&entries); err != nil {
+		return nil, fmt.Errorf("unable to parse channel matrix: %v", err)
+	}
+	for i := range entries {
+		entry := &entries[i]
+		var err error
+		entry.SlackChannelID, entry.SlackChannelName, err = normalizeSlackChannel(entry.SlackChannelID, entry.SlackChannelName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel matrix entry at index %d: %v", i, err)
+		}
+		if err := entry.validate(); err != nil {
+			return nil, fmt.Errorf("invalid channel matrix entry at index %d: %v", i, err)
+		}
+	}
+	return entries, nil
+}
+
+func (e ChannelMatrixEntry) validate() error {
+	if e.SlackChannelID == "" && e.SlackChannelName == "" {
+		return fmt.Errorf("either slack-channel-id or slack-channel-name must be set")
+	}
+	return e.Filters.validate()
+}
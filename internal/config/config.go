@@ -9,8 +9,13 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+	"github.com/hellej/pr-slack-reminder-action/internal/githubevent"
 
 	"github.com/hellej/pr-slack-reminder-action/internal/models"
 	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
@@ -18,30 +23,153 @@ import (
 
 const (
 	EnvGithubRepository        string = "GITHUB_REPOSITORY"
+	EnvGithubEventPath         string = "GITHUB_EVENT_PATH"
+	EnvGithubServerURL         string = "GITHUB_SERVER_URL"
+	EnvGithubRunID             string = "GITHUB_RUN_ID"
 	EnvSentSlackBlocksFilePath string = "SENT_SLACK_BLOCKS_FILE_PATH"
+	EnvSentSlackBlocksFormat   string = "SENT_SLACK_BLOCKS_FORMAT"
 	EnvStateFilePath           string = "STATE_FILE_PATH"
+	EnvGithubOutput            string = "GITHUB_OUTPUT"
+	EnvGithubStepSummary       string = "GITHUB_STEP_SUMMARY"
+	EnvFixedTime               string = "FIXED_TIME"
 
 	InputSlackBotToken               string = "slack-bot-token"
 	InputGithubToken                 string = "github-token"
 	InputGithubTokenForState         string = "github-token-for-state"
 	InputRunMode                     string = "run-mode"
 	InputStateArtifactName           string = "state-artifact-name"
+	InputStateArtifactBranch         string = "state-artifact-branch"
 	InputSlackChannelName            string = "slack-channel-name"
 	InputSlackChannelID              string = "slack-channel-id"
+	InputWorkspaceTargets            string = "workspace-targets"
 	InputGithubRepositories          string = "github-repositories"
 	InputGlobalFilters               string = "filters"
 	InputRepositoryFilters           string = "repository-filters"
 	InputSlackUserIdByGitHubUsername string = "github-user-slack-user-id-mapping"
+	InputSlackGroupIdByGitHubTeam    string = "github-team-slack-group-mapping"
 	InputPRListHeading               string = "pr-list-heading"
 	InputNoPRsMessage                string = "no-prs-message"
 	InputOldPRThresholdHours         string = "old-pr-threshold-hours"
 	InputGroupByRepository           string = "group-by-repository"
+	InputShowRunLink                 string = "show-run-link"
+	InputMetricsFilePath             string = "metrics-file-path"
+	InputMetricsFormat               string = "metrics-format"
+	InputSyncMaxMessageAgeHours      string = "sync-max-message-age-hours"
+	InputMessageTTLHours             string = "message-ttl-hours"
+	InputSkipArchivedRepos           string = "skip-archived-repos"
+	InputOnCallProvider              string = "oncall-provider"
+	InputOnCallScheduleID            string = "oncall-schedule-id"
+	InputOnCallAPIToken              string = "oncall-api-token"
+	InputOnCallSlackUserIdByEmail    string = "oncall-user-slack-user-id-mapping"
+	InputMessenger                   string = "messenger"
+	InputGoogleChatWebhookURL        string = "google-chat-webhook-url"
+	InputDiscordWebhookURL           string = "discord-webhook-url"
+	InputMatrixHomeserverURL         string = "matrix-homeserver-url"
+	InputMatrixAccessToken           string = "matrix-access-token"
+	InputMatrixRoomID                string = "matrix-room-id"
+	InputShowFailingWorkflows        string = "show-failing-workflows"
+	InputReleasePRTitlePattern       string = "release-pr-title-pattern"
+	InputReleasePRLabels             string = "release-pr-labels"
+	InputReviewSLAHours              string = "review-sla-hours"
+	InputReviewerLinkStyle           string = "reviewer-link-style"
+	InputOnUnknownRepo               string = "on-unknown-repo"
+	InputEnrichTopN                  string = "enrich-top-n"
+	InputShowFailingChecks           string = "show-failing-checks"
+	InputAuditBranchProtection       string = "audit-branch-protection"
+	InputShowRunReport               string = "show-run-report"
+	InputActionVersionCheck          string = "action-version-check"
+	InputValidateUnknownInputs       string = "validate-unknown-inputs"
+	InputTruncateKeep                string = "truncate-keep"
+	InputMinPRsPerRepo               string = "min-prs-per-repo"
+	InputShowReminderCount           string = "show-reminder-count"
+	InputSummaryTones                string = "summary-tones"
+	InputSeverityThresholds          string = "severity-thresholds"
+	InputAlwaysSaveState             string = "always-save-state"
+	InputPruneStateArtifacts         string = "prune-state-artifacts"
+	InputOnMissingState              string = "on-missing-state"
+	InputOnPRFetchError              string = "on-pr-fetch-error"
+	InputRepositoryAllowPattern      string = "repository-allow-pattern"
+	InputRepositoryDenyPattern       string = "repository-deny-pattern"
+	InputAllowedChannelPattern       string = "allowed-channel-pattern"
+	InputRepositoryOldPRThresholds   string = "repository-old-pr-threshold-hours"
+	InputHighlightAuthors            string = "highlight-authors"
+	InputReviewersIgnore             string = "reviewers-ignore"
+	InputBotAuthors                  string = "bot-authors"
+	InputHumanBots                   string = "human-bots"
+	InputShowCodeownerApproval       string = "show-codeowner-approval"
+	InputPRThreadMarker              string = "pr-thread-marker"
+	InputGroupBy                     string = "group-by"
+	InputLabelGroupOrder             string = "label-group-order"
+	InputGroupSort                   string = "group-sort"
+	InputMaxPRsPerRepo               string = "max-prs-per-repo"
+	InputShowQuietRepos              string = "show-quiet-repos"
+	InputSlackTimeoutSeconds         string = "slack-timeout-seconds"
+	InputStateSigningKey             string = "state-signing-key"
+	InputDryRun                      string = "dry-run"
+	InputPreviewFilePath             string = "preview-file-path"
+	InputReferenceTime               string = "reference-time"
+	InputTeamMembers                 string = "team-members"
+	InputTeamMembersMatch            string = "team-members-match"
+	InputShowReviewDecision          string = "show-review-decision"
+	InputStrictApprovals             string = "strict-approvals"
+	InputShowMergeQueue              string = "show-merge-queue"
+	InputReviewerThreads             string = "reviewer-threads"
+	InputSuggestedMappingFilePath    string = "suggested-mapping-file-path"
+	InputChannelMatrix               string = "channel-matrix"
+	InputPRDataCache                 string = "pr-data-cache"
+	InputPRDataCacheFilePath         string = "pr-data-cache-file-path"
+	InputPRDataCacheArtifact         string = "pr-data-cache-artifact-name"
+	InputEscalationChannel           string = "escalation-channel"
+	InputEscalationOldPRCount        string = "escalation-old-pr-count"
+	InputRespectDND                  string = "respect-dnd"
+	InputShowFilteredCount           string = "show-filtered-count"
+	InputPRCountsStepSummary         string = "pr-counts-step-summary"
+	InputSplitMessagesByRepo         string = "split-messages-by-repo"
+	InputShowRecentlyMergedHours     string = "show-recently-merged-hours"
+	InputShowResolvedPRs             string = "show-resolved-prs"
+	InputShowReviewEffort            string = "show-review-effort"
+	InputMaxAPICalls                 string = "max-api-calls"
+	InputShowQuickLinks              string = "show-quick-links"
+	InputCurrentRepository           string = "current-repository"
+	InputRepositoryTokens            string = "repository-tokens"
+	InputRedactSecretsInTitles       string = "redact-secrets-in-titles"
+	InputMessageStyle                string = "message-style"
+	InputOnFailureWebhook            string = "on-failure-webhook"
+	InputGlobalFiltersFile           string = "filters-file"
+	InputRepositoryFiltersFile       string = "repository-filters-file"
+	InputSlackUserIdMappingFile      string = "github-user-slack-user-id-mapping-file"
+	InputSlackGroupMappingFile       string = "github-team-slack-group-mapping-file"
+	InputShowReviewResponseTime      string = "show-review-response-time"
+	InputShowSilentReviewers         string = "show-silent-reviewers"
+	InputValidateUserMapping         string = "validate-user-mapping"
+	InputShowCommentCount            string = "show-comment-count"
+	InputRepoHeadingPrefixes         string = "repo-heading-prefixes"
+	InputIgnoredReviewers            string = "ignored-reviewers"
 
 	MaxRepositories int = 30
 
 	DefaultRunMode                 = RunModePost
+	DefaultMessenger               = MessengerSlack
+	DefaultReviewerLinkStyle       = ReviewerLinkStylePlain
+	DefaultUnknownRepoPolicy       = UnknownRepoKeep
+	DefaultMissingStatePolicy      = MissingStateFail
+	DefaultPRFetchErrorPolicy      = PRFetchErrorFail
+	DefaultUnknownInputsPolicy     = UnknownInputsIgnore
+	DefaultTeamMembersMatch        = TeamMembersMatchAuthorOrReviewer
+	DefaultTruncateKeep            = TruncateKeepOldest
+	DefaultGroupSort               = GroupSortOldestFirst
+	DefaultQuietRepos              = QuietReposHide
 	DefaultStateFilePath           = "pr-slack-reminder-state.json"
 	DefaultSentSlackBlocksFilePath = "pr-slack-reminder-sent-blocks.json"
+	DefaultPreviewFilePath         = "pr-slack-reminder-preview.html"
+	DefaultPRDataCacheFilePath     = "pr-slack-reminder-pr-data.json"
+	DefaultPRDataCacheArtifact     = "pr-slack-reminder-pr-data"
+	DefaultSuggestedMappingPath    = "suggested-user-mapping.txt"
+	DefaultSentSlackBlocksFormat   = SentBlocksFormatEnvelope
+	DefaultGithubServerURL         = "https://github.com"
+	DefaultMetricsFormat           = MetricsFormatJSON
+	DefaultSyncMaxMessageAgeHours  = 24
+	DefaultSlackTimeoutSeconds     = 30
 )
 
 type Config struct {
@@ -49,31 +177,194 @@ type Config struct {
 	GithubToken         string
 	GithubTokenForState string
 
+	Messenger            Messenger
+	GoogleChatWebhookURL string
+	DiscordWebhookURL    string
+	Matrix               MatrixInputs
+	// Webhook (e.g. a Slack incoming webhook) notified if the run fails (empty = disabled)
+	OnFailureWebhookURL string
+
 	RunMode                 RunMode
 	StateArtifactName       string
+	StateArtifactBranch     string // empty for the default branch of the current repository
 	StateFilePath           string
+	StateSigningKey         string // if set, states without a valid signature are not loaded
 	SentSlackBlocksFilePath string
+	SentSlackBlocksFormat   SentBlocksFormat
+	GithubEventPath         string
+	GithubOutputFilePath    string // the effective configuration is written to it as the config output
+	MetricsFilePath         string
+	MetricsFormat           MetricsFormat
+	// The table of the PR counts of the repositories is appended to it if PRCountsStepSummary is set
+	StepSummaryFilePath string
+	PRCountsStepSummary bool
+	// Sharing the fetched PRs with the later jobs of the workflow run (off by default)
+	PRDataCache PRDataCacheInputs
+	// Posting the old PRs to an additional channel if there are too many of them (off by default)
+	Escalation EscalationInputs
+	// If set, used as the current time of the run, so that the sent blocks are reproducible
+	FixedTime time.Time
+	// Key of the workflow run and its date (empty if not run in a workflow), saved to the state, so that
+	// re-running the same run updates the posted message instead of posting a duplicate (in post mode)
+	IdempotencyKey string
+	// If set, the ages of the PRs are computed at this time instead of the current time
+	// (e.g. for previewing what a reminder would have looked like at the time)
+	ReferenceTime time.Time
+	// Log the Slack messages instead of sending them, and render them to an HTML preview file
+	DryRun          bool
+	PreviewFilePath string
+	// The file to which the suggested user mapping is written in the suggest-mapping run mode
+	SuggestedMappingPath string
+	// Save the state even if no message is posted (no PRs and no no-prs-message)
+	AlwaysSaveState bool
+	// The number of the latest state artifacts to keep after a successful run (0 = old ones are not deleted)
+	PruneStateArtifacts int
+	// In sync mode, messages older than this are not updated but a new message is posted instead
+	SyncMaxMessageAgeHours int
+	// In sync and update modes, messages older than this are deleted and posted again (0 = disabled)
+	MessageTTLHours int
 
 	SlackChannelName string
 	SlackChannelID   string
+	WorkspaceTargets []WorkspaceTarget
+	// Channels to post to in parallel with the filters of each (post mode), sharing the fetched PRs
+	ChannelMatrix []ChannelMatrixEntry
+	// Regular expression that the names of the Slack channels must match before posting (empty = any)
+	AllowedChannelPattern string
+	// Text identifying existing messages about a PR in the channel (post mode), of which the threads
+	// the PR is reminded about instead of the main reminder (empty = disabled)
+	PRThreadMarker string
+	// Timeout of each Slack API call, so that a hanging call cannot stall the whole run
+	SlackRequestTimeout time.Duration
 
+	// Zero if not known (only when github-repositories is set and the artifacts are not used)
 	CurrentRepository models.Repository
 	Repositories      []models.Repository
+	// Tokens of the repositories that are not fetched with the GitHub token (e.g. of other organizations),
+	// left out of the printed configuration and the config output
+	RepositoryTokens  map[models.Repository]string `json:"-"`
+	SkipArchivedRepos bool
+	// Patterns that the repositories must (not) match, validated against the resolved repositories
+	RepositoryPatterns RepositoryPatterns
+	// Only the N oldest PRs are enriched with reviews and comments (0 = all)
+	EnrichTopN int
+	// The enrichment of the PRs is skipped when the run would make more GitHub API calls (0 = unlimited)
+	MaxAPICalls int
+	// How PRs in state from repositories that are no longer configured are handled (update mode)
+	OnUnknownRepo UnknownRepoPolicy
+	// What the update mode does when no state artifact is found
+	OnMissingState MissingStatePolicy
+	// What the update mode does when fetching a PR of the state fails
+	OnPRFetchError PRFetchErrorPolicy
+	// Which PRs are kept when more open PRs are found than can be included in the message
+	TruncateKeep TruncateKeep
+	// The number of PRs of each repository that are kept first when the PRs are truncated
+	MinPRsPerRepository int
+	// If set, only the PRs of these team members are included (e.g. one config per team channel)
+	TeamMembers TeamMembers
+	// What is done about INPUT_ environment variables that do not match any input
+	ValidateUnknownInputs UnknownInputsPolicy
+	// Show workflows failing on the default branches of the repositories after the PR lists
+	ShowFailingWorkflows bool
+	// List the PRs merged in the last hours after the PR lists (0 if not listed)
+	ShowRecentlyMergedHours int
+	// Summarize how the PRs of the previous reminder that are no longer open were resolved
+	ShowResolvedPRs bool
+	// Show how many PRs of each repository are blocked by failing checks (in the repository headings)
+	ShowFailingChecks bool
+	// Show whether a code owner of the changed files (from CODEOWNERS) has approved the PRs
+	ShowCodeownerApproval bool
+	// Show the review decision of the PRs (from the GraphQL API) instead of deriving it from the reviews only
+	ShowReviewDecision bool
+	// Don't count approvals of older commits than the head commits of the PRs
+	StrictApprovals bool
+	// Mark the PRs that are in the merge queue
+	ShowMergeQueue bool
+	// Show the review effort of the PRs estimated from their size and comments (from the GraphQL API)
+	ShowReviewEffort bool
+	// Show how long the first review request of the PRs waited for a review (from the GraphQL API)
+	ShowReviewResponseTime bool
+	// Reply to the message in a thread per (mapped) reviewer, listing the PRs waiting for their review
+	ReviewerThreads bool
+	// Show the mapped users who currently have Slack Do Not Disturb on by name instead of mentioning them
+	RespectDND bool
+	// Warn about the GitHub users and Slack users of the user mapping that are not found (e.g. typos)
+	ValidateUserMapping bool
+	// Append the number of the open PRs left out by the filters to the summary
+	ShowFilteredCount bool
+	// Post one message per repository group if the PRs grouped by repository do not fit in one message
+	SplitMessagesByRepo bool
+	// Warn about repositories of which the default branch does not require PR reviews
+	AuditBranchProtection bool
+	// Show a summary of the non-fatal issues of the run (e.g. PRs that could not be fetched) in the message
+	ShowRunReport bool
+	// GitHub usernames treated as bots (reviews ignored, PRs not listed) regardless of their user type
+	BotAuthors []string
+	// GitHub usernames of type Bot whose reviews and comments are treated as those of humans
+	HumanBots []string
 
 	GlobalFilters     Filters
 	RepositoryFilters map[string]Filters
 	ContentInputs     ContentInputs
+	OnCall            OnCallInputs
 }
 
 type ContentInputs struct {
 	SlackUserIdByGitHubUsername map[string]string
+	SlackGroupIdByGitHubTeam    map[string]string
 	PRListHeading               string
 	NoPRsMessage                string
-	OldPRThresholdHours         int
+	OldPRThresholdHours         int // the lowest threshold if several are given
 	GroupByRepository           bool
+	// All old PR thresholds in ascending order if several are given (tiers of escalating markers),
+	// otherwise empty
+	OldPRThresholdTiers []int
+	// Repository specific overrides of OldPRThresholdHours by repository name or owner/repo path
+	OldPRThresholdHoursByRepo map[string]int
+	// Prefixes (e.g. team emojis) of the headings of the repositories by repository name or owner/repo path
+	HeadingPrefixByRepo map[string]string
+	// Labels by which the PRs are grouped (group-by: label) in this order, empty if not grouped by label
+	GroupByLabels []string
+	// Order of the PRs within the groups (by repository or label)
+	GroupSort GroupSort
+	// Maximum number of PRs listed per repository (when grouped by repository), 0 = no limit
+	MaxPRsPerRepository int
+	// How the repositories without open PRs are shown (if there are PRs in other repositories)
+	ShowQuietRepos QuietRepos
+	// PRs matching the title pattern (regular expression) or having any of the labels
+	// are listed separately as pending releases at the top of the message
+	ReleasePRTitlePattern string
+	ReleasePRLabels       []string
+	// Hours within which PRs should get their first review (0 disables SLA tracking)
+	ReviewSLAHours int
+	// How approvers and commenters are shown in Slack messages
+	ReviewerLinkStyle ReviewerLinkStyle
+	// GitHub usernames that are never shown as approvers or commenters
+	IgnoredReviewers []string
+	// Show how many consecutive reminders PRs have been included in, e.g. "(3rd reminder)"
+	ShowReminderCount bool
+	// Show links to the "Files changed" and "Checks" tabs after the PRs
+	ShowQuickLinks bool
+	// Show the requested reviewers who have not reviewed or commented yet after the approvers and commenters
+	ShowSilentReviewers bool
+	// Show the number of review and issue comments on the PRs, e.g. "(12 comments)"
+	ShowCommentCount bool
+	// Redact strings resembling secrets (e.g. tokens) from the titles of the PRs
+	RedactSecretsInTitles bool
+	// Whether the PRs are listed or summarized per repository (digest)
+	MessageStyle MessageStyle
+	// GitHub usernames of authors whose PRs are highlighted (not a filter, all PRs are still shown)
+	HighlightAuthors []string
+	// Summary texts, heading emojis and colors chosen by the size of the backlog
+	SummaryTones SummaryTones
+	// Old PR counts at which the color bar of the message turns yellow and red (nil = no color bar)
+	SeverityThresholds *SeverityThresholds
+	// Set only if show-run-link is enabled
+	WorkflowRunURL string
 }
 
-func (c Config) Print() {
+// Redacted returns a copy of the configuration with the secrets masked.
+func (c Config) Redacted() Config {
 	copy := c
 	if copy.SlackBotToken != "" {
 		copy.SlackBotToken = "XXXXX"
@@ -84,38 +375,174 @@ func (c Config) Print() {
 	if copy.GithubTokenForState != "" {
 		copy.GithubTokenForState = "XXXXX"
 	}
-	asJson, _ := json.MarshalIndent(copy, "", "  ")
+	if copy.OnCall.APIToken != "" {
+		copy.OnCall.APIToken = "XXXXX"
+	}
+	if copy.GoogleChatWebhookURL != "" {
+		copy.GoogleChatWebhookURL = "XXXXX"
+	}
+	if copy.DiscordWebhookURL != "" {
+		copy.DiscordWebhookURL = "XXXXX"
+	}
+	if copy.Matrix.AccessToken != "" {
+		copy.Matrix.AccessToken = "XXXXX"
+	}
+	if copy.OnFailureWebhookURL != "" {
+		copy.OnFailureWebhookURL = "XXXXX"
+	}
+	if copy.StateSigningKey != "" {
+		copy.StateSigningKey = "XXXXX"
+	}
+	copy.WorkspaceTargets = utilities.Map(c.WorkspaceTargets, func(t WorkspaceTarget) WorkspaceTarget {
+		t.SlackBotToken = "XXXXX"
+		return t
+	})
+	return copy
+}
+
+func (c Config) Print() {
+	asJson, _ := json.MarshalIndent(c.Redacted(), "", "  ")
 	log.Print("Configuration:")
 	log.Println(string(asJson))
 }
 
+// GetConfigWithOverrides reads the configuration like GetConfig, but the inputs (by input name,
+// e.g. "github-token") and the environment variables in the maps override the environment.
+func GetConfigWithOverrides(inputs, env map[string]string) (Config, error) {
+	var cfg Config
+	var err error
+	inputhelpers.WithOverrides(inputs, env, func() {
+		cfg, err = getConfig()
+	})
+	return cfg, err
+}
+
+// GetConfig reads the configuration from the inputs and the environment variables of the action.
 func GetConfig() (Config, error) {
-	slackToken, err1 := inputhelpers.GetInputRequired(InputSlackBotToken)
+	return GetConfigWithOverrides(nil, nil)
+}
+
+func getConfig() (Config, error) {
+	// checked first, as the other inputs may not be understood by an older version of the action
+	if err := checkActionVersion(InputActionVersionCheck); err != nil {
+		return Config{}, err
+	}
+	inputhelpers.SetDeprecations(deprecatedInputs...)
+	inputhelpers.LogDeprecationWarnings()
+	// read before the other inputs, as the values of the files are parsed like the inputs
+	if err := inputhelpers.LoadFileInputs(fileInputs...); err != nil {
+		return Config{}, err
+	}
+	messenger, err19 := getMessenger(InputMessenger)
+	googleChatWebhookURL := inputhelpers.GetInput(InputGoogleChatWebhookURL)
+	discordWebhookURL := inputhelpers.GetInput(InputDiscordWebhookURL)
+	onFailureWebhookURL := inputhelpers.GetInput(InputOnFailureWebhook)
+	workspaceTargets, err12 := GetWorkspaceTargetsFromInput(InputWorkspaceTargets)
+	slackToken, err1 := getSlackBotToken(messenger)
 	githubToken, err2 := inputhelpers.GetInputRequired(InputGithubToken)
 	githubTokenForState := inputhelpers.GetInput(InputGithubTokenForState)
 
 	runMode, err3 := getRunMode(InputRunMode)
 	stateArtifactName := inputhelpers.GetInput(InputStateArtifactName)
+	stateArtifactBranch := inputhelpers.GetInput(InputStateArtifactBranch)
 	stateFilePath := cmp.Or(inputhelpers.GetEnv(EnvStateFilePath), DefaultStateFilePath)
+	stateSigningKey := inputhelpers.GetInput(InputStateSigningKey)
 	sentSlackBlocksFilePath := cmp.Or(
 		inputhelpers.GetEnv(EnvSentSlackBlocksFilePath), DefaultSentSlackBlocksFilePath,
 	)
 
-	slackChannelName := inputhelpers.GetInput(InputSlackChannelName)
-	slackChannelID := inputhelpers.GetInput(InputSlackChannelID)
-	repository, err4 := inputhelpers.GetEnvRequired(EnvGithubRepository)
-	currentRepository, err5 := models.ParseRepository(repository)
+	githubEventPath := inputhelpers.GetEnv(EnvGithubEventPath)
+	githubOutputFilePath := inputhelpers.GetEnv(EnvGithubOutput)
+	githubStepSummaryFilePath := inputhelpers.GetEnv(EnvGithubStepSummary)
+
+	slackChannelID, slackChannelName, err74 := normalizeSlackChannel(
+		inputhelpers.GetInput(InputSlackChannelID), inputhelpers.GetInput(InputSlackChannelName),
+	)
 	repositoryPaths := inputhelpers.GetInputList(InputGithubRepositories)
+	repository, err4 := getRepository(githubEventPath, len(repositoryPaths) == 0)
+	var currentRepository models.Repository
+	var err5 error
+	if repository != "" {
+		currentRepository, err5 = models.ParseRepository(repository)
+	}
 	globalFilters, err6 := GetGlobalFiltersFromInput(InputGlobalFilters)
 	repositoryFilters, err7 := GetRepositoryFiltersFromInput(InputRepositoryFilters)
-	slackUserIdByGitHubUsername, err8 := inputhelpers.GetInputMapping(InputSlackUserIdByGitHubUsername)
+	slackUserIdByGitHubUsername, err8 := getUserMapping(InputSlackUserIdByGitHubUsername)
 	prListHeading := inputhelpers.GetInput(InputPRListHeading)
 	noPRsMessage := inputhelpers.GetInput(InputNoPRsMessage)
-	oldPRsThresholdHours, err9 := inputhelpers.GetInputInt(InputOldPRThresholdHours)
+	oldPRsThresholdHours, oldPRThresholdTiers, err9 := getOldPRThresholds(InputOldPRThresholdHours)
 	groupByRepository, err10 := inputhelpers.GetInputBool(InputGroupByRepository)
+	slackGroupIdByGitHubTeam, err11 := inputhelpers.GetInputMapping(InputSlackGroupIdByGitHubTeam)
+	showRunLink, err13 := inputhelpers.GetInputBool(InputShowRunLink)
+	metricsFilePath := inputhelpers.GetInput(InputMetricsFilePath)
+	metricsFormat, err14 := getMetricsFormat(InputMetricsFormat)
+	syncMaxMessageAgeHours, err15 := inputhelpers.GetInputInt(InputSyncMaxMessageAgeHours)
+	messageTTLHours, err16 := inputhelpers.GetInputInt(InputMessageTTLHours)
+	skipArchivedRepos, err17 := inputhelpers.GetInputBoolOr(InputSkipArchivedRepos, true)
+	onCallInputs, err18 := getOnCallInputs()
+	showFailingWorkflows, err20 := inputhelpers.GetInputBool(InputShowFailingWorkflows)
+	reviewSLAHours, err21 := inputhelpers.GetInputInt(InputReviewSLAHours)
+	reviewerLinkStyle, err22 := getReviewerLinkStyle(InputReviewerLinkStyle)
+	sentSlackBlocksFormat, err23 := getSentBlocksFormat(EnvSentSlackBlocksFormat)
+	onUnknownRepo, err24 := getUnknownRepoPolicy(InputOnUnknownRepo)
+	enrichTopN, err25 := inputhelpers.GetInputInt(InputEnrichTopN)
+	showFailingChecks, err26 := inputhelpers.GetInputBool(InputShowFailingChecks)
+	auditBranchProtection, err27 := inputhelpers.GetInputBool(InputAuditBranchProtection)
+	showReminderCount, err28 := inputhelpers.GetInputBool(InputShowReminderCount)
+	summaryTones, err29 := getSummaryTones(InputSummaryTones)
+	severityThresholds, err30 := getSeverityThresholds(InputSeverityThresholds)
+	alwaysSaveState, err31 := inputhelpers.GetInputBool(InputAlwaysSaveState)
+	onMissingState, err32 := getMissingStatePolicy(InputOnMissingState)
+	repositoryPatterns, err33 := getRepositoryPatterns(InputRepositoryAllowPattern, InputRepositoryDenyPattern)
+	oldPRThresholdHoursByRepo, err34 := getOldPRThresholdHoursByRepo(InputRepositoryOldPRThresholds)
+	showCodeownerApproval, err35 := inputhelpers.GetInputBool(InputShowCodeownerApproval)
+	groupBy, err36 := getGroupBy(InputGroupBy, groupByRepository)
+	groupByLabels, err37 := getGroupByLabels(InputLabelGroupOrder, groupBy)
+	groupSort, err38 := getGroupSort(InputGroupSort)
+	maxPRsPerRepository, err39 := inputhelpers.GetInputInt(InputMaxPRsPerRepo)
+	showQuietRepos, err40 := getQuietRepos(InputShowQuietRepos)
+	slackTimeoutSeconds, err41 := inputhelpers.GetInputInt(InputSlackTimeoutSeconds)
+	pruneStateArtifacts, err42 := inputhelpers.GetInputInt(InputPruneStateArtifacts)
+	onPRFetchError, err43 := getPRFetchErrorPolicy(InputOnPRFetchError)
+	showRunReport, err44 := inputhelpers.GetInputBool(InputShowRunReport)
+	validateUnknownInputs, err45 := getUnknownInputsPolicy(InputValidateUnknownInputs)
+	truncateKeep, err46 := getTruncateKeep(InputTruncateKeep)
+	minPRsPerRepository, err47 := inputhelpers.GetInputInt(InputMinPRsPerRepo)
+	dryRun, err48 := inputhelpers.GetInputBool(InputDryRun)
+	fixedTime, err49 := getFixedTime(EnvFixedTime)
+	referenceTime, err50 := getReferenceTime(InputReferenceTime)
+	teamMembers, err51 := getTeamMembers(InputTeamMembers, InputTeamMembersMatch)
+	showReviewDecision, err52 := inputhelpers.GetInputBool(InputShowReviewDecision)
+	strictApprovals, err53 := inputhelpers.GetInputBool(InputStrictApprovals)
+	showMergeQueue, err54 := inputhelpers.GetInputBool(InputShowMergeQueue)
+	reviewerThreads, err55 := inputhelpers.GetInputBool(InputReviewerThreads)
+	channelMatrix, err56 := GetChannelMatrixFromInput(InputChannelMatrix)
+	prDataCache, err57 := getPRDataCacheInputs()
+	escalation, err58 := getEscalationInputs()
+	respectDND, err59 := inputhelpers.GetInputBool(InputRespectDND)
+	showFilteredCount, err60 := inputhelpers.GetInputBool(InputShowFilteredCount)
+	prCountsStepSummary, err61 := inputhelpers.GetInputBool(InputPRCountsStepSummary)
+	splitMessagesByRepo, err62 := inputhelpers.GetInputBool(InputSplitMessagesByRepo)
+	showRecentlyMergedHours, err63 := inputhelpers.GetInputInt(InputShowRecentlyMergedHours)
+	showResolvedPRs, err64 := inputhelpers.GetInputBool(InputShowResolvedPRs)
+	showReviewEffort, err65 := inputhelpers.GetInputBool(InputShowReviewEffort)
+	maxAPICalls, err66 := inputhelpers.GetInputInt(InputMaxAPICalls)
+	showQuickLinks, err67 := inputhelpers.GetInputBool(InputShowQuickLinks)
+	repositoryTokens, err68 := getRepositoryTokens(InputRepositoryTokens)
+	redactSecretsInTitles, err69 := inputhelpers.GetInputBool(InputRedactSecretsInTitles)
+	messageStyle, err70 := getMessageStyle(InputMessageStyle)
+	showReviewResponseTime, err71 := inputhelpers.GetInputBool(InputShowReviewResponseTime)
+	showSilentReviewers, err72 := inputhelpers.GetInputBool(InputShowSilentReviewers)
+	validateUserMapping, err73 := inputhelpers.GetInputBool(InputValidateUserMapping)
+	showCommentCount, err75 := inputhelpers.GetInputBool(InputShowCommentCount)
+	headingPrefixByRepo, err76 := getHeadingPrefixByRepo(InputRepoHeadingPrefixes)
 
 	if err := errors.Join(
-		err1, err2, err3, err4, err5, err6, err7, err8, err9, err10,
+		err1, err2, err3, err4, err5, err6, err7, err8, err9, err10, err11, err12, err13, err14, err15, err16, err17, err18,
+		err19, err20, err21, err22, err23, err24, err25, err26, err27, err28, err29, err30, err31, err32, err33, err34,
+		err35, err36, err37, err38, err39, err40, err41, err42, err43, err44, err45, err46, err47, err48, err49, err50, err51, err52, err53, err54,
+		err55, err56, err57, err58, err59, err60, err61, err62, err63, err64, err65, err66, err67, err68, err69, err70, err71, err72, err73, err74,
+		err75, err76,
 	); err != nil {
 		return Config{}, err
 	}
@@ -135,23 +562,114 @@ func GetConfig() (Config, error) {
 		SlackBotToken:           slackToken,
 		GithubToken:             githubToken,
 		GithubTokenForState:     githubTokenForState,
+		Messenger:               messenger,
+		GoogleChatWebhookURL:    googleChatWebhookURL,
+		DiscordWebhookURL:       discordWebhookURL,
+		Matrix:                  getMatrixInputs(),
+		OnFailureWebhookURL:     onFailureWebhookURL,
 		RunMode:                 runMode,
 		StateArtifactName:       stateArtifactName,
+		StateArtifactBranch:     stateArtifactBranch,
 		StateFilePath:           stateFilePath,
+		StateSigningKey:         stateSigningKey,
+		AlwaysSaveState:         alwaysSaveState,
+		PruneStateArtifacts:     pruneStateArtifacts,
 		SentSlackBlocksFilePath: sentSlackBlocksFilePath,
+		SentSlackBlocksFormat:   sentSlackBlocksFormat,
+		GithubEventPath:         githubEventPath,
+		GithubOutputFilePath:    githubOutputFilePath,
+		StepSummaryFilePath:     githubStepSummaryFilePath,
+		PRCountsStepSummary:     prCountsStepSummary,
+		MetricsFilePath:         metricsFilePath,
+		MetricsFormat:           metricsFormat,
+		DryRun:                  dryRun,
+		FixedTime:               fixedTime,
+		IdempotencyKey:          getIdempotencyKey(fixedTime),
+		ReferenceTime:           referenceTime,
+		TeamMembers:             teamMembers,
+		PreviewFilePath:         cmp.Or(inputhelpers.GetInput(InputPreviewFilePath), DefaultPreviewFilePath),
+		SuggestedMappingPath:    cmp.Or(inputhelpers.GetInput(InputSuggestedMappingFilePath), DefaultSuggestedMappingPath),
+		SyncMaxMessageAgeHours:  cmp.Or(syncMaxMessageAgeHours, DefaultSyncMaxMessageAgeHours),
+		MessageTTLHours:         messageTTLHours,
 		SlackChannelName:        slackChannelName,
 		SlackChannelID:          slackChannelID,
+		WorkspaceTargets:        workspaceTargets,
+		ChannelMatrix:           channelMatrix,
+		PRDataCache:             prDataCache,
+		Escalation:              escalation,
+		AllowedChannelPattern:   inputhelpers.GetInput(InputAllowedChannelPattern),
+		PRThreadMarker:          inputhelpers.GetInput(InputPRThreadMarker),
+		SlackRequestTimeout:     time.Duration(cmp.Or(slackTimeoutSeconds, DefaultSlackTimeoutSeconds)) * time.Second,
 		CurrentRepository:       currentRepository,
 		Repositories:            repositories,
+		RepositoryTokens:        repositoryTokens,
+		SkipArchivedRepos:       skipArchivedRepos,
+		RepositoryPatterns:      repositoryPatterns,
+		BotAuthors:              inputhelpers.GetInputList(InputBotAuthors),
+		HumanBots:               inputhelpers.GetInputList(InputHumanBots),
+		EnrichTopN:              enrichTopN,
+		MaxAPICalls:             maxAPICalls,
+		OnUnknownRepo:           onUnknownRepo,
+		OnMissingState:          onMissingState,
+		OnPRFetchError:          onPRFetchError,
+		ValidateUnknownInputs:   validateUnknownInputs,
+		TruncateKeep:            truncateKeep,
+		MinPRsPerRepository:     minPRsPerRepository,
+		ShowFailingWorkflows:    showFailingWorkflows,
+		ShowRecentlyMergedHours: showRecentlyMergedHours,
+		ShowResolvedPRs:         showResolvedPRs,
+		ShowFailingChecks:       showFailingChecks,
+		ShowCodeownerApproval:   showCodeownerApproval,
+		ShowReviewDecision:      showReviewDecision,
+		StrictApprovals:         strictApprovals,
+		ShowMergeQueue:          showMergeQueue,
+		ShowReviewEffort:        showReviewEffort,
+		ShowReviewResponseTime:  showReviewResponseTime,
+		ReviewerThreads:         reviewerThreads,
+		RespectDND:              respectDND,
+		ValidateUserMapping:     validateUserMapping,
+		ShowFilteredCount:       showFilteredCount,
+		SplitMessagesByRepo:     splitMessagesByRepo,
+		AuditBranchProtection:   auditBranchProtection,
+		ShowRunReport:           showRunReport,
 		GlobalFilters:           globalFilters,
 		RepositoryFilters:       repositoryFilters,
 		ContentInputs: ContentInputs{
 			SlackUserIdByGitHubUsername: slackUserIdByGitHubUsername,
+			SlackGroupIdByGitHubTeam:    slackGroupIdByGitHubTeam,
 			PRListHeading:               prListHeading,
 			NoPRsMessage:                noPRsMessage,
 			OldPRThresholdHours:         oldPRsThresholdHours,
-			GroupByRepository:           groupByRepository,
+			OldPRThresholdTiers:         oldPRThresholdTiers,
+			OldPRThresholdHoursByRepo:   oldPRThresholdHoursByRepo,
+			HeadingPrefixByRepo:         headingPrefixByRepo,
+			GroupByRepository:           groupBy == GroupByRepository,
+			GroupByLabels:               groupByLabels,
+			GroupSort:                   groupSort,
+			MaxPRsPerRepository:         maxPRsPerRepository,
+			ShowQuietRepos:              showQuietRepos,
+			ReleasePRTitlePattern:       inputhelpers.GetInput(InputReleasePRTitlePattern),
+			ReleasePRLabels:             inputhelpers.GetInputList(InputReleasePRLabels),
+			ReviewSLAHours:              reviewSLAHours,
+			ReviewerLinkStyle:           reviewerLinkStyle,
+			IgnoredReviewers:            inputhelpers.GetInputList(InputIgnoredReviewers),
+			ShowReminderCount:           showReminderCount,
+			ShowQuickLinks:              showQuickLinks,
+			ShowSilentReviewers:         showSilentReviewers,
+			ShowCommentCount:            showCommentCount,
+			RedactSecretsInTitles:       redactSecretsInTitles,
+			MessageStyle:                messageStyle,
+			HighlightAuthors:            inputhelpers.GetInputList(InputHighlightAuthors),
+			SummaryTones:                summaryTones,
+			SeverityThresholds:          severityThresholds,
 		},
+		OnCall: onCallInputs,
+	}
+	if showRunLink {
+		config.ContentInputs.WorkflowRunURL = getWorkflowRunURL(repository)
+	}
+	if err := checkUnknownInputs(validateUnknownInputs); err != nil {
+		return Config{}, err
 	}
 
 	if err := config.validate(); err != nil {
@@ -161,20 +679,138 @@ func GetConfig() (Config, error) {
 	return config, nil
 }
 
+// GITHUB_REPOSITORY is always set in GitHub Actions, but when it is not (e.g. when running
+// the action elsewhere), the repository is read from the current-repository input or from the event
+// payload if available. It is required only if github-repositories is not set, i.e. the PRs of the
+// current repository are listed. Otherwise it is needed only for the artifacts (see
+// validateCurrentRepository).
+func getRepository(githubEventPath string, isRequired bool) (string, error) {
+	if repository := inputhelpers.GetInput(InputCurrentRepository); repository != "" {
+		return repository, nil
+	}
+	if repository := inputhelpers.GetEnv(EnvGithubRepository); repository != "" {
+		return repository, nil
+	}
+	if repository := getRepositoryFromEventPayload(githubEventPath); repository != "" {
+		return repository, nil
+	}
+	if !isRequired {
+		return "", nil
+	}
+	return inputhelpers.GetEnvRequired(EnvGithubRepository)
+}
+
+func getRepositoryFromEventPayload(githubEventPath string) string {
+	if githubEventPath == "" {
+		return ""
+	}
+	payload, err := githubevent.Load(githubEventPath)
+	if err != nil {
+		log.Printf("Warning: unable to read repository from event payload: %v", err)
+		return ""
+	}
+	return payload.GetRepositoryPath()
+}
+
+func getWorkflowRunURL(repository string) string {
+	if repository == "" {
+		log.Printf("Warning: the current repository is not known, unable to link the workflow run")
+		return ""
+	}
+	runID := inputhelpers.GetEnv(EnvGithubRunID)
+	if runID == "" {
+		log.Printf("Warning: %s is not set, unable to link the workflow run", EnvGithubRunID)
+		return ""
+	}
+	serverURL := cmp.Or(inputhelpers.GetEnv(EnvGithubServerURL), DefaultGithubServerURL)
+	return fmt.Sprintf("%s/%s/actions/runs/%s", serverURL, repository, runID)
+}
+
+// slack-bot-token is only required when sending to Slack and workspace-targets are not used.
+func getSlackBotToken(messenger Messenger) (string, error) {
+	if messenger != MessengerSlack || inputhelpers.GetInput(InputWorkspaceTargets) != "" {
+		return inputhelpers.GetInput(InputSlackBotToken), nil
+	}
+	return inputhelpers.GetInputRequired(InputSlackBotToken)
+}
+
+// GetSlackTargets returns the configured workspace targets or, if none are set,
+// a single target built from the slack-bot-token and slack-channel-* inputs.
+func (c Config) GetSlackTargets() []WorkspaceTarget {
+	if len(c.WorkspaceTargets) > 0 {
+		return c.WorkspaceTargets
+	}
+	return []WorkspaceTarget{{
+		SlackBotToken:    c.SlackBotToken,
+		SlackChannelID:   c.SlackChannelID,
+		SlackChannelName: c.SlackChannelName,
+	}}
+}
+
 func (c Config) GetFiltersForRepository(repo models.Repository) Filters {
 	for _, key := range []string{repo.GetPath(), repo.Name} {
 		if filters, exists := c.RepositoryFilters[key]; exists {
-			return filters
+			return c.withTeamMembers(filters)
+		}
+	}
+	return c.withTeamMembers(c.GlobalFilters)
+}
+
+// Returns true if the merge queue state of the PRs is needed for marking or excluding queued PRs.
+func (c Config) UsesMergeQueue() bool {
+	if c.ShowMergeQueue || c.GlobalFilters.IgnoreQueued {
+		return true
+	}
+	for _, entry := range c.ChannelMatrix {
+		if entry.Filters.IgnoreQueued {
+			return true
+		}
+	}
+	for _, filters := range c.RepositoryFilters {
+		if filters.IgnoreQueued {
+			return true
 		}
 	}
-	return c.GlobalFilters
+	return false
+}
+
+func (c Config) withTeamMembers(filters Filters) Filters {
+	if c.TeamMembers.IsSet() {
+		filters.TeamMembers = &c.TeamMembers
+	}
+	return filters
 }
 
 // validate performs post-construction validation of business rules for Config.
 // It validates repository limits, Slack channel requirements and repository names.
 func (c Config) validate() error {
-	if c.SlackChannelID == "" && c.SlackChannelName == "" {
-		return fmt.Errorf("either %s or %s must be set", InputSlackChannelID, InputSlackChannelName)
+	if err := c.validateMessenger(); err != nil {
+		return err
+	}
+	if c.RunMode == RunModeSuggestMapping {
+		// only the users of the workspace are listed, no channel is needed
+		if c.SlackBotToken == "" {
+			return fmt.Errorf("%s is required when run mode is '%s'", InputSlackBotToken, RunModeSuggestMapping)
+		}
+	} else if c.Messenger == MessengerSlack && len(c.ChannelMatrix) == 0 {
+		if err := c.validateSlackTargets(); err != nil {
+			return err
+		}
+	}
+	if c.DryRun && c.Messenger != MessengerSlack {
+		return fmt.Errorf("%s is only supported with %s: %s", InputDryRun, InputMessenger, MessengerSlack)
+	}
+	if c.RespectDND && c.Messenger != MessengerSlack {
+		return fmt.Errorf("%s is only supported with %s: %s", InputRespectDND, InputMessenger, MessengerSlack)
+	}
+	if c.RespectDND && len(c.WorkspaceTargets) > 0 {
+		return fmt.Errorf("%s cannot be used with %s", InputRespectDND, InputWorkspaceTargets)
+	}
+	if c.ValidateUserMapping && c.Messenger != MessengerSlack {
+		return fmt.Errorf("%s is only supported with %s: %s", InputValidateUserMapping, InputMessenger, MessengerSlack)
+	}
+	if c.ValidateUserMapping && len(c.WorkspaceTargets) > 0 {
+		return fmt.Errorf("%s cannot be used with %s", InputValidateUserMapping, InputWorkspaceTargets)
 	}
 	if len(c.Repositories) > MaxRepositories {
 		return fmt.Errorf("too many repositories: maximum of %d repositories allowed, got %d", MaxRepositories, len(c.Repositories))
@@ -182,13 +818,116 @@ func (c Config) validate() error {
 	if err := c.validateRepositoryNames(); err != nil {
 		return err
 	}
+	if err := c.RepositoryPatterns.validate(c.Repositories); err != nil {
+		return err
+	}
 	if err := c.validateHeadingOptions(); err != nil {
 		return err
 	}
+	if err := c.validateHeadingPrefixByRepo(); err != nil {
+		return err
+	}
 	if err := c.validateStateArtifactName(); err != nil {
 		return err
 	}
+	if err := c.validateCurrentRepository(); err != nil {
+		return err
+	}
+	if err := c.validateRepositoryTokens(); err != nil {
+		return err
+	}
+	if err := c.validateMessageStyle(); err != nil {
+		return err
+	}
+	if err := c.validateEventMode(); err != nil {
+		return err
+	}
+	if err := c.OnCall.validate(); err != nil {
+		return err
+	}
+	if _, err := regexp.Compile(c.ContentInputs.ReleasePRTitlePattern); err != nil {
+		return fmt.Errorf("invalid %s: %v", InputReleasePRTitlePattern, err)
+	}
+	if _, err := regexp.Compile(c.AllowedChannelPattern); err != nil {
+		return fmt.Errorf("invalid %s: %v", InputAllowedChannelPattern, err)
+	}
+	if err := c.validatePRThreadMarker(); err != nil {
+		return err
+	}
+	if err := c.validateReviewerThreads(); err != nil {
+		return err
+	}
+	if err := c.validateChannelMatrix(); err != nil {
+		return err
+	}
+	if err := c.PRDataCache.validate(c.RunMode); err != nil {
+		return err
+	}
+	if err := c.validateEscalation(); err != nil {
+		return err
+	}
+	// the filtered PRs are counted only when fetching the open PRs
+	if c.ShowFilteredCount && !slices.Contains([]RunMode{RunModePost, RunModeSync}, c.RunMode) {
+		return fmt.Errorf("%s is supported only with run modes '%s' and '%s'", InputShowFilteredCount, RunModePost, RunModeSync)
+	}
+	if err := c.validateSplitMessagesByRepo(); err != nil {
+		return err
+	}
+	for _, login := range c.BotAuthors {
+		if slices.ContainsFunc(c.HumanBots, func(human string) bool { return strings.EqualFold(human, login) }) {
+			return fmt.Errorf("user %s cannot be in both %s and %s", login, InputBotAuthors, InputHumanBots)
+		}
+	}
+	if c.SyncMaxMessageAgeHours < 0 {
+		return fmt.Errorf("%s must not be negative", InputSyncMaxMessageAgeHours)
+	}
+	if c.ShowResolvedPRs && (c.Messenger != MessengerSlack || c.RunMode != RunModePost) {
+		return fmt.Errorf("%s is supported only with Slack and run mode '%s'", InputShowResolvedPRs, RunModePost)
+	}
+	if c.ShowRecentlyMergedHours < 0 {
+		return fmt.Errorf("%s must not be negative", InputShowRecentlyMergedHours)
+	}
+	if c.MessageTTLHours < 0 {
+		return fmt.Errorf("%s must not be negative", InputMessageTTLHours)
+	}
+	if c.SlackRequestTimeout < 0 {
+		return fmt.Errorf("%s must not be negative", InputSlackTimeoutSeconds)
+	}
+	if c.ContentInputs.ReviewSLAHours < 0 {
+		return fmt.Errorf("%s must not be negative", InputReviewSLAHours)
+	}
+	if c.EnrichTopN < 0 {
+		return fmt.Errorf("%s must not be negative", InputEnrichTopN)
+	}
+	if c.MaxAPICalls < 0 {
+		return fmt.Errorf("%s must not be negative", InputMaxAPICalls)
+	}
+	if c.MinPRsPerRepository < 0 {
+		return fmt.Errorf("%s must not be negative", InputMinPRsPerRepo)
+	}
+	if c.ContentInputs.MaxPRsPerRepository < 0 {
+		return fmt.Errorf("%s must not be negative", InputMaxPRsPerRepo)
+	}
+	if c.ContentInputs.ShowQuietRepos == QuietReposInline && !c.ContentInputs.GroupByRepository {
+		return fmt.Errorf("%s: %s requires %s: %s", InputShowQuietRepos, QuietReposInline, InputGroupBy, GroupByRepository)
+	}
+
+	return nil
+}
 
+func (c Config) validateSlackTargets() error {
+	if len(c.WorkspaceTargets) == 0 {
+		if c.SlackChannelID == "" && c.SlackChannelName == "" {
+			return fmt.Errorf("either %s or %s must be set", InputSlackChannelID, InputSlackChannelName)
+		}
+		return nil
+	}
+	if c.SlackBotToken != "" || c.SlackChannelID != "" || c.SlackChannelName != "" {
+		return fmt.Errorf(
+			"%s cannot be used together with %s, %s or %s",
+			InputWorkspaceTargets, InputSlackBotToken, InputSlackChannelID, InputSlackChannelName,
+		)
+	}
 	return nil
 }
 
@@ -201,6 +940,11 @@ func (c Config) validateRepositoryNames() error {
 	); err != nil {
 		return err
 	}
+	if err := validateRepositoryReferences(
+		c.Repositories, c.ContentInputs.OldPRThresholdHoursByRepo, InputRepositoryOldPRThresholds,
+	); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -248,16 +992,125 @@ func validateRepositoryReferences[V any](
 	return nil
 }
 
+// Only the Slack reminder is split by repository, the messages of the channel matrix are not.
+func (c Config) validateSplitMessagesByRepo() error {
+	if !c.SplitMessagesByRepo {
+		return nil
+	}
+	if !c.ContentInputs.GroupByRepository {
+		return fmt.Errorf("%s requires %s: %s", InputSplitMessagesByRepo, InputGroupBy, GroupByRepository)
+	}
+	if c.Messenger != MessengerSlack {
+		return fmt.Errorf("%s is only supported with %s: %s", InputSplitMessagesByRepo, InputMessenger, MessengerSlack)
+	}
+	if len(c.ChannelMatrix) > 0 {
+		return fmt.Errorf("%s cannot be used with %s", InputSplitMessagesByRepo, InputChannelMatrix)
+	}
+	return nil
+}
+
 func (c Config) validateHeadingOptions() error {
-	if !c.ContentInputs.GroupByRepository && c.ContentInputs.PRListHeading == "" {
+	isGrouped := c.ContentInputs.GroupByRepository || len(c.ContentInputs.GroupByLabels) > 0
+	if !isGrouped && c.ContentInputs.PRListHeading == "" {
 		return fmt.Errorf("%s is required when group-by-repository is false", InputPRListHeading)
 	}
 	return nil
 }
 
 func (c Config) validateStateArtifactName() error {
-	if c.RunMode == RunModeUpdate && c.StateArtifactName == "" {
-		return fmt.Errorf("%s is required when run mode is '%s'", InputStateArtifactName, RunModeUpdate)
+	if !slices.Contains([]RunMode{RunModePost, RunModeSuggestMapping}, c.RunMode) && c.StateArtifactName == "" {
+		return fmt.Errorf("%s is required when run mode is '%s'", InputStateArtifactName, c.RunMode)
+	}
+	if c.PruneStateArtifacts < 0 {
+		return fmt.Errorf("%s must not be negative", InputPruneStateArtifacts)
+	}
+	// the PRs of the previous reminder are read from its state
+	if c.ShowResolvedPRs && c.StateArtifactName == "" {
+		return fmt.Errorf("%s is required when %s is set", InputStateArtifactName, InputShowResolvedPRs)
+	}
+	if c.PruneStateArtifacts > 0 && c.StateArtifactName == "" {
+		return fmt.Errorf("%s is required when %s is set", InputStateArtifactName, InputPruneStateArtifacts)
+	}
+	return nil
+}
+
+// The state artifacts and the PR data cache artifact are read from the workflow runs of the current
+// repository, so it must be known if they are used.
+func (c Config) validateCurrentRepository() error {
+	if c.CurrentRepository != (models.Repository{}) {
+		return nil
+	}
+	if c.StateArtifactName != "" {
+		return fmt.Errorf(
+			"%s (or %s) is required when %s is set", EnvGithubRepository, InputCurrentRepository, InputStateArtifactName,
+		)
+	}
+	if c.PRDataCache.Mode == PRDataCacheRead {
+		return fmt.Errorf(
+			"%s (or %s) is required when %s is '%s'", EnvGithubRepository, InputCurrentRepository, InputPRDataCache, PRDataCacheRead,
+		)
+	}
+	return nil
+}
+
+func (c Config) validateEventMode() error {
+	if c.RunMode == RunModeEvent && c.GithubEventPath == "" {
+		return fmt.Errorf("%s must be set when run mode is '%s'", EnvGithubEventPath, RunModeEvent)
+	}
+	return nil
+}
+
+// The reviewer threads are replies to the Slack message, which are refreshed when the message is updated.
+func (c Config) validateReviewerThreads() error {
+	if !c.ReviewerThreads {
+		return nil
+	}
+	if c.Messenger != MessengerSlack || !slices.Contains([]RunMode{RunModePost, RunModeUpdate, RunModeSync}, c.RunMode) {
+		return fmt.Errorf(
+			"%s is supported only with Slack and run modes '%s', '%s' and '%s'",
+			InputReviewerThreads, RunModePost, RunModeUpdate, RunModeSync,
+		)
+	}
+	if len(c.WorkspaceTargets) > 0 {
+		return fmt.Errorf("%s cannot be used with %s", InputReviewerThreads, InputWorkspaceTargets)
+	}
+	if len(c.ContentInputs.SlackUserIdByGitHubUsername) == 0 {
+		return fmt.Errorf("%s requires %s", InputReviewerThreads, InputSlackUserIdByGitHubUsername)
+	}
+	return nil
+}
+
+// The channels of the channel matrix replace the single channel (or the workspace targets) of post mode.
+func (c Config) validateChannelMatrix() error {
+	if len(c.ChannelMatrix) == 0 {
+		return nil
+	}
+	if c.Messenger != MessengerSlack || c.RunMode != RunModePost {
+		return fmt.Errorf("%s is supported only with Slack and run mode '%s'", InputChannelMatrix, RunModePost)
+	}
+	if len(c.WorkspaceTargets) > 0 || c.SlackChannelID != "" || c.SlackChannelName != "" {
+		return fmt.Errorf(
+			"%s cannot be used together with %s, %s or %s",
+			InputChannelMatrix, InputWorkspaceTargets, InputSlackChannelID, InputSlackChannelName,
+		)
+	}
+	if c.PRThreadMarker != "" || c.ReviewerThreads {
+		return fmt.Errorf(
+			"%s cannot be used together with %s or %s", InputChannelMatrix, InputPRThreadMarker, InputReviewerThreads,
+		)
+	}
+	return nil
+}
+
+func (c Config) validatePRThreadMarker() error {
+	if c.PRThreadMarker == "" {
+		return nil
+	}
+	if c.Messenger != MessengerSlack || c.RunMode != RunModePost {
+		return fmt.Errorf("%s is supported only with Slack and run mode '%s'", InputPRThreadMarker, RunModePost)
+	}
+	if len(c.WorkspaceTargets) > 0 {
+		return fmt.Errorf("%s cannot be used with %s", InputPRThreadMarker, InputWorkspaceTargets)
 	}
 	return nil
 }
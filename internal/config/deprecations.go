@@ -0,0 +1,10 @@
+package config
+
+import "github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+
+// The deprecated inputs, which are kept in action.yml (without defaults) until they are removed.
+// A renamed input is declared with ReplacedBy (the new name), so that workflows using the old name
+// keep working; the new input must not have a default either, as the runner would always set it.
+var deprecatedInputs = []inputhelpers.Deprecation{
+	{Name: InputReviewersIgnore, ReplacedBy: InputIgnoredReviewers},
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// EscalationInputs configure posting a condensed message of the old PRs to an additional channel
+// (e.g. a channel of the team leads) when there are more old PRs than the given count.
+type EscalationInputs struct {
+	SlackChannelName string // empty if the escalation is disabled
+	// The escalation message is posted if the number of old PRs exceeds this
+	OldPRCount int
+}
+
+func (i EscalationInputs) IsEnabled() bool {
+	return i.SlackChannelName != ""
+}
+
+func getEscalationInputs() (EscalationInputs, error) {
+	oldPRCount, err := inputhelpers.GetInputInt(InputEscalationOldPRCount)
+	if err != nil {
+		return EscalationInputs{}, err
+	}
+	channelName, err := normalizeSlackChannelName(InputEscalationChannel, inputhelpers.GetInput(InputEscalationChannel))
+	if err != nil {
+		return EscalationInputs{}, err
+	}
+	return EscalationInputs{
+		SlackChannelName: channelName,
+		OldPRCount:       oldPRCount,
+	}, nil
+}
+
+// The escalation message is posted to the workspace of the (single) Slack channel after the reminder.
+func (c Config) validateEscalation() error {
+	if !c.Escalation.IsEnabled() {
+		if c.Escalation.OldPRCount != 0 {
+			return fmt.Errorf("%s requires %s", InputEscalationOldPRCount, InputEscalationChannel)
+		}
+		return nil
+	}
+	if c.Messenger != MessengerSlack || c.RunMode != RunModePost {
+		return fmt.Errorf("%s is supported only with Slack and run mode '%s'", InputEscalationChannel, RunModePost)
+	}
+	if len(c.WorkspaceTargets) > 0 || len(c.ChannelMatrix) > 0 {
+		return fmt.Errorf(
+			"%s cannot be used together with %s or %s", InputEscalationChannel, InputWorkspaceTargets, InputChannelMatrix,
+		)
+	}
+	if c.Escalation.OldPRCount < 0 {
+		return fmt.Errorf("%s must not be negative", InputEscalationOldPRCount)
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package config
+
+import "github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+
+// The inputs that can be read from a file in the repository instead (e.g. filters-file), so that long
+// filter lists and mappings can be managed outside the workflow file.
+var fileInputs = []inputhelpers.FileInput{
+	{Name: InputGlobalFilters, FileInput: InputGlobalFiltersFile},
+	{Name: InputRepositoryFilters, FileInput: InputRepositoryFiltersFile},
+	{Name: InputSlackUserIdByGitHubUsername, FileInput: InputSlackUserIdMappingFile},
+	{Name: InputSlackGroupIdByGitHubTeam, FileInput: InputSlackGroupMappingFile},
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"path"
 	"slices"
+	"strings"
 
 	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
 )
@@ -15,6 +17,16 @@ type Filters struct {
 	Labels         []string `json:"labels,omitempty"`
 	IgnoredLabels  []string `json:"ignored-labels,omitempty"`
 	IgnoredTerms   []string `json:"ignored-terms,omitempty"`
+	// Glob patterns of head branch names, e.g. "renovate/*" (as in path.Match, "*" does not match "/")
+	BranchesIgnore []string `json:"branches-ignore,omitempty"`
+	Milestones     []string `json:"milestones,omitempty"` // titles of milestones
+	MinAgeHours    int      `json:"min-age-hours,omitempty"`
+	// Slugs of teams (optionally prefixed with the organization), of which a review must be requested
+	RequestedTeams []string `json:"requested-teams,omitempty"`
+	// Exclude PRs in the merge queue (which no longer need the attention of reviewers)
+	IgnoreQueued bool `json:"ignore-queued,omitempty"`
+	// Set from the team-members input for all repositories (not configurable per repository)
+	TeamMembers *TeamMembers `json:"-"`
 }
 
 func GetGlobalFiltersFromInput(input string) (Filters, error) {
@@ -81,5 +93,28 @@ func (f Filters) validate() error {
 		return fmt.Errorf("ignored-terms cannot contain empty strings")
 	}
 
+	if slices.Contains(f.BranchesIgnore, "") {
+		return fmt.Errorf("branches-ignore cannot contain empty patterns")
+	}
+	for _, pattern := range f.BranchesIgnore {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern in branches-ignore: %s (%v)", pattern, err)
+		}
+	}
+
+	if slices.Contains(f.Milestones, "") {
+		return fmt.Errorf("milestones cannot contain empty strings")
+	}
+
+	if slices.ContainsFunc(f.RequestedTeams, func(team string) bool {
+		return team == "" || strings.HasSuffix(team, "/")
+	}) {
+		return fmt.Errorf("requested-teams cannot contain empty team slugs")
+	}
+
+	if f.MinAgeHours < 0 {
+		return fmt.Errorf("min-age-hours cannot be negative")
+	}
+
 	return nil
 }
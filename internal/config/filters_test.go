@@ -1,6 +1,7 @@
 package config_test
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -53,6 +54,41 @@ func TestGetGlobalFiltersFromInput_Valid(t *testing.T) {
 				IgnoredTerms: []string{"Release v1.0", "Automated Update"},
 			},
 		},
+		{
+			name:  "milestones only",
+			input: `{"milestones": ["2.4", "2.5"]}`,
+			expectedFilter: config.Filters{
+				Milestones: []string{"2.4", "2.5"},
+			},
+		},
+		{
+			name:  "min-age-hours only",
+			input: `{"min-age-hours": 2}`,
+			expectedFilter: config.Filters{
+				MinAgeHours: 2,
+			},
+		},
+		{
+			name:  "requested-teams only",
+			input: `{"requested-teams": ["platform", "test-org/frontend"]}`,
+			expectedFilter: config.Filters{
+				RequestedTeams: []string{"platform", "test-org/frontend"},
+			},
+		},
+		{
+			name:  "branches-ignore only",
+			input: `{"branches-ignore": ["renovate/*", "release-please--*"]}`,
+			expectedFilter: config.Filters{
+				BranchesIgnore: []string{"renovate/*", "release-please--*"},
+			},
+		},
+		{
+			name:  "ignore-queued only",
+			input: `{"ignore-queued": true}`,
+			expectedFilter: config.Filters{
+				IgnoreQueued: true,
+			},
+		},
 		{
 			name:  "all fields",
 			input: `{"authors": ["alice"], "labels": ["feature"], "ignored-labels": ["wip"]}`,
@@ -118,6 +154,26 @@ func TestGetGlobalFiltersFromInput_Valid(t *testing.T) {
 					t.Errorf("Expected ignored-terms[%d] '%s', got '%s'", i, term, filters.IgnoredTerms[i])
 				}
 			}
+
+			if !slices.Equal(filters.BranchesIgnore, tc.expectedFilter.BranchesIgnore) {
+				t.Errorf("Expected branches-ignore %v, got %v", tc.expectedFilter.BranchesIgnore, filters.BranchesIgnore)
+			}
+
+			if !slices.Equal(filters.Milestones, tc.expectedFilter.Milestones) {
+				t.Errorf("Expected milestones %v, got %v", tc.expectedFilter.Milestones, filters.Milestones)
+			}
+
+			if filters.MinAgeHours != tc.expectedFilter.MinAgeHours {
+				t.Errorf("Expected min-age-hours %d, got %d", tc.expectedFilter.MinAgeHours, filters.MinAgeHours)
+			}
+
+			if !slices.Equal(filters.RequestedTeams, tc.expectedFilter.RequestedTeams) {
+				t.Errorf("Expected requested-teams %v, got %v", tc.expectedFilter.RequestedTeams, filters.RequestedTeams)
+			}
+
+			if filters.IgnoreQueued != tc.expectedFilter.IgnoreQueued {
+				t.Errorf("Expected ignore-queued %v, got %v", tc.expectedFilter.IgnoreQueued, filters.IgnoreQueued)
+			}
 		})
 	}
 }
@@ -153,6 +209,31 @@ func TestGetGlobalFiltersFromInput_Invalid(t *testing.T) {
 			input:          `{"ignored-terms": ["valid term", ""]}`,
 			expectedErrMsg: "ignored-terms cannot contain empty strings",
 		},
+		{
+			name:           "empty pattern in branches-ignore",
+			input:          `{"branches-ignore": [""]}`,
+			expectedErrMsg: "branches-ignore cannot contain empty patterns",
+		},
+		{
+			name:           "malformed pattern in branches-ignore",
+			input:          `{"branches-ignore": ["renovate/["]}`,
+			expectedErrMsg: "invalid pattern in branches-ignore: renovate/[",
+		},
+		{
+			name:           "empty string in milestones",
+			input:          `{"milestones": [""]}`,
+			expectedErrMsg: "milestones cannot contain empty strings",
+		},
+		{
+			name:           "negative min-age-hours",
+			input:          `{"min-age-hours": -1}`,
+			expectedErrMsg: "min-age-hours cannot be negative",
+		},
+		{
+			name:           "team without slug in requested-teams",
+			input:          `{"requested-teams": ["test-org/"]}`,
+			expectedErrMsg: "requested-teams cannot contain empty team slugs",
+		},
 	}
 
 	for _, tc := range testCases {
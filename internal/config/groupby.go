@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// GroupBy defines by what the PRs are grouped under separate headings in the message.
+type GroupBy string
+
+const (
+	GroupByNone       GroupBy = ""           // a single PR list under pr-list-heading
+	GroupByRepository GroupBy = "repository" // same as group-by-repository: true
+	GroupByLabel      GroupBy = "label"      // by the labels of label-group-order, plus an "Other" group
+)
+
+// The group-by-repository input is kept for backwards compatibility, it must not conflict with group-by.
+func getGroupBy(inputName string, groupByRepository bool) (GroupBy, error) {
+	groupBy, err := parseGroupBy(inputhelpers.GetInput(inputName))
+	if err != nil {
+		return GroupByNone, err
+	}
+	if !groupByRepository {
+		return groupBy, nil
+	}
+	if groupBy != GroupByNone && groupBy != GroupByRepository {
+		return GroupByNone, fmt.Errorf(
+			"%s: %s cannot be used with %s: true", inputName, groupBy, InputGroupByRepository,
+		)
+	}
+	return GroupByRepository, nil
+}
+
+// The labels are required if the PRs are grouped by label, and not allowed otherwise.
+func getGroupByLabels(inputName string, groupBy GroupBy) ([]string, error) {
+	labels := inputhelpers.GetInputList(inputName)
+	switch {
+	case groupBy == GroupByLabel && len(labels) == 0:
+		return nil, fmt.Errorf("%s is required when %s is '%s'", inputName, InputGroupBy, GroupByLabel)
+	case groupBy != GroupByLabel && len(labels) > 0:
+		return nil, fmt.Errorf("%s can be used only when %s is '%s'", inputName, InputGroupBy, GroupByLabel)
+	case groupBy != GroupByLabel:
+		return nil, nil
+	}
+	return labels, nil
+}
+
+func parseGroupBy(raw string) (GroupBy, error) {
+	switch raw {
+	case string(GroupByNone):
+		return GroupByNone, nil
+	case string(GroupByRepository):
+		return GroupByRepository, nil
+	case string(GroupByLabel):
+		return GroupByLabel, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s' or '%s')", InputGroupBy, raw, GroupByRepository, GroupByLabel,
+		)
+	}
+}
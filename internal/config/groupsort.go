@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// GroupSort defines the order of the PRs within the groups of the message (group-by).
+type GroupSort string
+
+const (
+	GroupSortOldestFirst GroupSort = "oldest-first"
+	GroupSortNewestFirst GroupSort = "newest-first"
+)
+
+func getGroupSort(inputName string) (GroupSort, error) {
+	return parseGroupSort(inputhelpers.GetInputOr(inputName, string(DefaultGroupSort)))
+}
+
+func parseGroupSort(raw string) (GroupSort, error) {
+	switch raw {
+	case string(GroupSortOldestFirst):
+		return GroupSortOldestFirst, nil
+	case string(GroupSortNewestFirst):
+		return GroupSortNewestFirst, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s' or '%s')", InputGroupSort, raw, GroupSortOldestFirst, GroupSortNewestFirst,
+		)
+	}
+}
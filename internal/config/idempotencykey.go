@@ -0,0 +1,20 @@
+package config
+
+import (
+	"cmp"
+	"time"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/clock"
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// Returns the idempotency key of the run: the ID of the workflow run, which stays the same when the
+// run is re-run (e.g. after a failure), and the date of the run (UTC), so that a re-run on a later day
+// is a new reminder. Returns an empty string if GITHUB_RUN_ID is not set (e.g. outside GitHub Actions).
+func getIdempotencyKey(fixedTime time.Time) string {
+	runID := inputhelpers.GetEnv(EnvGithubRunID)
+	if runID == "" {
+		return ""
+	}
+	return runID + "-" + cmp.Or(fixedTime, clock.Now()).UTC().Format(time.DateOnly)
+}
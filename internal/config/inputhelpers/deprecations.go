@@ -0,0 +1,97 @@
+package inputhelpers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Deprecation declares a deprecated input. If ReplacedBy is set, the input was renamed and
+// it is an alias of the new input: its value is used if the new input is not set, so that the
+// rename does not break existing workflows. Otherwise a warning is only logged if it is set.
+type Deprecation struct {
+	Name       string
+	ReplacedBy string
+	Note       string // e.g. how to migrate, appended to the warning
+}
+
+// SetDeprecations declares the deprecated inputs of the current read (replacing the previously
+// declared ones).
+func SetDeprecations(ds ...Deprecation) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	state.deprecations = ds
+}
+
+func getDeprecations() []Deprecation {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	return state.deprecations
+}
+
+// Returns the deprecated inputs that are aliases of the input.
+func getAliases(name string) []string {
+	var aliases []string
+	for _, d := range getDeprecations() {
+		if d.ReplacedBy == name {
+			aliases = append(aliases, d.Name)
+		}
+	}
+	return aliases
+}
+
+// Looks up the input, or its value read from a file (see LoadFileInputs) or the first set alias of it
+// if the input is not set (or is empty).
+func lookupInput(name string) (string, bool) {
+	value, exists := lookupEnv(inputNameAsEnv(name))
+	if isSet(name) {
+		return value, exists
+	}
+	if fileValue, ok := getFileValue(name); ok {
+		return fileValue, true
+	}
+	for _, alias := range getAliases(name) {
+		if isSet(alias) {
+			return lookupEnv(inputNameAsEnv(alias))
+		}
+	}
+	return value, exists
+}
+
+// DeprecationWarnings returns the warnings about the deprecated inputs that are set.
+func DeprecationWarnings() []string {
+	var warnings []string
+	for _, d := range getDeprecations() {
+		if !isSet(d.Name) {
+			continue
+		}
+		var warning string
+		switch {
+		case d.ReplacedBy == "":
+			warning = fmt.Sprintf("input %s is deprecated", d.Name)
+		case isSet(d.ReplacedBy):
+			warning = fmt.Sprintf("input %s is deprecated and ignored, as %s is also set", d.Name, d.ReplacedBy)
+		default:
+			warning = fmt.Sprintf("input %s is deprecated, use %s instead", d.Name, d.ReplacedBy)
+		}
+		if d.Note != "" {
+			warning += " (" + d.Note + ")"
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// LogDeprecationWarnings logs the warnings about the deprecated inputs that are set.
+func LogDeprecationWarnings() {
+	for _, warning := range DeprecationWarnings() {
+		log.Printf("Warning: %s", warning)
+	}
+}
+
+// The runner sets the inputs with defaults (in action.yml) even if they are not given in the
+// workflow, so only non-empty values are considered to be set.
+func isSet(name string) bool {
+	value, _ := lookupEnv(inputNameAsEnv(name))
+	return strings.TrimSpace(value) != ""
+}
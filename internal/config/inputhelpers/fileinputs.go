@@ -0,0 +1,54 @@
+package inputhelpers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+)
+
+// FileInput declares an input of which the value can be read from a file given by another input
+// (e.g. filters-file for filters), so that long values can be kept in the repository instead of the
+// workflow file. The file contains the value in the format of the input, e.g. a mapping as lines of
+// "key: value" or as a JSON object.
+type FileInput struct {
+	Name      string
+	FileInput string
+}
+
+// LoadFileInputs reads the files of the file inputs that are set (replacing the previously read ones
+// of the current read).
+// The content of a file is then used as the value of the input, so it is parsed and validated like
+// the input. It is an error to set both the input and its file input.
+func LoadFileInputs(fs ...FileInput) error {
+	values := map[string]string{}
+	var errs []error
+	for _, f := range fs {
+		path := GetInput(f.FileInput)
+		if path == "" {
+			continue
+		}
+		if isSet(f.Name) {
+			errs = append(errs, fmt.Errorf("only one of %s and %s can be set", f.Name, f.FileInput))
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read %s: %w", f.FileInput, err))
+			continue
+		}
+		log.Printf("Read input %s from %s", f.Name, path)
+		values[f.Name] = string(content)
+	}
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	state.fileValues = values
+	return errors.Join(errs...)
+}
+
+func getFileValue(name string) (string, bool) {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	value, ok := state.fileValues[name]
+	return value, ok
+}
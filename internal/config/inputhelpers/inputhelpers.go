@@ -8,8 +8,80 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 )
 
+// readState is the state of one read of the inputs (e.g. the configuration of one reminder): the
+// overrides of the environment, the declared deprecations and the values read from files.
+type readState struct {
+	overrides    map[string]string // by environment variable name
+	deprecations []Deprecation
+	fileValues   map[string]string // by input name
+}
+
+func newReadState(overrides map[string]string) *readState {
+	return &readState{overrides: overrides, fileValues: map[string]string{}}
+}
+
+var (
+	// Serializes the reads of WithOverrides, so that concurrent reads (e.g. of several reminders
+	// embedded in one program) do not see the overrides, deprecations or file values of each other
+	readMu sync.Mutex
+	// Protects the state (which is also used outside WithOverrides, e.g. in tests)
+	stateMu sync.RWMutex
+	state   = newReadState(nil)
+)
+
+// WithOverrides calls f with a fresh state of reading the inputs, so that nothing set while reading
+// (e.g. the deprecations or the values of the file inputs) is left over for the next read. The inputs
+// (by input name, e.g. "github-token") and the environment variables in the maps are read from the
+// maps instead of the environment, the others from the environment. Only one f is called at a time,
+// so WithOverrides must not be called from f.
+func WithOverrides(inputs, env map[string]string, f func()) {
+	readMu.Lock()
+	defer readMu.Unlock()
+	overrides := make(map[string]string, len(inputs)+len(env))
+	for name, value := range env {
+		overrides[name] = value
+	}
+	for name, value := range inputs {
+		overrides[inputNameAsEnv(name)] = value
+	}
+	stateMu.Lock()
+	previous := state
+	state = newReadState(overrides)
+	stateMu.Unlock()
+	defer func() {
+		stateMu.Lock()
+		state = previous
+		stateMu.Unlock()
+	}()
+	f()
+}
+
+func currentState() *readState {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	return state
+}
+
+// Looks up the environment variable, or its override
+func lookupEnv(name string) (string, bool) {
+	if value, ok := currentState().overrides[name]; ok {
+		return value, true
+	}
+	return os.LookupEnv(name)
+}
+
+// Lists the environment variables (as key=value) with the overrides
+func environ() []string {
+	variables := os.Environ()
+	for name, value := range currentState().overrides {
+		variables = append(variables, name+"="+value)
+	}
+	return variables
+}
+
 func inputNameAsEnv(name string) string {
 	e := strings.ReplaceAll(name, " ", "_")
 	e = strings.ToUpper(e)
@@ -24,15 +96,19 @@ func withErrorIfEmpty(value string, name string) (string, error) {
 }
 
 func GetEnv(name string) string {
-	return os.Getenv(name)
+	value, _ := lookupEnv(name)
+	return value
 }
 
 func GetEnvRequired(name string) (string, error) {
-	return withErrorIfEmpty(os.Getenv(name), name)
+	return withErrorIfEmpty(GetEnv(name), name)
 }
 
+// GetInput returns the trimmed value of the input, or of a deprecated alias of it
+// (see SetDeprecations) if the input is not set.
 func GetInput(name string) string {
-	return strings.TrimSpace(GetEnv((inputNameAsEnv(name))))
+	value, _ := lookupInput(name)
+	return strings.TrimSpace(value)
 }
 
 // GetInputOr returns the input value if set, otherwise returns the provided default.
@@ -42,7 +118,7 @@ func GetInputOr(name string, defaultValue string) string {
 	if val == "" {
 		// Distinguish between unset and intentionally empty:
 		// If the variable name exists in the environment but is empty, empty it is.
-		if _, exists := os.LookupEnv(inputNameAsEnv(name)); exists {
+		if _, exists := lookupInput(name); exists {
 			return ""
 		}
 		return defaultValue
@@ -84,6 +160,14 @@ func GetInputBool(name string) (bool, error) {
 	return parsed, nil
 }
 
+// GetInputBoolOr parses the input as a boolean if set, otherwise returns the provided default.
+func GetInputBoolOr(name string, defaultValue bool) (bool, error) {
+	if GetInput(name) == "" {
+		return defaultValue, nil
+	}
+	return GetInputBool(name)
+}
+
 func GetInputList(name string) []string {
 	val := GetInput(name)
 	if val == "" {
@@ -101,12 +185,14 @@ func GetInputList(name string) []string {
 }
 
 func GetInputMapping(inputName string) (map[string]string, error) {
-	name := inputNameAsEnv(inputName)
 	mapping := make(map[string]string)
-	val := os.Getenv(name)
+	val, _ := lookupInput(inputName)
 	if val == "" {
 		return mapping, nil
 	}
+	if isStructuredMapping(val) {
+		return parseStructuredMapping(inputName, val)
+	}
 	separator := "\n"
 	if strings.Contains(val, ";") {
 		// for more convenient local testing
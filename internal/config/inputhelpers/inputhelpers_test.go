@@ -1,6 +1,13 @@
 package inputhelpers_test
 
 import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
@@ -100,6 +107,24 @@ func TestGetInputBool(t *testing.T) {
 	}
 }
 
+func TestGetInputBoolOr(t *testing.T) {
+	t.Setenv("INPUT_FALSE", "false")
+	value, err := inputhelpers.GetInputBoolOr("false", true)
+	if err != nil || value {
+		t.Errorf("Expected false without error, got %v (error: %v)", value, err)
+	}
+
+	value, err = inputhelpers.GetInputBoolOr("notSet", true)
+	if err != nil || !value {
+		t.Errorf("Expected default true without error, got %v (error: %v)", value, err)
+	}
+
+	t.Setenv("INPUT_INVALID", "maybe")
+	if _, err := inputhelpers.GetInputBoolOr("invalid", true); err == nil {
+		t.Errorf("Expected error for invalid boolean input, got nil")
+	}
+}
+
 func TestReadStringMapping(t *testing.T) {
 	t.Setenv("INPUT_TEST", "a:b;c:d")
 	mapping, _ := inputhelpers.GetInputMapping("test")
@@ -131,6 +156,76 @@ func TestReadInputMappingInvalid2(t *testing.T) {
 	}
 }
 
+func TestGetInputMapping_Structured(t *testing.T) {
+	testCases := []struct {
+		name             string
+		value            string
+		expected         map[string]string
+		expectedErrorMsg string
+	}{
+		{
+			name:     "JSON object",
+			value:    `{"alice": "U1", "bob": "U2"}`,
+			expected: map[string]string{"alice": "U1", "bob": "U2"},
+		},
+		{
+			name:     "JSON object values are passed on as compact JSON",
+			value:    "{\n\t\"repo1\": {\"labels\": [\"bug\"]},\n\t\"repo2\": 48\n}",
+			expected: map[string]string{"repo1": `{"labels":["bug"]}`, "repo2": "48"},
+		},
+		{
+			name:     "list of JSON objects",
+			value:    `[{"alice": "U1"}, {"bob": "U2"}]`,
+			expected: map[string]string{"alice": "U1", "bob": "U2"},
+		},
+		{
+			name:     "YAML flow mapping",
+			value:    `{alice: U1, "my-org/platform": S1}`,
+			expected: map[string]string{"alice": "U1", "my-org/platform": "S1"},
+		},
+		{
+			name:             "invalid JSON",
+			value:            `{"alice": "U1",, }`,
+			expectedErrorMsg: "invalid JSON in test",
+		},
+		{
+			name:             "empty value",
+			value:            "{\n  \"alice\": \"U1\",\n  \"bob\": \"\"\n}",
+			expectedErrorMsg: "invalid value of mapping key 'bob' in test at line 3, column 10: the value is empty",
+		},
+		{
+			name:             "duplicate key",
+			value:            `[{"alice": "U1"}, {"alice": "U2"}]`,
+			expectedErrorMsg: "duplicate mapping key 'alice' in test at line 1, column 20",
+		},
+		{
+			name:             "list of other than objects",
+			value:            `["alice"]`,
+			expectedErrorMsg: "invalid mapping in test at line 1, column 2: expected an object",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("INPUT_TEST", tc.value)
+
+			mapping, err := inputhelpers.GetInputMapping("test")
+			if tc.expectedErrorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErrorMsg) {
+					t.Fatalf("Expected error containing '%s', got: %v", tc.expectedErrorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !maps.Equal(mapping, tc.expected) {
+				t.Errorf("Expected mapping %v, got %v", tc.expected, mapping)
+			}
+		})
+	}
+}
+
 func TestGetInputOr_NotSetUsesDefault(t *testing.T) {
 	value := inputhelpers.GetInputOr("missing", "default-val")
 	if value != "default-val" {
@@ -154,3 +249,213 @@ func TestGetInputOr_ExplicitEmptyOverridesDefault(t *testing.T) {
 		t.Errorf("Expected empty string, got '%s'", value)
 	}
 }
+
+func TestWithOverrides(t *testing.T) {
+	t.Setenv("INPUT_TEST", "from_env")
+	t.Setenv("INPUT_OTHER", "other_from_env")
+	t.Setenv("TEST_ENV", "from_env")
+
+	inputhelpers.WithOverrides(
+		map[string]string{"test": "from_override", "empty": ""},
+		map[string]string{"TEST_ENV": "from_override"},
+		func() {
+			if value := inputhelpers.GetInput("test"); value != "from_override" {
+				t.Errorf("Expected 'from_override', got '%s'", value)
+			}
+			if value := inputhelpers.GetInput("other"); value != "other_from_env" {
+				t.Errorf("Expected 'other_from_env', got '%s'", value)
+			}
+			if value := inputhelpers.GetInputOr("empty", "default"); value != "" {
+				t.Errorf("Expected '', got '%s'", value)
+			}
+			if value := inputhelpers.GetEnv("TEST_ENV"); value != "from_override" {
+				t.Errorf("Expected 'from_override', got '%s'", value)
+			}
+		},
+	)
+
+	if value := inputhelpers.GetInput("test"); value != "from_env" {
+		t.Errorf("Expected the overrides to be removed, got '%s'", value)
+	}
+}
+
+func TestWithOverrides_StatePerRead(t *testing.T) {
+	inputhelpers.WithOverrides(nil, nil, func() {
+		inputhelpers.SetDeprecations(inputhelpers.Deprecation{Name: "old-name", ReplacedBy: "new-name"})
+	})
+	inputhelpers.WithOverrides(map[string]string{"old-name": "old"}, nil, func() {
+		if value := inputhelpers.GetInput("new-name"); value != "" {
+			t.Errorf("Expected the deprecations of the previous read to be left out, got '%s'", value)
+		}
+	})
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			expected := strconv.Itoa(i)
+			inputhelpers.WithOverrides(map[string]string{"test": expected}, nil, func() {
+				if value := inputhelpers.GetInput("test"); value != expected {
+					t.Errorf("Expected '%s', got '%s'", expected, value)
+				}
+			})
+		}()
+	}
+	wg.Wait()
+}
+
+func TestUnknownInputs(t *testing.T) {
+	t.Setenv("INPUT_OLD-PR-THRESHOLD-HOURS", "8")
+	t.Setenv("INPUT_OLD_PR_TRESHOLD_HOURS", "8")
+	t.Setenv("INPUT_SOMETHING-ELSE", "x")
+
+	// The declared inputs are known even if they have not been read
+	unknown := inputhelpers.UnknownInputs([]string{"old-pr-threshold-hours", "pr-list-heading"})
+
+	expected := []inputhelpers.UnknownInput{
+		{EnvName: "INPUT_OLD_PR_TRESHOLD_HOURS", Suggestion: "old-pr-threshold-hours"},
+		{EnvName: "INPUT_SOMETHING-ELSE"},
+	}
+	if !slices.Equal(unknown, expected) {
+		t.Errorf("Expected unknown inputs %v, got %v", expected, unknown)
+	}
+}
+
+func TestDeprecations(t *testing.T) {
+	inputhelpers.SetDeprecations(
+		inputhelpers.Deprecation{Name: "old-name", ReplacedBy: "new-name"},
+		inputhelpers.Deprecation{Name: "removed", Note: "it has no effect"},
+	)
+	t.Cleanup(func() { inputhelpers.SetDeprecations() })
+
+	testCases := []struct {
+		name             string
+		env              map[string]string
+		expectedValue    string
+		expectedWarnings []string
+	}{
+		{
+			name:          "new name",
+			env:           map[string]string{"INPUT_NEW-NAME": "new"},
+			expectedValue: "new",
+		},
+		{
+			name:             "old name is an alias of the new name",
+			env:              map[string]string{"INPUT_OLD-NAME": "old"},
+			expectedValue:    "old",
+			expectedWarnings: []string{"input old-name is deprecated, use new-name instead"},
+		},
+		{
+			name:             "new name is used if both are set",
+			env:              map[string]string{"INPUT_OLD-NAME": "old", "INPUT_NEW-NAME": "new"},
+			expectedValue:    "new",
+			expectedWarnings: []string{"input old-name is deprecated and ignored, as new-name is also set"},
+		},
+		{
+			name:             "deprecated input without replacement",
+			env:              map[string]string{"INPUT_REMOVED": "true"},
+			expectedWarnings: []string{"input removed is deprecated (it has no effect)"},
+		},
+		{
+			name: "empty deprecated input is not warned about",
+			env:  map[string]string{"INPUT_REMOVED": ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+			if value := inputhelpers.GetInput("new-name"); value != tc.expectedValue {
+				t.Errorf("Expected '%s', got '%s'", tc.expectedValue, value)
+			}
+			warnings := inputhelpers.DeprecationWarnings()
+			if !slices.Equal(warnings, tc.expectedWarnings) {
+				t.Errorf("Expected warnings %v, got %v", tc.expectedWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestLoadFileInputs(t *testing.T) {
+	fileInputs := []inputhelpers.FileInput{
+		{Name: "filters", FileInput: "filters-file"},
+		{Name: "mapping", FileInput: "mapping-file"},
+	}
+	t.Cleanup(func() { _ = inputhelpers.LoadFileInputs() })
+
+	testCases := []struct {
+		name             string
+		env              map[string]string
+		files            map[string]string // contents by input name of the file
+		expectedFilters  string
+		expectedMapping  map[string]string
+		expectedErrorMsg string
+	}{
+		{
+			name:            "input is read from the file",
+			files:           map[string]string{"filters-file": `{"authors": ["alice"]}`},
+			expectedFilters: `{"authors": ["alice"]}`,
+			expectedMapping: map[string]string{},
+		},
+		{
+			name:            "mapping file in the format of the input",
+			files:           map[string]string{"mapping-file": "# comment\nalice: U1\nbob: U2\n"},
+			expectedMapping: map[string]string{"alice": "U1", "bob": "U2"},
+		},
+		{
+			name:            "mapping file as JSON object",
+			files:           map[string]string{"mapping-file": `{"alice": "U1", "repo": {"labels": ["bug"]}}`},
+			expectedMapping: map[string]string{"alice": "U1", "repo": `{"labels":["bug"]}`},
+		},
+		{
+			name:             "input and its file are both set",
+			env:              map[string]string{"INPUT_FILTERS": `{"authors": ["bob"]}`},
+			files:            map[string]string{"filters-file": `{"authors": ["alice"]}`},
+			expectedErrorMsg: "only one of filters and filters-file can be set",
+		},
+		{
+			name:             "file not found",
+			env:              map[string]string{"INPUT_FILTERS-FILE": "does-not-exist.json"},
+			expectedErrorMsg: "failed to read filters-file",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+			for fileInput, content := range tc.files {
+				path := filepath.Join(t.TempDir(), fileInput)
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write file: %v", err)
+				}
+				t.Setenv("INPUT_"+strings.ToUpper(fileInput), path)
+			}
+
+			err := inputhelpers.LoadFileInputs(fileInputs...)
+			if tc.expectedErrorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErrorMsg) {
+					t.Fatalf("Expected error containing '%s', got: %v", tc.expectedErrorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if value := inputhelpers.GetInput("filters"); value != tc.expectedFilters {
+				t.Errorf("Expected filters '%s', got '%s'", tc.expectedFilters, value)
+			}
+			mapping, err := inputhelpers.GetInputMapping("mapping")
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !maps.Equal(mapping, tc.expectedMapping) {
+				t.Errorf("Expected mapping %v, got %v", tc.expectedMapping, mapping)
+			}
+		})
+	}
+}
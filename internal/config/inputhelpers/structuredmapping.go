@@ -0,0 +1,89 @@
+package inputhelpers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mapping inputs can also be given as a JSON object (or a YAML flow mapping), e.g. {"alice": "U1234567890"},
+// or as a list of such objects. This is less error-prone than the "key: value" lines when the values are
+// JSON themselves (e.g. the filters of repository-filters), as the value of each key is parsed separately.
+func isStructuredMapping(value string) bool {
+	trimmed := strings.TrimSpace(value)
+	return strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")
+}
+
+// Parses the mapping from a JSON object or a list of them. String values are used as they are, other
+// values (e.g. filters) as compact JSON. The errors point to the offending key by its line and column.
+func parseStructuredMapping(inputName, value string) (map[string]string, error) {
+	if json.Valid([]byte(value)) {
+		// tabs are valid whitespace in JSON but not in YAML (in valid JSON they only occur as whitespace)
+		value = strings.ReplaceAll(value, "\t", " ")
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &root); err != nil {
+		return nil, fmt.Errorf("invalid JSON in %s: %w", inputName, err)
+	}
+	document := root.Content[0]
+	objects := []*yaml.Node{document}
+	if document.Kind == yaml.SequenceNode {
+		objects = document.Content
+	}
+
+	mapping := make(map[string]string)
+	for _, object := range objects {
+		if object.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf(
+				"invalid mapping in %s at line %d, column %d: expected an object", inputName, object.Line, object.Column,
+			)
+		}
+		for i := 0; i+1 < len(object.Content); i += 2 {
+			keyNode, valueNode := object.Content[i], object.Content[i+1]
+			key := strings.TrimSpace(keyNode.Value)
+			if keyNode.Kind != yaml.ScalarNode || key == "" {
+				return nil, fmt.Errorf(
+					"invalid mapping key in %s at line %d, column %d", inputName, keyNode.Line, keyNode.Column,
+				)
+			}
+			if _, exists := mapping[key]; exists {
+				return nil, fmt.Errorf(
+					"duplicate mapping key '%s' in %s at line %d, column %d", key, inputName, keyNode.Line, keyNode.Column,
+				)
+			}
+			mappingValue, err := getStructuredMappingValue(valueNode)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"invalid value of mapping key '%s' in %s at line %d, column %d: %w",
+					key, inputName, valueNode.Line, valueNode.Column, err,
+				)
+			}
+			mapping[key] = mappingValue
+		}
+	}
+	return mapping, nil
+}
+
+func getStructuredMappingValue(node *yaml.Node) (string, error) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode {
+		if node.Tag == "!!null" || strings.TrimSpace(node.Value) == "" {
+			return "", errors.New("the value is empty")
+		}
+		return node.Value, nil
+	}
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return "", err
+	}
+	compacted, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(compacted), nil
+}
@@ -0,0 +1,68 @@
+package inputhelpers
+
+import (
+	"slices"
+	"strings"
+)
+
+// UnknownInput is an INPUT_ environment variable that does not match any input of the action.
+type UnknownInput struct {
+	EnvName    string // e.g. INPUT_OLD_PR_TRESHOLD_HOURS
+	Suggestion string // the closest known input (e.g. old-pr-threshold-hours), if any is close enough
+}
+
+// UnknownInputs returns the INPUT_ environment variables that do not match any of the declared
+// inputs (of action.yml).
+func UnknownInputs(declared []string) []UnknownInput {
+	known := slices.Sorted(slices.Values(declared))
+	knownEnvNames := map[string]bool{}
+	for _, name := range known {
+		knownEnvNames[inputNameAsEnv(name)] = true
+	}
+	var unknown []UnknownInput
+	for _, variable := range environ() {
+		envName, _, _ := strings.Cut(variable, "=")
+		if !strings.HasPrefix(envName, "INPUT_") || knownEnvNames[envName] {
+			continue
+		}
+		knownEnvNames[envName] = true // listed once even if overridden
+		unknown = append(unknown, UnknownInput{EnvName: envName, Suggestion: closestInput(envName, known)})
+	}
+	slices.SortFunc(unknown, func(a, b UnknownInput) int { return strings.Compare(a.EnvName, b.EnvName) })
+	return unknown
+}
+
+// Returns the known input closest to the environment variable (ignoring case and the difference
+// of "_" and "-"), or an empty string if none is within a few typos of it.
+func closestInput(envName string, known []string) string {
+	const maxDistance = 3
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(envName, "INPUT_")), "_", "-")
+	closest, closestDistance := "", maxDistance+1
+	for _, name := range known {
+		if distance := editDistance(normalized, name); distance < closestDistance {
+			closest, closestDistance = name, distance
+		}
+	}
+	return closest
+}
+
+// Levenshtein distance of the strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// MessageStyle defines how the PRs are shown in the message.
+type MessageStyle string
+
+const (
+	MessageStyleList MessageStyle = "list" // each PR on its own line (default)
+	// One line per repository with the numbers of its open and old PRs, e.g. for org-wide channels
+	MessageStyleDigest MessageStyle = "digest"
+)
+
+func getMessageStyle(inputName string) (MessageStyle, error) {
+	switch raw := cmp.Or(inputhelpers.GetInput(inputName), string(MessageStyleList)); raw {
+	case string(MessageStyleList):
+		return MessageStyleList, nil
+	case string(MessageStyleDigest):
+		return MessageStyleDigest, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s' or '%s')", inputName, raw, MessageStyleList, MessageStyleDigest,
+		)
+	}
+}
+
+// The digest replaces the PR lists, so the PRs cannot be grouped or split into threads by PR.
+func (c Config) validateMessageStyle() error {
+	if c.ContentInputs.MessageStyle != MessageStyleDigest {
+		return nil
+	}
+	if c.ContentInputs.GroupByRepository || len(c.ContentInputs.GroupByLabels) > 0 {
+		return fmt.Errorf("%s: %s cannot be used with %s", InputMessageStyle, MessageStyleDigest, InputGroupBy)
+	}
+	if c.RunMode == RunModeEvent {
+		return fmt.Errorf("%s: %s is not supported with run mode '%s'", InputMessageStyle, MessageStyleDigest, RunModeEvent)
+	}
+	return nil
+}
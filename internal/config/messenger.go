@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// Messenger is the chat service the reminder is sent to.
+type Messenger string
+
+const (
+	MessengerSlack      Messenger = "slack"
+	MessengerGoogleChat Messenger = "googlechat"
+	MessengerDiscord    Messenger = "discord"
+	MessengerMatrix     Messenger = "matrix"
+)
+
+// MatrixInputs configures posting to a Matrix room via the client-server API.
+type MatrixInputs struct {
+	HomeserverURL string
+	AccessToken   string
+	RoomID        string
+}
+
+func getMatrixInputs() MatrixInputs {
+	return MatrixInputs{
+		HomeserverURL: inputhelpers.GetInput(InputMatrixHomeserverURL),
+		AccessToken:   inputhelpers.GetInput(InputMatrixAccessToken),
+		RoomID:        inputhelpers.GetInput(InputMatrixRoomID),
+	}
+}
+
+func getMessenger(inputName string) (Messenger, error) {
+	return parseMessenger(inputhelpers.GetInputOr(inputName, string(DefaultMessenger)))
+}
+
+// parseMessenger validates a raw string as a Messenger.
+// It returns an error for unsupported values; defaulting is handled by callers.
+func parseMessenger(raw string) (Messenger, error) {
+	switch raw {
+	case string(MessengerSlack):
+		return MessengerSlack, nil
+	case string(MessengerGoogleChat):
+		return MessengerGoogleChat, nil
+	case string(MessengerDiscord):
+		return MessengerDiscord, nil
+	case string(MessengerMatrix):
+		return MessengerMatrix, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid messenger: %s (expected '%s', '%s', '%s' or '%s')",
+			raw, MessengerSlack, MessengerGoogleChat, MessengerDiscord, MessengerMatrix,
+		)
+	}
+}
+
+// Messengers other than Slack can only post new messages (no updating or deleting them).
+func (c Config) validateMessenger() error {
+	switch c.Messenger {
+	case MessengerGoogleChat:
+		if c.GoogleChatWebhookURL == "" {
+			return fmt.Errorf("%s is required when %s is '%s'", InputGoogleChatWebhookURL, InputMessenger, c.Messenger)
+		}
+	case MessengerDiscord:
+		if c.DiscordWebhookURL == "" {
+			return fmt.Errorf("%s is required when %s is '%s'", InputDiscordWebhookURL, InputMessenger, c.Messenger)
+		}
+	case MessengerMatrix:
+		if c.Matrix.HomeserverURL == "" || c.Matrix.AccessToken == "" || c.Matrix.RoomID == "" {
+			return fmt.Errorf(
+				"%s, %s and %s are required when %s is '%s'",
+				InputMatrixHomeserverURL, InputMatrixAccessToken, InputMatrixRoomID, InputMessenger, c.Messenger,
+			)
+		}
+	default:
+		return nil
+	}
+	if c.RunMode != RunModePost {
+		return fmt.Errorf("run mode '%s' is not supported with messenger '%s'", c.RunMode, c.Messenger)
+	}
+	return nil
+}
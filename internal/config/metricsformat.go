@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+type MetricsFormat string
+
+const (
+	MetricsFormatJSON MetricsFormat = "json"
+	MetricsFormatCSV  MetricsFormat = "csv"
+)
+
+func getMetricsFormat(inputName string) (MetricsFormat, error) {
+	return parseMetricsFormat(inputhelpers.GetInputOr(inputName, string(DefaultMetricsFormat)))
+}
+
+func parseMetricsFormat(raw string) (MetricsFormat, error) {
+	switch raw {
+	case string(MetricsFormatJSON):
+		return MetricsFormatJSON, nil
+	case string(MetricsFormatCSV):
+		return MetricsFormatCSV, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid metrics format: %s (expected '%s' or '%s')", raw, MetricsFormatJSON, MetricsFormatCSV,
+		)
+	}
+}
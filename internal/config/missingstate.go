@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// MissingStatePolicy defines what the update mode does when no state artifact is found
+// (e.g. on the first run of the day, before a message has been posted).
+type MissingStatePolicy string
+
+const (
+	MissingStateFail MissingStatePolicy = "fail" // fail the run
+	MissingStatePost MissingStatePolicy = "post" // fall back to post mode (post a new message)
+	MissingStateSkip MissingStatePolicy = "skip" // exit without doing anything
+)
+
+func getMissingStatePolicy(inputName string) (MissingStatePolicy, error) {
+	return parseMissingStatePolicy(inputhelpers.GetInputOr(inputName, string(DefaultMissingStatePolicy)))
+}
+
+func parseMissingStatePolicy(raw string) (MissingStatePolicy, error) {
+	switch raw {
+	case string(MissingStateFail):
+		return MissingStateFail, nil
+	case string(MissingStatePost):
+		return MissingStatePost, nil
+	case string(MissingStateSkip):
+		return MissingStateSkip, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s', '%s' or '%s')",
+			InputOnMissingState, raw, MissingStateFail, MissingStatePost, MissingStateSkip,
+		)
+	}
+}
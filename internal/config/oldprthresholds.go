@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+)
+
+// MaxOldPRThresholdTiers is the maximum number of old PR thresholds, one per marker
+// (⚠️, 🚨 and 🔥).
+const MaxOldPRThresholdTiers = 3
+
+// Parses the old PR threshold, or a list of thresholds in ascending order (e.g. "24; 72; 168")
+// for escalating markers. Returns the lowest threshold, and all thresholds if several are given.
+func getOldPRThresholds(inputName string) (int, []int, error) {
+	rawThresholds := inputhelpers.GetInputList(inputName)
+	if len(rawThresholds) == 0 {
+		return 0, nil, nil
+	}
+	thresholds := make([]int, 0, len(rawThresholds))
+	for _, rawHours := range rawThresholds {
+		hours, err := strconv.Atoi(rawHours)
+		if err != nil {
+			return 0, nil, fmt.Errorf("error parsing input %s as integer: %v", inputName, err)
+		}
+		thresholds = append(thresholds, hours)
+	}
+	if len(thresholds) == 1 {
+		return thresholds[0], nil, nil
+	}
+	if len(thresholds) > MaxOldPRThresholdTiers {
+		return 0, nil, fmt.Errorf(
+			"%s accepts at most %d thresholds, got %d", inputName, MaxOldPRThresholdTiers, len(thresholds),
+		)
+	}
+	for i, hours := range thresholds {
+		if hours <= 0 || (i > 0 && hours <= thresholds[i-1]) {
+			return 0, nil, fmt.Errorf(
+				"invalid %s: %v (expected positive hours in ascending order)", inputName, thresholds,
+			)
+		}
+	}
+	return thresholds[0], thresholds, nil
+}
+
+// Parses the repository specific overrides of the old PR threshold from a mapping of repository
+// names (or owner/repo paths) to hours, e.g. "infra-repo: 8; app: 48".
+func getOldPRThresholdHoursByRepo(inputName string) (map[string]int, error) {
+	rawHoursByRepo, err := inputhelpers.GetInputMapping(inputName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input %s: %w", inputName, err)
+	}
+	hoursByRepo := make(map[string]int, len(rawHoursByRepo))
+	for repo, rawHours := range rawHoursByRepo {
+		hours, err := strconv.Atoi(rawHours)
+		if err != nil || hours < 0 {
+			return nil, fmt.Errorf(
+				"invalid %s for repository %s: %s (expected a non-negative number of hours)", inputName, repo, rawHours,
+			)
+		}
+		hoursByRepo[repo] = hours
+	}
+	return hoursByRepo, nil
+}
+
+// GetOldPRThresholdHours returns the old PR threshold of the repository: the repository specific
+// override if one is set (by owner/repo path or name), otherwise the global threshold.
+func (c ContentInputs) GetOldPRThresholdHours(repo models.Repository) int {
+	for _, key := range []string{repo.GetPath(), repo.Name} {
+		if hours, exists := c.OldPRThresholdHoursByRepo[key]; exists {
+			return hours
+		}
+	}
+	return c.OldPRThresholdHours
+}
+
+// GetOldPRThresholdTiers returns the old PR thresholds of the repository in ascending order.
+// A repository specific override replaces all tiers of the global thresholds. Returns nil if
+// old PRs are not highlighted in the repository.
+func (c ContentInputs) GetOldPRThresholdTiers(repo models.Repository) []int {
+	for _, key := range []string{repo.GetPath(), repo.Name} {
+		if hours, exists := c.OldPRThresholdHoursByRepo[key]; exists {
+			return thresholdTiersOf(hours)
+		}
+	}
+	if len(c.OldPRThresholdTiers) > 0 {
+		return c.OldPRThresholdTiers
+	}
+	return thresholdTiersOf(c.OldPRThresholdHours)
+}
+
+func thresholdTiersOf(hours int) []int {
+	if hours == 0 {
+		return nil
+	}
+	return []int{hours}
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+type OnCallProvider string
+
+const (
+	OnCallProviderNone      OnCallProvider = ""
+	OnCallProviderPagerDuty OnCallProvider = "pagerduty"
+	OnCallProviderOpsgenie  OnCallProvider = "opsgenie"
+)
+
+// OnCallInputs configures the optional on-call schedule integration, which mentions
+// the current on-call user as the review captain in the message.
+type OnCallInputs struct {
+	Provider   OnCallProvider
+	ScheduleID string
+	APIToken   string
+	// Slack user IDs by the email addresses of the on-call users
+	SlackUserIdByEmail map[string]string
+}
+
+func (o OnCallInputs) IsEnabled() bool {
+	return o.Provider != OnCallProviderNone
+}
+
+func getOnCallInputs() (OnCallInputs, error) {
+	provider, err := parseOnCallProvider(inputhelpers.GetInput(InputOnCallProvider))
+	if err != nil {
+		return OnCallInputs{}, err
+	}
+	slackUserIdByEmail, err := inputhelpers.GetInputMapping(InputOnCallSlackUserIdByEmail)
+	if err != nil {
+		return OnCallInputs{}, err
+	}
+	return OnCallInputs{
+		Provider:           provider,
+		ScheduleID:         inputhelpers.GetInput(InputOnCallScheduleID),
+		APIToken:           inputhelpers.GetInput(InputOnCallAPIToken),
+		SlackUserIdByEmail: slackUserIdByEmail,
+	}, nil
+}
+
+func parseOnCallProvider(raw string) (OnCallProvider, error) {
+	switch raw {
+	case string(OnCallProviderNone):
+		return OnCallProviderNone, nil
+	case string(OnCallProviderPagerDuty):
+		return OnCallProviderPagerDuty, nil
+	case string(OnCallProviderOpsgenie):
+		return OnCallProviderOpsgenie, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid on-call provider: %s (expected '%s' or '%s')",
+			raw, OnCallProviderPagerDuty, OnCallProviderOpsgenie,
+		)
+	}
+}
+
+func (o OnCallInputs) validate() error {
+	if !o.IsEnabled() {
+		return nil
+	}
+	if o.ScheduleID == "" || o.APIToken == "" {
+		return fmt.Errorf(
+			"%s and %s are required when %s is set", InputOnCallScheduleID, InputOnCallAPIToken, InputOnCallProvider,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+)
+
+// ConfigOutputName is the name of the action output with the effective configuration.
+const ConfigOutputName = "config"
+
+// WriteOutput writes the configuration (with the secrets masked) as JSON to the GITHUB_OUTPUT file
+// as the config output, so that workflows can check the effective configuration (e.g. of each
+// job of a matrix). Nothing is written if the GITHUB_OUTPUT file is not set (e.g. when run locally).
+func (c Config) WriteOutput() error {
+	if c.GithubOutputFilePath == "" {
+		return nil
+	}
+	asJson, err := json.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	file, err := os.OpenFile(c.GithubOutputFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open output file %s: %w", c.GithubOutputFilePath, err)
+	}
+	defer file.Close()
+	// compact JSON is a single line, as newlines in strings are escaped
+	if _, err := fmt.Fprintf(file, "%s=%s\n", ConfigOutputName, asJson); err != nil {
+		return fmt.Errorf("failed to write output file %s: %w", c.GithubOutputFilePath, err)
+	}
+	log.Printf("Wrote the configuration to the %s output", ConfigOutputName)
+	return nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// PRDataCacheMode defines whether the fetched PRs are shared between the jobs of a workflow run
+// (e.g. jobs posting to different channels), so that the later jobs do not fetch them again.
+type PRDataCacheMode string
+
+const (
+	PRDataCacheOff   PRDataCacheMode = ""
+	PRDataCacheWrite PRDataCacheMode = "write" // the fetched PRs are written to the cache file
+	PRDataCacheRead  PRDataCacheMode = "read"  // the PRs are read from the cache artifact of the workflow run
+)
+
+// PRDataCacheInputs configure sharing the fetched PRs between the jobs of a workflow run: the job
+// writing the cache file uploads it as an artifact, which the later jobs of the run download.
+type PRDataCacheInputs struct {
+	Mode         PRDataCacheMode
+	FilePath     string
+	ArtifactName string
+	// ID of the current workflow run, of which the cache artifact is read
+	RunID int64
+}
+
+func getPRDataCacheInputs() (PRDataCacheInputs, error) {
+	mode, err := parsePRDataCacheMode(inputhelpers.GetInput(InputPRDataCache))
+	if err != nil {
+		return PRDataCacheInputs{}, err
+	}
+	var runID int64
+	if rawRunID := inputhelpers.GetEnv(EnvGithubRunID); rawRunID != "" && mode == PRDataCacheRead {
+		if runID, err = strconv.ParseInt(rawRunID, 10, 64); err != nil {
+			return PRDataCacheInputs{}, fmt.Errorf("invalid %s: %s", EnvGithubRunID, rawRunID)
+		}
+	}
+	return PRDataCacheInputs{
+		Mode:         mode,
+		FilePath:     cmp.Or(inputhelpers.GetInput(InputPRDataCacheFilePath), DefaultPRDataCacheFilePath),
+		ArtifactName: cmp.Or(inputhelpers.GetInput(InputPRDataCacheArtifact), DefaultPRDataCacheArtifact),
+		RunID:        runID,
+	}, nil
+}
+
+func parsePRDataCacheMode(raw string) (PRDataCacheMode, error) {
+	switch raw {
+	case string(PRDataCacheOff):
+		return PRDataCacheOff, nil
+	case string(PRDataCacheWrite):
+		return PRDataCacheWrite, nil
+	case string(PRDataCacheRead):
+		return PRDataCacheRead, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s' or '%s')", InputPRDataCache, raw, PRDataCacheWrite, PRDataCacheRead,
+		)
+	}
+}
+
+// The cache is only used when fetching the open PRs, i.e. not in update and event modes.
+func (i PRDataCacheInputs) validate(runMode RunMode) error {
+	if i.Mode == PRDataCacheOff {
+		return nil
+	}
+	if !slices.Contains([]RunMode{RunModePost, RunModeSync}, runMode) {
+		return fmt.Errorf("%s is supported only with run modes '%s' and '%s'", InputPRDataCache, RunModePost, RunModeSync)
+	}
+	if i.Mode == PRDataCacheRead && i.RunID == 0 {
+		return fmt.Errorf("%s must be set when %s is '%s'", EnvGithubRunID, InputPRDataCache, PRDataCacheRead)
+	}
+	return nil
+}
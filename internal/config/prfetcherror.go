@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// PRFetchErrorPolicy defines what the update mode does when fetching a PR of the state fails
+// (e.g. if the PR or its repository was deleted, or access to it was revoked).
+type PRFetchErrorPolicy string
+
+const (
+	PRFetchErrorFail PRFetchErrorPolicy = "fail" // fail the run
+	PRFetchErrorSkip PRFetchErrorPolicy = "skip" // leave the PR out of the message (logged as a warning)
+)
+
+func getPRFetchErrorPolicy(inputName string) (PRFetchErrorPolicy, error) {
+	return parsePRFetchErrorPolicy(inputhelpers.GetInputOr(inputName, string(DefaultPRFetchErrorPolicy)))
+}
+
+func parsePRFetchErrorPolicy(raw string) (PRFetchErrorPolicy, error) {
+	switch raw {
+	case string(PRFetchErrorFail):
+		return PRFetchErrorFail, nil
+	case string(PRFetchErrorSkip):
+		return PRFetchErrorSkip, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s' or '%s')", InputOnPRFetchError, raw, PRFetchErrorFail, PRFetchErrorSkip,
+		)
+	}
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// QuietRepos defines how the repositories without open PRs are shown in the message.
+type QuietRepos string
+
+const (
+	QuietReposHide   QuietRepos = "hide"   // not shown at all
+	QuietReposFooter QuietRepos = "footer" // listed in a single footer line
+	QuietReposInline QuietRepos = "inline" // shown as repository groups of their own (requires group-by: repository)
+)
+
+func getQuietRepos(inputName string) (QuietRepos, error) {
+	return parseQuietRepos(inputhelpers.GetInputOr(inputName, string(DefaultQuietRepos)))
+}
+
+func parseQuietRepos(raw string) (QuietRepos, error) {
+	switch raw {
+	case string(QuietReposHide):
+		return QuietReposHide, nil
+	case string(QuietReposFooter):
+		return QuietReposFooter, nil
+	case string(QuietReposInline):
+		return QuietReposInline, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s', '%s' or '%s')",
+			InputShowQuietRepos, raw, QuietReposHide, QuietReposFooter, QuietReposInline,
+		)
+	}
+}
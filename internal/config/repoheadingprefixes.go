@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+)
+
+// Parses the prefixes of the repository headings from a mapping of repository names (or owner/repo
+// paths) to prefixes, e.g. "infra: 🛠; app: 📱", so that the headings can carry e.g. team emojis.
+func getHeadingPrefixByRepo(inputName string) (map[string]string, error) {
+	prefixByRepo, err := inputhelpers.GetInputMapping(inputName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input %s: %w", inputName, err)
+	}
+	return prefixByRepo, nil
+}
+
+// GetRepositoryHeadingPrefix returns the prefix of the heading of the PRs of the repository (by
+// owner/repo path or name), or an empty string if none is configured.
+func (c ContentInputs) GetRepositoryHeadingPrefix(repo models.Repository) string {
+	for _, key := range []string{repo.GetPath(), repo.Name} {
+		if prefix, exists := c.HeadingPrefixByRepo[key]; exists {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// The prefixes are only shown in the headings of the repositories.
+func (c Config) validateHeadingPrefixByRepo() error {
+	if len(c.ContentInputs.HeadingPrefixByRepo) > 0 && !c.ContentInputs.GroupByRepository {
+		return fmt.Errorf("%s requires %s: %s", InputRepoHeadingPrefixes, InputGroupBy, GroupByRepository)
+	}
+	return validateRepositoryReferences(c.Repositories, c.ContentInputs.HeadingPrefixByRepo, InputRepoHeadingPrefixes)
+}
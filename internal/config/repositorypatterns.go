@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+)
+
+// RepositoryPatterns are glob patterns (e.g. "my-org/*") of the repositories that are allowed
+// and denied in the reminders, matched case-insensitively against the "owner/name" paths of
+// the repositories. They guard against accidentally including sensitive repositories.
+type RepositoryPatterns struct {
+	Allow []string // if set, each repository must match one of these
+	Deny  []string // no repository may match any of these
+}
+
+func getRepositoryPatterns(allowInputName, denyInputName string) (RepositoryPatterns, error) {
+	patterns := RepositoryPatterns{
+		Allow: inputhelpers.GetInputList(allowInputName),
+		Deny:  inputhelpers.GetInputList(denyInputName),
+	}
+	if err := validateGlobPatterns(allowInputName, patterns.Allow); err != nil {
+		return RepositoryPatterns{}, err
+	}
+	if err := validateGlobPatterns(denyInputName, patterns.Deny); err != nil {
+		return RepositoryPatterns{}, err
+	}
+	return patterns, nil
+}
+
+func validateGlobPatterns(inputName string, patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid %s: %s (%v)", inputName, pattern, err)
+		}
+	}
+	return nil
+}
+
+// Returns an error if any of the repositories is not allowed or is denied by the patterns.
+func (p RepositoryPatterns) validate(repositories []models.Repository) error {
+	for _, repo := range repositories {
+		if len(p.Allow) > 0 && findMatchingPattern(p.Allow, repo) == "" {
+			return fmt.Errorf(
+				"repository '%s' does not match any of the patterns of %s", repo.GetPath(), InputRepositoryAllowPattern,
+			)
+		}
+		if pattern := findMatchingPattern(p.Deny, repo); pattern != "" {
+			return fmt.Errorf(
+				"repository '%s' is denied by the pattern '%s' of %s", repo.GetPath(), pattern, InputRepositoryDenyPattern,
+			)
+		}
+	}
+	return nil
+}
+
+// Returns the first of the patterns that matches the repository, or an empty string if none matches.
+func findMatchingPattern(patterns []string, repo models.Repository) string {
+	for _, pattern := range patterns {
+		// The patterns are validated when parsed, so the error can be ignored
+		if matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(repo.GetPath())); matched {
+			return pattern
+		}
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+)
+
+// Parses the tokens of the repositories from a mapping of owner/repo paths to tokens, e.g.
+// "other-org/repo: github_pat_...", so that the repositories of organizations requiring their own
+// fine-grained tokens can be fetched in the same run.
+func getRepositoryTokens(inputName string) (map[models.Repository]string, error) {
+	rawTokensByRepo, err := inputhelpers.GetInputMapping(inputName)
+	if err != nil {
+		// the error message would contain the token
+		return nil, fmt.Errorf("invalid %s: expected lines of owner/repo: token", inputName)
+	}
+	tokens := make(map[models.Repository]string, len(rawTokensByRepo))
+	for rawRepo, token := range rawTokensByRepo {
+		repo, err := models.ParseRepository(rawRepo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", inputName, err)
+		}
+		tokens[repo] = token
+	}
+	return tokens, nil
+}
+
+// A token of a repository that is not fetched is most likely a typo.
+func (c Config) validateRepositoryTokens() error {
+	for repo := range c.RepositoryTokens {
+		if !slices.Contains(c.Repositories, repo) {
+			return fmt.Errorf(
+				"%s has a token of %s, which is not one of the repositories", InputRepositoryTokens, repo.GetPath(),
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// ReviewerLinkStyle defines how the approvers and commenters of PRs are shown in Slack messages.
+type ReviewerLinkStyle string
+
+const (
+	ReviewerLinkStylePlain  ReviewerLinkStyle = "plain"  // GitHub names as plain text
+	ReviewerLinkStyleGitHub ReviewerLinkStyle = "github" // GitHub names linked to the GitHub profiles
+	ReviewerLinkStyleSlack  ReviewerLinkStyle = "slack"  // Slack mentions if mapped, otherwise plain text
+)
+
+func getReviewerLinkStyle(inputName string) (ReviewerLinkStyle, error) {
+	return parseReviewerLinkStyle(inputhelpers.GetInputOr(inputName, string(DefaultReviewerLinkStyle)))
+}
+
+func parseReviewerLinkStyle(raw string) (ReviewerLinkStyle, error) {
+	switch raw {
+	case string(ReviewerLinkStylePlain):
+		return ReviewerLinkStylePlain, nil
+	case string(ReviewerLinkStyleGitHub):
+		return ReviewerLinkStyleGitHub, nil
+	case string(ReviewerLinkStyleSlack):
+		return ReviewerLinkStyleSlack, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s', '%s' or '%s')",
+			InputReviewerLinkStyle, raw, ReviewerLinkStylePlain, ReviewerLinkStyleGitHub, ReviewerLinkStyleSlack,
+		)
+	}
+}
@@ -11,6 +11,10 @@ type RunMode string
 const (
 	RunModePost   RunMode = "post"
 	RunModeUpdate RunMode = "update"
+	RunModeEvent  RunMode = "event"
+	RunModeSync   RunMode = "sync"
+	// Writes a suggested github-user-slack-user-id-mapping to a file instead of sending a reminder
+	RunModeSuggestMapping RunMode = "suggest-mapping"
 )
 
 func getRunMode(inputName string) (RunMode, error) {
@@ -25,7 +29,16 @@ func parseRunMode(raw string) (RunMode, error) {
 		return RunModePost, nil
 	case string(RunModeUpdate):
 		return RunModeUpdate, nil
+	case string(RunModeEvent):
+		return RunModeEvent, nil
+	case string(RunModeSync):
+		return RunModeSync, nil
+	case string(RunModeSuggestMapping):
+		return RunModeSuggestMapping, nil
 	default:
-		return "", fmt.Errorf("invalid run mode: %s (expected '%s' or '%s')", raw, RunModePost, RunModeUpdate)
+		return "", fmt.Errorf(
+			"invalid run mode: %s (expected '%s', '%s', '%s', '%s' or '%s')",
+			raw, RunModePost, RunModeUpdate, RunModeEvent, RunModeSync, RunModeSuggestMapping,
+		)
 	}
 }
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// SentBlocksFormat defines the format of the file to which the sent Slack blocks are saved.
+type SentBlocksFormat string
+
+const (
+	// Blocks in an envelope with metadata of the message (see docs/sent-slack-blocks.schema.json)
+	SentBlocksFormatEnvelope SentBlocksFormat = "envelope"
+	// Bare array of blocks (the format before the envelope was added)
+	SentBlocksFormatBlocks SentBlocksFormat = "blocks"
+)
+
+func getSentBlocksFormat(envName string) (SentBlocksFormat, error) {
+	raw := inputhelpers.GetEnv(envName)
+	if raw == "" {
+		return DefaultSentSlackBlocksFormat, nil
+	}
+	return parseSentBlocksFormat(raw)
+}
+
+func parseSentBlocksFormat(raw string) (SentBlocksFormat, error) {
+	switch raw {
+	case string(SentBlocksFormatEnvelope):
+		return SentBlocksFormatEnvelope, nil
+	case string(SentBlocksFormatBlocks):
+		return SentBlocksFormatBlocks, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s' or '%s')",
+			EnvSentSlackBlocksFormat, raw, SentBlocksFormatEnvelope, SentBlocksFormatBlocks,
+		)
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// Colors of the severity levels, matching the legacy "good", "warning" and "danger" colors of Slack.
+const (
+	SeverityColorOK       = "#2eb886"
+	SeverityColorWarn     = "#daa038"
+	SeverityColorCritical = "#a30200"
+)
+
+// SeverityThresholds are the counts of old PRs at which the color bar of the message
+// turns from green to yellow (warn) and red (critical).
+type SeverityThresholds struct {
+	Warn     int
+	Critical int
+}
+
+// Parses the thresholds from a comma separated pair of old PR counts, e.g. "1,5".
+// Returns nil if the input is not set.
+func getSeverityThresholds(inputName string) (*SeverityThresholds, error) {
+	raw := inputhelpers.GetInput(inputName)
+	if raw == "" {
+		return nil, nil
+	}
+	parts := strings.Split(raw, ",")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid %s: %s (expected two old PR counts, e.g. 1,5)", inputName, raw)
+	}
+	warn, err1 := strconv.Atoi(strings.TrimSpace(parts[0]))
+	critical, err2 := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err1 != nil || err2 != nil || warn < 1 || critical < warn {
+		return nil, fmt.Errorf(
+			"invalid %s: %s (expected positive old PR counts with the warn threshold first, e.g. 1,5)", inputName, raw,
+		)
+	}
+	return &SeverityThresholds{Warn: warn, Critical: critical}, nil
+}
+
+// GetColor returns the color of the severity level reached by the count of old PRs.
+func (t SeverityThresholds) GetColor(oldPRCount int) string {
+	switch {
+	case oldPRCount >= t.Critical:
+		return SeverityColorCritical
+	case oldPRCount >= t.Warn:
+		return SeverityColorWarn
+	default:
+		return SeverityColorOK
+	}
+}
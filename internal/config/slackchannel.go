@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// IDs of Slack conversations start with C (channels), G (older private channels and group DMs) or
+// D (DMs), e.g. C1234567890.
+var slackChannelIDPattern = regexp.MustCompile(`^[CGD][A-Z0-9]+$`)
+
+const maxSlackChannelNameLength = 80
+
+// Normalizes the channel given by ID and/or name (as in slack-channel-id and slack-channel-name), so
+// that malformed values fail here instead of when posting the message. A link to the channel copied
+// from Slack is accepted as the ID (in either of them) and "#general" as the name "general".
+func normalizeSlackChannel(channelID, channelName string) (string, string, error) {
+	channelID = strings.TrimSpace(channelID)
+	channelName = strings.TrimSpace(channelName)
+
+	if id, isLink, err := getSlackChannelIDFromLink(InputSlackChannelName, channelName); isLink {
+		if err != nil {
+			return "", "", err
+		}
+		if channelID != "" && channelID != id {
+			return "", "", fmt.Errorf(
+				"%s links to channel %s but %s is %s", InputSlackChannelName, id, InputSlackChannelID, channelID,
+			)
+		}
+		channelID, channelName = id, ""
+	}
+	if id, isLink, err := getSlackChannelIDFromLink(InputSlackChannelID, channelID); isLink {
+		if err != nil {
+			return "", "", err
+		}
+		channelID = id
+	}
+
+	if channelID != "" && !slackChannelIDPattern.MatchString(channelID) {
+		if strings.HasPrefix(channelID, "#") {
+			return "", "", fmt.Errorf(
+				"invalid %s '%s': this is a channel name, use %s instead", InputSlackChannelID, channelID, InputSlackChannelName,
+			)
+		}
+		return "", "", fmt.Errorf(
+			"invalid %s '%s': expected a Slack channel ID (e.g. C1234567890) or a link to the channel",
+			InputSlackChannelID, channelID,
+		)
+	}
+
+	channelName, err := normalizeSlackChannelName(InputSlackChannelName, channelName)
+	if err != nil {
+		return "", "", err
+	}
+	return channelID, channelName, nil
+}
+
+// Slack channel names are lowercase (so the name given in any case matches the channel) and have no
+// spaces. The leading # (as channels are shown in Slack) is removed.
+func normalizeSlackChannelName(inputName, channelName string) (string, error) {
+	trimmed := strings.TrimSpace(channelName)
+	if trimmed == "" {
+		return "", nil
+	}
+	name := strings.ToLower(strings.TrimPrefix(trimmed, "#"))
+	if name == "" || utf8.RuneCountInString(name) > maxSlackChannelNameLength ||
+		strings.ContainsFunc(name, unicode.IsSpace) || strings.ContainsAny(name, "#/:,") {
+		return "", fmt.Errorf(
+			"invalid %s '%s': expected a Slack channel name (e.g. dev-team) of at most %d characters without spaces",
+			inputName, trimmed, maxSlackChannelNameLength,
+		)
+	}
+	return name, nil
+}
+
+// Returns the ID of the channel from a link to it, i.e. https://<workspace>.slack.com/archives/<ID>
+// (optionally followed by /p<message timestamp>) or https://app.slack.com/client/<team ID>/<ID>.
+// The second return value is false if the value is not a link at all.
+func getSlackChannelIDFromLink(inputName, value string) (string, bool, error) {
+	if !strings.Contains(value, "://") {
+		return "", false, nil
+	}
+	invalidLinkErr := fmt.Errorf(
+		"invalid %s '%s': expected a link to a Slack channel (e.g. https://my-org.slack.com/archives/C1234567890)",
+		inputName, value,
+	)
+	link, err := url.Parse(value)
+	if err != nil || (link.Scheme != "https" && link.Scheme != "http") {
+		return "", true, invalidLinkErr
+	}
+	if host := link.Hostname(); host != "slack.com" && !strings.HasSuffix(host, ".slack.com") {
+		return "", true, invalidLinkErr
+	}
+	segments := strings.Split(strings.Trim(link.Path, "/"), "/")
+	var id string
+	switch {
+	case len(segments) >= 2 && segments[0] == "archives":
+		id = segments[1]
+	case len(segments) >= 3 && segments[0] == "client":
+		id = segments[2]
+	}
+	if !slackChannelIDPattern.MatchString(id) {
+		return "", true, invalidLinkErr
+	}
+	return id, true, nil
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// SummaryTones are the tones of the message, chosen by the size of the backlog so that
+// the message escalates visually as the backlog grows. Tones that are not set are not used.
+type SummaryTones struct {
+	OK       *SummaryTone `json:"ok,omitempty"` // used if the thresholds of the other tones are not reached
+	Warn     *SummaryTone `json:"warn,omitempty"`
+	Critical *SummaryTone `json:"critical,omitempty"`
+}
+
+type SummaryTone struct {
+	// The tone is chosen if either of the thresholds is reached (0 = threshold not used)
+	MinPRs    int `json:"min-prs,omitempty"`
+	MinOldPRs int `json:"min-old-prs,omitempty"`
+	// Replaces the summary text, <pr_count> and <old_pr_count> are replaced with the counts
+	Summary string `json:"summary,omitempty"`
+	// Prepended to the headings of the PR lists
+	Emoji string `json:"emoji,omitempty"`
+	// Hex color of the message, e.g. "#e01e5a" (overrides the color of severity-thresholds)
+	Color string `json:"color,omitempty"`
+}
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+func getSummaryTones(inputName string) (SummaryTones, error) {
+	raw := inputhelpers.GetInput(inputName)
+	if raw == "" {
+		return SummaryTones{}, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader([]byte(raw)))
+	dec.DisallowUnknownFields()
+	var tones SummaryTones
+	if err := dec.Decode(&tones); err != nil {
+		return SummaryTones{}, fmt.Errorf("error reading input %s: unable to parse tones from %v: %v", inputName, raw, err)
+	}
+	if err := tones.validate(); err != nil {
+		return SummaryTones{}, fmt.Errorf("invalid %s: %v", inputName, err)
+	}
+	return tones, nil
+}
+
+func (t SummaryTones) validate() error {
+	if t.OK != nil && (t.OK.MinPRs != 0 || t.OK.MinOldPRs != 0) {
+		return fmt.Errorf("the ok tone cannot have thresholds")
+	}
+	names := []string{"ok", "warn", "critical"}
+	for i, tone := range []*SummaryTone{t.OK, t.Warn, t.Critical} {
+		name := names[i]
+		if tone == nil {
+			continue
+		}
+		if tone.MinPRs < 0 || tone.MinOldPRs < 0 {
+			return fmt.Errorf("the thresholds of the %s tone cannot be negative", name)
+		}
+		if name != "ok" && tone.MinPRs == 0 && tone.MinOldPRs == 0 {
+			return fmt.Errorf("the %s tone requires min-prs or min-old-prs", name)
+		}
+		if tone.Color != "" && !hexColorPattern.MatchString(tone.Color) {
+			return fmt.Errorf("invalid color of the %s tone: %s (expected a hex color, e.g. #e01e5a)", name, tone.Color)
+		}
+	}
+	return nil
+}
+
+// GetTone returns the most severe tone of which a threshold is reached by the PR counts,
+// the ok tone if none is reached, or nil if no matching tone is set.
+func (t SummaryTones) GetTone(prCount, oldPRCount int) *SummaryTone {
+	for _, tone := range []*SummaryTone{t.Critical, t.Warn} {
+		if tone != nil && tone.isReached(prCount, oldPRCount) {
+			return tone
+		}
+	}
+	return t.OK
+}
+
+func (t SummaryTone) isReached(prCount, oldPRCount int) bool {
+	return (t.MinPRs > 0 && prCount >= t.MinPRs) || (t.MinOldPRs > 0 && oldPRCount >= t.MinOldPRs)
+}
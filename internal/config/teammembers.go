@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// TeamMembersMatch defines which PRs are included when the team-members input is set.
+type TeamMembersMatch string
+
+const (
+	// PRs authored by the team members or requesting a review from them (or from the team)
+	TeamMembersMatchAuthorOrReviewer TeamMembersMatch = "author-or-reviewer"
+	TeamMembersMatchAuthor           TeamMembersMatch = "author"
+	TeamMembersMatchReviewer         TeamMembersMatch = "reviewer"
+)
+
+// TeamMembers are the members of the team of the channel, of whose PRs the reminder is posted.
+type TeamMembers struct {
+	Logins []string // GitHub usernames
+	Teams  []string // GitHub teams as org/team-slug, of which the members are fetched from GitHub
+	Match  TeamMembersMatch
+}
+
+// IsSet returns true if the PRs are limited to those of the team members.
+func (t TeamMembers) IsSet() bool {
+	return len(t.Logins) > 0 || len(t.Teams) > 0
+}
+
+// Parses the team members from a list of GitHub usernames and teams (as org/team-slug or
+// @org/team-slug), e.g. "alice; bob; my-org/backend".
+func getTeamMembers(inputName, matchInputName string) (TeamMembers, error) {
+	var teamMembers TeamMembers
+	for _, entry := range inputhelpers.GetInputList(inputName) {
+		entry = strings.TrimPrefix(entry, "@")
+		switch {
+		case entry == "":
+			continue
+		case strings.Contains(entry, "/"):
+			org, slug, _ := strings.Cut(entry, "/")
+			if org == "" || slug == "" || strings.Contains(slug, "/") {
+				return TeamMembers{}, fmt.Errorf("invalid team in %s: %s (expected org/team-slug)", inputName, entry)
+			}
+			teamMembers.Teams = append(teamMembers.Teams, entry)
+		default:
+			teamMembers.Logins = append(teamMembers.Logins, entry)
+		}
+	}
+	match, err := parseTeamMembersMatch(inputhelpers.GetInputOr(matchInputName, string(DefaultTeamMembersMatch)))
+	if err != nil {
+		return TeamMembers{}, err
+	}
+	teamMembers.Match = match
+	return teamMembers, nil
+}
+
+func parseTeamMembersMatch(raw string) (TeamMembersMatch, error) {
+	switch raw {
+	case string(TeamMembersMatchAuthorOrReviewer):
+		return TeamMembersMatchAuthorOrReviewer, nil
+	case string(TeamMembersMatchAuthor):
+		return TeamMembersMatchAuthor, nil
+	case string(TeamMembersMatchReviewer):
+		return TeamMembersMatchReviewer, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s', '%s' or '%s')", InputTeamMembersMatch, raw,
+			TeamMembersMatchAuthorOrReviewer, TeamMembersMatchAuthor, TeamMembersMatchReviewer,
+		)
+	}
+}
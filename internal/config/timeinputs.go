@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/clock"
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// Parses the fixed current time of the run (RFC 3339, e.g. "2025-01-02T09:00:00Z"), which makes
+// the PR ages and timestamps of the run reproducible. Returns a zero time if it is not set.
+func getFixedTime(envName string) (time.Time, error) {
+	return parseTimestamp(envName, inputhelpers.GetEnv(envName))
+}
+
+// Parses the reference time input, at which the ages of the PRs are computed instead of the
+// current time. Returns a zero time if it is not set.
+func getReferenceTime(inputName string) (time.Time, error) {
+	return parseTimestamp(inputName, inputhelpers.GetInput(inputName))
+}
+
+func parseTimestamp(name, raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %s (expected an RFC 3339 timestamp)", name, raw)
+	}
+	return timestamp, nil
+}
+
+// GetContentClock returns the clock at which the ages of the PRs are computed: fixed to the
+// reference time if it is set, otherwise the clock of the run.
+func (c Config) GetContentClock() clock.Clock {
+	if !c.ReferenceTime.IsZero() {
+		return clock.Fixed(c.ReferenceTime)
+	}
+	return clock.Default()
+}
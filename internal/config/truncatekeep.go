@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// TruncateKeep defines which PRs are kept when more open PRs are found than can be included
+// in the message (githubclient.MaxPRsToFetch).
+type TruncateKeep string
+
+const (
+	TruncateKeepOldest TruncateKeep = "oldest" // the oldest PRs, which are most in need of reminders
+	TruncateKeepNewest TruncateKeep = "newest"
+)
+
+func getTruncateKeep(inputName string) (TruncateKeep, error) {
+	return parseTruncateKeep(inputhelpers.GetInputOr(inputName, string(DefaultTruncateKeep)))
+}
+
+func parseTruncateKeep(raw string) (TruncateKeep, error) {
+	switch raw {
+	case string(TruncateKeepOldest):
+		return TruncateKeepOldest, nil
+	case string(TruncateKeepNewest):
+		return TruncateKeepNewest, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s' or '%s')", InputTruncateKeep, raw, TruncateKeepOldest, TruncateKeepNewest,
+		)
+	}
+}
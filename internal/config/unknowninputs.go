@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	action "github.com/hellej/pr-slack-reminder-action"
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+	"gopkg.in/yaml.v3"
+)
+
+// UnknownInputsPolicy defines what is done about INPUT_ environment variables that do not match
+// any input of the action (e.g. typos like INPUT_OLD_PR_TRESHOLD_HOURS, that would do nothing).
+type UnknownInputsPolicy string
+
+const (
+	UnknownInputsIgnore UnknownInputsPolicy = "false" // not checked
+	UnknownInputsWarn   UnknownInputsPolicy = "warn"  // a warning is logged
+	UnknownInputsFail   UnknownInputsPolicy = "true"  // the configuration is invalid
+)
+
+func getUnknownInputsPolicy(inputName string) (UnknownInputsPolicy, error) {
+	return parseUnknownInputsPolicy(inputhelpers.GetInputOr(inputName, string(DefaultUnknownInputsPolicy)))
+}
+
+func parseUnknownInputsPolicy(raw string) (UnknownInputsPolicy, error) {
+	switch raw {
+	case string(UnknownInputsIgnore):
+		return UnknownInputsIgnore, nil
+	case string(UnknownInputsWarn):
+		return UnknownInputsWarn, nil
+	case string(UnknownInputsFail):
+		return UnknownInputsFail, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s', '%s' or '%s')",
+			InputValidateUnknownInputs, raw, UnknownInputsIgnore, UnknownInputsWarn, UnknownInputsFail,
+		)
+	}
+}
+
+// Checks the INPUT_ environment variables against the inputs declared in action.yml.
+func checkUnknownInputs(policy UnknownInputsPolicy) error {
+	if policy == UnknownInputsIgnore {
+		return nil
+	}
+	declared, err := getDeclaredInputs()
+	if err != nil {
+		return err
+	}
+	unknown := inputhelpers.UnknownInputs(declared)
+	if len(unknown) == 0 {
+		return nil
+	}
+	descriptions := make([]string, len(unknown))
+	for i, input := range unknown {
+		descriptions[i] = input.EnvName
+		if input.Suggestion != "" {
+			descriptions[i] += fmt.Sprintf(" (did you mean %s?)", input.Suggestion)
+		}
+	}
+	message := "unknown inputs: " + strings.Join(descriptions, ", ")
+	if policy == UnknownInputsFail {
+		return fmt.Errorf("%s (set %s: %s to only warn about them)", message, InputValidateUnknownInputs, UnknownInputsWarn)
+	}
+	log.Printf("Warning: %s", message)
+	return nil
+}
+
+// Returns the names of the inputs declared in action.yml (including the deprecated ones).
+var getDeclaredInputs = sync.OnceValues(func() ([]string, error) {
+	var definition struct {
+		Inputs map[string]any `yaml:"inputs"`
+	}
+	if err := yaml.Unmarshal(action.YAML, &definition); err != nil {
+		return nil, fmt.Errorf("error parsing the inputs of action.yml: %w", err)
+	}
+	return slices.Sorted(maps.Keys(definition.Inputs)), nil
+})
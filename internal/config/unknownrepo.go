@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+)
+
+// UnknownRepoPolicy defines how PRs in state from repositories that are no longer configured
+// are handled when updating the message.
+type UnknownRepoPolicy string
+
+const (
+	UnknownRepoKeep UnknownRepoPolicy = "keep" // keep the PRs (only the global filters are applied to them)
+	UnknownRepoDrop UnknownRepoPolicy = "drop" // remove the PRs from the message
+)
+
+func getUnknownRepoPolicy(inputName string) (UnknownRepoPolicy, error) {
+	return parseUnknownRepoPolicy(inputhelpers.GetInputOr(inputName, string(DefaultUnknownRepoPolicy)))
+}
+
+func parseUnknownRepoPolicy(raw string) (UnknownRepoPolicy, error) {
+	switch raw {
+	case string(UnknownRepoKeep):
+		return UnknownRepoKeep, nil
+	case string(UnknownRepoDrop):
+		return UnknownRepoDrop, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s: %s (expected '%s' or '%s')", InputOnUnknownRepo, raw, UnknownRepoKeep, UnknownRepoDrop,
+		)
+	}
+}
+
+// IsConfiguredRepository returns true if the repository is one of the configured repositories.
+func (c Config) IsConfiguredRepository(repo models.Repository) bool {
+	for _, configured := range c.Repositories {
+		if strings.EqualFold(configured.GetPath(), repo.GetPath()) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFiltersForStateRepository returns the filters for PRs loaded from state. Repository
+// specific filters only apply to the configured repositories and the global filters to others.
+func (c Config) GetFiltersForStateRepository(repo models.Repository) Filters {
+	if !c.IsConfiguredRepository(repo) {
+		return c.withTeamMembers(c.GlobalFilters)
+	}
+	return c.GetFiltersForRepository(repo)
+}
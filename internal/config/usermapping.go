@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// Reads the mapping of GitHub logins to Slack user IDs with the logins lowercased, as GitHub logins are
+// case-insensitive (e.g. the mapping of "Alice" applies to the PRs of "alice"). Logins that differ only
+// by case are an error, as it would be ambiguous which Slack user the login is mapped to.
+func getUserMapping(inputName string) (map[string]string, error) {
+	rawMapping, err := inputhelpers.GetInputMapping(inputName)
+	if err != nil {
+		return nil, err
+	}
+	mapping := make(map[string]string, len(rawMapping))
+	loginsByKey := make(map[string]string, len(rawMapping))
+	for login, slackUserID := range rawMapping {
+		key := strings.ToLower(login)
+		if otherLogin, exists := loginsByKey[key]; exists {
+			first, second := min(login, otherLogin), max(login, otherLogin)
+			return nil, fmt.Errorf(
+				"%s maps both %s and %s, which are the same GitHub user (logins are case-insensitive)",
+				inputName, first, second,
+			)
+		}
+		loginsByKey[key] = login
+		mapping[key] = slackUserID
+	}
+	return mapping, nil
+}
+
+// LookupSlackUserID returns the Slack user ID to which the GitHub login is mapped (case-insensitively),
+// or an empty string if the login is not mapped.
+func LookupSlackUserID(slackUserIdByGitHubUsername map[string]string, login string) string {
+	return slackUserIdByGitHubUsername[strings.ToLower(login)]
+}
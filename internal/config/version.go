@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// Version of the binary, set at build time to the commit hash of the build (see Makefile).
+var Version = "dev"
+
+// InputsSchemaVersion is the version of the inputs of the action. It is increased whenever
+// inputs are added or their meaning changes, so that workflows can require a version of the
+// action that supports the inputs they use (with action-version-check). Otherwise an older
+// version of the action would silently ignore the inputs it does not know. TestInputsSchemaVersion
+// fails if the inputs of action.yml change without increasing the version.
+const InputsSchemaVersion = 3
+
+// Fails with a clear message if the workflow requires a newer inputs schema than this version
+// of the action supports.
+func checkActionVersion(inputName string) error {
+	required, err := inputhelpers.GetInputInt(inputName)
+	if err != nil {
+		return err
+	}
+	if required > InputsSchemaVersion {
+		return fmt.Errorf(
+			"the workflow requires inputs schema version %d (%s), but this version of the action (%s) "+
+				"only supports inputs schema version %d: update the version of the action in the workflow",
+			required, inputName, Version, InputsSchemaVersion,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config/inputhelpers"
+)
+
+// WorkspaceTarget pairs a Slack bot token with the channel the message
+// should be posted to in the workspace of that token.
+type WorkspaceTarget struct {
+	SlackBotToken    string `json:"slack-bot-token"`
+	SlackChannelID   string `json:"slack-channel-id,omitempty"`
+	SlackChannelName string `json:"slack-channel-name,omitempty"`
+}
+
+func GetWorkspaceTargetsFromInput(input string) ([]WorkspaceTarget, error) {
+	targets, err := parseWorkspaceTargets(inputhelpers.GetInput(input))
+	if err != nil {
+		return nil, fmt.Errorf("error reading input %s: %w", input, err)
+	}
+	return targets, nil
+}
+
+func parseWorkspaceTargets(rawTargets string) ([]WorkspaceTarget, error) {
+	if rawTargets == "" {
+		return []WorkspaceTarget{}, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(rawTargets)))
+	dec.DisallowUnknownFields()
+	var targets []WorkspaceTarget
+	if err := dec.Decode(&targets); err != nil {
+		return nil, fmt.Errorf("unable to parse workspace targets: %v", err)
+	}
+	for i := range targets {
+		target := &targets[i]
+		var err error
+		target.SlackChannelID, target.SlackChannelName, err = normalizeSlackChannel(target.SlackChannelID, target.SlackChannelName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace target at index %d: %v", i, err)
+		}
+		if err := target.validate(); err != nil {
+			return nil, fmt.Errorf("invalid workspace target at index %d: %v", i, err)
+		}
+	}
+	return targets, nil
+}
+
+func (t WorkspaceTarget) validate() error {
+	if t.SlackBotToken == "" {
+		return fmt.Errorf("slack-bot-token must be set")
+	}
+	if t.SlackChannelID == "" && t.SlackChannelName == "" {
+		return fmt.Errorf("either slack-channel-id or slack-channel-name must be set")
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Package githubevent parses the payload of the event that triggered the workflow run.
+// GitHub Actions writes the payload as JSON to the file at GITHUB_EVENT_PATH.
+package githubevent
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/google/go-github/v78/github"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+)
+
+// Payload contains the fields of the event payload that the action is interested in.
+// The pull_request field is set in pull_request, pull_request_target and
+// pull_request_review events.
+type Payload struct {
+	Action      string              `json:"action"`
+	PullRequest *github.PullRequest `json:"pull_request"`
+	Repository  *github.Repository  `json:"repository"`
+}
+
+type PullRequestEvent struct {
+	Action      string
+	PullRequest models.PullRequestRef
+	// The branch of the PR, i.e. the branch of the workflow runs of the events of the PR
+	HeadBranch string
+}
+
+// GetRepositoryPath returns the full name (owner/repo) of the repository of the event.
+// Returns an empty string if the payload does not contain a repository.
+func (p Payload) GetRepositoryPath() string {
+	return p.Repository.GetFullName()
+}
+
+func Load(filePath string) (Payload, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return Payload{}, fmt.Errorf("failed to read event payload file %s: %w", filePath, err)
+	}
+	var payload Payload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return Payload{}, fmt.Errorf("failed to parse event payload: %w", err)
+	}
+	return payload, nil
+}
+
+func LoadPullRequestEvent(filePath string) (PullRequestEvent, error) {
+	payload, err := Load(filePath)
+	if err != nil {
+		return PullRequestEvent{}, err
+	}
+	return payload.AsPullRequestEvent()
+}
+
+func (p Payload) AsPullRequestEvent() (PullRequestEvent, error) {
+	if p.PullRequest == nil || p.PullRequest.GetNumber() == 0 {
+		return PullRequestEvent{}, fmt.Errorf("event payload does not contain a pull request")
+	}
+	owner := p.Repository.GetOwner().GetLogin()
+	name := p.Repository.GetName()
+	if owner == "" || name == "" {
+		return PullRequestEvent{}, fmt.Errorf("event payload does not contain a repository")
+	}
+	return PullRequestEvent{
+		Action: p.Action,
+		PullRequest: models.PullRequestRef{
+			Repository: models.NewRepository(owner, name),
+			Number:     p.PullRequest.GetNumber(),
+			NodeID:     p.PullRequest.GetNodeID(),
+		},
+		HeadBranch: p.PullRequest.GetHead().GetRef(),
+	}, nil
+}
@@ -0,0 +1,113 @@
+package githubevent_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/githubevent"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+)
+
+func writeEventFile(t *testing.T, content string) string {
+	t.Helper()
+	filePath := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write event file: %v", err)
+	}
+	return filePath
+}
+
+func TestLoadPullRequestEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       string
+		expectedEvent githubevent.PullRequestEvent
+		expectedError string
+	}{
+		{
+			name: "pull_request event",
+			payload: `{
+				"action": "opened",
+				"number": 42,
+				"pull_request": {"number": 42, "title": "Add feature", "head": {"ref": "add-feature"}},
+				"repository": {"name": "test-repo", "owner": {"login": "test-org"}}
+			}`,
+			expectedEvent: githubevent.PullRequestEvent{
+				Action: "opened",
+				PullRequest: models.PullRequestRef{
+					Repository: models.NewRepository("test-org", "test-repo"),
+					Number:     42,
+				},
+				HeadBranch: "add-feature",
+			},
+		},
+		{
+			name: "pull_request_review event",
+			payload: `{
+				"action": "submitted",
+				"review": {"state": "approved"},
+				"pull_request": {"number": 7},
+				"repository": {"name": "test-repo", "owner": {"login": "test-org"}}
+			}`,
+			expectedEvent: githubevent.PullRequestEvent{
+				Action: "submitted",
+				PullRequest: models.PullRequestRef{
+					Repository: models.NewRepository("test-org", "test-repo"),
+					Number:     7,
+				},
+			},
+		},
+		{
+			name: "event without pull request",
+			payload: `{
+				"ref": "refs/heads/main",
+				"repository": {"name": "test-repo", "owner": {"login": "test-org"}}
+			}`,
+			expectedError: "event payload does not contain a pull request",
+		},
+		{
+			name:          "event without repository",
+			payload:       `{"action": "opened", "pull_request": {"number": 42}}`,
+			expectedError: "event payload does not contain a repository",
+		},
+		{
+			name:          "invalid JSON",
+			payload:       `{"action": `,
+			expectedError: "failed to parse event payload",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := githubevent.LoadPullRequestEvent(writeEventFile(t, tt.payload))
+
+			if tt.expectedError != "" {
+				if err == nil {
+					t.Fatalf("Expected error containing %q, got nil", tt.expectedError)
+				}
+				if !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected error containing %q, got %q", tt.expectedError, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if event != tt.expectedEvent {
+				t.Errorf("Expected event %+v, got %+v", tt.expectedEvent, event)
+			}
+		})
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	_, err := githubevent.Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err == nil {
+		t.Fatal("Expected error for missing event file, got nil")
+	}
+	if !strings.Contains(err.Error(), "failed to read event payload file") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+package messagebuilder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/discordclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagecontent"
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
+)
+
+// Discord message content (outside of embeds) is limited to 2000 characters.
+const maximumDiscordContentLength = 2000
+
+// BuildDiscordMessages builds Discord webhook messages with the same content as the Slack message.
+// Each PR list is an embed with a field per PR. Lists that exceed the field or character limits of
+// an embed are continued in the next embed, and the embeds are paginated to as many messages as needed.
+func BuildDiscordMessages(content messagecontent.Content) []discordclient.Message {
+	paginator := &discordPaginator{color: getDiscordColor(content.Color)}
+	if len(content.ReleasePRs) > 0 {
+		paginator.addPRList(messagecontent.ReleasePRsHeading, "", content.ReleasePRs, "")
+	}
+	switch {
+	case !content.HasPRs():
+		paginator.addEmbed(discordclient.Embed{
+			Description: truncate(content.SummaryText, discordclient.MaxDescriptionLength),
+		})
+	case len(content.RepositoryDigests) > 0:
+		lines := utilities.Map(content.RepositoryDigests, func(d messagecontent.RepositoryDigest) string {
+			return fmt.Sprintf("- %s → [%s](%s)", d.Text(), messagecontent.DigestLinkText, d.RepositoryLink)
+		})
+		paginator.addEmbed(discordclient.Embed{
+			Title:       truncate(content.PRListHeading, discordclient.MaxEmbedTitleLength),
+			Description: truncate(strings.Join(lines, "\n"), discordclient.MaxDescriptionLength),
+		})
+	case !content.GroupedByRepository:
+		if len(content.PRs) > 0 {
+			paginator.addPRList(content.PRListHeading, "", content.PRs, "")
+		}
+	default:
+		for _, group := range content.PRsGroupedByRepository {
+			if len(group.PRs) == 0 {
+				paginator.addEmbed(discordclient.Embed{
+					Title:       truncate(group.HeadingPrefix+group.RepositoryLinkLabel, discordclient.MaxEmbedTitleLength),
+					URL:         group.RepositoryLink,
+					Description: group.NoPRsText,
+				})
+				continue
+			}
+			paginator.addPRList(
+				group.HeadingPrefix+group.RepositoryLinkLabel, group.RepositoryLink, group.PRs, group.GetHiddenPRsText(),
+			)
+		}
+	}
+
+	if len(content.FailingWorkflows) > 0 {
+		lines := utilities.Map(content.FailingWorkflows, func(w messagecontent.FailingWorkflow) string {
+			return fmt.Sprintf("- [**%s**](%s) in %s", w.Name, w.RunURL, w.RepositoryPath)
+		})
+		paginator.addEmbed(discordclient.Embed{
+			Title:       failingWorkflowsHeading,
+			Description: truncate(strings.Join(lines, "\n"), discordclient.MaxDescriptionLength),
+		})
+	}
+
+	messages := paginator.messages
+	var headerLines []string
+	if content.HasPRs() {
+		headerLines = append(headerLines, content.SummaryText)
+		if content.ReviewCaptain != nil {
+			headerLines = append(headerLines, "Today's review captain: **"+content.ReviewCaptain.Name+"**")
+		}
+	}
+	messages[0].Content = joinDiscordContent(headerLines)
+	last := &messages[len(messages)-1]
+	last.Content = joinDiscordContent(append([]string{last.Content}, getDiscordFooterLines(content)...))
+	return messages
+}
+
+func getDiscordFooterLines(content messagecontent.Content) []string {
+	var lines []string
+	for _, progress := range content.MilestoneProgress {
+		lines = append(lines, "-# "+progress.Text())
+	}
+	if len(content.SkippedArchivedRepositories) > 0 {
+		lines = append(lines, "-# Skipped archived repositories: "+strings.Join(content.SkippedArchivedRepositories, ", "))
+	}
+	if quietRepositoriesText := content.GetQuietRepositoriesText(); quietRepositoriesText != "" {
+		lines = append(lines, "-# "+quietRepositoriesText)
+	}
+	if warning := content.GetBranchProtectionWarning(); warning != "" {
+		lines = append(lines, "-# "+warning)
+	}
+	if content.RunReportSummary != "" {
+		lines = append(lines, "-# "+content.RunReportSummary)
+	}
+	if content.WorkflowRunURL != "" {
+		lines = append(lines, "-# [generated by this workflow run](<"+content.WorkflowRunURL+">)")
+	}
+	return lines
+}
+
+func joinDiscordContent(lines []string) string {
+	lines = utilities.Filter(lines, func(line string) bool { return line != "" })
+	return truncate(strings.Join(lines, "\n"), maximumDiscordContentLength)
+}
+
+// discordPaginator collects embeds into messages so that each message stays within the
+// embed count and total character limits of Discord.
+type discordPaginator struct {
+	messages       []discordclient.Message
+	characterCount int // of the last message
+	color          int // of all embeds, 0 for the default color
+}
+
+// Returns the RGB value of a hex color (validated in the config), or 0 if the color is not set.
+func getDiscordColor(hexColor string) int {
+	color, err := strconv.ParseInt(strings.TrimPrefix(hexColor, "#"), 16, 32)
+	if err != nil {
+		return 0
+	}
+	return int(color)
+}
+
+func (p *discordPaginator) addEmbed(embed discordclient.Embed) {
+	embed.Color = p.color
+	if len(p.messages) == 0 ||
+		len(p.messages[len(p.messages)-1].Embeds) == discordclient.MaxEmbedsPerMessage ||
+		p.characterCount+embed.CharacterCount() > discordclient.MaxEmbedCharacters {
+		p.messages = append(p.messages, discordclient.Message{})
+		p.characterCount = 0
+	}
+	last := &p.messages[len(p.messages)-1]
+	last.Embeds = append(last.Embeds, embed)
+	p.characterCount += embed.CharacterCount()
+}
+
+// Adds the PRs as fields of one or more embeds. Each embed is kept within the character limit
+// of a whole message, so that it always fits in a message of its own.
+// Adds the PRs as fields of embeds titled with the heading, and the hiddenPRsText (if any)
+// as the last field linking to the url.
+func (p *discordPaginator) addPRList(title, url string, prs []prparser.PR, hiddenPRsText string) {
+	title = truncate(title, discordclient.MaxEmbedTitleLength-len(" (continued)"))
+	embed := discordclient.Embed{Title: title, URL: url}
+	fields := utilities.Map(prs, buildDiscordPRField)
+	if hiddenPRsText != "" {
+		fields = append(fields, discordclient.Field{Name: hiddenPRsText, Value: "[See all open PRs](" + url + ")"})
+	}
+	for _, field := range fields {
+		if len(embed.Fields) == discordclient.MaxFieldsPerEmbed ||
+			embed.CharacterCount()+field.CharacterCount() > discordclient.MaxEmbedCharacters {
+			p.addEmbed(embed)
+			embed = discordclient.Embed{Title: title + " (continued)", URL: url}
+		}
+		embed.Fields = append(embed.Fields, field)
+	}
+	p.addEmbed(embed)
+}
+
+func buildDiscordPRField(pr prparser.PR) discordclient.Field {
+	name := truncate(pr.GetHighlightPrefix()+pr.Title, discordclient.MaxFieldNameLength-4)
+	if pr.IsClosedButNotMerged() {
+		name = "~~" + name + "~~"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[#%d](%s)", pr.Number, pr.HTMLURL)
+	if pr.IsOldPR {
+		b.WriteString(" " + pr.GetOldPRMarker() + " **" + pr.GetPRAgeText() + " old**")
+	} else {
+		b.WriteString(" _" + pr.GetPRAgeText() + " ago_")
+	}
+	if reminderText := pr.GetReminderText(); reminderText != "" {
+		b.WriteString(" _(" + reminderText + ")_")
+	}
+	if pr.IsHighlighted {
+		b.WriteString(" by **" + pr.Author.GetGitHubName() + "**")
+	} else {
+		b.WriteString(" by " + pr.Author.GetGitHubName())
+	}
+	b.WriteString(getReviewersText(pr) + pr.GetCodeownerApprovalText())
+	b.WriteString(pr.GetReviewDecisionText() + pr.GetMergeQueueText() + pr.GetReviewEffortText() + pr.GetReviewResponseText())
+	b.WriteString(pr.GetCommentCountText())
+	if len(pr.RequestedTeams) > 0 {
+		teamNames := utilities.Map(pr.RequestedTeams, prparser.Team.GetGitHubName)
+		b.WriteString(" (👥 " + strings.Join(teamNames, ", ") + ")")
+	}
+	if pr.MovedToRepository != nil {
+		b.WriteString(" _(repo moved to " + pr.MovedToRepository.GetPath() + ")_")
+	}
+	if pr.IsMerged() {
+		b.WriteString(" 🚀")
+	}
+	for _, link := range pr.GetQuickLinks() {
+		fmt.Fprintf(&b, " \\[[%s](%s)\\]", link.Text, link.URL)
+	}
+	return discordclient.Field{Name: name, Value: truncate(b.String(), discordclient.MaxFieldValueLength)}
+}
+
+// Truncates the text to at most maxLength characters (runes), ending it with an ellipsis if truncated.
+func truncate(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength-1]) + "…"
+}
@@ -0,0 +1,162 @@
+package messagebuilder_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/discordclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagebuilder"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagecontent"
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+)
+
+func TestBuildDiscordMessages(t *testing.T) {
+	testCases := []struct {
+		name            string
+		content         messagecontent.Content
+		expectedContent string
+		expectedEmbed   discordclient.Embed
+	}{
+		{
+			name:          "no PRs",
+			content:       messagecontent.Content{SummaryText: "No open PRs"},
+			expectedEmbed: discordclient.Embed{Description: "No open PRs"},
+		},
+		{
+			name: "PR list with review captain and run link",
+			content: messagecontent.Content{
+				SummaryText:    "1 open PR",
+				PRListHeading:  "Open PRs",
+				PRs:            getTestPRs().PRs,
+				ReviewCaptain:  &messagecontent.ReviewCaptain{Name: "Alice"},
+				WorkflowRunURL: "https://github.com/org/repo/actions/runs/1",
+			},
+			expectedContent: "1 open PR\nToday's review captain: **Alice**\n" +
+				"-# [generated by this workflow run](<https://github.com/org/repo/actions/runs/1>)",
+			expectedEmbed: discordclient.Embed{
+				Title: "Open PRs",
+				Fields: []discordclient.Field{
+					{Name: "This is a test PR", Value: "[#0]() _3 hours ago_ by Test User"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			messages := messagebuilder.BuildDiscordMessages(tc.content)
+
+			if len(messages) != 1 || len(messages[0].Embeds) != 1 {
+				t.Fatalf("Expected one message with one embed, got %+v", messages)
+			}
+			if messages[0].Content != tc.expectedContent {
+				t.Errorf("Expected content '%s', got '%s'", tc.expectedContent, messages[0].Content)
+			}
+			embed := messages[0].Embeds[0]
+			if embed.Title != tc.expectedEmbed.Title || embed.Description != tc.expectedEmbed.Description {
+				t.Errorf("Expected embed %+v, got %+v", tc.expectedEmbed, embed)
+			}
+			for i, field := range tc.expectedEmbed.Fields {
+				if embed.Fields[i] != field {
+					t.Errorf("Expected field %+v, got %+v", field, embed.Fields[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildDiscordMessages_Pagination(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		prCount                int
+		titleLength            int
+		expectedEmbedsPerMsg   []int
+		expectedFieldsInEmbeds []int
+	}{
+		{
+			name:                   "PR list is continued in the next embed after 25 fields",
+			prCount:                60,
+			titleLength:            10,
+			expectedEmbedsPerMsg:   []int{3},
+			expectedFieldsInEmbeds: []int{25, 25, 10},
+		},
+		{
+			name:                   "embeds are split to messages by the character limit",
+			prCount:                30,
+			titleLength:            250,
+			expectedEmbedsPerMsg:   []int{1, 1},
+			expectedFieldsInEmbeds: []int{21, 9},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var prs []prparser.PR
+			for range tc.prCount {
+				pr := getTestPRs().PR1
+				pr.Title = strings.Repeat("x", tc.titleLength)
+				prs = append(prs, pr)
+			}
+
+			messages := messagebuilder.BuildDiscordMessages(
+				messagecontent.Content{SummaryText: "Open PRs", PRListHeading: "Open PRs", PRs: prs},
+			)
+
+			if len(messages) != len(tc.expectedEmbedsPerMsg) {
+				t.Fatalf("Expected %d messages, got %d", len(tc.expectedEmbedsPerMsg), len(messages))
+			}
+			var fieldCounts []int
+			for i, message := range messages {
+				if len(message.Embeds) != tc.expectedEmbedsPerMsg[i] {
+					t.Errorf("Expected %d embeds in message %d, got %d", tc.expectedEmbedsPerMsg[i], i, len(message.Embeds))
+				}
+				characterCount := 0
+				for _, embed := range message.Embeds {
+					fieldCounts = append(fieldCounts, len(embed.Fields))
+					characterCount += embed.CharacterCount()
+				}
+				if characterCount > discordclient.MaxEmbedCharacters {
+					t.Errorf("Expected message %d to have at most %d characters, got %d",
+						i, discordclient.MaxEmbedCharacters, characterCount)
+				}
+			}
+			if !slices.Equal(fieldCounts, tc.expectedFieldsInEmbeds) {
+				t.Errorf("Expected fields in embeds %v, got %v", tc.expectedFieldsInEmbeds, fieldCounts)
+			}
+			if title := messages[len(messages)-1].Embeds[0].Title; len(messages) > 1 && title != "Open PRs (continued)" {
+				t.Errorf("Expected continued title, got '%s'", title)
+			}
+		})
+	}
+}
+
+func TestBuildDiscordMessages_Color(t *testing.T) {
+	testCases := []struct {
+		name          string
+		color         string
+		expectedColor int
+	}{
+		{name: "default color", color: "", expectedColor: 0},
+		{name: "color of the summary tone", color: "#e01e5a", expectedColor: 0xe01e5a},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			messages := messagebuilder.BuildDiscordMessages(messagecontent.Content{
+				SummaryText:   "1 open PR",
+				PRListHeading: "Open PRs",
+				PRs:           []prparser.PR{getTestPRs().PR1},
+				Color:         tc.color,
+			})
+
+			for _, message := range messages {
+				for _, embed := range message.Embeds {
+					if embed.Color != tc.expectedColor {
+						t.Errorf("Expected embed color %d, got %d", tc.expectedColor, embed.Color)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package messagebuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/googlechatclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagecontent"
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
+)
+
+// Google Chat allows messages of up to 32,000 bytes and cards of up to 100 widgets.
+// If the content exceeds either, PRs are dropped from the end of the (last) PR list.
+const (
+	maximumGoogleChatMessageBytes = 32000
+	maximumGoogleChatCardWidgets  = 100
+)
+
+// BuildGoogleChatMessage builds a Google Chat card message with the same content as the Slack
+// message. Slack user and group mappings do not apply, so users and teams are shown by GitHub name.
+func BuildGoogleChatMessage(content messagecontent.Content) googlechatclient.Message {
+	var headerSections []googlechatclient.Section
+	if content.ReviewCaptain != nil && content.HasPRs() {
+		headerSections = append(headerSections, textSection(
+			"Today's review captain: <b>"+html.EscapeString(content.ReviewCaptain.Name)+"</b>",
+		))
+	}
+
+	var prSections []googlechatclient.Section
+	if len(content.ReleasePRs) > 0 {
+		prSections = append(prSections, prListSection(messagecontent.ReleasePRsHeading, content.ReleasePRs))
+	}
+	switch {
+	case !content.HasPRs():
+		prSections = append(prSections, textSection(html.EscapeString(content.SummaryText)))
+	case len(content.RepositoryDigests) > 0:
+		widgets := utilities.Map(content.RepositoryDigests, func(d messagecontent.RepositoryDigest) googlechatclient.Widget {
+			return googlechatclient.NewTextWidget(buildHTMLDigestText(d))
+		})
+		prSections = append(prSections, googlechatclient.Section{
+			Header: html.EscapeString(content.PRListHeading), Widgets: widgets,
+		})
+	case !content.GroupedByRepository:
+		if len(content.PRs) > 0 {
+			prSections = append(prSections, prListSection(html.EscapeString(content.PRListHeading), content.PRs))
+		}
+	default:
+		for _, group := range content.PRsGroupedByRepository {
+			heading := fmt.Sprintf(
+				"%s<a href=\"%s\">%s</a>:",
+				html.EscapeString(group.HeadingPrefix), group.RepositoryLink, html.EscapeString(group.RepositoryLinkLabel),
+			)
+			section := prListSection(heading, group.PRs)
+			if group.NoPRsText != "" {
+				section.Widgets = append(section.Widgets, googlechatclient.NewTextWidget(
+					"<i>"+html.EscapeString(group.NoPRsText)+"</i>",
+				))
+			}
+			if hiddenPRsText := group.GetHiddenPRsText(); hiddenPRsText != "" {
+				section.Widgets = append(section.Widgets, googlechatclient.NewTextWidget(
+					fmt.Sprintf("<i><a href=\"%s\">%s</a></i>", group.RepositoryLink, html.EscapeString(hiddenPRsText)),
+				))
+			}
+			prSections = append(prSections, section)
+		}
+	}
+
+	footerSections := getGoogleChatFooterSections(content)
+	message := newGoogleChatMessage(content.SummaryText, headerSections, prSections, footerSections)
+	droppedPRCount := 0
+	for !fitsGoogleChatLimits(message) && dropLastPRWidget(&prSections) {
+		droppedPRCount++
+		message = newGoogleChatMessage(
+			content.SummaryText,
+			headerSections,
+			prSections,
+			append(footerSections, textSection(fmt.Sprintf("<i>…and %d more PRs</i>", droppedPRCount))),
+		)
+	}
+	if droppedPRCount > 0 {
+		log.Printf("Message content is too large for Google Chat (dropping %d PRs)", droppedPRCount)
+	}
+	return message
+}
+
+func newGoogleChatMessage(text string, sectionGroups ...[]googlechatclient.Section) googlechatclient.Message {
+	var sections []googlechatclient.Section
+	for _, group := range sectionGroups {
+		sections = append(sections, group...)
+	}
+	return googlechatclient.Message{
+		Text: text,
+		CardsV2: []googlechatclient.CardWithID{
+			{CardID: "pr_reminder", Card: googlechatclient.Card{Sections: sections}},
+		},
+	}
+}
+
+func getGoogleChatFooterSections(content messagecontent.Content) []googlechatclient.Section {
+	var sections []googlechatclient.Section
+	if len(content.FailingWorkflows) > 0 {
+		workflowWidgets := utilities.Map(content.FailingWorkflows, func(w messagecontent.FailingWorkflow) googlechatclient.Widget {
+			return googlechatclient.NewTextWidget(fmt.Sprintf(
+				"<a href=\"%s\"><b>%s</b></a> in %s", w.RunURL, html.EscapeString(w.Name), html.EscapeString(w.RepositoryPath),
+			))
+		})
+		sections = append(sections, googlechatclient.Section{Header: failingWorkflowsHeading, Widgets: workflowWidgets})
+	}
+
+	var widgets []googlechatclient.Widget
+	for _, progress := range content.MilestoneProgress {
+		widgets = append(widgets, googlechatclient.NewTextWidget("<i>"+html.EscapeString(progress.Text())+"</i>"))
+	}
+	if len(content.SkippedArchivedRepositories) > 0 {
+		widgets = append(widgets, googlechatclient.NewTextWidget(
+			"<i>Skipped archived repositories: "+html.EscapeString(strings.Join(content.SkippedArchivedRepositories, ", "))+"</i>",
+		))
+	}
+	if quietRepositoriesText := content.GetQuietRepositoriesText(); quietRepositoriesText != "" {
+		widgets = append(widgets, googlechatclient.NewTextWidget("<i>"+html.EscapeString(quietRepositoriesText)+"</i>"))
+	}
+	if warning := content.GetBranchProtectionWarning(); warning != "" {
+		widgets = append(widgets, googlechatclient.NewTextWidget("<i>"+html.EscapeString(warning)+"</i>"))
+	}
+	if content.RunReportSummary != "" {
+		widgets = append(widgets, googlechatclient.NewTextWidget("<i>"+html.EscapeString(content.RunReportSummary)+"</i>"))
+	}
+	if content.WorkflowRunURL != "" {
+		widgets = append(widgets, googlechatclient.NewTextWidget(
+			"<a href=\""+content.WorkflowRunURL+"\">generated by this workflow run</a>",
+		))
+	}
+	if len(widgets) > 0 {
+		sections = append(sections, googlechatclient.Section{Widgets: widgets})
+	}
+	return sections
+}
+
+func fitsGoogleChatLimits(message googlechatclient.Message) bool {
+	widgetCount := 0
+	for _, card := range message.CardsV2 {
+		for _, section := range card.Card.Sections {
+			widgetCount += len(section.Widgets)
+		}
+	}
+	asJSON, _ := json.Marshal(message)
+	return widgetCount <= maximumGoogleChatCardWidgets && len(asJSON) <= maximumGoogleChatMessageBytes
+}
+
+// Drops the last PR of the last PR list (and the list itself if it becomes empty).
+// Returns false if there are no PRs left to drop.
+func dropLastPRWidget(prSections *[]googlechatclient.Section) bool {
+	sections := *prSections
+	if len(sections) == 0 {
+		return false
+	}
+	last := &sections[len(sections)-1]
+	last.Widgets = last.Widgets[:len(last.Widgets)-1]
+	if len(last.Widgets) == 0 {
+		sections = sections[:len(sections)-1]
+	}
+	*prSections = sections
+	return true
+}
+
+func textSection(text string) googlechatclient.Section {
+	return googlechatclient.Section{Widgets: []googlechatclient.Widget{googlechatclient.NewTextWidget(text)}}
+}
+
+func prListSection(heading string, prs []prparser.PR) googlechatclient.Section {
+	widgets := make([]googlechatclient.Widget, 0, len(prs))
+	for _, pr := range prs {
+		widgets = append(widgets, googlechatclient.NewTextWidget(buildHTMLPRText(pr)))
+	}
+	return googlechatclient.Section{Header: heading, Widgets: widgets}
+}
+
+// Returns the line of the repository digest as (simple) HTML, as supported by both Google Chat and Matrix.
+func buildHTMLDigestText(digest messagecontent.RepositoryDigest) string {
+	return fmt.Sprintf(
+		"%s → <a href=\"%s\">%s</a>", html.EscapeString(digest.Text()), digest.RepositoryLink, messagecontent.DigestLinkText,
+	)
+}
+
+// Returns the PR as (simple) HTML, as supported by both Google Chat and Matrix.
+func buildHTMLPRText(pr prparser.PR) string {
+	var b strings.Builder
+
+	title := "<b>" + html.EscapeString(pr.Title) + "</b>"
+	if pr.IsClosedButNotMerged() {
+		title = "<s>" + title + "</s>"
+	}
+	fmt.Fprintf(&b, "%s<a href=\"%s\">%s</a>", pr.GetHighlightPrefix(), pr.HTMLURL, title)
+
+	if pr.IsOldPR {
+		b.WriteString(" " + pr.GetOldPRMarker() + " <b>" + pr.GetPRAgeText() + " old</b>")
+	} else {
+		b.WriteString(" <i>" + pr.GetPRAgeText() + " ago</i>")
+	}
+	if reminderText := pr.GetReminderText(); reminderText != "" {
+		b.WriteString(" <i>(" + reminderText + ")</i>")
+	}
+	if pr.IsHighlighted {
+		b.WriteString(" by <b>" + html.EscapeString(pr.Author.GetGitHubName()) + "</b>")
+	} else {
+		b.WriteString(" by " + html.EscapeString(pr.Author.GetGitHubName()))
+	}
+
+	b.WriteString(html.EscapeString(getReviewersText(pr)) + pr.GetCodeownerApprovalText())
+	b.WriteString(pr.GetReviewDecisionText() + pr.GetMergeQueueText() + pr.GetReviewEffortText() + pr.GetReviewResponseText())
+	b.WriteString(pr.GetCommentCountText())
+	if len(pr.RequestedTeams) > 0 {
+		teamNames := utilities.Map(pr.RequestedTeams, prparser.Team.GetGitHubName)
+		b.WriteString(" (👥 " + html.EscapeString(strings.Join(teamNames, ", ")) + ")")
+	}
+	if pr.MovedToRepository != nil {
+		b.WriteString(" <i>(repo moved to " + html.EscapeString(pr.MovedToRepository.GetPath()) + ")</i>")
+	}
+	if pr.IsMerged() {
+		b.WriteString(" 🚀")
+	}
+	for _, link := range pr.GetQuickLinks() {
+		fmt.Fprintf(&b, " [<a href=\"%s\">%s</a>]", link.URL, link.Text)
+	}
+	return b.String()
+}
+
+// Returns the reviewers grouped by their state in the same format as in the Slack message,
+// e.g. " (✅ alice / 💬 bob / ⏳ carol)".
+func getReviewersText(pr prparser.PR) string {
+	groups := pr.GetReviewerGroups()
+	if len(groups) == 0 {
+		return ""
+	}
+	parts := utilities.Map(groups, func(g prparser.ReviewerGroup) string {
+		return g.Emoji + " " + joinGitHubNames(g.Reviewers)
+	})
+	return " (" + strings.Join(parts, " / ") + ")"
+}
+
+func joinGitHubNames(collaborators []prparser.Collaborator) string {
+	return strings.Join(utilities.Map(collaborators, func(c prparser.Collaborator) string {
+		return c.GetGitHubName()
+	}), ", ")
+}
@@ -0,0 +1,106 @@
+package messagebuilder_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/messagebuilder"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagecontent"
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+)
+
+func TestBuildGoogleChatMessage(t *testing.T) {
+	testCases := []struct {
+		name             string
+		content          messagecontent.Content
+		expectedSections []string // section headers
+		expectedTexts    []string // texts of the first widgets of each section
+	}{
+		{
+			name:             "no PRs",
+			content:          messagecontent.Content{SummaryText: "No open PRs"},
+			expectedSections: []string{""},
+			expectedTexts:    []string{"No open PRs"},
+		},
+		{
+			name: "PR list with review captain and run link",
+			content: messagecontent.Content{
+				PRListHeading:  "Open PRs",
+				PRs:            getTestPRs().PRs,
+				ReviewCaptain:  &messagecontent.ReviewCaptain{Name: "Alice"},
+				WorkflowRunURL: "https://github.com/org/repo/actions/runs/1",
+			},
+			expectedSections: []string{"", "Open PRs", ""},
+			expectedTexts: []string{
+				"Today's review captain: <b>Alice</b>",
+				`<a href=""><b>This is a test PR</b></a> <i>3 hours ago</i> by Test User`,
+				`<a href="https://github.com/org/repo/actions/runs/1">generated by this workflow run</a>`,
+			},
+		},
+		{
+			name: "grouped by repository",
+			content: messagecontent.Content{
+				GroupedByRepository:    true,
+				PRsGroupedByRepository: []messagecontent.PRsOfRepository{newRepositoryList(1)},
+			},
+			expectedSections: []string{`Open PRs in repo 1<a href="https://github.com/owner/repo-1">owner/repo-1</a>:`},
+			expectedTexts:    []string{`<a href=""><b>This is a test PR</b></a> <i>3 hours ago</i> by Test User`},
+		},
+		{
+			name: "PR of a highlighted author",
+			content: messagecontent.Content{
+				PRListHeading: "Open PRs",
+				PRs:           []prparser.PR{getHighlightedTestPR()},
+			},
+			expectedSections: []string{"Open PRs"},
+			expectedTexts:    []string{`⭐ <a href=""><b>This is a test PR</b></a> <i>3 hours ago</i> by <b>Test User</b>`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			message := messagebuilder.BuildGoogleChatMessage(tc.content)
+
+			sections := message.CardsV2[0].Card.Sections
+			if len(sections) != len(tc.expectedSections) {
+				t.Fatalf("Expected %d sections, got %d: %+v", len(tc.expectedSections), len(sections), sections)
+			}
+			for i, section := range sections {
+				if section.Header != tc.expectedSections[i] {
+					t.Errorf("Expected section %d header '%s', got '%s'", i, tc.expectedSections[i], section.Header)
+				}
+				if text := section.Widgets[0].TextParagraph.Text; text != tc.expectedTexts[i] {
+					t.Errorf("Expected section %d text '%s', got '%s'", i, tc.expectedTexts[i], text)
+				}
+			}
+		})
+	}
+}
+
+func getHighlightedTestPR() prparser.PR {
+	pr := getTestPRs().PR1
+	pr.IsHighlighted = true
+	return pr
+}
+
+func TestBuildGoogleChatMessage_LimitsMessageSize(t *testing.T) {
+	var prs []prparser.PR
+	for range 150 {
+		prs = append(prs, getTestPRs().PR1)
+	}
+
+	message := messagebuilder.BuildGoogleChatMessage(messagecontent.Content{PRListHeading: "Open PRs", PRs: prs})
+
+	sections := message.CardsV2[0].Card.Sections
+	widgetCount := 0
+	for _, section := range sections {
+		widgetCount += len(section.Widgets)
+	}
+	if widgetCount != 100 {
+		t.Errorf("Expected the message to be limited to 100 widgets, got %d", widgetCount)
+	}
+	note := sections[len(sections)-1].Widgets[0].TextParagraph.Text
+	if !strings.Contains(note, "…and 51 more PRs") {
+		t.Errorf("Expected a note about the dropped PRs, got '%s'", note)
+	}
+}
@@ -0,0 +1,195 @@
+package messagebuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/matrixclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagecontent"
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+)
+
+// Matrix events are limited to 65,536 bytes, which includes the event metadata added by the
+// homeserver. If the content exceeds the limit, PRs are dropped from the end of the (last) PR list.
+const maximumMatrixMessageBytes = 60000
+
+// BuildMatrixMessage builds a Matrix message with the same content as the Slack message,
+// formatted as HTML with a plain text fallback.
+func BuildMatrixMessage(content messagecontent.Content) matrixclient.Message {
+	prCount := content.GetPRCount() - content.GetHiddenPRCount()
+	message := buildMatrixMessage(content, prCount)
+	shownPRCount := prCount
+	for !fitsMatrixLimit(message) && shownPRCount > 0 {
+		shownPRCount--
+		message = buildMatrixMessage(content, shownPRCount)
+	}
+	if shownPRCount < prCount {
+		log.Printf("Message content is too large for Matrix (dropping %d PRs)", prCount-shownPRCount)
+	}
+	return message
+}
+
+func fitsMatrixLimit(message matrixclient.Message) bool {
+	asJSON, _ := json.Marshal(message)
+	return len(asJSON) <= maximumMatrixMessageBytes
+}
+
+// matrixMessageWriter writes the HTML and plain text bodies of the message side by side.
+type matrixMessageWriter struct {
+	html  strings.Builder
+	plain strings.Builder
+}
+
+func (w *matrixMessageWriter) writeParagraph(htmlText, plainText string) {
+	w.html.WriteString("<p>" + htmlText + "</p>")
+	w.plain.WriteString(plainText + "\n\n")
+}
+
+func (w *matrixMessageWriter) writePRList(htmlHeading, plainHeading string, prs []prparser.PR) {
+	w.html.WriteString("<p><b>" + htmlHeading + "</b></p><ul>")
+	w.plain.WriteString(plainHeading + "\n")
+	for _, pr := range prs {
+		w.html.WriteString("<li>" + buildHTMLPRText(pr) + "</li>")
+		w.plain.WriteString("- " + buildPlainPRText(pr) + "\n")
+	}
+	w.html.WriteString("</ul>")
+	w.plain.WriteString("\n")
+}
+
+func (w *matrixMessageWriter) writeRepositoryDigests(heading string, digests []messagecontent.RepositoryDigest) {
+	w.html.WriteString("<p><b>" + html.EscapeString(heading) + "</b></p><ul>")
+	w.plain.WriteString(heading + "\n")
+	for _, digest := range digests {
+		w.html.WriteString("<li>" + buildHTMLDigestText(digest) + "</li>")
+		fmt.Fprintf(&w.plain, "- %s → %s\n", digest.Text(), digest.RepositoryLink)
+	}
+	w.html.WriteString("</ul>")
+	w.plain.WriteString("\n")
+}
+
+func (w *matrixMessageWriter) writeFailingWorkflows(workflows []messagecontent.FailingWorkflow) {
+	w.html.WriteString("<p><b>" + failingWorkflowsHeading + "</b></p><ul>")
+	w.plain.WriteString(failingWorkflowsHeading + "\n")
+	for _, workflow := range workflows {
+		fmt.Fprintf(
+			&w.html, "<li><a href=\"%s\"><b>%s</b></a> in %s</li>",
+			workflow.RunURL, html.EscapeString(workflow.Name), html.EscapeString(workflow.RepositoryPath),
+		)
+		fmt.Fprintf(&w.plain, "- %s in %s (%s)\n", workflow.Name, workflow.RepositoryPath, workflow.RunURL)
+	}
+	w.html.WriteString("</ul>")
+	w.plain.WriteString("\n")
+}
+
+// Builds the message with at most maxPRs PRs, noting the number of PRs left out.
+func buildMatrixMessage(content messagecontent.Content, maxPRs int) matrixclient.Message {
+	w := &matrixMessageWriter{}
+
+	if content.ReviewCaptain != nil && content.HasPRs() {
+		name := content.ReviewCaptain.Name
+		w.writeParagraph("Today's review captain: <b>"+html.EscapeString(name)+"</b>", "Today's review captain: "+name)
+	}
+
+	remaining := maxPRs
+	takePRs := func(prs []prparser.PR) []prparser.PR {
+		prs = prs[:min(len(prs), remaining)]
+		remaining -= len(prs)
+		return prs
+	}
+	if releasePRs := takePRs(content.ReleasePRs); len(releasePRs) > 0 {
+		w.writePRList(messagecontent.ReleasePRsHeading, messagecontent.ReleasePRsHeading, releasePRs)
+	}
+	switch {
+	case !content.HasPRs():
+		w.writeParagraph(html.EscapeString(content.SummaryText), content.SummaryText)
+	case len(content.RepositoryDigests) > 0:
+		w.writeRepositoryDigests(content.PRListHeading, content.RepositoryDigests)
+	case !content.GroupedByRepository:
+		if prs := takePRs(content.PRs); len(prs) > 0 {
+			w.writePRList(html.EscapeString(content.PRListHeading), content.PRListHeading, prs)
+		}
+	default:
+		for _, group := range content.PRsGroupedByRepository {
+			htmlHeading := fmt.Sprintf(
+				"%s<a href=\"%s\">%s</a>:",
+				html.EscapeString(group.HeadingPrefix), group.RepositoryLink, html.EscapeString(group.RepositoryLinkLabel),
+			)
+			if group.NoPRsText != "" {
+				w.writeParagraph(
+					"<b>"+htmlHeading+"</b> <i>"+html.EscapeString(group.NoPRsText)+"</i>",
+					group.HeadingPrefix+group.RepositoryLinkLabel+": "+group.NoPRsText,
+				)
+				continue
+			}
+			prs := takePRs(group.PRs)
+			if len(prs) == 0 {
+				break
+			}
+			w.writePRList(htmlHeading, group.HeadingPrefix+group.RepositoryLinkLabel+":", prs)
+			if hiddenPRsText := group.GetHiddenPRsText(); hiddenPRsText != "" && len(prs) == len(group.PRs) {
+				w.writeParagraph(
+					fmt.Sprintf("<i><a href=\"%s\">%s</a></i>", group.RepositoryLink, html.EscapeString(hiddenPRsText)),
+					hiddenPRsText+" "+group.RepositoryLink,
+				)
+			}
+		}
+	}
+
+	if len(content.FailingWorkflows) > 0 {
+		w.writeFailingWorkflows(content.FailingWorkflows)
+	}
+	if droppedPRCount := content.GetPRCount() - content.GetHiddenPRCount() - maxPRs; droppedPRCount > 0 {
+		note := fmt.Sprintf("…and %d more PRs", droppedPRCount)
+		w.writeParagraph("<i>"+note+"</i>", note)
+	}
+	for _, progress := range content.MilestoneProgress {
+		w.writeParagraph("<i>"+html.EscapeString(progress.Text())+"</i>", progress.Text())
+	}
+	if len(content.SkippedArchivedRepositories) > 0 {
+		note := "Skipped archived repositories: " + strings.Join(content.SkippedArchivedRepositories, ", ")
+		w.writeParagraph("<i>"+html.EscapeString(note)+"</i>", note)
+	}
+	if quietRepositoriesText := content.GetQuietRepositoriesText(); quietRepositoriesText != "" {
+		w.writeParagraph("<i>"+html.EscapeString(quietRepositoriesText)+"</i>", quietRepositoriesText)
+	}
+	if warning := content.GetBranchProtectionWarning(); warning != "" {
+		w.writeParagraph("<i>"+html.EscapeString(warning)+"</i>", warning)
+	}
+	if content.RunReportSummary != "" {
+		w.writeParagraph("<i>"+html.EscapeString(content.RunReportSummary)+"</i>", content.RunReportSummary)
+	}
+	if content.WorkflowRunURL != "" {
+		w.writeParagraph(
+			"<a href=\""+content.WorkflowRunURL+"\">generated by this workflow run</a>",
+			"generated by this workflow run: "+content.WorkflowRunURL,
+		)
+	}
+
+	return matrixclient.NewHTMLMessage(strings.TrimSpace(w.plain.String()), w.html.String())
+}
+
+func buildPlainPRText(pr prparser.PR) string {
+	var b strings.Builder
+	b.WriteString(pr.GetHighlightPrefix() + pr.Title + " (" + pr.HTMLURL + ")")
+	if pr.IsOldPR {
+		b.WriteString(" " + pr.GetOldPRMarker() + " " + pr.GetPRAgeText() + " old")
+	} else {
+		b.WriteString(" " + pr.GetPRAgeText() + " ago")
+	}
+	if reminderText := pr.GetReminderText(); reminderText != "" {
+		b.WriteString(" (" + reminderText + ")")
+	}
+	b.WriteString(" by " + pr.Author.GetGitHubName() + getReviewersText(pr) + pr.GetCodeownerApprovalText())
+	b.WriteString(pr.GetReviewDecisionText() + pr.GetMergeQueueText() + pr.GetReviewEffortText() + pr.GetReviewResponseText())
+	b.WriteString(pr.GetCommentCountText())
+	if pr.IsMerged() {
+		b.WriteString(" 🚀")
+	}
+	for _, link := range pr.GetQuickLinks() {
+		b.WriteString(" [" + link.Text + ": " + link.URL + "]")
+	}
+	return b.String()
+}
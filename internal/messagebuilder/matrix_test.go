@@ -0,0 +1,81 @@
+package messagebuilder_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/messagebuilder"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagecontent"
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+)
+
+func TestBuildMatrixMessage(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		content               messagecontent.Content
+		expectedFormattedBody string
+		expectedBody          string
+	}{
+		{
+			name:                  "no PRs",
+			content:               messagecontent.Content{SummaryText: "No open PRs"},
+			expectedFormattedBody: "<p>No open PRs</p>",
+			expectedBody:          "No open PRs",
+		},
+		{
+			name: "PR list with review captain",
+			content: messagecontent.Content{
+				PRListHeading: "Open <PRs>",
+				PRs:           getTestPRs().PRs,
+				ReviewCaptain: &messagecontent.ReviewCaptain{Name: "Alice"},
+			},
+			expectedFormattedBody: "<p>Today's review captain: <b>Alice</b></p>" +
+				"<p><b>Open &lt;PRs&gt;</b></p>" +
+				`<ul><li><a href=""><b>This is a test PR</b></a> <i>3 hours ago</i> by Test User</li></ul>`,
+			expectedBody: "Today's review captain: Alice\n\nOpen <PRs>\n- This is a test PR () 3 hours ago by Test User",
+		},
+		{
+			name: "repository digest",
+			content: messagecontent.Content{
+				PRListHeading: "Open PRs",
+				RepositoryDigests: []messagecontent.RepositoryDigest{
+					{RepositoryPath: "org/repo", RepositoryLink: "https://github.com/org/repo/pulls", PRCount: 4, OldPRCount: 2},
+				},
+			},
+			expectedFormattedBody: "<p><b>Open PRs</b></p>" +
+				`<ul><li>org/repo: 4 open (2 stale) → <a href="https://github.com/org/repo/pulls">pull requests</a></li></ul>`,
+			expectedBody: "Open PRs\n- org/repo: 4 open (2 stale) → https://github.com/org/repo/pulls",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			message := messagebuilder.BuildMatrixMessage(tc.content)
+
+			if message.FormattedBody != tc.expectedFormattedBody {
+				t.Errorf("Expected formatted body '%s', got '%s'", tc.expectedFormattedBody, message.FormattedBody)
+			}
+			if message.Body != tc.expectedBody {
+				t.Errorf("Expected body '%s', got '%s'", tc.expectedBody, message.Body)
+			}
+		})
+	}
+}
+
+func TestBuildMatrixMessage_LimitsMessageSize(t *testing.T) {
+	var prs []prparser.PR
+	for range 300 {
+		pr := getTestPRs().PR1
+		pr.Title = strings.Repeat("x", 200)
+		prs = append(prs, pr)
+	}
+
+	message := messagebuilder.BuildMatrixMessage(messagecontent.Content{PRListHeading: "Open PRs", PRs: prs})
+
+	if size := len(message.Body) + len(message.FormattedBody); size > 60000 {
+		t.Errorf("Expected the message to be limited to 60000 bytes, got %d", size)
+	}
+	if !strings.Contains(message.FormattedBody, "more PRs</i></p>") {
+		t.Errorf("Expected a note about the dropped PRs")
+	}
+}
@@ -4,10 +4,14 @@
 package messagebuilder
 
 import (
+	"fmt"
 	"log"
+	"strings"
 
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
 	"github.com/hellej/pr-slack-reminder-action/internal/messagecontent"
 	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+	"github.com/hellej/pr-slack-reminder-action/pkg/blockids"
 	"github.com/slack-go/slack"
 )
 
@@ -18,38 +22,295 @@ import (
 // which doesn't need spacing block after it).
 const maximumBlocksInSlackMessage = 50
 
+const failingWorkflowsHeading = "🔴 Failing workflows"
+
 func BuildMessage(content messagecontent.Content) (slack.Message, string) {
-	var blocks []slack.Block
+	blocks := getHeaderBlocks(content)
 
-	if !content.HasPRs() {
+	switch {
+	case !content.HasPRs():
 		blocks = addNoPRsBlock(blocks, content.SummaryText)
-		return slack.NewBlockMessage(blocks...), content.SummaryText
+	case len(content.RepositoryDigests) > 0:
+		blocks = addRepositoryDigestBlocks(blocks, content.PRListHeading, content.RepositoryDigests)
+	case !content.GroupedByRepository:
+		if len(content.PRs) > 0 {
+			blocks = addPRListBLock(blocks, content.PRListHeading, content.PRs)
+		}
+	default:
+		blocks = addRepositoryPRListBlocks(blocks, content.PRsGroupedByRepository)
 	}
 
-	if !content.GroupedByRepository {
-		blocks = addPRListBLock(blocks, content.PRListHeading, content.PRs)
-	} else {
-		blocks = addRepositoryPRListBlocks(blocks, content.PRsGroupedByRepository)
+	footerBlocks := getFooterBlocks(content)
+	blocks = limitMaximumMessageSize(blocks, maximumBlocksInSlackMessage-len(footerBlocks))
+	blocks = append(blocks, footerBlocks...)
+	return newBlockMessage(blocks, content.Color), content.SummaryText
+}
+
+// SplitMessage is one of the messages of a reminder that is split by repository.
+type SplitMessage struct {
+	Message     slack.Message
+	SummaryText string
+}
+
+// BuildMessages builds the reminder as one message (see BuildMessage), or if splitByRepository is set
+// and the PRs grouped by repository do not fit in one message, as one message per repository group
+// (split-messages-by-repo) instead of dropping the groups over the block limit. The header of the
+// reminder is then in the first message and the footer in the last one.
+func BuildMessages(content messagecontent.Content, splitByRepository bool) []SplitMessage {
+	headerBlocks := getHeaderBlocks(content)
+	footerBlocks := getFooterBlocks(content)
+	groups := content.PRsGroupedByRepository
+	blockCount := len(headerBlocks) + len(addRepositoryPRListBlocks(nil, groups)) + len(footerBlocks)
+	if !splitByRepository || !content.GroupedByRepository || len(groups) < 2 || blockCount <= maximumBlocksInSlackMessage {
+		message, summaryText := BuildMessage(content)
+		return []SplitMessage{{Message: message, SummaryText: summaryText}}
+	}
+	log.Printf(
+		"Message content is too large (too many blocks: %v), splitting it into %v messages by repository",
+		blockCount, len(groups),
+	)
+	messages := make([]SplitMessage, 0, len(groups))
+	for i, group := range groups {
+		var blocks []slack.Block
+		summaryText := content.SummaryText
+		if i == 0 {
+			blocks = headerBlocks
+		} else {
+			summaryText = fmt.Sprintf("%s (%d/%d)", group.RepositoryLinkLabel, i+1, len(groups))
+		}
+		blocks = addRepositoryPRListBlocks(blocks, groups[i:i+1])
+		if i == len(groups)-1 {
+			blocks = append(blocks, footerBlocks...)
+		}
+		messages = append(messages, SplitMessage{Message: newBlockMessage(blocks, content.Color), SummaryText: summaryText})
 	}
+	return messages
+}
 
-	blocks = limitMaximumMessageSize(blocks)
+func newBlockMessage(blocks []slack.Block, color string) slack.Message {
+	message := slack.NewBlockMessage(blocks...)
+	if color != "" {
+		// The blocks are sent in a colored attachment to show the color bar
+		message.Attachments = []slack.Attachment{{Color: color, Blocks: message.Blocks}}
+	}
+	return message
+}
+
+// Header blocks are added before the PR lists (to the first message if the reminder is split).
+func getHeaderBlocks(content messagecontent.Content) []slack.Block {
+	var blocks []slack.Block
+	if content.ResolvedPRsSummary != "" {
+		blocks = append(blocks,
+			slack.NewContextBlock(blockids.ResolvedPRs,
+				slack.NewTextBlockObject("mrkdwn", content.ResolvedPRsSummary, false, false),
+			),
+		)
+	}
+	if content.ReviewCaptain != nil && content.HasPRs() {
+		blocks = addReviewCaptainBlock(blocks, *content.ReviewCaptain)
+	}
+	if len(content.ReleasePRs) > 0 {
+		blocks = addReleasePRListBlocks(blocks, content.ReleasePRs)
+	}
+	return blocks
+}
+
+// BuildReviewerThreadMessage builds the thread reply that lists the PRs waiting for the review
+// of the reviewer (reviewer-threads). The reviewer is mentioned only if some PRs are listed, and
+// shown by name if their Slack user ID is not known (e.g. when they have Do Not Disturb on).
+func BuildReviewerThreadMessage(content messagecontent.ReviewerContent) (slack.Message, string) {
+	headingElements := []slack.RichTextSectionElement{
+		slack.NewRichTextSectionTextElement(content.SummaryText, &slack.RichTextSectionTextStyle{}),
+	}
+	if content.HasPRs() {
+		var reviewerElement slack.RichTextSectionElement = slack.NewRichTextSectionUserElement(
+			content.Reviewer.SlackUserID, &slack.RichTextSectionTextStyle{},
+		)
+		if content.Reviewer.SlackUserID == "" {
+			reviewerElement = slack.NewRichTextSectionTextElement(
+				content.Reviewer.GetGitHubName(), &slack.RichTextSectionTextStyle{},
+			)
+		}
+		headingElements = append([]slack.RichTextSectionElement{
+			reviewerElement,
+			slack.NewRichTextSectionTextElement(" ", &slack.RichTextSectionTextStyle{}),
+		}, headingElements...)
+	}
+	blocks := []slack.Block{
+		slack.NewRichTextBlock(blockids.ReviewerThreadHeading, slack.NewRichTextSection(headingElements...)),
+	}
+	if content.HasPRs() {
+		blocks = append(blocks, makePRListBlockWithID(content.PRs, blockids.ReviewerThreadPRs))
+	}
 	return slack.NewBlockMessage(blocks...), content.SummaryText
 }
 
-func limitMaximumMessageSize(blocks []slack.Block) []slack.Block {
-	if len(blocks) > maximumBlocksInSlackMessage {
+// BuildEscalationMessage builds the condensed message of the old PRs posted to the escalation channel:
+// each PR is listed only with its age, repository and author, the oldest first.
+func BuildEscalationMessage(content messagecontent.EscalationContent) (slack.Message, string) {
+	var prElements []slack.RichTextElement
+	for _, pr := range content.PRs {
+		prElements = append(prElements, slack.NewRichTextSection(
+			slack.NewRichTextSectionLinkElement(pr.HTMLURL, pr.Title, &slack.RichTextSectionTextStyle{Bold: true}),
+			slack.NewRichTextSectionTextElement(" "+pr.GetPRAgeText()+" old", &slack.RichTextSectionTextStyle{Code: true}),
+			slack.NewRichTextSectionTextElement(" in "+pr.Repository.GetPath()+" by ", &slack.RichTextSectionTextStyle{}),
+			getUserNameElement(pr),
+		))
+	}
+	return slack.NewBlockMessage(
+		slack.NewRichTextBlock(blockids.EscalationHeading, slack.NewRichTextSection(
+			slack.NewRichTextSectionTextElement(content.SummaryText, &slack.RichTextSectionTextStyle{Bold: true}),
+		)),
+		slack.NewRichTextBlock(blockids.EscalationPRs,
+			slack.NewRichTextList(slack.RichTextListElementType("bullet"), 0, prElements...),
+		),
+	), content.SummaryText
+}
+
+// Footer blocks are added after the PR lists, which are limited to leave room for them.
+func getFooterBlocks(content messagecontent.Content) []slack.Block {
+	var blocks []slack.Block
+	if content.RecentlyMerged != nil {
+		blocks = addRecentlyMergedBlocks(blocks, *content.RecentlyMerged)
+	}
+	if len(content.FailingWorkflows) > 0 {
+		blocks = addFailingWorkflowsBlocks(blocks, content.FailingWorkflows)
+	}
+	if len(content.MilestoneProgress) > 0 {
+		blocks = addMilestoneProgressBlock(blocks, content.MilestoneProgress)
+	}
+	if len(content.SkippedArchivedRepositories) > 0 {
+		blocks = addSkippedArchivedRepositoriesBlock(blocks, content.SkippedArchivedRepositories)
+	}
+	if quietRepositoriesText := content.GetQuietRepositoriesText(); quietRepositoriesText != "" {
+		blocks = append(blocks,
+			slack.NewContextBlock(blockids.QuietRepositories,
+				slack.NewTextBlockObject("mrkdwn", quietRepositoriesText, false, false),
+			),
+		)
+	}
+	if warning := content.GetBranchProtectionWarning(); warning != "" {
+		blocks = addBranchProtectionWarningBlock(blocks, warning)
+	}
+	if content.RunReportSummary != "" {
+		blocks = append(blocks,
+			slack.NewContextBlock(blockids.RunReport,
+				slack.NewTextBlockObject("mrkdwn", content.RunReportSummary, false, false),
+			),
+		)
+	}
+	if content.WorkflowRunURL != "" {
+		blocks = addWorkflowRunLinkBlock(blocks, content.WorkflowRunURL)
+	}
+	return blocks
+}
+
+func limitMaximumMessageSize(blocks []slack.Block, maximumBlocks int) []slack.Block {
+	if len(blocks) > maximumBlocks {
 		log.Printf(
 			"Message content is too large (too many blocks: %v, dropping: %v)",
-			len(blocks), len(blocks)-maximumBlocksInSlackMessage,
+			len(blocks), len(blocks)-maximumBlocks,
 		)
-		blocks = blocks[:maximumBlocksInSlackMessage]
+		blocks = blocks[:maximumBlocks]
 	}
 	return blocks
 }
 
+func addWorkflowRunLinkBlock(blocks []slack.Block, workflowRunURL string) []slack.Block {
+	return append(blocks,
+		slack.NewContextBlock(blockids.WorkflowRunLink,
+			slack.NewTextBlockObject("mrkdwn", "<"+workflowRunURL+"|generated by this workflow run>", false, false),
+		),
+	)
+}
+
+func addMilestoneProgressBlock(blocks []slack.Block, progress []messagecontent.MilestoneProgress) []slack.Block {
+	var elements []slack.MixedElement
+	for _, p := range progress {
+		elements = append(elements, slack.NewTextBlockObject("mrkdwn", p.Text(), false, false))
+	}
+	return append(blocks, slack.NewContextBlock(blockids.MilestoneProgress, elements...))
+}
+
+func addSkippedArchivedRepositoriesBlock(blocks []slack.Block, repositories []string) []slack.Block {
+	return append(blocks,
+		slack.NewContextBlock(blockids.SkippedArchivedRepositories,
+			slack.NewTextBlockObject(
+				"mrkdwn", "Skipped archived repositories: "+strings.Join(repositories, ", "), false, false,
+			),
+		),
+	)
+}
+
+func addBranchProtectionWarningBlock(blocks []slack.Block, warning string) []slack.Block {
+	return append(blocks,
+		slack.NewContextBlock(blockids.BranchProtectionWarning,
+			slack.NewTextBlockObject("mrkdwn", warning, false, false),
+		),
+	)
+}
+
+// The merged PRs are listed compactly with their titles struck through.
+func addRecentlyMergedBlocks(blocks []slack.Block, recentlyMerged messagecontent.RecentlyMerged) []slack.Block {
+	var prElements []slack.RichTextElement
+	for _, pr := range recentlyMerged.PRs {
+		prElements = append(prElements, slack.NewRichTextSection(
+			slack.NewRichTextSectionLinkElement(pr.URL, pr.Title, &slack.RichTextSectionTextStyle{Strike: true}),
+			slack.NewRichTextSectionTextElement(" 🚀 in "+pr.RepositoryPath, &slack.RichTextSectionTextStyle{}),
+		))
+	}
+	return append(blocks,
+		slack.NewRichTextBlock(blockids.RecentlyMergedHeading,
+			slack.NewRichTextSection(
+				slack.NewRichTextSectionTextElement(recentlyMerged.Heading(), &slack.RichTextSectionTextStyle{Bold: true}),
+			),
+		),
+		slack.NewRichTextBlock(blockids.RecentlyMerged,
+			slack.NewRichTextList(slack.RichTextListElementType("bullet"), 0, prElements...),
+		),
+	)
+}
+
+func addFailingWorkflowsBlocks(blocks []slack.Block, workflows []messagecontent.FailingWorkflow) []slack.Block {
+	var workflowElements []slack.RichTextElement
+	for _, workflow := range workflows {
+		workflowElements = append(workflowElements, slack.NewRichTextSection(
+			slack.NewRichTextSectionLinkElement(workflow.RunURL, workflow.Name, &slack.RichTextSectionTextStyle{Bold: true}),
+			slack.NewRichTextSectionTextElement(" in "+workflow.RepositoryPath, &slack.RichTextSectionTextStyle{}),
+		))
+	}
+	return append(blocks,
+		slack.NewRichTextBlock(blockids.FailingWorkflowsHeading,
+			slack.NewRichTextSection(
+				slack.NewRichTextSectionTextElement(failingWorkflowsHeading, &slack.RichTextSectionTextStyle{Bold: true}),
+			),
+		),
+		slack.NewRichTextBlock(blockids.FailingWorkflows,
+			slack.NewRichTextList(slack.RichTextListElementType("bullet"), 0, workflowElements...),
+		),
+	)
+}
+
+func addReviewCaptainBlock(blocks []slack.Block, captain messagecontent.ReviewCaptain) []slack.Block {
+	var captainElement slack.RichTextSectionElement = slack.NewRichTextSectionTextElement(
+		captain.Name, &slack.RichTextSectionTextStyle{Bold: true},
+	)
+	if captain.SlackUserID != "" {
+		captainElement = slack.NewRichTextSectionUserElement(captain.SlackUserID, &slack.RichTextSectionTextStyle{})
+	}
+	return append(blocks,
+		slack.NewRichTextBlock(blockids.ReviewCaptain,
+			slack.NewRichTextSection(
+				slack.NewRichTextSectionTextElement("Today's review captain: ", &slack.RichTextSectionTextStyle{}),
+				captainElement,
+			),
+		),
+	)
+}
+
 func addNoPRsBlock(blocks []slack.Block, noPRsText string) []slack.Block {
 	return append(blocks,
-		slack.NewRichTextBlock("no_prs_block",
+		slack.NewRichTextBlock(blockids.NoPRs,
 			slack.NewRichTextSection(
 				slack.NewRichTextSectionTextElement(noPRsText, &slack.RichTextSectionTextStyle{}),
 			),
@@ -59,12 +320,47 @@ func addNoPRsBlock(blocks []slack.Block, noPRsText string) []slack.Block {
 
 func addPRListBLock(blocks []slack.Block, heading string, prs []prparser.PR) []slack.Block {
 	return append(blocks,
-		slack.NewRichTextBlock("pr_list_heading",
+		slack.NewRichTextBlock(blockids.PRListHeading,
 			slack.NewRichTextSection(
 				slack.NewRichTextSectionTextElement(heading, &slack.RichTextSectionTextStyle{Bold: true}),
 			),
 		),
-		makePRListBlockWithID(prs, "open_prs"),
+		makePRListBlockWithID(prs, blockids.PRList),
+	)
+}
+
+func addRepositoryDigestBlocks(
+	blocks []slack.Block, heading string, digests []messagecontent.RepositoryDigest,
+) []slack.Block {
+	var digestElements []slack.RichTextElement
+	for _, digest := range digests {
+		digestElements = append(digestElements, slack.NewRichTextSection(
+			slack.NewRichTextSectionTextElement(digest.Text()+" → ", &slack.RichTextSectionTextStyle{}),
+			slack.NewRichTextSectionLinkElement(digest.RepositoryLink, messagecontent.DigestLinkText, nil),
+		))
+	}
+	return append(blocks,
+		slack.NewRichTextBlock(blockids.PRListHeading,
+			slack.NewRichTextSection(
+				slack.NewRichTextSectionTextElement(heading, &slack.RichTextSectionTextStyle{Bold: true}),
+			),
+		),
+		slack.NewRichTextBlock(blockids.RepositoryDigest,
+			slack.NewRichTextList(slack.RichTextListElementType("bullet"), 0, digestElements...),
+		),
+	)
+}
+
+func addReleasePRListBlocks(blocks []slack.Block, releasePRs []prparser.PR) []slack.Block {
+	return append(blocks,
+		slack.NewRichTextBlock(blockids.ReleasePRsHeading,
+			slack.NewRichTextSection(
+				slack.NewRichTextSectionTextElement(
+					messagecontent.ReleasePRsHeading, &slack.RichTextSectionTextStyle{Bold: true},
+				),
+			),
+		),
+		makePRListBlockWithID(releasePRs, blockids.ReleasePRs),
 	)
 }
 
@@ -73,23 +369,45 @@ func addRepositoryPRListBlocks(
 	prsGroupedByRepository []messagecontent.PRsOfRepository,
 ) []slack.Block {
 	for idx, group := range prsGroupedByRepository {
+		headingElements := []slack.RichTextSectionElement{
+			slack.NewRichTextSectionTextElement(group.HeadingPrefix, &slack.RichTextSectionTextStyle{Bold: true}),
+			slack.NewRichTextSectionLinkElement(
+				group.RepositoryLink, group.RepositoryLinkLabel, &slack.RichTextSectionTextStyle{Bold: true},
+			),
+			slack.NewRichTextSectionTextElement(":", &slack.RichTextSectionTextStyle{Bold: true}),
+		}
+		for _, text := range []string{group.FailingChecksText, group.NoPRsText} {
+			if text != "" {
+				headingElements = append(headingElements,
+					slack.NewRichTextSectionTextElement(" "+text, &slack.RichTextSectionTextStyle{Italic: true}),
+				)
+			}
+		}
 		blocks = append(blocks,
-			slack.NewRichTextBlock("pr_list_heading_"+group.RepositoryLinkLabel,
-				slack.NewRichTextSection(
-					slack.NewRichTextSectionTextElement(group.HeadingPrefix, &slack.RichTextSectionTextStyle{Bold: true}),
-					slack.NewRichTextSectionLinkElement(
-						group.RepositoryLink, group.RepositoryLinkLabel, &slack.RichTextSectionTextStyle{Bold: true},
-					),
-					slack.NewRichTextSectionTextElement(":", &slack.RichTextSectionTextStyle{Bold: true}),
-				),
+			slack.NewRichTextBlock(blockids.RepositoryHeading(group.RepositoryLinkLabel),
+				slack.NewRichTextSection(headingElements...),
 			),
 		)
-		blocks = append(blocks, makePRListBlockWithID(group.PRs, "open_prs_"+group.RepositoryLinkLabel))
+		if len(group.PRs) > 0 {
+			blocks = append(blocks, makePRListBlockWithID(group.PRs, blockids.RepositoryPRList(group.RepositoryLinkLabel)))
+		}
+		if hiddenPRsText := group.GetHiddenPRsText(); hiddenPRsText != "" {
+			blocks = append(blocks,
+				slack.NewRichTextBlock(blockids.RepositoryHiddenPRs(group.RepositoryLinkLabel),
+					slack.NewRichTextSection(slack.NewRichTextSectionLinkElement(
+						group.RepositoryLink, hiddenPRsText, &slack.RichTextSectionTextStyle{Italic: true},
+					)),
+				),
+			)
+		}
 
 		if idx < len(prsGroupedByRepository)-1 {
 			// adding spacing block between repositories
 			blocks = append(blocks,
-				slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", " ", false, false), nil, nil),
+				slack.NewSectionBlock(
+					slack.NewTextBlockObject("mrkdwn", " ", false, false), nil, nil,
+					slack.SectionBlockOptionBlockID(blockids.RepositorySpacing(group.RepositoryLinkLabel)),
+				),
 			)
 		}
 	}
@@ -114,7 +432,7 @@ func buildPRBulletPointBlock(pr prparser.PR) slack.RichTextElement {
 
 	if pr.IsOldPR {
 		ageElements = append(ageElements,
-			slack.NewRichTextSectionTextElement(" 🚨 ", &slack.RichTextSectionTextStyle{}),
+			slack.NewRichTextSectionTextElement(" "+pr.GetOldPRMarker()+" ", &slack.RichTextSectionTextStyle{}),
 			slack.NewRichTextSectionTextElement(pr.GetPRAgeText()+" old", &slack.RichTextSectionTextStyle{Bold: true, Code: true}),
 		)
 	} else {
@@ -122,12 +440,20 @@ func buildPRBulletPointBlock(pr prparser.PR) slack.RichTextElement {
 			slack.NewRichTextSectionTextElement(" "+pr.GetPRAgeText()+" ago", &slack.RichTextSectionTextStyle{Italic: true}),
 		)
 	}
+	if reminderText := pr.GetReminderText(); reminderText != "" {
+		ageElements = append(ageElements,
+			slack.NewRichTextSectionTextElement(" ("+reminderText+")", &slack.RichTextSectionTextStyle{Italic: true}),
+		)
+	}
 
 	prItemElements := []slack.RichTextSectionElement{}
+	if prefix := pr.GetHighlightPrefix(); prefix != "" {
+		prItemElements = append(prItemElements, slack.NewRichTextSectionTextElement(prefix, &slack.RichTextSectionTextStyle{}))
+	}
 
 	linkStyle := &slack.RichTextSectionTextStyle{Bold: true, Strike: pr.IsClosedButNotMerged()}
 	prItemElements = append(prItemElements,
-		slack.NewRichTextSectionLinkElement(pr.GetHTMLURL(), pr.GetTitle(), linkStyle),
+		slack.NewRichTextSectionLinkElement(pr.HTMLURL, pr.Title, linkStyle),
 	)
 	prItemElements = append(prItemElements, ageElements...)
 	prItemElements = append(prItemElements,
@@ -136,79 +462,142 @@ func buildPRBulletPointBlock(pr prparser.PR) slack.RichTextElement {
 	)
 
 	prItemElements = append(prItemElements, getReviewersElements(pr)...)
+	if approvalText := pr.GetCodeownerApprovalText(); approvalText != "" {
+		prItemElements = append(prItemElements,
+			slack.NewRichTextSectionTextElement(approvalText, &slack.RichTextSectionTextStyle{}),
+		)
+	}
+	if decisionText := pr.GetReviewDecisionText(); decisionText != "" {
+		prItemElements = append(prItemElements,
+			slack.NewRichTextSectionTextElement(decisionText, &slack.RichTextSectionTextStyle{}),
+		)
+	}
+	if queueText := pr.GetMergeQueueText(); queueText != "" {
+		prItemElements = append(prItemElements,
+			slack.NewRichTextSectionTextElement(queueText, &slack.RichTextSectionTextStyle{}),
+		)
+	}
+	if effortText := pr.GetReviewEffortText(); effortText != "" {
+		prItemElements = append(prItemElements,
+			slack.NewRichTextSectionTextElement(effortText, &slack.RichTextSectionTextStyle{}),
+		)
+	}
+	if responseText := pr.GetReviewResponseText(); responseText != "" {
+		prItemElements = append(prItemElements,
+			slack.NewRichTextSectionTextElement(responseText, &slack.RichTextSectionTextStyle{}),
+		)
+	}
+	if commentCountText := pr.GetCommentCountText(); commentCountText != "" {
+		prItemElements = append(prItemElements,
+			slack.NewRichTextSectionTextElement(commentCountText, &slack.RichTextSectionTextStyle{}),
+		)
+	}
+	prItemElements = append(prItemElements, getRequestedTeamsElements(pr)...)
+
+	if pr.MovedToRepository != nil {
+		prItemElements = append(prItemElements,
+			slack.NewRichTextSectionTextElement(
+				" (repo moved to "+pr.MovedToRepository.GetPath()+")", &slack.RichTextSectionTextStyle{Italic: true},
+			),
+		)
+	}
 
 	if pr.IsMerged() {
 		prItemElements = append(prItemElements,
 			slack.NewRichTextSectionTextElement(" 🚀", &slack.RichTextSectionTextStyle{}),
 		)
 	}
+	for _, link := range pr.GetQuickLinks() {
+		prItemElements = append(prItemElements,
+			slack.NewRichTextSectionTextElement(" [", &slack.RichTextSectionTextStyle{}),
+			slack.NewRichTextSectionLinkElement(link.URL, link.Text, &slack.RichTextSectionTextStyle{}),
+			slack.NewRichTextSectionTextElement("]", &slack.RichTextSectionTextStyle{}),
+		)
+	}
 
 	return slack.NewRichTextSection(prItemElements...)
 }
 
+// The names of highlighted authors are bold.
 func getUserNameElement(pr prparser.PR) slack.RichTextSectionElement {
+	style := &slack.RichTextSectionTextStyle{Bold: pr.IsHighlighted}
 	if pr.Author.SlackUserID != "" {
-		return slack.NewRichTextSectionUserElement(
-			pr.Author.SlackUserID, &slack.RichTextSectionTextStyle{},
-		)
+		return slack.NewRichTextSectionUserElement(pr.Author.SlackUserID, style)
 	}
-	return slack.NewRichTextSectionTextElement(
-		pr.Author.GetGitHubName(), &slack.RichTextSectionTextStyle{},
-	)
+	return slack.NewRichTextSectionTextElement(pr.Author.GetGitHubName(), style)
 }
 
+// Returns the reviewers grouped by their state, e.g. " (✅ alice / 💬 bob / ⏳ carol)".
 func getReviewersElements(pr prparser.PR) []slack.RichTextSectionElement {
 	var elements []slack.RichTextSectionElement
-	approverCount := len(pr.Approvers)
-	commenterCount := len(pr.Commenters)
-
-	if approverCount == 0 && commenterCount == 0 {
+	groups := pr.GetReviewerGroups()
+	if len(groups) == 0 {
 		return elements
 	}
 
-	reviewerTextPrefix := " (💬 "
-	if len(pr.Approvers) > 0 {
-		reviewerTextPrefix = " (✅ "
-	}
-	elements = append(elements, slack.NewRichTextSectionTextElement(
-		reviewerTextPrefix, &slack.RichTextSectionTextStyle{},
-	))
-
-	for idx, approver := range pr.Approvers {
-		if idx > 0 {
-			elements = append(elements, slack.NewRichTextSectionTextElement(
-				", ", &slack.RichTextSectionTextStyle{},
-			))
+	for groupIdx, group := range groups {
+		groupPrefix := " / " + group.Emoji + " "
+		if groupIdx == 0 {
+			groupPrefix = " (" + group.Emoji + " "
 		}
 		elements = append(elements, slack.NewRichTextSectionTextElement(
-			approver.GetGitHubName(), &slack.RichTextSectionTextStyle{},
+			groupPrefix, &slack.RichTextSectionTextStyle{},
 		))
+		for idx, reviewer := range group.Reviewers {
+			if idx > 0 {
+				elements = append(elements, slack.NewRichTextSectionTextElement(
+					", ", &slack.RichTextSectionTextStyle{},
+				))
+			}
+			elements = append(elements, getReviewerElement(reviewer, pr.ReviewerLinkStyle))
+		}
 	}
 
-	if commenterCount == 0 {
-		return append(elements, slack.NewRichTextSectionTextElement(
-			")", &slack.RichTextSectionTextStyle{},
-		))
+	return append(elements, slack.NewRichTextSectionTextElement(
+		")", &slack.RichTextSectionTextStyle{},
+	))
+}
+
+func getReviewerElement(reviewer prparser.Collaborator, linkStyle config.ReviewerLinkStyle) slack.RichTextSectionElement {
+	switch {
+	case linkStyle == config.ReviewerLinkStyleGitHub:
+		return slack.NewRichTextSectionLinkElement(
+			"https://github.com/"+reviewer.Login, reviewer.GetGitHubName(), &slack.RichTextSectionTextStyle{},
+		)
+	case linkStyle == config.ReviewerLinkStyleSlack && reviewer.SlackUserID != "":
+		return slack.NewRichTextSectionUserElement(reviewer.SlackUserID, &slack.RichTextSectionTextStyle{})
+	default:
+		return slack.NewRichTextSectionTextElement(reviewer.GetGitHubName(), &slack.RichTextSectionTextStyle{})
 	}
+}
 
-	if reviewerTextPrefix == " (✅ " {
-		elements = append(elements, slack.NewRichTextSectionTextElement(
-			" / 💬 ", &slack.RichTextSectionTextStyle{},
-		))
+func getRequestedTeamsElements(pr prparser.PR) []slack.RichTextSectionElement {
+	var elements []slack.RichTextSectionElement
+	if len(pr.RequestedTeams) == 0 {
+		return elements
 	}
 
-	for idx, commenter := range pr.Commenters {
+	elements = append(elements, slack.NewRichTextSectionTextElement(
+		" (👥 ", &slack.RichTextSectionTextStyle{},
+	))
+	for idx, team := range pr.RequestedTeams {
 		if idx > 0 {
 			elements = append(elements, slack.NewRichTextSectionTextElement(
 				", ", &slack.RichTextSectionTextStyle{},
 			))
 		}
-		elements = append(elements, slack.NewRichTextSectionTextElement(
-			commenter.GetGitHubName(), &slack.RichTextSectionTextStyle{},
-		))
+		elements = append(elements, getTeamNameElement(team))
 	}
-
 	return append(elements, slack.NewRichTextSectionTextElement(
 		")", &slack.RichTextSectionTextStyle{},
 	))
 }
+
+func getTeamNameElement(team prparser.Team) slack.RichTextSectionElement {
+	if team.SlackGroupID != "" {
+		return slack.NewRichTextSectionUserGroupElement(team.SlackGroupID)
+	}
+	return slack.NewRichTextSectionTextElement(
+		team.GetGitHubName(), &slack.RichTextSectionTextStyle{},
+	)
+}
@@ -1,17 +1,21 @@
 package messagebuilder_test
 
 import (
+	"reflect"
+	"slices"
 	"strconv"
 	"testing"
 	"time"
 
-	"github.com/google/go-github/v78/github"
 	"github.com/slack-go/slack"
 
 	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
 	"github.com/hellej/pr-slack-reminder-action/internal/messagebuilder"
 	"github.com/hellej/pr-slack-reminder-action/internal/messagecontent"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
 	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+	"github.com/hellej/pr-slack-reminder-action/pkg/blockids"
 )
 
 func TestBuildSlackBlocksMessage(t *testing.T) {
@@ -74,8 +78,8 @@ func TestBuildSlackBlocksMessage(t *testing.T) {
 		prAgeElement := prBulletPointTextElements[1].(*slack.RichTextSectionTextElement)
 		prBeforeUserElement := prBulletPointTextElements[2].(*slack.RichTextSectionTextElement)
 		prUserElement := prBulletPointTextElements[3].(*slack.RichTextSectionUserElement)
-		if prLinkElement.Text != *testPRs.PR1.Title {
-			t.Errorf("Expected text to be '%s', got '%s'", *testPRs.PR1.Title, prLinkElement.Text)
+		if prLinkElement.Text != testPRs.PR1.Title {
+			t.Errorf("Expected text to be '%s', got '%s'", testPRs.PR1.Title, prLinkElement.Text)
 		}
 		expectedAgeText := " 3 hours ago"
 		if prAgeElement.Text != expectedAgeText {
@@ -146,6 +150,7 @@ func TestLimitMessageSizeByMaxBlocks(t *testing.T) {
 	testCases := []struct {
 		name            string
 		numRepositories int
+		workflowRunURL  string
 		expectedBlocks  int
 	}{
 		{
@@ -158,6 +163,12 @@ func TestLimitMessageSizeByMaxBlocks(t *testing.T) {
 			numRepositories: 20, // -> 59 blocks
 			expectedBlocks:  50,
 		},
+		{
+			name:            "Exceeding block limit with workflow run link",
+			numRepositories: 20, // -> 59 blocks + link block
+			workflowRunURL:  "https://github.com/owner/repo/actions/runs/1",
+			expectedBlocks:  50,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -174,16 +185,427 @@ func TestLimitMessageSizeByMaxBlocks(t *testing.T) {
 					SummaryText:            "2 open PRs are waiting for attention 👀",
 					GroupedByRepository:    true,
 					PRsGroupedByRepository: repoLists,
+					WorkflowRunURL:         tc.workflowRunURL,
 				},
 			)
 
 			if len(message.Blocks.BlockSet) != tc.expectedBlocks {
 				t.Errorf("Expected %d blocks, got %d", tc.expectedBlocks, len(message.Blocks.BlockSet))
 			}
+			lastBlock := message.Blocks.BlockSet[len(message.Blocks.BlockSet)-1]
+			if isLinkBlock := lastBlock.BlockType() == slack.MBTContext; isLinkBlock != (tc.workflowRunURL != "") {
+				t.Errorf("Expected workflow run link block to be the last block: %v", tc.workflowRunURL != "")
+			}
+		})
+	}
+}
+
+func TestBuildMessagesSplitByRepository(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		numRepositories      int
+		splitByRepository    bool
+		expectedMessageCount int
+	}{
+		{
+			name:                 "Within block limit",
+			numRepositories:      5,
+			splitByRepository:    true,
+			expectedMessageCount: 1,
+		},
+		{
+			name:                 "Exceeding block limit",
+			numRepositories:      20, // -> 59 blocks + link block
+			splitByRepository:    true,
+			expectedMessageCount: 20,
+		},
+		{
+			name:                 "Exceeding block limit without splitting",
+			numRepositories:      20,
+			expectedMessageCount: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var repoLists []messagecontent.PRsOfRepository
+			for repoId := 1; repoId <= tc.numRepositories; repoId++ {
+				repoLists = append(repoLists, newRepositoryList(repoId))
+			}
+			summaryText := "20 open PRs are waiting for attention 👀"
+
+			messages := messagebuilder.BuildMessages(
+				messagecontent.Content{
+					SummaryText:            summaryText,
+					GroupedByRepository:    true,
+					PRsGroupedByRepository: repoLists,
+					WorkflowRunURL:         "https://github.com/owner/repo/actions/runs/1",
+				},
+				tc.splitByRepository,
+			)
+
+			if len(messages) != tc.expectedMessageCount {
+				t.Fatalf("Expected %d messages, got %d", tc.expectedMessageCount, len(messages))
+			}
+			if messages[0].SummaryText != summaryText {
+				t.Errorf("Expected the summary text in the first message, got: %s", messages[0].SummaryText)
+			}
+			if tc.expectedMessageCount == 1 {
+				return
+			}
+			for i, message := range messages {
+				blocks := message.Message.Blocks.BlockSet
+				expectedHeadingID := blockids.RepositoryHeading("owner/repo-" + strconv.Itoa(i+1))
+				if blocks[0].ID() != expectedHeadingID {
+					t.Errorf("Expected message %d to start with the heading %s, got %s", i, expectedHeadingID, blocks[0].ID())
+				}
+				if hasLink := blocks[len(blocks)-1].ID() == blockids.WorkflowRunLink; hasLink != (i == len(messages)-1) {
+					t.Errorf("Expected the workflow run link only in the last message, got it in message %d", i)
+				}
+			}
+			if expected := "owner/repo-2 (2/20)"; messages[1].SummaryText != expected {
+				t.Errorf("Expected the summary text %s in the second message, got: %s", expected, messages[1].SummaryText)
+			}
 		})
 	}
 }
 
+func TestWorkflowRunLink(t *testing.T) {
+	for _, content := range []messagecontent.Content{
+		{SummaryText: "No open PRs", WorkflowRunURL: "https://github.com/owner/repo/actions/runs/123"},
+		{PRListHeading: "Open PRs", PRs: getTestPRs().PRs, WorkflowRunURL: "https://github.com/owner/repo/actions/runs/123"},
+	} {
+		message, _ := messagebuilder.BuildMessage(content)
+
+		lastBlock, ok := message.Blocks.BlockSet[len(message.Blocks.BlockSet)-1].(*slack.ContextBlock)
+		if !ok {
+			t.Fatalf("Expected the last block to be a context block")
+		}
+		linkText := lastBlock.ContextElements.Elements[0].(*slack.TextBlockObject).Text
+		expectedLinkText := "<https://github.com/owner/repo/actions/runs/123|generated by this workflow run>"
+		if linkText != expectedLinkText {
+			t.Errorf("Expected link text '%s', got '%s'", expectedLinkText, linkText)
+		}
+	}
+}
+
+func TestSkippedArchivedRepositoriesNote(t *testing.T) {
+	content := messagecontent.Content{
+		PRListHeading:               "Open PRs",
+		PRs:                         getTestPRs().PRs,
+		SkippedArchivedRepositories: []string{"owner/old-repo", "owner/legacy"},
+		WorkflowRunURL:              "https://github.com/owner/repo/actions/runs/123",
+	}
+
+	message, _ := messagebuilder.BuildMessage(content)
+
+	blockCount := len(message.Blocks.BlockSet)
+	noteBlock, ok := message.Blocks.BlockSet[blockCount-2].(*slack.ContextBlock)
+	if !ok {
+		t.Fatalf("Expected the second to last block to be a context block")
+	}
+	noteText := noteBlock.ContextElements.Elements[0].(*slack.TextBlockObject).Text
+	expectedNoteText := "Skipped archived repositories: owner/old-repo, owner/legacy"
+	if noteText != expectedNoteText {
+		t.Errorf("Expected note text '%s', got '%s'", expectedNoteText, noteText)
+	}
+	if message.Blocks.BlockSet[blockCount-1].ID() != "workflow_run_link" {
+		t.Errorf("Expected the workflow run link to be the last block")
+	}
+}
+
+func TestRecentlyMergedSection(t *testing.T) {
+	content := messagecontent.Content{
+		SummaryText: "1 open PR is waiting for attention 👀",
+		PRs:         []prparser.PR{getTestPRs().PR1},
+		RecentlyMerged: &messagecontent.RecentlyMerged{
+			Hours: 24,
+			PRs: []messagecontent.MergedPR{
+				{Title: "Add feature", URL: "https://github.com/owner/repo/pull/2", RepositoryPath: "owner/repo"},
+			},
+		},
+		WorkflowRunURL: "https://github.com/owner/repo/actions/runs/1",
+	}
+
+	message, _ := messagebuilder.BuildMessage(content)
+
+	blocks := message.Blocks.BlockSet
+	headingIndex := slices.IndexFunc(blocks, func(b slack.Block) bool { return b.ID() == blockids.RecentlyMergedHeading })
+	if headingIndex == -1 || headingIndex+1 >= len(blocks) || blocks[headingIndex+1].ID() != blockids.RecentlyMerged {
+		t.Fatalf("Expected the recently merged heading and list in the message")
+	}
+	heading := blocks[headingIndex].(*slack.RichTextBlock).Elements[0].(*slack.RichTextSection).Elements[0]
+	if text := heading.(*slack.RichTextSectionTextElement).Text; text != "🎉 Merged in the last 24 hours" {
+		t.Errorf("Expected the heading with the hours, got: %s", text)
+	}
+	item := blocks[headingIndex+1].(*slack.RichTextBlock).Elements[0].(*slack.RichTextList).Elements[0].(*slack.RichTextSection)
+	link := item.Elements[0].(*slack.RichTextSectionLinkElement)
+	if link.Text != "Add feature" || !link.Style.Strike {
+		t.Errorf("Expected the title of the merged PR to be struck through, got: %+v", link)
+	}
+	if text := item.Elements[1].(*slack.RichTextSectionTextElement).Text; text != " 🚀 in owner/repo" {
+		t.Errorf("Expected the rocket and the repository after the title, got: %s", text)
+	}
+	if blocks[len(blocks)-1].ID() != blockids.WorkflowRunLink {
+		t.Errorf("Expected the recently merged PRs before the workflow run link")
+	}
+}
+
+func TestFailingWorkflowsSection(t *testing.T) {
+	content := messagecontent.Content{
+		PRListHeading: "Open PRs",
+		PRs:           getTestPRs().PRs,
+		FailingWorkflows: []messagecontent.FailingWorkflow{
+			{Name: "Nightly build", RepositoryPath: "owner/repo", RunURL: "https://github.com/owner/repo/actions/runs/1"},
+			{Name: "E2E", RepositoryPath: "owner/other", RunURL: "https://github.com/owner/other/actions/runs/2"},
+		},
+		WorkflowRunURL: "https://github.com/owner/repo/actions/runs/123",
+	}
+
+	message, _ := messagebuilder.BuildMessage(content)
+
+	var blockIDs []string
+	for _, block := range message.Blocks.BlockSet {
+		blockIDs = append(blockIDs, block.ID())
+	}
+	expectedBlockIDs := []string{
+		"pr_list_heading", "open_prs", "failing_workflows_heading", "failing_workflows", "workflow_run_link",
+	}
+	if !slices.Equal(blockIDs, expectedBlockIDs) {
+		t.Fatalf("Expected blocks %v, got %v", expectedBlockIDs, blockIDs)
+	}
+	workflowList := message.Blocks.BlockSet[3].(*slack.RichTextBlock).Elements[0].(*slack.RichTextList)
+	if len(workflowList.Elements) != 2 {
+		t.Errorf("Expected 2 failing workflows, got %d", len(workflowList.Elements))
+	}
+}
+
+func TestMilestoneProgressBlock(t *testing.T) {
+	content := messagecontent.Content{
+		PRListHeading: "Open PRs",
+		PRs:           getTestPRs().PRs,
+		MilestoneProgress: []messagecontent.MilestoneProgress{
+			{Title: "2.4", ClosedCount: 12, TotalCount: 20},
+			{Title: "2.5", ClosedCount: 0, TotalCount: 3},
+		},
+	}
+
+	message, _ := messagebuilder.BuildMessage(content)
+
+	lastBlock := message.Blocks.BlockSet[len(message.Blocks.BlockSet)-1].(*slack.ContextBlock)
+	if lastBlock.BlockID != "milestone_progress" {
+		t.Fatalf("Expected the milestone progress block last, got %s", lastBlock.BlockID)
+	}
+	var texts []string
+	for _, element := range lastBlock.ContextElements.Elements {
+		texts = append(texts, element.(*slack.TextBlockObject).Text)
+	}
+	expectedTexts := []string{"Milestone 2.4: 12/20 PRs merged", "Milestone 2.5: 0/3 PRs merged"}
+	if !slices.Equal(texts, expectedTexts) {
+		t.Errorf("Expected %v, got %v", expectedTexts, texts)
+	}
+}
+
+func TestReviewerLinkStyles(t *testing.T) {
+	mappedReviewer := prparser.Collaborator{
+		Collaborator: &githubclient.Collaborator{Login: "reviewer1", Name: "Reviewer One"},
+		SlackUserID:  "U23456789",
+	}
+	unmappedReviewer := prparser.Collaborator{
+		Collaborator: &githubclient.Collaborator{Login: "reviewer2"},
+	}
+	testCases := []struct {
+		name             string
+		linkStyle        config.ReviewerLinkStyle
+		expectedElements []slack.RichTextSectionElement
+	}{
+		{
+			name:      "plain",
+			linkStyle: config.ReviewerLinkStylePlain,
+			expectedElements: []slack.RichTextSectionElement{
+				slack.NewRichTextSectionTextElement("Reviewer One", &slack.RichTextSectionTextStyle{}),
+				slack.NewRichTextSectionTextElement("reviewer2", &slack.RichTextSectionTextStyle{}),
+			},
+		},
+		{
+			name:      "github",
+			linkStyle: config.ReviewerLinkStyleGitHub,
+			expectedElements: []slack.RichTextSectionElement{
+				slack.NewRichTextSectionLinkElement(
+					"https://github.com/reviewer1", "Reviewer One", &slack.RichTextSectionTextStyle{},
+				),
+				slack.NewRichTextSectionLinkElement(
+					"https://github.com/reviewer2", "reviewer2", &slack.RichTextSectionTextStyle{},
+				),
+			},
+		},
+		{
+			name:      "slack mentions mapped reviewers only",
+			linkStyle: config.ReviewerLinkStyleSlack,
+			expectedElements: []slack.RichTextSectionElement{
+				slack.NewRichTextSectionUserElement("U23456789", &slack.RichTextSectionTextStyle{}),
+				slack.NewRichTextSectionTextElement("reviewer2", &slack.RichTextSectionTextStyle{}),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := getTestPRs().PR1
+			pr.Approvers = []prparser.Collaborator{mappedReviewer, unmappedReviewer}
+			pr.ReviewerLinkStyle = tc.linkStyle
+
+			message, _ := messagebuilder.BuildMessage(messagecontent.Content{
+				PRListHeading: "Open PRs",
+				PRs:           []prparser.PR{pr},
+			})
+
+			prList := message.Blocks.BlockSet[1].(*slack.RichTextBlock).Elements[0].(*slack.RichTextList)
+			elements := prList.Elements[0].(*slack.RichTextSection).Elements
+			// ... " (✅ ", reviewer1, ", ", reviewer2, ")"
+			reviewerElements := []slack.RichTextSectionElement{elements[len(elements)-4], elements[len(elements)-2]}
+			if !reflect.DeepEqual(reviewerElements, tc.expectedElements) {
+				t.Errorf("Expected reviewer elements %+v, got %+v", tc.expectedElements, reviewerElements)
+			}
+		})
+	}
+}
+
+func TestReviewCaptainBlock(t *testing.T) {
+	testCases := []struct {
+		name            string
+		captain         messagecontent.ReviewCaptain
+		expectedElement slack.RichTextSectionElement
+	}{
+		{
+			name:            "mapped Slack user is mentioned",
+			captain:         messagecontent.ReviewCaptain{Name: "Alice", SlackUserID: "U12345678"},
+			expectedElement: slack.NewRichTextSectionUserElement("U12345678", &slack.RichTextSectionTextStyle{}),
+		},
+		{
+			name:    "unmapped user is shown by name",
+			captain: messagecontent.ReviewCaptain{Name: "Alice"},
+			expectedElement: slack.NewRichTextSectionTextElement(
+				"Alice", &slack.RichTextSectionTextStyle{Bold: true},
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := messagecontent.Content{
+				PRListHeading: "Open PRs",
+				PRs:           getTestPRs().PRs,
+				ReviewCaptain: &tc.captain,
+			}
+
+			message, _ := messagebuilder.BuildMessage(content)
+
+			firstBlock := message.Blocks.BlockSet[0].(*slack.RichTextBlock)
+			if firstBlock.BlockID != "review_captain" {
+				t.Fatalf("Expected the first block to be the review captain block, got '%s'", firstBlock.BlockID)
+			}
+			elements := firstBlock.Elements[0].(*slack.RichTextSection).Elements
+			if !reflect.DeepEqual(elements[1], tc.expectedElement) {
+				t.Errorf("Expected captain element %+v, got %+v", tc.expectedElement, elements[1])
+			}
+		})
+	}
+
+	noPRsMessage, _ := messagebuilder.BuildMessage(messagecontent.Content{
+		SummaryText:   "No open PRs",
+		ReviewCaptain: &messagecontent.ReviewCaptain{Name: "Alice"},
+	})
+	if noPRsMessage.Blocks.BlockSet[0].ID() == "review_captain" {
+		t.Errorf("Expected no review captain block without PRs")
+	}
+}
+
+func TestReviewerThreadMessage(t *testing.T) {
+	reviewer := prparser.Collaborator{
+		Collaborator: &githubclient.Collaborator{Login: "reviewer"},
+		SlackUserID:  "U87654321",
+	}
+	testCases := []struct {
+		name              string
+		content           messagecontent.ReviewerContent
+		expectedBlockIDs  []string
+		expectedMentioned bool
+	}{
+		{
+			name: "reviewer is mentioned with the PRs waiting for them",
+			content: messagecontent.ReviewerContent{
+				Reviewer: reviewer, PRs: getTestPRs().PRs, SummaryText: "1 PR is waiting for your review 👀",
+			},
+			expectedBlockIDs:  []string{"reviewer_thread_heading", "reviewer_thread_prs"},
+			expectedMentioned: true,
+		},
+		{
+			name: "reviewer without Slack user ID is shown by name",
+			content: messagecontent.ReviewerContent{
+				Reviewer: prparser.Collaborator{Collaborator: &githubclient.Collaborator{Login: "reviewer"}},
+				PRs:      getTestPRs().PRs, SummaryText: "1 PR is waiting for your review 👀",
+			},
+			expectedBlockIDs: []string{"reviewer_thread_heading", "reviewer_thread_prs"},
+		},
+		{
+			name: "reviewer is not mentioned without PRs",
+			content: messagecontent.ReviewerContent{
+				Reviewer: reviewer, SummaryText: messagecontent.NoPRsWaitingForReviewerText,
+			},
+			expectedBlockIDs: []string{"reviewer_thread_heading"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			message, summaryText := messagebuilder.BuildReviewerThreadMessage(tc.content)
+
+			if summaryText != tc.content.SummaryText {
+				t.Errorf("Expected summary text '%s', got '%s'", tc.content.SummaryText, summaryText)
+			}
+			var blockIDs []string
+			for _, block := range message.Blocks.BlockSet {
+				blockIDs = append(blockIDs, block.ID())
+			}
+			if !slices.Equal(blockIDs, tc.expectedBlockIDs) {
+				t.Errorf("Expected blocks %v, got %v", tc.expectedBlockIDs, blockIDs)
+			}
+			headingElements := message.Blocks.BlockSet[0].(*slack.RichTextBlock).Elements[0].(*slack.RichTextSection).Elements
+			_, mentioned := headingElements[0].(*slack.RichTextSectionUserElement)
+			if mentioned != tc.expectedMentioned {
+				t.Errorf("Expected reviewer mentioned: %v, got: %v", tc.expectedMentioned, mentioned)
+			}
+		})
+	}
+}
+
+func TestEscalationMessage(t *testing.T) {
+	content := messagecontent.EscalationContent{
+		PRs: getTestPRs().PRs, SummaryText: "🚨 2 old PRs are waiting for attention",
+	}
+
+	message, summaryText := messagebuilder.BuildEscalationMessage(content)
+
+	if summaryText != content.SummaryText {
+		t.Errorf("Expected summary text '%s', got '%s'", content.SummaryText, summaryText)
+	}
+	var blockIDs []string
+	for _, block := range message.Blocks.BlockSet {
+		blockIDs = append(blockIDs, block.ID())
+	}
+	if expected := []string{"escalation_heading", "escalation_prs"}; !slices.Equal(blockIDs, expected) {
+		t.Errorf("Expected blocks %v, got %v", expected, blockIDs)
+	}
+	prList := message.Blocks.BlockSet[1].(*slack.RichTextBlock).Elements[0].(*slack.RichTextList)
+	if len(prList.Elements) != len(content.PRs) {
+		t.Fatalf("Expected %d PRs, got %d", len(content.PRs), len(prList.Elements))
+	}
+	if elements := prList.Elements[0].(*slack.RichTextSection).Elements; len(elements) != 4 {
+		t.Errorf("Expected only the title, age, repository and author of the PR, got %d elements", len(elements))
+	}
+}
+
 func newRepositoryList(id int) messagecontent.PRsOfRepository {
 	return messagecontent.PRsOfRepository{
 		HeadingPrefix:       "Open PRs in repo " + strconv.Itoa(id),
@@ -200,15 +622,9 @@ type TestPRs struct {
 
 func getTestPRs() TestPRs {
 	pr1 := prparser.PR{
-		PR: &githubclient.PR{
-			PullRequest: &github.PullRequest{
-				CreatedAt: &github.Timestamp{Time: time.Now().Add(-3 * time.Hour)}, // 1 day ago
-				Title:     github.Ptr("This is a test PR"),
-				User: &github.User{
-					Login: github.Ptr("testuser"),
-					Name:  github.Ptr("Test User"),
-				},
-			},
+		PullRequest: models.PullRequest{
+			CreatedAt: time.Now().Add(-3 * time.Hour), // 1 day ago
+			Title:     "This is a test PR",
 		},
 		Author: prparser.Collaborator{
 			Collaborator: &githubclient.Collaborator{
@@ -234,17 +650,11 @@ func TestMergedAndClosedPRFormatting(t *testing.T) {
 		{
 			name: "Open PR - no special formatting",
 			pr: prparser.PR{
-				PR: &githubclient.PR{
-					PullRequest: &github.PullRequest{
-						CreatedAt: &github.Timestamp{Time: time.Now().Add(-3 * time.Hour)},
-						Title:     github.Ptr("Open PR"),
-						State:     github.Ptr("open"),
-						Merged:    github.Ptr(false),
-						User: &github.User{
-							Login: github.Ptr("alice"),
-							Name:  github.Ptr("Alice"),
-						},
-					},
+				PullRequest: models.PullRequest{
+					CreatedAt: time.Now().Add(-3 * time.Hour),
+					Title:     "Open PR",
+					State:     "open",
+					Merged:    false,
 				},
 				Author: prparser.Collaborator{
 					Collaborator: &githubclient.Collaborator{Login: "alice", Name: "Alice"},
@@ -257,17 +667,11 @@ func TestMergedAndClosedPRFormatting(t *testing.T) {
 		{
 			name: "Merged PR with reviewers",
 			pr: prparser.PR{
-				PR: &githubclient.PR{
-					PullRequest: &github.PullRequest{
-						CreatedAt: &github.Timestamp{Time: time.Now().Add(-3 * time.Hour)},
-						Title:     github.Ptr("Merged PR"),
-						State:     github.Ptr("closed"),
-						Merged:    github.Ptr(true),
-						User: &github.User{
-							Login: github.Ptr("bob"),
-							Name:  github.Ptr("Bob"),
-						},
-					},
+				PullRequest: models.PullRequest{
+					CreatedAt: time.Now().Add(-3 * time.Hour),
+					Title:     "Merged PR",
+					State:     "closed",
+					Merged:    true,
 				},
 				Author: prparser.Collaborator{
 					Collaborator: &githubclient.Collaborator{Login: "bob", Name: "Bob"},
@@ -283,17 +687,11 @@ func TestMergedAndClosedPRFormatting(t *testing.T) {
 		{
 			name: "Closed PR without merge",
 			pr: prparser.PR{
-				PR: &githubclient.PR{
-					PullRequest: &github.PullRequest{
-						CreatedAt: &github.Timestamp{Time: time.Now().Add(-3 * time.Hour)},
-						Title:     github.Ptr("Closed PR"),
-						State:     github.Ptr("closed"),
-						Merged:    github.Ptr(false),
-						User: &github.User{
-							Login: github.Ptr("charlie"),
-							Name:  github.Ptr("Charlie"),
-						},
-					},
+				PullRequest: models.PullRequest{
+					CreatedAt: time.Now().Add(-3 * time.Hour),
+					Title:     "Closed PR",
+					State:     "closed",
+					Merged:    false,
 				},
 				Author: prparser.Collaborator{
 					Collaborator: &githubclient.Collaborator{Login: "charlie", Name: "Charlie"},
@@ -306,17 +704,11 @@ func TestMergedAndClosedPRFormatting(t *testing.T) {
 		{
 			name: "Merged PR without reviewers",
 			pr: prparser.PR{
-				PR: &githubclient.PR{
-					PullRequest: &github.PullRequest{
-						CreatedAt: &github.Timestamp{Time: time.Now().Add(-3 * time.Hour)},
-						Title:     github.Ptr("Merged PR no reviewers"),
-						State:     github.Ptr("closed"),
-						Merged:    github.Ptr(true),
-						User: &github.User{
-							Login: github.Ptr("dave"),
-							Name:  github.Ptr("Dave"),
-						},
-					},
+				PullRequest: models.PullRequest{
+					CreatedAt: time.Now().Add(-3 * time.Hour),
+					Title:     "Merged PR no reviewers",
+					State:     "closed",
+					Merged:    true,
 				},
 				Author: prparser.Collaborator{
 					Collaborator: &githubclient.Collaborator{Login: "dave", Name: "Dave"},
@@ -382,3 +774,57 @@ func TestMergedAndClosedPRFormatting(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestedTeamsFormatting(t *testing.T) {
+	pr := getTestPRs().PR1
+	pr.RequestedTeams = []prparser.Team{
+		{Slug: "platform", Name: "Platform", SlackGroupID: "S12345678"},
+		{Slug: "web"},
+	}
+	content := messagecontent.Content{
+		SummaryText:   "Test",
+		PRListHeading: "Test PRs",
+		PRs:           []prparser.PR{pr},
+	}
+
+	message, _ := messagebuilder.BuildMessage(content)
+
+	prBlock := message.Blocks.BlockSet[1].(*slack.RichTextBlock)
+	elements := prBlock.Elements[0].(*slack.RichTextList).Elements[0].(*slack.RichTextSection).Elements
+	teamElements := elements[len(elements)-5:]
+
+	prefix := teamElements[0].(*slack.RichTextSectionTextElement)
+	if prefix.Text != " (👥 " {
+		t.Errorf("Expected requested teams prefix ' (👥 ', got '%s'", prefix.Text)
+	}
+	userGroup, ok := teamElements[1].(*slack.RichTextSectionUserGroupElement)
+	if !ok {
+		t.Fatalf("Expected user group element for mapped team, got %T", teamElements[1])
+	}
+	if userGroup.UsergroupID != "S12345678" {
+		t.Errorf("Expected user group ID 'S12345678', got '%s'", userGroup.UsergroupID)
+	}
+	unmappedTeam := teamElements[3].(*slack.RichTextSectionTextElement)
+	if unmappedTeam.Text != "web" {
+		t.Errorf("Expected unmapped team to be rendered as 'web', got '%s'", unmappedTeam.Text)
+	}
+}
+
+func TestMovedRepositoryFormatting(t *testing.T) {
+	pr := getTestPRs().PR1
+	pr.MovedToRepository = &models.Repository{Owner: "new-org", Name: "new-name"}
+	content := messagecontent.Content{
+		SummaryText:   "Test",
+		PRListHeading: "Test PRs",
+		PRs:           []prparser.PR{pr},
+	}
+
+	message, _ := messagebuilder.BuildMessage(content)
+
+	prBlock := message.Blocks.BlockSet[1].(*slack.RichTextBlock)
+	elements := prBlock.Elements[0].(*slack.RichTextList).Elements[0].(*slack.RichTextSection).Elements
+	lastElement := elements[len(elements)-1].(*slack.RichTextSectionTextElement)
+	if lastElement.Text != " (repo moved to new-org/new-name)" {
+		t.Errorf("Expected repository move annotation, got '%s'", lastElement.Text)
+	}
+}
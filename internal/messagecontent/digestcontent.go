@@ -0,0 +1,56 @@
+package messagecontent
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
+)
+
+// DigestLinkText is the text of the link to the open PRs of a repository in the digest.
+const DigestLinkText = "pull requests"
+
+// RepositoryDigest is the line of a repository in the digest (message-style: digest), showing the
+// numbers of the open and old PRs of the repository instead of listing them.
+type RepositoryDigest struct {
+	RepositoryPath string
+	RepositoryLink string // to the open PRs of the repository
+	PRCount        int
+	OldPRCount     int
+}
+
+// Text returns e.g. "org/repo-x: 4 open (2 stale)", followed by a link to the PRs in the message.
+func (d RepositoryDigest) Text() string {
+	if d.OldPRCount == 0 {
+		return fmt.Sprintf("%s: %d open", d.RepositoryPath, d.PRCount)
+	}
+	return fmt.Sprintf("%s: %d open (%d stale)", d.RepositoryPath, d.PRCount, d.OldPRCount)
+}
+
+// Returns the digests of the repositories of the PRs, sorted by the repository paths.
+func getRepositoryDigests(openPRs []prparser.PR) []RepositoryDigest {
+	digestsByPath := make(map[string]*RepositoryDigest)
+	for _, pr := range openPRs {
+		path := pr.Repository.GetPath()
+		digest, exists := digestsByPath[path]
+		if !exists {
+			digest = &RepositoryDigest{
+				RepositoryPath: path,
+				RepositoryLink: fmt.Sprintf("https://github.com/%s/pulls", path),
+			}
+			digestsByPath[path] = digest
+		}
+		digest.PRCount++
+		if pr.IsOldPR {
+			digest.OldPRCount++
+		}
+	}
+
+	var paths []string
+	for path := range digestsByPath {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return utilities.Map(paths, func(path string) RepositoryDigest { return *digestsByPath[path] })
+}
@@ -0,0 +1,37 @@
+package messagecontent
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
+)
+
+// EscalationContent is the content of the condensed message posted to the escalation channel
+// (escalation-channel), listing only the old PRs.
+type EscalationContent struct {
+	PRs         []prparser.PR // the oldest first
+	SummaryText string
+}
+
+// GetEscalationContent returns the content of the escalation message and true if the number of the
+// old PRs exceeds the count (escalation-old-pr-count), otherwise false.
+func GetEscalationContent(prs []prparser.PR, oldPRCount int) (EscalationContent, bool) {
+	oldPRs := utilities.Filter(prs, func(pr prparser.PR) bool { return pr.IsOldPR })
+	if len(oldPRs) <= oldPRCount {
+		return EscalationContent{}, false
+	}
+	slices.Reverse(oldPRs) // the parsed PRs are sorted newest first
+	return EscalationContent{
+		PRs:         oldPRs,
+		SummaryText: getEscalationSummaryText(len(oldPRs)),
+	}, true
+}
+
+func getEscalationSummaryText(oldPRCount int) string {
+	if oldPRCount == 1 {
+		return "🚨 1 old PR is waiting for attention"
+	}
+	return fmt.Sprintf("🚨 %d old PRs are waiting for attention", oldPRCount)
+}
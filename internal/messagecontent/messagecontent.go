@@ -5,26 +5,243 @@ package messagecontent
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
 	"github.com/hellej/pr-slack-reminder-action/internal/config"
 	"github.com/hellej/pr-slack-reminder-action/internal/models"
 	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+	"github.com/hellej/pr-slack-reminder-action/internal/secretredaction"
 	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
 )
 
+const ReleasePRsHeading = "🚢 Pending releases"
+
+// Heading of the PRs without any of the labels when grouped by label
+const OtherLabelGroupHeading = "Other open PRs"
+
+// Shown for the repositories without open PRs (show-quiet-repos: inline)
+const QuietRepositoryText = "All clear ✅"
+
 type Content struct {
 	SummaryText            string
 	PRListHeading          string
 	PRs                    []prparser.PR
+	ReleasePRs             []prparser.PR // listed separately at the top of the message
 	GroupedByRepository    bool
 	PRsGroupedByRepository []PRsOfRepository
+	WorkflowRunURL         string
+	// One line per repository instead of the PR lists (message-style: digest)
+	RepositoryDigests []RepositoryDigest
+	// Archived repositories that were skipped (noted in the message)
+	SkippedArchivedRepositories []string
+	// Repositories without open PRs (listed in the footer with show-quiet-repos: footer)
+	QuietRepositories []string
+	// Repositories of which the default branch does not require PR reviews (warned about in the message)
+	RepositoriesWithoutRequiredReviews []string
+	// The current on-call user of the configured schedule, nil if not available
+	ReviewCaptain *ReviewCaptain
+	// Workflows failing on the default branches of the repositories (shown after the PR lists)
+	FailingWorkflows []FailingWorkflow
+	// PRs merged recently (shown after the PR lists), nil if not shown or none were merged
+	RecentlyMerged *RecentlyMerged
+	// How the PRs of the previous reminder were resolved, e.g. "✅ 4 PRs from last reminder were
+	// merged, 1 closed" (shown above the PR lists with show-resolved-prs)
+	ResolvedPRsSummary string
+	// Progress of the milestones used in the filters (shown in the footer)
+	MilestoneProgress []MilestoneProgress
+	// Summary of the non-fatal issues of the run, e.g. "⚠️ 1 PR could not be fetched" (show-run-report)
+	RunReportSummary string
+	// Hex color of the message chosen by the severity thresholds or the summary tone, e.g. "#e01e5a"
+	// (shown as the color bar of the message in Slack and as the embed color in Discord)
+	Color string
+}
+
+// MilestoneProgress is the progress of a milestone, summed over the repositories that have it.
+type MilestoneProgress struct {
+	Title       string
+	ClosedCount int // closed issues and PRs
+	TotalCount  int // open and closed issues and PRs
+}
+
+// Text returns the progress as a footer text, e.g. "Milestone 2.4: 12/20 PRs merged".
+func (m MilestoneProgress) Text() string {
+	return fmt.Sprintf("Milestone %s: %d/%d PRs merged", m.Title, m.ClosedCount, m.TotalCount)
+}
+
+// GetMilestoneProgress sums the issue counts of milestones with the same title (in different
+// repositories), keeping the milestones in the order in which they were found.
+func GetMilestoneProgress(milestones []githubclient.Milestone) []MilestoneProgress {
+	var progress []MilestoneProgress
+	for _, milestone := range milestones {
+		i := slices.IndexFunc(progress, func(p MilestoneProgress) bool { return p.Title == milestone.Title })
+		if i == -1 {
+			progress = append(progress, MilestoneProgress{Title: milestone.Title})
+			i = len(progress) - 1
+		}
+		progress[i].ClosedCount += milestone.ClosedIssues
+		progress[i].TotalCount += milestone.OpenIssues + milestone.ClosedIssues
+	}
+	return progress
+}
+
+// FailingWorkflow is a workflow of which the latest run on the default branch failed.
+type FailingWorkflow struct {
+	Name           string
+	RepositoryPath string
+	RunURL         string
+}
+
+func GetFailingWorkflows(workflows []githubclient.FailingWorkflow) []FailingWorkflow {
+	return utilities.Map(workflows, func(w githubclient.FailingWorkflow) FailingWorkflow {
+		return FailingWorkflow{Name: w.Name, RepositoryPath: w.Repository.GetPath(), RunURL: w.HTMLURL}
+	})
+}
+
+// RecentlyMerged lists the PRs merged in the last hours (show-recently-merged-hours) to celebrate progress.
+type RecentlyMerged struct {
+	Hours int
+	PRs   []MergedPR // the latest merged first
+}
+
+type MergedPR struct {
+	Title          string
+	URL            string
+	RepositoryPath string
+}
+
+// GetRecentlyMerged returns the recently merged PRs to show, or nil if none were merged.
+func GetRecentlyMerged(prs []githubclient.MergedPR, hours int, redactSecretsInTitles bool) *RecentlyMerged {
+	if len(prs) == 0 {
+		return nil
+	}
+	return &RecentlyMerged{
+		Hours: hours,
+		PRs: utilities.Map(prs, func(pr githubclient.MergedPR) MergedPR {
+			title := pr.Title
+			if redactSecretsInTitles {
+				title = secretredaction.RedactPRTitle(title, pr.HTMLURL)
+			}
+			return MergedPR{Title: title, URL: pr.HTMLURL, RepositoryPath: pr.Repository.GetPath()}
+		}),
+	}
+}
+
+// Heading returns the heading of the recently merged PRs, e.g. "🎉 Merged in the last 24 hours".
+func (r RecentlyMerged) Heading() string {
+	if r.Hours == 1 {
+		return "🎉 Merged in the last hour"
+	}
+	return fmt.Sprintf("🎉 Merged in the last %d hours", r.Hours)
+}
+
+// GetResolvedPRsSummary returns how the PRs of the previous reminder that are no longer listed were
+// resolved, or an empty string if none of them were merged or closed (e.g. only filtered out).
+func GetResolvedPRsSummary(prs []models.PullRequest) string {
+	mergedCount, closedCount := 0, 0
+	for _, pr := range prs {
+		switch {
+		case pr.Merged:
+			mergedCount++
+		case pr.State == "closed":
+			closedCount++
+		}
+	}
+	total := mergedCount + closedCount
+	if total == 0 {
+		return ""
+	}
+	prsText := fmt.Sprintf("%d PRs from last reminder were", total)
+	if total == 1 {
+		prsText = "1 PR from last reminder was"
+	}
+	switch {
+	case closedCount == 0:
+		return fmt.Sprintf("✅ %s merged", prsText)
+	case mergedCount == 0:
+		return fmt.Sprintf("✅ %s closed", prsText)
+	default:
+		return fmt.Sprintf("✅ %d PRs from last reminder were merged, %d closed", mergedCount, closedCount)
+	}
+}
+
+// ReviewCaptain is the user responsible for PR reviews today (mentioned in the message header).
+type ReviewCaptain struct {
+	Name        string
+	SlackUserID string // empty string if not available
 }
 
 func (c Content) HasPRs() bool {
-	return len(c.PRs) > 0 || len(c.PRsGroupedByRepository) > 0
+	return len(c.PRs) > 0 || len(c.PRsGroupedByRepository) > 0 || len(c.ReleasePRs) > 0 ||
+		len(c.RepositoryDigests) > 0
+}
+
+// GetPRCount returns the number of the PRs in the message, including the PRs rolled up by max-prs-per-repo
+// or in the digest.
+func (c Content) GetPRCount() int {
+	prCount := len(c.PRs) + len(c.ReleasePRs) + c.GetHiddenPRCount()
+	for _, group := range c.PRsGroupedByRepository {
+		prCount += len(group.PRs)
+	}
+	return prCount
+}
+
+// GetHiddenPRCount returns the number of the PRs rolled up by max-prs-per-repo or in the digest
+// (i.e. not listed).
+func (c Content) GetHiddenPRCount() int {
+	hiddenPRCount := 0
+	for _, group := range c.PRsGroupedByRepository {
+		hiddenPRCount += group.HiddenPRCount
+	}
+	for _, digest := range c.RepositoryDigests {
+		hiddenPRCount += digest.PRCount
+	}
+	return hiddenPRCount
+}
+
+// AddPRCountTrend appends a trend arrow with the change in the PR count since
+// the previous run to the summary text (e.g. "▲ 2 since last run").
+func (c *Content) AddPRCountTrend(previousPRCount int) {
+	if !c.HasPRs() {
+		return
+	}
+	delta := c.GetPRCount() - previousPRCount
+	switch {
+	case delta > 0:
+		c.SummaryText += fmt.Sprintf(" (▲ %d since last run)", delta)
+	case delta < 0:
+		c.SummaryText += fmt.Sprintf(" (▼ %d since last run)", -delta)
+	default:
+		c.SummaryText += " (— no change since last run)"
+	}
+}
+
+// AddFilteredPRCount appends the number of the open PRs left out by the filters to the summary text
+// (e.g. "(3 PRs hidden by filters)"), so that a short list is not mistaken for a short queue.
+func (c *Content) AddFilteredPRCount(filteredPRCount int) {
+	if filteredPRCount == 0 || c.SummaryText == "" {
+		return
+	}
+	if filteredPRCount == 1 {
+		c.SummaryText += " (1 PR hidden by filters)"
+		return
+	}
+	c.SummaryText += fmt.Sprintf(" (%d PRs hidden by filters)", filteredPRCount)
+}
+
+// GetBranchProtectionWarning returns a warning about the repositories that don't require PR reviews,
+// or an empty string if there are none.
+func (c Content) GetBranchProtectionWarning() string {
+	if len(c.RepositoriesWithoutRequiredReviews) == 0 {
+		return ""
+	}
+	return "⚠️ PR reviews are not required on the default branch of: " +
+		strings.Join(c.RepositoriesWithoutRequiredReviews, ", ")
 }
 
 type PRsOfRepository struct {
@@ -32,18 +249,161 @@ type PRsOfRepository struct {
 	RepositoryLinkLabel string
 	RepositoryLink      string
 	PRs                 []prparser.PR
+	// e.g. "2 PRs blocked by failing checks", empty if none of the PRs have failing checks
+	FailingChecksText string
+	// Number of PRs not listed because of max-prs-per-repo (rolled up in a line linking to RepositoryLink)
+	HiddenPRCount int
+	// Shown instead of the PR list if the repository has no open PRs (show-quiet-repos: inline)
+	NoPRsText string
+}
+
+// GetHiddenPRsText returns e.g. "and 3 more…" for the PRs that are not listed,
+// or an empty string if all PRs are listed.
+func (p PRsOfRepository) GetHiddenPRsText() string {
+	if p.HiddenPRCount == 0 {
+		return ""
+	}
+	return fmt.Sprintf("and %d more…", p.HiddenPRCount)
 }
 
 func GetContent(openPRs []prparser.PR, contentInputs config.ContentInputs) Content {
+	releasePRs, otherPRs := splitReleasePRs(openPRs, contentInputs)
+	content := getPRContent(otherPRs, contentInputs)
+	if len(releasePRs) > 0 {
+		content.ReleasePRs = releasePRs
+		content.SummaryText = getSummaryText(len(openPRs))
+	}
+	if len(openPRs) > 0 {
+		oldPRCount := len(utilities.Filter(openPRs, func(pr prparser.PR) bool { return pr.IsOldPR }))
+		if contentInputs.SeverityThresholds != nil {
+			content.Color = contentInputs.SeverityThresholds.GetColor(oldPRCount)
+		}
+		content.applySummaryTone(contentInputs.SummaryTones, len(openPRs), oldPRCount)
+	}
+	if contentInputs.ReviewSLAHours > 0 && len(openPRs) > 0 {
+		content.SummaryText += " " + getReviewSLAText(openPRs, contentInputs.ReviewSLAHours)
+	}
+	content.WorkflowRunURL = contentInputs.WorkflowRunURL
+	return content
+}
+
+// AddQuietRepositories shows the repositories without open PRs as configured by show-quiet-repos:
+// in the footer or as (empty) repository groups after the others. Nothing is added if there are
+// no PRs at all, as the message says it already.
+func (c *Content) AddQuietRepositories(repositories []models.Repository, contentInputs config.ContentInputs) {
+	if !c.HasPRs() || len(repositories) == 0 {
+		return
+	}
+	switch contentInputs.ShowQuietRepos {
+	case config.QuietReposFooter:
+		c.QuietRepositories = utilities.Map(repositories, models.Repository.GetPath)
+	case config.QuietReposInline:
+		for _, repo := range repositories {
+			c.PRsGroupedByRepository = append(c.PRsGroupedByRepository, PRsOfRepository{
+				HeadingPrefix:       getRepositoryHeadingPrefix(repo, contentInputs),
+				RepositoryLinkLabel: repo.GetPath(),
+				RepositoryLink:      fmt.Sprintf("https://github.com/%s/pulls", repo.GetPath()),
+				NoPRsText:           QuietRepositoryText,
+			})
+		}
+	}
+}
+
+// GetQuietRepositoriesText returns e.g. "All clear in: org/repo1, org/repo2 ✅",
+// or an empty string if there are no quiet repositories to list in the footer.
+func (c Content) GetQuietRepositoriesText() string {
+	if len(c.QuietRepositories) == 0 {
+		return ""
+	}
+	return "All clear in: " + strings.Join(c.QuietRepositories, ", ") + " ✅"
+}
+
+// Replaces the summary text, prepends the emoji to the headings and sets the color of the tone
+// chosen by the PR counts (if any).
+func (c *Content) applySummaryTone(tones config.SummaryTones, prCount, oldPRCount int) {
+	tone := tones.GetTone(prCount, oldPRCount)
+	if tone == nil {
+		return
+	}
+	if tone.Summary != "" {
+		c.SummaryText = strings.NewReplacer(
+			"<pr_count>", strconv.Itoa(prCount),
+			"<old_pr_count>", strconv.Itoa(oldPRCount),
+		).Replace(tone.Summary)
+	}
+	if tone.Emoji != "" {
+		if c.PRListHeading != "" {
+			c.PRListHeading = tone.Emoji + " " + c.PRListHeading
+		}
+		for i := range c.PRsGroupedByRepository {
+			c.PRsGroupedByRepository[i].HeadingPrefix = tone.Emoji + " " + c.PRsGroupedByRepository[i].HeadingPrefix
+		}
+	}
+	if tone.Color != "" {
+		c.Color = tone.Color
+	}
+}
+
+// e.g. "(3 of 7 PRs breached the 24h review SLA)"
+func getReviewSLAText(openPRs []prparser.PR, slaHours int) string {
+	breachedCount := len(utilities.Filter(openPRs, func(pr prparser.PR) bool { return pr.BreachedReviewSLA }))
+	return fmt.Sprintf("(%d of %d PRs breached the %dh review SLA)", breachedCount, len(openPRs), slaHours)
+}
+
+// Separates the release PRs (matching the release title pattern or labels) from the other PRs.
+// The release PRs are counted with the others in the digest, as it does not list any PRs.
+func splitReleasePRs(openPRs []prparser.PR, contentInputs config.ContentInputs) ([]prparser.PR, []prparser.PR) {
+	if contentInputs.ReleasePRTitlePattern == "" && len(contentInputs.ReleasePRLabels) == 0 ||
+		contentInputs.MessageStyle == config.MessageStyleDigest {
+		return nil, openPRs
+	}
+	var titlePattern *regexp.Regexp
+	if contentInputs.ReleasePRTitlePattern != "" {
+		titlePattern = regexp.MustCompile(contentInputs.ReleasePRTitlePattern) // validated in config
+	}
+	isReleasePR := func(pr prparser.PR) bool {
+		if titlePattern != nil && titlePattern.MatchString(pr.Title) {
+			return true
+		}
+		return slices.ContainsFunc(pr.Labels, func(label string) bool {
+			return slices.Contains(contentInputs.ReleasePRLabels, label)
+		})
+	}
+
+	var releasePRs, otherPRs []prparser.PR
+	for _, pr := range openPRs {
+		if isReleasePR(pr) {
+			releasePRs = append(releasePRs, pr)
+		} else {
+			otherPRs = append(otherPRs, pr)
+		}
+	}
+	return releasePRs, otherPRs
+}
+
+func getPRContent(openPRs []prparser.PR, contentInputs config.ContentInputs) Content {
 	switch {
 	case len(openPRs) == 0:
 		return Content{
 			SummaryText: contentInputs.NoPRsMessage,
 		}
+	case contentInputs.MessageStyle == config.MessageStyleDigest:
+		return Content{
+			SummaryText:       getSummaryText(len(openPRs)),
+			PRListHeading:     formatListHeading(contentInputs.PRListHeading, len(openPRs)),
+			RepositoryDigests: getRepositoryDigests(openPRs),
+		}
 	case contentInputs.GroupByRepository:
 		return Content{
 			SummaryText:            getSummaryText(len(openPRs)),
-			PRsGroupedByRepository: groupPRsByRepositories(openPRs),
+			PRsGroupedByRepository: groupPRsByRepositories(openPRs, contentInputs),
+			GroupedByRepository:    true,
+		}
+	case len(contentInputs.GroupByLabels) > 0:
+		// the label groups are shown like the repository groups, with headings linking to GitHub search
+		return Content{
+			SummaryText:            getSummaryText(len(openPRs)),
+			PRsGroupedByRepository: groupPRsByLabels(openPRs, contentInputs.GroupByLabels, contentInputs.GroupSort),
 			GroupedByRepository:    true,
 		}
 	default:
@@ -56,7 +416,7 @@ func GetContent(openPRs []prparser.PR, contentInputs config.ContentInputs) Conte
 	}
 }
 
-func groupPRsByRepositories(openPRs []prparser.PR) []PRsOfRepository {
+func groupPRsByRepositories(openPRs []prparser.PR, contentInputs config.ContentInputs) []PRsOfRepository {
 	prsByRepo := make(map[string][]prparser.PR)
 	repoMap := make(map[string]models.Repository)
 
@@ -74,13 +434,105 @@ func groupPRsByRepositories(openPRs []prparser.PR) []PRsOfRepository {
 
 	return utilities.Map(repoKeys, func(repoKey string) PRsOfRepository {
 		repo := repoMap[repoKey]
-		return PRsOfRepository{
-			HeadingPrefix:       "Open PRs in ",
+		prs := sortGroupPRs(prsByRepo[repoKey], contentInputs.GroupSort)
+		group := PRsOfRepository{
+			HeadingPrefix:       getRepositoryHeadingPrefix(repo, contentInputs),
 			RepositoryLinkLabel: repo.GetPath(),
 			RepositoryLink:      fmt.Sprintf("https://github.com/%s/pulls", repo.GetPath()),
-			PRs:                 prsByRepo[repoKey],
+			PRs:                 prs,
+			FailingChecksText:   getFailingChecksText(prs),
+		}
+		if maxPRs := contentInputs.MaxPRsPerRepository; maxPRs > 0 && len(prs) > maxPRs {
+			group.PRs = prs[:maxPRs]
+			group.HiddenPRCount = len(prs) - maxPRs
+		}
+		return group
+	})
+}
+
+// e.g. "Open PRs in " or "🛠 Open PRs in " if the prefix of the repository is 🛠
+func getRepositoryHeadingPrefix(repo models.Repository, contentInputs config.ContentInputs) string {
+	if prefix := contentInputs.GetRepositoryHeadingPrefix(repo); prefix != "" {
+		return prefix + " Open PRs in "
+	}
+	return "Open PRs in "
+}
+
+// Groups the PRs under the first of the labels (in the given order) that they have, and the PRs
+// without any of the labels under OtherLabelGroupHeading. Groups without PRs are omitted.
+func groupPRsByLabels(openPRs []prparser.PR, labels []string, groupSort config.GroupSort) []PRsOfRepository {
+	prsByLabel := make([][]prparser.PR, len(labels)+1) // the last one is the "other" group
+	for _, pr := range openPRs {
+		i := slices.IndexFunc(labels, func(label string) bool { return hasLabel(pr, label) })
+		if i == -1 {
+			i = len(labels)
+		}
+		prsByLabel[i] = append(prsByLabel[i], pr)
+	}
+
+	repositoryQuery := getRepositorySearchQuery(openPRs)
+	var groups []PRsOfRepository
+	for i, prs := range prsByLabel {
+		if len(prs) == 0 {
+			continue
+		}
+		group := PRsOfRepository{PRs: sortGroupPRs(prs, groupSort), FailingChecksText: getFailingChecksText(prs)}
+		if i < len(labels) {
+			group.HeadingPrefix = "Open PRs labeled "
+			group.RepositoryLinkLabel = labels[i]
+			group.RepositoryLink = getPRSearchURL(fmt.Sprintf("label:%q %s", labels[i], repositoryQuery))
+		} else {
+			excludedLabels := utilities.Map(labels, func(label string) string { return fmt.Sprintf("-label:%q", label) })
+			group.RepositoryLinkLabel = OtherLabelGroupHeading
+			group.RepositoryLink = getPRSearchURL(strings.Join(excludedLabels, " ") + " " + repositoryQuery)
 		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// The PRs are sorted newest first by prparser, oldest-first sorts them again by the creation time.
+func sortGroupPRs(prs []prparser.PR, groupSort config.GroupSort) []prparser.PR {
+	if groupSort != config.GroupSortOldestFirst {
+		return prs
+	}
+	sorted := slices.Clone(prs)
+	slices.SortStableFunc(sorted, func(a, b prparser.PR) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
 	})
+	return sorted
+}
+
+func hasLabel(pr prparser.PR, label string) bool {
+	return slices.ContainsFunc(pr.Labels, func(name string) bool { return strings.EqualFold(name, label) })
+}
+
+// e.g. "repo:org/repo-a repo:org/repo-b"
+func getRepositorySearchQuery(prs []prparser.PR) string {
+	var repositoryPaths []string
+	for _, pr := range prs {
+		if path := pr.Repository.GetPath(); !slices.Contains(repositoryPaths, path) {
+			repositoryPaths = append(repositoryPaths, path)
+		}
+	}
+	sort.Strings(repositoryPaths)
+	return strings.Join(utilities.Map(repositoryPaths, func(path string) string { return "repo:" + path }), " ")
+}
+
+func getPRSearchURL(query string) string {
+	return "https://github.com/search?type=pullrequests&q=" + url.QueryEscape("is:pr is:open "+query)
+}
+
+func getFailingChecksText(prs []prparser.PR) string {
+	failingCount := len(utilities.Filter(prs, func(pr prparser.PR) bool { return pr.HasFailingChecks }))
+	switch failingCount {
+	case 0:
+		return ""
+	case 1:
+		return "1 PR blocked by failing checks"
+	default:
+		return fmt.Sprintf("%d PRs blocked by failing checks", failingCount)
+	}
 }
 
 func getSummaryText(prCount int) string {
@@ -0,0 +1,83 @@
+package messagecontent
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+)
+
+// Summary of a reviewer thread reply when none of the PRs wait for the reviewer anymore
+const NoPRsWaitingForReviewerText = "No PRs are waiting for your review anymore 🎉"
+
+// ReviewerContent is the content of a thread reply to a reviewer (reviewer-threads),
+// listing only the PRs from which a review has been requested from the reviewer.
+type ReviewerContent struct {
+	Reviewer    prparser.Collaborator
+	PRs         []prparser.PR
+	SummaryText string
+}
+
+func (c ReviewerContent) HasPRs() bool {
+	return len(c.PRs) > 0
+}
+
+// GetReviewerContents returns the content of each reviewer from whom a review of any of the PRs
+// has been requested, sorted by login. Reviewers without a Slack user ID are skipped, as they
+// could not be mentioned in the reply.
+func GetReviewerContents(prs []prparser.PR, slackUserIdByGitHubUsername map[string]string) []ReviewerContent {
+	var logins []string
+	for _, pr := range prs {
+		for _, reviewer := range pr.RequestedReviewers {
+			login := reviewer.Login
+			if config.LookupSlackUserID(slackUserIdByGitHubUsername, login) != "" && !slices.Contains(logins, login) {
+				logins = append(logins, login)
+			}
+		}
+	}
+	slices.SortFunc(logins, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	contents := make([]ReviewerContent, 0, len(logins))
+	for _, login := range logins {
+		contents = append(contents, GetReviewerContent(prs, login, slackUserIdByGitHubUsername))
+	}
+	return contents
+}
+
+// GetReviewerContent returns the content of the reviewer, which has no PRs if none of the PRs
+// wait for the reviewer anymore (e.g. when refreshing an earlier reply).
+func GetReviewerContent(
+	prs []prparser.PR, login string, slackUserIdByGitHubUsername map[string]string,
+) ReviewerContent {
+	var reviewerPRs []prparser.PR
+	for _, pr := range prs {
+		if slices.ContainsFunc(pr.RequestedReviewers, func(reviewer models.User) bool {
+			return strings.EqualFold(reviewer.Login, login)
+		}) {
+			reviewerPRs = append(reviewerPRs, pr)
+		}
+	}
+	return ReviewerContent{
+		Reviewer: prparser.NewCollaborator(
+			githubclient.Collaborator{Login: login}, config.LookupSlackUserID(slackUserIdByGitHubUsername, login),
+		),
+		PRs:         reviewerPRs,
+		SummaryText: getReviewerSummaryText(len(reviewerPRs)),
+	}
+}
+
+func getReviewerSummaryText(prCount int) string {
+	switch prCount {
+	case 0:
+		return NoPRsWaitingForReviewerText
+	case 1:
+		return "1 PR is waiting for your review 👀"
+	default:
+		return fmt.Sprintf("%d PRs are waiting for your review 👀", prCount)
+	}
+}
@@ -0,0 +1,228 @@
+// Package messagepreview renders Slack messages as an HTML page with approximate Slack styling,
+// so that layout changes can be reviewed (e.g. from a workflow artifact of a dry run) without
+// posting the messages to a test channel. Only the blocks used by the message builder are
+// rendered faithfully, the others are shown as placeholders.
+package messagepreview
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+const pageStyle = `
+body { font-family: Lato, "Helvetica Neue", Helvetica, Arial, sans-serif; font-size: 15px; color: #1d1c1d; background: #f8f8f8; margin: 24px; }
+.message { background: #fff; border: 1px solid #ddd; border-radius: 8px; padding: 12px 16px; margin-bottom: 24px; max-width: 800px; }
+.meta { color: #616061; font-size: 13px; margin-bottom: 8px; }
+.attachment { border-left: 4px solid #ddd; padding-left: 12px; }
+.block { margin: 4px 0; }
+.context { color: #616061; font-size: 13px; }
+ul, ol { margin: 4px 0; padding-left: 28px; }
+a { color: #1264a3; text-decoration: none; }
+code { color: #e01e5a; background: #f6f6f6; border: 1px solid #e8e8e8; border-radius: 3px; padding: 0 3px; font-size: 12px; }
+.mention { color: #1264a3; background: #e8f5fa; border-radius: 3px; padding: 0 2px; }
+.unsupported { color: #868686; font-style: italic; }
+`
+
+var (
+	mrkdwnLinkPattern    = regexp.MustCompile(`&lt;(https?://[^|]+?)\|(.*?)&gt;`)
+	mrkdwnMentionPattern = regexp.MustCompile(`&lt;(?:@|!subteam\^)([A-Z0-9]+)(?:\|[^&]*)?&gt;`)
+	mrkdwnBoldPattern    = regexp.MustCompile(`\*([^*\n]+)\*`)
+	mrkdwnItalicPattern  = regexp.MustCompile(`(^|\s)_([^_\n]+)_`)
+	mrkdwnCodePattern    = regexp.MustCompile("`([^`\n]+)`")
+)
+
+type message struct {
+	channelID   string
+	summaryText string
+	message     slack.Message
+}
+
+// Preview collects the messages of a run and writes them to an HTML file.
+// It is safe for concurrent use.
+type Preview struct {
+	mu       sync.Mutex
+	filePath string
+	messages []message
+}
+
+func New(filePath string) *Preview {
+	return &Preview{filePath: filePath}
+}
+
+// Add adds the message to the preview and (re)writes the preview file with all messages added so far,
+// so that the file is complete even if the run fails later.
+func (p *Preview) Add(channelID string, slackMessage slack.Message, summaryText string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = append(p.messages, message{channelID: channelID, summaryText: summaryText, message: slackMessage})
+
+	if dir := filepath.Dir(p.filePath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory for message preview: %w", err)
+		}
+	}
+	if err := os.WriteFile(p.filePath, []byte(renderPage(p.messages)), 0644); err != nil {
+		return fmt.Errorf("failed to write message preview: %w", err)
+	}
+	return nil
+}
+
+func renderPage(messages []message) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<title>PR reminder message preview</title>\n<style>" + pageStyle + "</style>\n</head>\n<body>\n")
+	for _, m := range messages {
+		b.WriteString("<div class=\"message\">\n")
+		fmt.Fprintf(&b, "<div class=\"meta\">Channel %s · %s</div>\n",
+			html.EscapeString(m.channelID), html.EscapeString(m.summaryText),
+		)
+		b.WriteString(renderMessage(m.message))
+		b.WriteString("</div>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// The blocks of colored messages are sent in an attachment, which is shown with a color bar.
+func renderMessage(slackMessage slack.Message) string {
+	var b strings.Builder
+	if len(slackMessage.Attachments) > 0 {
+		for _, attachment := range slackMessage.Attachments {
+			fmt.Fprintf(&b, "<div class=\"attachment\" style=\"border-left-color: %s\">\n",
+				html.EscapeString(attachment.Color),
+			)
+			renderBlocks(&b, attachment.Blocks.BlockSet)
+			b.WriteString("</div>\n")
+		}
+		return b.String()
+	}
+	renderBlocks(&b, slackMessage.Blocks.BlockSet)
+	return b.String()
+}
+
+func renderBlocks(b *strings.Builder, blocks []slack.Block) {
+	for _, block := range blocks {
+		switch block := block.(type) {
+		case *slack.RichTextBlock:
+			b.WriteString("<div class=\"block\">")
+			for _, element := range block.Elements {
+				renderRichTextElement(b, element)
+			}
+			b.WriteString("</div>\n")
+		case *slack.ContextBlock:
+			var texts []string
+			for _, element := range block.ContextElements.Elements {
+				if text, ok := element.(*slack.TextBlockObject); ok {
+					texts = append(texts, renderTextObject(text))
+				}
+			}
+			b.WriteString("<div class=\"block context\">" + strings.Join(texts, " ") + "</div>\n")
+		case *slack.SectionBlock:
+			text := ""
+			if block.Text != nil {
+				text = renderTextObject(block.Text)
+			}
+			b.WriteString("<div class=\"block\">" + text + "&nbsp;</div>\n")
+		case *slack.HeaderBlock:
+			b.WriteString("<h3>" + renderTextObject(block.Text) + "</h3>\n")
+		case *slack.DividerBlock:
+			b.WriteString("<hr>\n")
+		default:
+			fmt.Fprintf(b, "<div class=\"block unsupported\">[%s block]</div>\n", block.BlockType())
+		}
+	}
+}
+
+func renderRichTextElement(b *strings.Builder, element slack.RichTextElement) {
+	switch element := element.(type) {
+	case *slack.RichTextSection:
+		b.WriteString("<div>")
+		renderRichTextSectionElements(b, element.Elements)
+		b.WriteString("</div>")
+	case *slack.RichTextList:
+		tag := "ul"
+		if element.Style == slack.RTEListOrdered {
+			tag = "ol"
+		}
+		fmt.Fprintf(b, "<%s style=\"margin-left: %dpx\">", tag, element.Indent*24)
+		for _, item := range element.Elements {
+			if section, ok := item.(*slack.RichTextSection); ok {
+				b.WriteString("<li>")
+				renderRichTextSectionElements(b, section.Elements)
+				b.WriteString("</li>")
+			}
+		}
+		fmt.Fprintf(b, "</%s>", tag)
+	default:
+		fmt.Fprintf(b, "<div class=\"unsupported\">[%s]</div>", element.RichTextElementType())
+	}
+}
+
+func renderRichTextSectionElements(b *strings.Builder, elements []slack.RichTextSectionElement) {
+	for _, element := range elements {
+		switch element := element.(type) {
+		case *slack.RichTextSectionTextElement:
+			b.WriteString(withStyle(html.EscapeString(element.Text), element.Style))
+		case *slack.RichTextSectionLinkElement:
+			text := element.Text
+			if text == "" {
+				text = element.URL
+			}
+			link := fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(element.URL), html.EscapeString(text))
+			b.WriteString(withStyle(link, element.Style))
+		case *slack.RichTextSectionUserElement:
+			b.WriteString("<span class=\"mention\">@" + html.EscapeString(element.UserID) + "</span>")
+		case *slack.RichTextSectionUserGroupElement:
+			b.WriteString("<span class=\"mention\">@" + html.EscapeString(element.UsergroupID) + "</span>")
+		case *slack.RichTextSectionChannelElement:
+			b.WriteString("<span class=\"mention\">#" + html.EscapeString(element.ChannelID) + "</span>")
+		case *slack.RichTextSectionEmojiElement:
+			b.WriteString(":" + html.EscapeString(element.Name) + ":")
+		default:
+			fmt.Fprintf(b, "<span class=\"unsupported\">[%s]</span>", element.RichTextSectionElementType())
+		}
+	}
+}
+
+func withStyle(content string, style *slack.RichTextSectionTextStyle) string {
+	if style == nil {
+		return content
+	}
+	if style.Code {
+		content = "<code>" + content + "</code>"
+	}
+	if style.Strike {
+		content = "<s>" + content + "</s>"
+	}
+	if style.Italic {
+		content = "<i>" + content + "</i>"
+	}
+	if style.Bold {
+		content = "<b>" + content + "</b>"
+	}
+	return content
+}
+
+// Renders the most common mrkdwn formatting (links, mentions, bold, italic and code) of the text.
+func renderTextObject(text *slack.TextBlockObject) string {
+	if text == nil {
+		return ""
+	}
+	escaped := html.EscapeString(text.Text)
+	if text.Type != slack.MarkdownType {
+		return escaped
+	}
+	escaped = mrkdwnLinkPattern.ReplaceAllString(escaped, `<a href="$1">$2</a>`)
+	escaped = mrkdwnMentionPattern.ReplaceAllString(escaped, `<span class="mention">@$1</span>`)
+	escaped = mrkdwnCodePattern.ReplaceAllString(escaped, `<code>$1</code>`)
+	escaped = mrkdwnBoldPattern.ReplaceAllString(escaped, `<b>$1</b>`)
+	escaped = mrkdwnItalicPattern.ReplaceAllString(escaped, `$1<i>$2</i>`)
+	return escaped
+}
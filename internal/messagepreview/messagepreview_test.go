@@ -0,0 +1,95 @@
+package messagepreview_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/messagepreview"
+	"github.com/slack-go/slack"
+)
+
+func TestPreview(t *testing.T) {
+	prList := slack.NewRichTextBlock("open_prs",
+		slack.NewRichTextList(slack.RichTextListElementType("bullet"), 0,
+			slack.NewRichTextSection(
+				slack.NewRichTextSectionLinkElement(
+					"https://github.com/org/repo/pull/1", "Fix <script> tags", &slack.RichTextSectionTextStyle{Bold: true},
+				),
+				slack.NewRichTextSectionTextElement(" 🚨 ", &slack.RichTextSectionTextStyle{}),
+				slack.NewRichTextSectionTextElement("2 days old", &slack.RichTextSectionTextStyle{Bold: true, Code: true}),
+				slack.NewRichTextSectionTextElement(" by ", nil),
+				slack.NewRichTextSectionUserElement("U123", nil),
+			),
+		),
+	)
+	footer := slack.NewContextBlock("footer",
+		slack.NewTextBlockObject("mrkdwn", "<https://github.com/org/repo/actions/runs/1|generated by this workflow run>", false, false),
+	)
+	coloredMessage := slack.NewBlockMessage(prList)
+	coloredMessage.Attachments = []slack.Attachment{{Color: "#e01e5a", Blocks: coloredMessage.Blocks}}
+
+	tests := []struct {
+		name             string
+		messages         []slack.Message
+		expectedContents []string
+	}{
+		{
+			name:     "rich text and context blocks",
+			messages: []slack.Message{slack.NewBlockMessage(prList, footer)},
+			expectedContents: []string{
+				`<ul style="margin-left: 0px"><li>`,
+				`<b><a href="https://github.com/org/repo/pull/1">Fix &lt;script&gt; tags</a></b>`,
+				`<b><code>2 days old</code></b>`,
+				`<span class="mention">@U123</span>`,
+				`<a href="https://github.com/org/repo/actions/runs/1">generated by this workflow run</a>`,
+			},
+		},
+		{
+			name:             "colored message",
+			messages:         []slack.Message{coloredMessage},
+			expectedContents: []string{`<div class="attachment" style="border-left-color: #e01e5a">`},
+		},
+		{
+			name:             "all messages of the run",
+			messages:         []slack.Message{slack.NewBlockMessage(footer), slack.NewBlockMessage(prList)},
+			expectedContents: []string{"generated by this workflow run", "2 days old"},
+		},
+		{
+			name:             "unsupported blocks",
+			messages:         []slack.Message{slack.NewBlockMessage(slack.NewImageBlock("https://example.com/a.png", "", "", nil))},
+			expectedContents: []string{`<div class="block unsupported">[image block]</div>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "preview", "preview.html")
+			preview := messagepreview.New(filePath)
+
+			for _, message := range tt.messages {
+				if err := preview.Add("C123", message, "1 open PR is waiting for attention 👀"); err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+			}
+
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Expected the preview file to be written, got: %v", err)
+			}
+			page := string(content)
+			if count := strings.Count(page, `<div class="message">`); count != len(tt.messages) {
+				t.Errorf("Expected %d messages in the preview, got %d", len(tt.messages), count)
+			}
+			if !strings.Contains(page, "Channel C123 · 1 open PR is waiting for attention 👀") {
+				t.Errorf("Expected the channel and summary in the preview, got:\n%s", page)
+			}
+			for _, expected := range tt.expectedContents {
+				if !strings.Contains(page, expected) {
+					t.Errorf("Expected the preview to contain %q, got:\n%s", expected, page)
+				}
+			}
+		})
+	}
+}
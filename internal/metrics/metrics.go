@@ -0,0 +1,117 @@
+// Package metrics appends a snapshot of the PR backlog to a time series file
+// on each run, so that teams can chart how the backlog develops over time.
+// Supported formats are JSON Lines (one JSON object per line) and CSV.
+package metrics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+)
+
+var csvHeader = []string{"timestamp", "pr_count", "old_pr_count", "pr_count_by_repository"}
+
+type Snapshot struct {
+	Timestamp           time.Time      `json:"timestamp"`
+	PRCount             int            `json:"prCount"`
+	OldPRCount          int            `json:"oldPRCount"`
+	PRCountByRepository map[string]int `json:"prCountByRepository"`
+}
+
+func NewSnapshot(prs []prparser.PR, timestamp time.Time) Snapshot {
+	snapshot := Snapshot{
+		Timestamp:           timestamp.UTC(),
+		PRCount:             len(prs),
+		PRCountByRepository: make(map[string]int),
+	}
+	for _, pr := range prs {
+		if pr.IsOldPR {
+			snapshot.OldPRCount++
+		}
+		snapshot.PRCountByRepository[pr.Repository.GetPath()]++
+	}
+	return snapshot
+}
+
+func Append(filePath string, format config.MetricsFormat, snapshot Snapshot) error {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open metrics file %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	switch format {
+	case config.MetricsFormatCSV:
+		err = appendCSV(file, snapshot)
+	default:
+		err = appendJSON(file, snapshot)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write metrics file %s: %w", filePath, err)
+	}
+	log.Printf("Appended metrics to %s (%d PRs, %d old PRs)", filePath, snapshot.PRCount, snapshot.OldPRCount)
+	return nil
+}
+
+func appendJSON(file *os.File, snapshot Snapshot) error {
+	line, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+func appendCSV(file *os.File, snapshot Snapshot) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(file)
+	if info.Size() == 0 {
+		if err := writer.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+	if err := writer.Write([]string{
+		snapshot.Timestamp.Format(time.RFC3339),
+		strconv.Itoa(snapshot.PRCount),
+		strconv.Itoa(snapshot.OldPRCount),
+		formatPRCountByRepository(snapshot.PRCountByRepository),
+	}); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Formats the counts as "owner/repo1=2;owner/repo2=1" (sorted by repository)
+// since the set of repositories may vary between runs.
+func formatPRCountByRepository(prCountByRepository map[string]int) string {
+	repositories := make([]string, 0, len(prCountByRepository))
+	for repository := range prCountByRepository {
+		repositories = append(repositories, repository)
+	}
+	slices.Sort(repositories)
+
+	counts := make([]string, len(repositories))
+	for i, repository := range repositories {
+		counts[i] = repository + "=" + strconv.Itoa(prCountByRepository[repository])
+	}
+	return strings.Join(counts, ";")
+}
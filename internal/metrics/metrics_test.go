@@ -0,0 +1,104 @@
+package metrics_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/metrics"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+)
+
+func newTestPR(number int, repository string, isOld bool) prparser.PR {
+	repo, _ := models.ParseRepository(repository)
+	return prparser.PR{
+		PullRequest: models.PullRequest{Repository: repo, Number: number},
+		IsOldPR:     isOld,
+	}
+}
+
+var testPRs = []prparser.PR{
+	newTestPR(1, "org/repo-a", true),
+	newTestPR(2, "org/repo-a", false),
+	newTestPR(3, "org/repo-b", false),
+}
+
+var testTimestamp = time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func TestNewSnapshot(t *testing.T) {
+	snapshot := metrics.NewSnapshot(testPRs, testTimestamp)
+
+	if snapshot.PRCount != 3 {
+		t.Errorf("Expected PR count 3, got %d", snapshot.PRCount)
+	}
+	if snapshot.OldPRCount != 1 {
+		t.Errorf("Expected old PR count 1, got %d", snapshot.OldPRCount)
+	}
+	if snapshot.PRCountByRepository["org/repo-a"] != 2 || snapshot.PRCountByRepository["org/repo-b"] != 1 {
+		t.Errorf("Unexpected PR counts by repository: %v", snapshot.PRCountByRepository)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	tests := []struct {
+		name          string
+		format        config.MetricsFormat
+		expectedLines []string
+	}{
+		{
+			name:   "JSON lines",
+			format: config.MetricsFormatJSON,
+			expectedLines: []string{
+				`{"timestamp":"2025-10-01T09:00:00Z","prCount":3,"oldPRCount":1,"prCountByRepository":{"org/repo-a":2,"org/repo-b":1}}`,
+				`{"timestamp":"2025-10-01T09:00:00Z","prCount":3,"oldPRCount":1,"prCountByRepository":{"org/repo-a":2,"org/repo-b":1}}`,
+			},
+		},
+		{
+			name:   "CSV with header written once",
+			format: config.MetricsFormatCSV,
+			expectedLines: []string{
+				"timestamp,pr_count,old_pr_count,pr_count_by_repository",
+				"2025-10-01T09:00:00Z,3,1,org/repo-a=2;org/repo-b=1",
+				"2025-10-01T09:00:00Z,3,1,org/repo-a=2;org/repo-b=1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "metrics", "pr-metrics")
+			snapshot := metrics.NewSnapshot(testPRs, testTimestamp)
+
+			for range 2 {
+				if err := metrics.Append(filePath, tt.format, snapshot); err != nil {
+					t.Fatalf("Append failed: %v", err)
+				}
+			}
+
+			data, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read metrics file: %v", err)
+			}
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			if len(lines) != len(tt.expectedLines) {
+				t.Fatalf("Expected %d lines, got %d:\n%s", len(tt.expectedLines), len(lines), data)
+			}
+			for i, expectedLine := range tt.expectedLines {
+				if lines[i] != expectedLine {
+					t.Errorf("Line %d mismatch:\ngot:  %s\nwant: %s", i, lines[i], expectedLine)
+				}
+			}
+			if tt.format == config.MetricsFormatJSON {
+				var parsed metrics.Snapshot
+				if err := json.Unmarshal([]byte(lines[0]), &parsed); err != nil {
+					t.Errorf("Expected a valid JSON line, got error: %v", err)
+				}
+			}
+		})
+	}
+}
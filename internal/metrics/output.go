@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+)
+
+// PRCountsOutputName is the name of the action output with the number of the open PRs of each repository.
+const PRCountsOutputName = "pr-counts"
+
+// CountPRsByRepository returns the number of the PRs of each of the repositories (paths), including
+// the repositories without PRs, so that the counts of all repositories can be looked up.
+func CountPRsByRepository(prs []prparser.PR, repositories []string) map[string]int {
+	prCounts := make(map[string]int, len(repositories))
+	for _, repository := range repositories {
+		prCounts[repository] = 0
+	}
+	for _, pr := range prs {
+		prCounts[pr.Repository.GetPath()]++
+	}
+	return prCounts
+}
+
+// WritePRCountsOutput writes the PR counts as a JSON object (e.g. {"owner/repo":2}) to the GITHUB_OUTPUT
+// file as the pr-counts output, so that later steps can act on the repositories crossing a threshold.
+func WritePRCountsOutput(outputFilePath string, prCounts map[string]int) error {
+	asJson, err := json.Marshal(prCounts) // the keys are sorted
+	if err != nil {
+		return fmt.Errorf("failed to marshal the PR counts: %w", err)
+	}
+	if err := appendToFile(outputFilePath, fmt.Sprintf("%s=%s\n", PRCountsOutputName, asJson)); err != nil {
+		return err
+	}
+	log.Printf("Wrote the PR counts of %d repositories to the %s output", len(prCounts), PRCountsOutputName)
+	return nil
+}
+
+// WritePRCountsStepSummary appends a table of the PR counts (sorted by repository) to the step summary
+// file (GITHUB_STEP_SUMMARY), which is shown on the summary page of the workflow run.
+func WritePRCountsStepSummary(summaryFilePath string, prCounts map[string]int) error {
+	repositories := make([]string, 0, len(prCounts))
+	for repository := range prCounts {
+		repositories = append(repositories, repository)
+	}
+	slices.Sort(repositories)
+
+	var summary strings.Builder
+	summary.WriteString("### Open PRs\n\n| Repository | Open PRs |\n| --- | ---: |\n")
+	for _, repository := range repositories {
+		fmt.Fprintf(&summary, "| %s | %d |\n", repository, prCounts[repository])
+	}
+	return appendToFile(summaryFilePath, summary.String())
+}
+
+func appendToFile(filePath string, content string) error {
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(content); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", filePath, err)
+	}
+	return nil
+}
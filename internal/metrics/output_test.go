@@ -0,0 +1,37 @@
+package metrics_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/metrics"
+)
+
+func TestWritePRCounts(t *testing.T) {
+	prCounts := metrics.CountPRsByRepository(testPRs, []string{"org/repo-a", "org/repo-b", "org/repo-c"})
+	outputFilePath := filepath.Join(t.TempDir(), "github-output")
+	summaryFilePath := filepath.Join(t.TempDir(), "step-summary.md")
+	if err := os.WriteFile(outputFilePath, []byte("config={}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write output file: %v", err)
+	}
+
+	if err := metrics.WritePRCountsOutput(outputFilePath, prCounts); err != nil {
+		t.Fatalf("Expected no error writing the output, got: %v", err)
+	}
+	if err := metrics.WritePRCountsStepSummary(summaryFilePath, prCounts); err != nil {
+		t.Fatalf("Expected no error writing the step summary, got: %v", err)
+	}
+
+	output, _ := os.ReadFile(outputFilePath)
+	expectedOutput := "config={}\npr-counts={\"org/repo-a\":2,\"org/repo-b\":1,\"org/repo-c\":0}\n"
+	if string(output) != expectedOutput {
+		t.Errorf("Expected the output to be appended as %q, got %q", expectedOutput, output)
+	}
+	summary, _ := os.ReadFile(summaryFilePath)
+	expectedSummary := "### Open PRs\n\n| Repository | Open PRs |\n| --- | ---: |\n" +
+		"| org/repo-a | 2 |\n| org/repo-b | 1 |\n| org/repo-c | 0 |\n"
+	if string(summary) != expectedSummary {
+		t.Errorf("Expected the step summary %q, got %q", expectedSummary, summary)
+	}
+}
@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// PullRequest is the data of a PR from which the messages and the state are built. It is populated
+// by the GitHub client, so that the rest of the action does not depend on the types of the GitHub SDK.
+type PullRequest struct {
+	Repository Repository
+	Number     int
+	// GraphQL node ID, which stays the same if the repository is renamed or transferred
+	NodeID    string
+	Title     string
+	HTMLURL   string
+	State     string // "open" or "closed"
+	Merged    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Labels    []string // names of the labels
+	Milestone string   // title of the milestone, empty if none
+	// Branch and commit of the head of the PR
+	HeadBranch string
+	HeadSHA    string
+	// Users from whom a review has been requested
+	RequestedReviewers []User
+	// Teams from which a review has been requested
+	RequestedTeams []Team
+	// Set if the repository has been renamed or transferred (GitHub redirects requests to the new path)
+	MovedToRepository *Repository
+	// Set if any of the latest check runs of the head commit failed (only if checks are fetched)
+	HasFailingChecks bool
+	// Set if any of the approvers is a code owner of the changed files (only if code owners are checked)
+	HasCodeownerApproval bool
+	// Review decision of the PR, e.g. "APPROVED" (only if review decisions are fetched)
+	ReviewDecision string
+	// Set if the PR is in the merge queue (only if the merge queue state is fetched)
+	IsInMergeQueue bool
+	// Size and discussion of the PR (only if the review effort is shown), nil if not fetched
+	Stats *PullRequestStats
+	// First review request of the PR and the first review after it (only if the review response time
+	// is shown), nil if not fetched or no review has been requested
+	ReviewResponse *PullRequestReviewResponse
+}
+
+// User is a GitHub user, e.g. a requested reviewer of a PR.
+type User struct {
+	Login string
+	Name  string // empty if not available
+	// Set if the user is treated as a bot, by the type of the user and the bot-authors and human-bots inputs
+	IsBot bool
+}
+
+// Team is a GitHub team.
+type Team struct {
+	Slug string
+	Name string
+}
+
+// PullRequestReview is a review of a PR.
+type PullRequestReview struct {
+	AuthorLogin string
+	State       string    // e.g. "APPROVED"
+	SubmittedAt time.Time // zero if the review has not been submitted (is pending)
+}
+
+// PullRequestStats are the stats of a PR from which its review effort is estimated.
+type PullRequestStats struct {
+	ChangedFiles int
+	Additions    int
+	Deletions    int
+	Comments     int // comments on the conversation of the PR
+}
+
+// PullRequestReviewResponse tells how long the first review request of a PR waited for a review.
+type PullRequestReviewResponse struct {
+	RequestedAt time.Time
+	// Submission time of the first review after the request by another user than the author (not a bot),
+	// nil if there is no response yet
+	RespondedAt *time.Time
+}
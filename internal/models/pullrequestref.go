@@ -3,4 +3,10 @@ package models
 type PullRequestRef struct {
 	Repository Repository `json:"repository"`
 	Number     int        `json:"number"`
+	// GraphQL node ID of the PR, which stays the same if the repository is renamed or transferred.
+	// Not available in states saved before schema version 2.
+	NodeID string `json:"nodeId,omitempty"`
+	// Number of consecutive reminders the PR has been included in.
+	// Not available in states saved before reminders were counted.
+	ReminderCount int `json:"reminderCount,omitempty"`
 }
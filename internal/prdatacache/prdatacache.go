@@ -0,0 +1,85 @@
+// Package prdatacache saves the open PRs fetched from GitHub to a file, which is uploaded as an artifact
+// and read by the later jobs of the same workflow run instead of fetching the PRs again (pr-data-cache
+// input). The PRs are cached as fetched (before parsing), as the jobs may show them differently.
+package prdatacache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+)
+
+// Schema version history:
+//   - 1: PRs as the models of the GitHub client (earlier caches had the PRs of the GitHub SDK)
+const CurrentSchemaVersion = 1
+
+// Data is the content of the cache file.
+type Data struct {
+	SchemaVersion int               `json:"schemaVersion"` // set by Save
+	CreatedAt     time.Time         `json:"createdAt"`
+	PRs           []githubclient.PR `json:"prs"`
+	// The repositories from which the PRs were fetched and the skipped archived ones
+	Repositories         []models.Repository `json:"repositories"`
+	ArchivedRepositories []models.Repository `json:"archivedRepositories,omitempty"`
+}
+
+type RunArtifactFetcher interface {
+	FetchRunArtifactByName(
+		ctx context.Context,
+		owner, repo string,
+		runID int64,
+		artifactName, jsonFilePath string,
+		target any,
+	) error
+}
+
+// Save writes the data to the file (e.g. to be uploaded as an artifact after the action).
+func Save(filePath string, data Data) error {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	data.SchemaVersion = CurrentSchemaVersion
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the PR data cache: %w", err)
+	}
+	if err := os.WriteFile(filePath, jsonData, 0644); err != nil {
+		return fmt.Errorf("failed to write the PR data cache file %s: %w", filePath, err)
+	}
+	log.Printf("Saved the PR data cache to %s with %d PRs", filePath, len(data.PRs))
+	return nil
+}
+
+// Load reads the data from the artifact uploaded by the workflow run (i.e. by an earlier job of
+// the current run), so that the cache of other runs is never used. A cache of another schema version
+// (e.g. written by an earlier version of the action) is not read.
+func Load(
+	ctx context.Context,
+	fetcher RunArtifactFetcher,
+	repository models.Repository,
+	runID int64,
+	artifactName string,
+	filePath string,
+) (Data, error) {
+	var data Data
+	if err := fetcher.FetchRunArtifactByName(
+		ctx, repository.Owner, repository.Name, runID, artifactName, filePath, &data,
+	); err != nil {
+		return Data{}, err
+	}
+	if data.SchemaVersion != CurrentSchemaVersion {
+		return Data{}, fmt.Errorf(
+			"unsupported PR data cache schema version %d, expected %d", data.SchemaVersion, CurrentSchemaVersion,
+		)
+	}
+	log.Printf("Loaded the PR data cache created at %s with %d PRs", data.CreatedAt.Format(time.RFC3339), len(data.PRs))
+	return data, nil
+}
@@ -0,0 +1,95 @@
+package prdatacache_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+	"github.com/hellej/pr-slack-reminder-action/internal/prdatacache"
+)
+
+// Reads the saved cache file as if it was downloaded from the artifact of the run.
+type mockFetcher struct {
+	filePath string
+	runID    int64
+}
+
+func (m mockFetcher) FetchRunArtifactByName(
+	ctx context.Context, owner, repo string, runID int64, artifactName, jsonFilePath string, target any,
+) error {
+	if runID != m.runID {
+		return githubclient.ErrArtifactNotFound
+	}
+	data, err := os.ReadFile(m.filePath)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	repo := models.Repository{Owner: "test-org", Name: "test-repo"}
+	data := prdatacache.Data{
+		SchemaVersion: prdatacache.CurrentSchemaVersion,
+		CreatedAt:     time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+		PRs: []githubclient.PR{{
+			PullRequest: models.PullRequest{
+				Repository:         repo,
+				Number:             1,
+				Title:              "Test PR",
+				CreatedAt:          time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+				Labels:             []string{"frontend"},
+				RequestedReviewers: []models.User{{Login: "bob"}},
+				HasFailingChecks:   true,
+				ReviewDecision:     "APPROVED",
+			},
+			Author:          githubclient.Collaborator{Login: "alice", Name: "Alice"},
+			ApprovedByUsers: []githubclient.Collaborator{{Login: "carol"}},
+		}},
+		Repositories:         []models.Repository{repo},
+		ArchivedRepositories: []models.Repository{{Owner: "test-org", Name: "old-repo"}},
+	}
+	filePath := filepath.Join(t.TempDir(), "cache", "pr-data.json")
+
+	if err := prdatacache.Save(filePath, data); err != nil {
+		t.Fatalf("Expected no error saving, got: %v", err)
+	}
+
+	loaded, err := prdatacache.Load(
+		context.Background(), mockFetcher{filePath: filePath, runID: 42}, repo, 42, "pr-data", "pr-data.json",
+	)
+	if err != nil {
+		t.Fatalf("Expected no error loading, got: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, data) {
+		t.Errorf("Expected the loaded data to equal the saved data:\n%+v\ngot:\n%+v", data, loaded)
+	}
+
+	if _, err := prdatacache.Load(
+		context.Background(), mockFetcher{filePath: filePath, runID: 42}, repo, 43, "pr-data", "pr-data.json",
+	); err == nil {
+		t.Errorf("Expected an error loading the cache of another run")
+	}
+}
+
+func TestLoad_OtherSchemaVersion(t *testing.T) {
+	repo := models.Repository{Owner: "test-org", Name: "test-repo"}
+	filePath := filepath.Join(t.TempDir(), "pr-data.json")
+	// a cache written before the schema version was added, with the PRs of the GitHub SDK
+	content := `{"createdAt":"2025-01-03T00:00:00Z","prs":[{"number":1,"Repository":{"Owner":"test-org","Name":"test-repo"}}]}`
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write the cache file: %v", err)
+	}
+
+	if _, err := prdatacache.Load(
+		context.Background(), mockFetcher{filePath: filePath, runID: 42}, repo, 42, "pr-data", "pr-data.json",
+	); err == nil {
+		t.Errorf("Expected an error loading a cache of another schema version")
+	}
+}
@@ -4,22 +4,85 @@
 package prparser
 
 import (
+	"cmp"
 	"fmt"
 	"math"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/clock"
 	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+	"github.com/hellej/pr-slack-reminder-action/internal/secretredaction"
 	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
 )
 
 type PR struct {
-	*githubclient.PR
+	models.PullRequest
 	Author     Collaborator
-	Approvers  []Collaborator // Users who have approved the PR at least once
+	Approvers  []Collaborator // Users whose latest review approves the PR
 	Commenters []Collaborator // Users who have commented on the PR but did not approve it
-	IsOldPR    bool           // true if the PR is older than the configured threshold
+	IsOldPR    bool           // true if the PR is older than the configured (lowest) threshold
+	// The time at which the age of the PR is computed (the current time of the run if zero)
+	ReferenceTime time.Time
+	// Number of the configured old PR thresholds that the PR is older than (0 if it is not old)
+	OldPRTier int
+	// Number of the configured old PR thresholds, the markers escalate only if there are several
+	OldPRTierCount int
+	// Time of the first review by another user than the author, nil if not reviewed yet
+	FirstReviewedAt *time.Time
+	// true if the PR was not reviewed within the review SLA (always false if no SLA is configured)
+	BreachedReviewSLA bool
+	// How the approvers and commenters are shown in the message
+	ReviewerLinkStyle config.ReviewerLinkStyle
+	// Number of consecutive reminders the PR has been included in, including the current one
+	// (0 if not counted, e.g. in the event run-mode)
+	ReminderCount     int
+	ShowReminderCount bool
+	// Links to the "Files changed" and "Checks" tabs are shown after the PR
+	ShowQuickLinks bool
+	// true if the author is one of the highlighted authors (only affects how the PR is shown)
+	IsHighlighted bool
+	// Teams from which a review has been requested, with their Slack groups (the teams of PullRequest
+	// without them)
+	RequestedTeams []Team
+	// Requested reviewers who have not reviewed or commented on the PR (only if silent reviewers are shown)
+	SilentReviewers []Collaborator
+	// Number of review and issue comments on the PR (0 if the comment count is not shown)
+	CommentCount int
+}
+
+type Team struct {
+	Slug         string
+	Name         string
+	SlackGroupID string // empty string if not available
+}
+
+// ReviewerGroup is a group of the reviewers of a PR by their review state, e.g. the approvers.
+type ReviewerGroup struct {
+	Emoji     string
+	Reviewers []Collaborator
+}
+
+// GetReviewerGroups returns the non-empty groups of the reviewers of the PR in the order in which
+// they are shown: ✅ approvers, 💬 commenters and ⏳ silent reviewers.
+func (pr PR) GetReviewerGroups() []ReviewerGroup {
+	groups := []ReviewerGroup{
+		{Emoji: "✅", Reviewers: pr.Approvers},
+		{Emoji: "💬", Reviewers: pr.Commenters},
+		{Emoji: "⏳", Reviewers: pr.SilentReviewers},
+	}
+	return utilities.Filter(groups, func(g ReviewerGroup) bool {
+		return len(g.Reviewers) > 0
+	})
+}
+
+// Returns the GitHub team name if available, otherwise slug.
+func (t Team) GetGitHubName() string {
+	return cmp.Or(t.Name, t.Slug)
 }
 
 type Collaborator struct {
@@ -35,7 +98,11 @@ func NewCollaborator(c githubclient.Collaborator, slackUserId string) Collaborat
 }
 
 func (pr PR) GetPRAgeText() string {
-	duration := time.Since(pr.CreatedAt.Time)
+	return formatDuration(cmp.Or(pr.ReferenceTime, clock.Now()).Sub(pr.CreatedAt))
+}
+
+// e.g. "3 days", "5 hours" or "10 minutes"
+func formatDuration(duration time.Duration) string {
 	if duration.Hours() >= 24 {
 		days := int(math.Round(duration.Hours())) / 24
 		return fmt.Sprintf("%d days", days)
@@ -48,59 +115,353 @@ func (pr PR) GetPRAgeText() string {
 	}
 }
 
+// GetReminderText returns e.g. "3rd reminder" for PRs carried over from the previous reminders,
+// or an empty string if showing the reminder count is disabled or this is the first reminder of the PR.
+func (pr PR) GetReminderText() string {
+	if !pr.ShowReminderCount || pr.ReminderCount < 2 {
+		return ""
+	}
+	return getOrdinal(pr.ReminderCount) + " reminder"
+}
+
+// GetOldPRMarker returns the marker shown before the age of old PRs: 🚨 if a single old PR
+// threshold is configured, otherwise ⚠️, 🚨 or 🔥 by the tier of the PR.
+// Returns an empty string if the PR is not old.
+func (pr PR) GetOldPRMarker() string {
+	if !pr.IsOldPR {
+		return ""
+	}
+	if pr.OldPRTierCount <= 1 {
+		return "🚨"
+	}
+	markers := []string{"⚠️", "🚨", "🔥"}
+	return markers[min(max(pr.OldPRTier, 1), len(markers))-1]
+}
+
+// GetHighlightPrefix returns the prefix of the titles of PRs by highlighted authors,
+// or an empty string if the author is not highlighted.
+func (pr PR) GetHighlightPrefix() string {
+	if !pr.IsHighlighted {
+		return ""
+	}
+	return "⭐ "
+}
+
+// GetCodeownerApprovalText returns the text shown after the reviewers of PRs approved by a code owner,
+// or an empty string if no code owner has approved the PR.
+func (pr PR) GetCodeownerApprovalText() string {
+	if !pr.HasCodeownerApproval {
+		return ""
+	}
+	return " 👑 owner-approved"
+}
+
+// GetMergeQueueText returns the text shown after the reviewers of PRs in the merge queue,
+// or an empty string if the PR is not queued.
+func (pr PR) GetMergeQueueText() string {
+	if !pr.IsInMergeQueue {
+		return ""
+	}
+	return " 🕗 queued"
+}
+
+// GetReviewDecisionText returns the text shown after the reviewers of PRs by their review decision,
+// or an empty string if the decision is unknown (e.g. the repository does not require reviews).
+func (pr PR) GetReviewDecisionText() string {
+	switch pr.ReviewDecision {
+	case githubclient.ReviewDecisionApproved:
+		return " 🟢 approved"
+	case githubclient.ReviewDecisionChangesRequested:
+		return " 🔴 changes requested"
+	case githubclient.ReviewDecisionReviewRequired:
+		return " 🟡 review required"
+	default:
+		return ""
+	}
+}
+
+// QuickLink is a link to a tab of the PR, shown after the PR as e.g. "[files]".
+type QuickLink struct {
+	Text string
+	URL  string
+}
+
+// GetQuickLinks returns the links to the "Files changed" and "Checks" tabs of the PR,
+// or nil if the quick links are not shown.
+func (pr PR) GetQuickLinks() []QuickLink {
+	if !pr.ShowQuickLinks || pr.HTMLURL == "" {
+		return nil
+	}
+	prURL := strings.TrimSuffix(pr.HTMLURL, "/")
+	return []QuickLink{
+		{Text: "files", URL: prURL + "/files"},
+		{Text: "checks", URL: prURL + "/checks"},
+	}
+}
+
+// The review effort score of a PR is the number of changed lines, weighted with the changed files and
+// comments (as each file and discussion thread takes time to review regardless of its size).
+const (
+	reviewEffortFileWeight    = 20
+	reviewEffortCommentWeight = 10
+	mediumReviewEffortScore   = 200
+	highReviewEffortScore     = 800
+)
+
+// GetReviewEffortText returns the estimated review effort of the PR, e.g. " ◑ medium effort",
+// or an empty string if the stats of the PR were not fetched.
+func (pr PR) GetReviewEffortText() string {
+	if pr.Stats == nil {
+		return ""
+	}
+	score := pr.Stats.Additions + pr.Stats.Deletions +
+		pr.Stats.ChangedFiles*reviewEffortFileWeight + pr.Stats.Comments*reviewEffortCommentWeight
+	switch {
+	case score >= highReviewEffortScore:
+		return " ● high effort"
+	case score >= mediumReviewEffortScore:
+		return " ◑ medium effort"
+	default:
+		return " ◔ low effort"
+	}
+}
+
+// GetReviewResponseText returns how long the first review request of the PR waited for a review, e.g.
+// " ⏱ review requested 2 days ago, no response" or " ⏱ first review in 3 hours", or an empty string if
+// the review response was not fetched or no review has been requested.
+func (pr PR) GetReviewResponseText() string {
+	response := pr.ReviewResponse
+	if response == nil {
+		return ""
+	}
+	if response.RespondedAt == nil {
+		waited := cmp.Or(pr.ReferenceTime, clock.Now()).Sub(response.RequestedAt)
+		return " ⏱ review requested " + formatDuration(waited) + " ago, no response"
+	}
+	return " ⏱ first review in " + formatDuration(response.RespondedAt.Sub(response.RequestedAt))
+}
+
+// GetCommentCountText returns the number of comments on the PR, e.g. " (12 comments)", or an empty
+// string if the PR has no comments or the comment count is not shown.
+func (pr PR) GetCommentCountText() string {
+	switch pr.CommentCount {
+	case 0:
+		return ""
+	case 1:
+		return " (1 comment)"
+	default:
+		return fmt.Sprintf(" (%d comments)", pr.CommentCount)
+	}
+}
+
+// e.g. 1st, 2nd, 3rd, 4th, 11th, 12th, 13th, 21st
+func getOrdinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
 func (pr PR) IsMerged() bool {
-	return pr.GetMerged()
+	return pr.Merged
 }
 
 func (pr PR) IsClosedButNotMerged() bool {
-	return pr.GetState() == "closed" && !pr.IsMerged()
+	return pr.State == "closed" && !pr.IsMerged()
 }
 
-func ParsePRs(prs []githubclient.PR, config config.ContentInputs) []PR {
-	return sortPRsByCreatedAt(utilities.Map(prs, getPRParser(config)))
+// ParsePRs parses the PRs for the message. The ages of the PRs (e.g. whether they are old) are
+// computed at the current time of the clock.
+func ParsePRs(prs []githubclient.PR, config config.ContentInputs, clk clock.Clock) []PR {
+	return sortPRsByCreatedAt(utilities.Map(prs, getPRParser(config, clk.Now())))
 }
 
-func getPRParser(config config.ContentInputs) func(pr githubclient.PR) PR {
+func getPRParser(config config.ContentInputs, now time.Time) func(pr githubclient.PR) PR {
 	return func(pr githubclient.PR) PR {
-		return parsePR(pr, config)
+		return parsePR(pr, config, now)
 	}
 }
 
-func parsePR(pr githubclient.PR, config config.ContentInputs) PR {
+func parsePR(pr githubclient.PR, config config.ContentInputs, now time.Time) PR {
+	firstReviewedAt := getFirstReviewTime(pr)
+	approvers := withoutIgnoredReviewers(pr.ApprovedByUsers, config.IgnoredReviewers)
+	commenters := withoutIgnoredReviewers(pr.CommentedByUsers, config.IgnoredReviewers)
+	silentReviewers := getSilentReviewers(pr, config)
+	thresholdTiers := config.GetOldPRThresholdTiers(pr.Repository)
+	oldPRTier := getOldPRTier(pr, thresholdTiers, now)
+	commentCount := 0
+	if config.ShowCommentCount {
+		commentCount = pr.CommentCount
+	}
+	pullRequest := pr.PullRequest
+	if config.RedactSecretsInTitles {
+		pullRequest.Title = secretredaction.RedactPRTitle(pullRequest.Title, pullRequest.HTMLURL)
+	}
 	return PR{
-		PR:         &pr,
-		Author:     NewCollaborator(pr.Author, config.SlackUserIdByGitHubUsername[pr.Author.Login]),
-		Approvers:  withSlackUserIds(pr.ApprovedByUsers, config.SlackUserIdByGitHubUsername),
-		Commenters: withSlackUserIds(pr.CommentedByUsers, config.SlackUserIdByGitHubUsername),
-		IsOldPR:    isOlderThan(pr, config.OldPRThresholdHours),
+		PullRequest:    pullRequest,
+		ReferenceTime:  now,
+		Author:         withSlackUserId(pr.Author, config.SlackUserIdByGitHubUsername),
+		Approvers:      sortCollaborators(withSlackUserIds(approvers, config.SlackUserIdByGitHubUsername)),
+		Commenters:     sortCollaborators(withSlackUserIds(commenters, config.SlackUserIdByGitHubUsername)),
+		IsOldPR:        oldPRTier > 0,
+		OldPRTier:      oldPRTier,
+		OldPRTierCount: len(thresholdTiers),
+		RequestedTeams: withSlackGroupIds(
+			pullRequest.RequestedTeams, pr.Repository.Owner, config.SlackGroupIdByGitHubTeam,
+		),
+		FirstReviewedAt:   firstReviewedAt,
+		SilentReviewers:   sortCollaborators(withSlackUserIds(silentReviewers, config.SlackUserIdByGitHubUsername)),
+		CommentCount:      commentCount,
+		BreachedReviewSLA: breachedReviewSLA(pr, firstReviewedAt, config.ReviewSLAHours, now),
+		ReviewerLinkStyle: config.ReviewerLinkStyle,
+		ShowReminderCount: config.ShowReminderCount,
+		ShowQuickLinks:    config.ShowQuickLinks,
+		IsHighlighted: slices.ContainsFunc(config.HighlightAuthors, func(login string) bool {
+			return strings.EqualFold(login, pr.Author.Login)
+		}),
 	}
 }
 
+// Returns the submission time of the earliest review by another user than the author
+// (reviews without a submission time are ignored), nil if there are none.
+func getFirstReviewTime(pr githubclient.PR) *time.Time {
+	var first *time.Time
+	for _, review := range pr.Reviews {
+		if review.AuthorLogin == pr.Author.Login || review.SubmittedAt.IsZero() {
+			continue
+		}
+		submittedAt := review.SubmittedAt
+		if first == nil || submittedAt.Before(*first) {
+			first = &submittedAt
+		}
+	}
+	return first
+}
+
+// A PR breaches the SLA if its first review came later than the SLA allows, or if it
+// has not been reviewed yet and is already older than the SLA.
+func breachedReviewSLA(pr githubclient.PR, firstReviewedAt *time.Time, slaHours int, now time.Time) bool {
+	if slaHours == 0 {
+		return false
+	}
+	deadline := pr.CreatedAt.Add(time.Duration(slaHours) * time.Hour)
+	if firstReviewedAt != nil {
+		return firstReviewedAt.After(deadline)
+	}
+	return now.After(deadline)
+}
+
+// Teams can be mapped either by slug or by org/slug (the latter takes precedence).
+func withSlackGroupIds(
+	teams []models.Team,
+	org string,
+	slackGroupIdByGitHubTeam map[string]string,
+) []Team {
+	return utilities.Map(teams, func(t models.Team) Team {
+		return Team{
+			Slug: t.Slug,
+			Name: t.Name,
+			SlackGroupID: cmp.Or(
+				slackGroupIdByGitHubTeam[org+"/"+t.Slug],
+				slackGroupIdByGitHubTeam[t.Slug],
+			),
+		}
+	})
+}
+
 func withSlackUserIds(
 	collaborators []githubclient.Collaborator,
 	slackUserIdByGitHubUsername map[string]string,
 ) []Collaborator {
 	return utilities.Map(collaborators, func(c githubclient.Collaborator) Collaborator {
-		return NewCollaborator(c, slackUserIdByGitHubUsername[c.Login])
+		return withSlackUserId(c, slackUserIdByGitHubUsername)
+	})
+}
+
+func withSlackUserId(c githubclient.Collaborator, slackUserIdByGitHubUsername map[string]string) Collaborator {
+	return NewCollaborator(c, config.LookupSlackUserID(slackUserIdByGitHubUsername, c.Login))
+}
+
+// Returns the requested reviewers (not bots) who have neither reviewed nor commented on the PR,
+// or nil if silent reviewers are not shown.
+func getSilentReviewers(pr githubclient.PR, config config.ContentInputs) []githubclient.Collaborator {
+	if !config.ShowSilentReviewers {
+		return nil
+	}
+	active := slices.Concat(pr.ApprovedByUsers, pr.CommentedByUsers)
+	var silent []githubclient.Collaborator
+	for _, user := range pr.RequestedReviewers {
+		if user.IsBot || slices.ContainsFunc(active, func(c githubclient.Collaborator) bool {
+			return strings.EqualFold(c.Login, user.Login)
+		}) {
+			continue
+		}
+		silent = append(silent, githubclient.Collaborator{Login: user.Login, Name: user.Name})
+	}
+	return withoutIgnoredReviewers(silent, config.IgnoredReviewers)
+}
+
+// Removes the ignored reviewers (by GitHub username) from the shown approvers or commenters.
+// Their reviews are still taken into account otherwise, e.g. in the review SLA.
+func withoutIgnoredReviewers(
+	collaborators []githubclient.Collaborator,
+	ignoredReviewers []string,
+) []githubclient.Collaborator {
+	return utilities.Filter(collaborators, func(c githubclient.Collaborator) bool {
+		return !slices.ContainsFunc(ignoredReviewers, func(login string) bool {
+			return strings.EqualFold(login, c.Login)
+		})
 	})
 }
 
+// The PRs are sorted newest first. The ties are broken by the repository and the number of the PR,
+// so that the order does not depend on the order in which the PRs were fetched.
 func sortPRsByCreatedAt(prs []PR) []PR {
 	slices.SortStableFunc(prs, func(a, b PR) int {
-		if !a.GetCreatedAt().Time.Equal(b.GetCreatedAt().Time) {
-			return b.GetCreatedAt().Time.Compare(a.GetCreatedAt().Time)
-		}
-		return b.GetUpdatedAt().Time.Compare(a.GetUpdatedAt().Time)
+		return cmp.Or(
+			b.CreatedAt.Compare(a.CreatedAt),
+			b.UpdatedAt.Compare(a.UpdatedAt),
+			strings.Compare(a.Repository.GetPath(), b.Repository.GetPath()),
+			cmp.Compare(a.Number, b.Number),
+		)
 	})
 	return prs
 }
 
-func isOlderThan(pr githubclient.PR, hours int) bool {
+// The reviewers are sorted by username, so that their order does not depend on the order of the reviews.
+func sortCollaborators(collaborators []Collaborator) []Collaborator {
+	slices.SortStableFunc(collaborators, func(a, b Collaborator) int {
+		return strings.Compare(strings.ToLower(a.Login), strings.ToLower(b.Login))
+	})
+	return collaborators
+}
+
+// Returns the number of the thresholds (in ascending order) that the PR is older than.
+func getOldPRTier(pr githubclient.PR, thresholdTiers []int, now time.Time) int {
+	tier := 0
+	for _, hours := range thresholdTiers {
+		if !isOlderThan(pr, hours, now) {
+			break
+		}
+		tier++
+	}
+	return tier
+}
+
+func isOlderThan(pr githubclient.PR, hours int, now time.Time) bool {
 	if hours == 0 {
 		return false
 	}
-	if pr.GetCreatedAt().IsZero() {
+	if pr.CreatedAt.IsZero() {
 		return true
 	}
-	return pr.GetCreatedAt().Before(time.Now().Add(-time.Duration(hours) * time.Hour))
+	return pr.CreatedAt.Before(now.Add(-time.Duration(hours) * time.Hour))
 }
@@ -0,0 +1,120 @@
+package prparser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+)
+
+var now = time.Date(2025, 6, 16, 12, 0, 0, 0, time.UTC)
+
+func getPRCreatedAt(createdAt time.Time) githubclient.PR {
+	return githubclient.PR{PullRequest: models.PullRequest{CreatedAt: createdAt}}
+}
+
+func TestGetOldPRTier(t *testing.T) {
+	defaultTiers := []int{24, 72, 168}
+
+	tests := []struct {
+		name     string
+		age      time.Duration // the creation time is unknown if zero
+		tiers    []int
+		expected int
+	}{
+		{name: "new PR", age: time.Hour, tiers: defaultTiers, expected: 0},
+		{name: "exactly at the first threshold", age: 24 * time.Hour, tiers: defaultTiers, expected: 0},
+		{name: "just past the first threshold", age: 24*time.Hour + time.Second, tiers: defaultTiers, expected: 1},
+		{name: "exactly at the second threshold", age: 72 * time.Hour, tiers: defaultTiers, expected: 1},
+		{name: "just past the second threshold", age: 72*time.Hour + time.Second, tiers: defaultTiers, expected: 2},
+		{name: "just past the last threshold", age: 168*time.Hour + time.Second, tiers: defaultTiers, expected: 3},
+		{name: "no thresholds", age: 1000 * time.Hour, expected: 0},
+		{name: "zero threshold is never passed", age: 1000 * time.Hour, tiers: []int{0}, expected: 0},
+		{name: "unknown creation time is old", tiers: defaultTiers, expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var createdAt time.Time
+			if tt.age > 0 {
+				createdAt = now.Add(-tt.age)
+			}
+			result := getOldPRTier(getPRCreatedAt(createdAt), tt.tiers, now)
+			if result != tt.expected {
+				t.Errorf("getOldPRTier() = %d, expected %d", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBreachedReviewSLA(t *testing.T) {
+	createdAt := now.Add(-48 * time.Hour)
+	deadline := createdAt.Add(24 * time.Hour)
+	beforeDeadline := deadline.Add(-time.Second)
+	afterDeadline := deadline.Add(time.Second)
+
+	tests := []struct {
+		name            string
+		firstReviewedAt *time.Time
+		slaHours        int
+		now             time.Time
+		expected        bool
+	}{
+		{name: "no SLA", slaHours: 0, now: now, expected: false},
+		{name: "reviewed before the deadline", firstReviewedAt: &beforeDeadline, slaHours: 24, now: now, expected: false},
+		{name: "reviewed exactly at the deadline", firstReviewedAt: &deadline, slaHours: 24, now: now, expected: false},
+		{name: "reviewed after the deadline", firstReviewedAt: &afterDeadline, slaHours: 24, now: now, expected: true},
+		{name: "not reviewed before the deadline", slaHours: 24, now: beforeDeadline, expected: false},
+		{name: "not reviewed exactly at the deadline", slaHours: 24, now: deadline, expected: false},
+		{name: "not reviewed after the deadline", slaHours: 24, now: afterDeadline, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := breachedReviewSLA(getPRCreatedAt(createdAt), tt.firstReviewedAt, tt.slaHours, tt.now)
+			if result != tt.expected {
+				t.Errorf("breachedReviewSLA() = %t, expected %t", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetReviewEffortText(t *testing.T) {
+	tests := []struct {
+		name     string
+		stats    *models.PullRequestStats
+		expected string
+	}{
+		{name: "stats not fetched", stats: nil, expected: ""},
+		{name: "no changes", stats: &models.PullRequestStats{}, expected: " ◔ low effort"},
+		{name: "just below medium", stats: &models.PullRequestStats{Additions: 199}, expected: " ◔ low effort"},
+		{
+			name:     "exactly medium",
+			stats:    &models.PullRequestStats{Additions: 150, Deletions: 50},
+			expected: " ◑ medium effort",
+		},
+		{name: "medium by changed files", stats: &models.PullRequestStats{ChangedFiles: 10}, expected: " ◑ medium effort"},
+		{name: "medium by comments", stats: &models.PullRequestStats{Comments: 20}, expected: " ◑ medium effort"},
+		{
+			name:     "just below high",
+			stats:    &models.PullRequestStats{Additions: 399, Deletions: 100, ChangedFiles: 10, Comments: 10},
+			expected: " ◑ medium effort",
+		},
+		{
+			name:     "exactly high",
+			stats:    &models.PullRequestStats{Additions: 400, Deletions: 100, ChangedFiles: 10, Comments: 10},
+			expected: " ● high effort",
+		},
+		{name: "high by changed files", stats: &models.PullRequestStats{ChangedFiles: 40}, expected: " ● high effort"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := PR{PullRequest: models.PullRequest{Stats: tt.stats}}
+			if result := pr.GetReviewEffortText(); result != tt.expected {
+				t.Errorf("GetReviewEffortText() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Package reminderoptions holds the options of the reminder package (pkg/reminder). The options that
+// take the API clients of this module (e.g. to replace them with mock clients in tests) are declared
+// here, as the types of the clients are internal and cannot be used outside the module.
+package reminderoptions
+
+import (
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
+)
+
+type Options struct {
+	Inputs          map[string]string
+	Env             map[string]string
+	GetGitHubClient func(token, tokenForState string) githubclient.Client
+	GetSlackClient  func(token string) slackclient.Client
+	PrintConfig     bool
+}
+
+// WithGitHubClientGetter replaces the GitHub client, e.g. with a mock client in tests.
+func WithGitHubClientGetter(getGitHubClient func(token, tokenForState string) githubclient.Client) func(*Options) {
+	return func(o *Options) {
+		o.GetGitHubClient = getGitHubClient
+	}
+}
+
+// WithSlackClientGetter replaces the Slack client, e.g. with a mock client in tests.
+func WithSlackClientGetter(getSlackClient func(token string) slackclient.Client) func(*Options) {
+	return func(o *Options) {
+		o.GetSlackClient = getSlackClient
+	}
+}
@@ -0,0 +1,116 @@
+// Package runreport collects the non-fatal issues of a run (e.g. skipped repositories or PRs
+// of which the reviews could not be fetched), so that they can be summarized at the end of
+// the run and in the message instead of being scattered through the log.
+// The report is passed in the context, so that it is available wherever the issues occur.
+package runreport
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+// Kind is the kind of an issue, it defines how the issues are counted in the summary.
+type Kind struct {
+	singular string // e.g. "%d repository was skipped"
+	plural   string
+}
+
+var (
+	KindSkippedRepository   = Kind{"%d repository was skipped", "%d repositories were skipped"}
+	KindSkippedPR           = Kind{"%d PR could not be fetched", "%d PRs could not be fetched"}
+	KindMissingReviewerInfo = Kind{"reviews of %d PR could not be fetched", "reviews of %d PRs could not be fetched"}
+	KindSlack               = Kind{"%d Slack API call failed", "%d Slack API calls failed"}
+	KindOther               = Kind{"%d other issue", "%d other issues"}
+	kindsInSummaryOrder     = []Kind{KindSkippedRepository, KindSkippedPR, KindMissingReviewerInfo, KindSlack, KindOther}
+	reportContextKey        = contextKey{}
+)
+
+type contextKey struct{}
+
+type Issue struct {
+	Kind    Kind
+	Message string
+}
+
+// Report collects the issues of a run. It is safe for concurrent use, and the methods of
+// a nil report only log the issues.
+type Report struct {
+	mu     sync.Mutex
+	issues []Issue
+}
+
+func New() *Report {
+	return &Report{}
+}
+
+// NewContext returns a copy of the context with the report.
+func NewContext(ctx context.Context, report *Report) context.Context {
+	return context.WithValue(ctx, reportContextKey, report)
+}
+
+// FromContext returns the report of the context, or nil if it has none.
+func FromContext(ctx context.Context) *Report {
+	report, _ := ctx.Value(reportContextKey).(*Report)
+	return report
+}
+
+// Add logs the issue as a warning and adds it to the report.
+func (r *Report) Add(kind Kind, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	log.Printf("Warning: %s", message)
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.issues = append(r.issues, Issue{Kind: kind, Message: message})
+}
+
+func (r *Report) Issues() []Issue {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Issue(nil), r.issues...)
+}
+
+// Summary returns the counts of the issues by kind, e.g. "⚠️ 2 repositories were skipped,
+// 1 PR could not be fetched", or an empty string if there are no issues.
+func (r *Report) Summary() string {
+	issues := r.Issues()
+	if len(issues) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, kind := range kindsInSummaryOrder {
+		count := 0
+		for _, issue := range issues {
+			if issue.Kind == kind {
+				count++
+			}
+		}
+		switch {
+		case count == 1:
+			parts = append(parts, fmt.Sprintf(kind.singular, count))
+		case count > 1:
+			parts = append(parts, fmt.Sprintf(kind.plural, count))
+		}
+	}
+	return "⚠️ " + strings.Join(parts, ", ")
+}
+
+// Log logs the summary and all issues of the report (nothing if there are no issues).
+func (r *Report) Log() {
+	issues := r.Issues()
+	if len(issues) == 0 {
+		return
+	}
+	log.Printf("Run report: %s", r.Summary())
+	for _, issue := range issues {
+		log.Printf("  - %s", issue.Message)
+	}
+}
@@ -0,0 +1,81 @@
+package runreport_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/runreport"
+)
+
+func TestSummary(t *testing.T) {
+	type issue struct {
+		kind    runreport.Kind
+		message string
+	}
+	tests := []struct {
+		name            string
+		issues          []issue
+		expectedSummary string
+	}{
+		{name: "no issues", expectedSummary: ""},
+		{
+			name:            "single issue",
+			issues:          []issue{{runreport.KindSkippedPR, "PR 1 not found"}},
+			expectedSummary: "⚠️ 1 PR could not be fetched",
+		},
+		{
+			name: "issues of multiple kinds in the order of the kinds",
+			issues: []issue{
+				{runreport.KindSlack, "failed to delete message"},
+				{runreport.KindSkippedRepository, "skipping archived repository o/a"},
+				{runreport.KindSkippedRepository, "skipping archived repository o/b"},
+				{runreport.KindMissingReviewerInfo, "unable to fetch reviews"},
+			},
+			expectedSummary: "⚠️ 2 repositories were skipped, reviews of 1 PR could not be fetched, 1 Slack API call failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := runreport.New()
+			for _, issue := range tt.issues {
+				report.Add(issue.kind, "%s", issue.message)
+			}
+			if summary := report.Summary(); summary != tt.expectedSummary {
+				t.Errorf("Expected summary %q, got %q", tt.expectedSummary, summary)
+			}
+		})
+	}
+}
+
+func TestReportInContext(t *testing.T) {
+	report := runreport.New()
+	ctx := runreport.NewContext(context.Background(), report)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runreport.FromContext(ctx).Add(runreport.KindOther, "concurrent issue")
+		}()
+	}
+	wg.Wait()
+
+	if count := len(report.Issues()); count != 10 {
+		t.Errorf("Expected 10 issues, got %d", count)
+	}
+}
+
+func TestReportNotInContext(t *testing.T) {
+	report := runreport.FromContext(context.Background())
+	if report != nil {
+		t.Fatalf("Expected no report, got %v", report)
+	}
+	// the issues of a nil report are only logged
+	report.Add(runreport.KindOther, "logged issue")
+	if summary := report.Summary(); summary != "" {
+		t.Errorf("Expected an empty summary, got %q", summary)
+	}
+}
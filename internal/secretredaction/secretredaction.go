@@ -0,0 +1,111 @@
+// Package secretredaction redacts strings resembling secrets (e.g. tokens pasted into PR titles by
+// accident) from texts before they are sent, so that a leaked secret is not broadcast further.
+package secretredaction
+
+import (
+	"log"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Placeholder replaces the redacted secrets.
+const Placeholder = "[redacted]"
+
+// Secrets with a known format, e.g. Slack, GitHub and AWS tokens (not within words, e.g. "task_...").
+var knownSecretPattern = regexp.MustCompile(`\b(?:` + strings.Join([]string{
+	`xox[abposr]-[A-Za-z0-9-]{10,}`,                                    // Slack tokens
+	`xapp-[A-Za-z0-9-]{10,}`,                                           // Slack app-level tokens
+	`gh[pousr]_[A-Za-z0-9]{30,}`,                                       // GitHub tokens
+	`github_pat_[A-Za-z0-9_]{22,}`,                                     // GitHub fine-grained tokens
+	`(?:AKIA|ASIA)[0-9A-Z]{16}`,                                        // AWS access key IDs
+	`AIza[0-9A-Za-z_-]{35}`,                                            // Google API keys
+	`sk_(?:live|test)_[A-Za-z0-9]{20,}`,                                // Stripe keys
+	`sk-(?:proj-)?[A-Za-z0-9]{20,}[A-Za-z0-9_-]*`,                      // OpenAI keys
+	`eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`, // JSON web tokens
+}, "|") + `)`)
+
+// Candidates for random (high-entropy) secrets without a known format.
+var randomStringPattern = regexp.MustCompile(`[A-Za-z0-9+/_]{20,}={0,2}`)
+
+const (
+	minEntropyBitsPerChar = 3.5
+	// Share of the adjacent characters of a different class (upper, lower, digit), which is about 0.6 in
+	// random strings but lower in identifiers and words, e.g. "AddReviewEffortInfo2025Q1" (0.4).
+	minClassChangeRatio = 0.55
+)
+
+// RedactSecrets returns the text with the strings resembling secrets replaced by the Placeholder,
+// and the number of the redacted strings.
+func RedactSecrets(text string) (string, int) {
+	count := 0
+	redact := func(string) string {
+		count++
+		return Placeholder
+	}
+	text = knownSecretPattern.ReplaceAllStringFunc(text, redact)
+	text = randomStringPattern.ReplaceAllStringFunc(text, func(candidate string) string {
+		if !looksRandom(candidate) {
+			return candidate
+		}
+		return redact(candidate)
+	})
+	return text, count
+}
+
+// RedactPRTitle returns the title of the PR with the strings resembling secrets redacted. The PR is
+// logged if something is redacted, as the secrets may need to be rotated.
+func RedactPRTitle(title, prURL string) string {
+	redacted, count := RedactSecrets(title)
+	if count > 0 {
+		log.Printf("Warning: redacted %d possible secret(s) from the title of PR %s", count, prURL)
+	}
+	return redacted
+}
+
+// Random strings mix upper and lower case letters and digits, and their characters are neither
+// repeated nor grouped into words like those of identifiers.
+func looksRandom(s string) bool {
+	var hasUpper, hasLower, hasDigit bool
+	classChanges := 0
+	runes := []rune(s)
+	for i, r := range runes {
+		hasUpper = hasUpper || unicode.IsUpper(r)
+		hasLower = hasLower || unicode.IsLower(r)
+		hasDigit = hasDigit || unicode.IsDigit(r)
+		if i > 0 && charClass(r) != charClass(runes[i-1]) {
+			classChanges++
+		}
+	}
+	return hasUpper && hasLower && hasDigit &&
+		float64(classChanges)/float64(len(runes)-1) >= minClassChangeRatio &&
+		entropyBitsPerChar(runes) >= minEntropyBitsPerChar
+}
+
+func charClass(r rune) int {
+	switch {
+	case unicode.IsUpper(r):
+		return 0
+	case unicode.IsLower(r):
+		return 1
+	case unicode.IsDigit(r):
+		return 2
+	default:
+		return 3
+	}
+}
+
+// Returns the Shannon entropy of the characters.
+func entropyBitsPerChar(runes []rune) float64 {
+	counts := map[rune]int{}
+	for _, r := range runes {
+		counts[r]++
+	}
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(len(runes))
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,74 @@
+package secretredaction_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/secretredaction"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	// the fake secrets are built at runtime so that they are not flagged by secret scanners
+	githubToken := "ghp_" + strings.Repeat("a1B2", 9)
+	slackToken := "xoxb-" + strings.Repeat("1234", 3) + "-" + strings.Repeat("aB3d", 5)
+	awsKeyID := "AKIA" + strings.Repeat("Q7X2", 4)
+	randomString := "Zx9Qw2Er7Ty4Ui1Op8As3Df6"
+
+	tests := []struct {
+		name          string
+		text          string
+		expected      string
+		expectedCount int
+	}{
+		{
+			name:     "plain title",
+			text:     "Fix login redirect (#123)",
+			expected: "Fix login redirect (#123)",
+		},
+		{
+			name:          "GitHub token",
+			text:          "Use token " + githubToken + " in CI",
+			expected:      "Use token [redacted] in CI",
+			expectedCount: 1,
+		},
+		{
+			name:          "Slack and AWS tokens",
+			text:          "Configure " + slackToken + " and " + awsKeyID,
+			expected:      "Configure [redacted] and [redacted]",
+			expectedCount: 2,
+		},
+		{
+			name:          "random string",
+			text:          "Rotate key=" + randomString,
+			expected:      "Rotate key=[redacted]",
+			expectedCount: 1,
+		},
+		{
+			name:     "identifiers and versions are not redacted",
+			text:     "Add AddReviewEffortInfo2025Q1Update and TestFindOpenPRs_APICallBudget for v1.2.3",
+			expected: "Add AddReviewEffortInfo2025Q1Update and TestFindOpenPRs_APICallBudget for v1.2.3",
+		},
+		{
+			name:     "commit SHAs and branch paths are not redacted",
+			text:     "Revert 3f786850e387550fdab836ed7e6dc881de23001b from dependabot/npm_and_yarn/eslint-9.12.0",
+			expected: "Revert 3f786850e387550fdab836ed7e6dc881de23001b from dependabot/npm_and_yarn/eslint-9.12.0",
+		},
+		{
+			name:     "prefixes within words are not redacted",
+			text:     "Refactor task_manager_refactoring_v2_module and sk-learn-upgrade-to-version",
+			expected: "Refactor task_manager_refactoring_v2_module and sk-learn-upgrade-to-version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redacted, count := secretredaction.RedactSecrets(tt.text)
+			if redacted != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, redacted)
+			}
+			if count != tt.expectedCount {
+				t.Errorf("Expected %d redacted secrets, got %d", tt.expectedCount, count)
+			}
+		})
+	}
+}
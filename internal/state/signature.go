@@ -0,0 +1,59 @@
+package state
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidSignature is returned when loading a state that is not signed with the signing key.
+// In public repositories anyone who can run a workflow can upload an artifact with the name of the
+// state artifact, so a state without a valid signature cannot be trusted.
+var ErrInvalidSignature = errors.New("state signature is missing or invalid")
+
+// Signs the state with the key, i.e. sets the signature to the HMAC-SHA256 of the state without
+// the signature. The state is left unsigned if the key is empty.
+func (s *State) sign(signingKey string) error {
+	if signingKey == "" {
+		s.Signature = ""
+		return nil
+	}
+	signature, err := s.computeSignature(signingKey)
+	if err != nil {
+		return err
+	}
+	s.Signature = signature
+	return nil
+}
+
+// Verifies that the state was signed with the key. All states are accepted if the key is empty.
+func (s State) verifySignature(signingKey string) error {
+	if signingKey == "" {
+		return nil
+	}
+	if s.Signature == "" {
+		return fmt.Errorf("%w: the state is not signed", ErrInvalidSignature)
+	}
+	expected, err := s.computeSignature(signingKey)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(s.Signature), []byte(expected)) {
+		return fmt.Errorf("%w: the state was signed with a different key", ErrInvalidSignature)
+	}
+	return nil
+}
+
+func (s State) computeSignature(signingKey string) (string, error) {
+	s.Signature = ""
+	payload, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal state for signing: %w", err)
+	}
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
@@ -7,21 +7,36 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/clock"
 	"github.com/hellej/pr-slack-reminder-action/internal/models"
 	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
 	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
 )
 
-const CurrentSchemaVersion = 1
+// Schema version history:
+//   - 1: initial version
+//   - 2: PR references include GraphQL node IDs
+const CurrentSchemaVersion = 2
 
 type State struct {
 	SchemaVersion int                     `json:"schemaVersion"`
 	CreatedAt     time.Time               `json:"createdAt"`
 	SlackMessage  SlackRef                `json:"slackMessage"`
+	SlackMessages []SlackRef              `json:"slackMessages,omitempty"`
 	PullRequests  []models.PullRequestRef `json:"pullRequests"`
+	// True if no message was posted (no PRs and no no-prs-message), the state has no messages then
+	NoMessagePosted bool `json:"noMessagePosted,omitempty"`
+	// Thread replies to the reviewers under the (first) message, refreshed in the update run-mode
+	ReviewerThreads []ReviewerThreadRef `json:"reviewerThreads,omitempty"`
+	// Idempotency key of the run that posted the messages (run ID and date), with which a re-run of
+	// the same run is recognized
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+	// HMAC-SHA256 of the state, set if the state was saved with a state-signing-key
+	Signature string `json:"signature,omitempty"`
 }
 
 type SlackRef struct {
@@ -29,40 +44,135 @@ type SlackRef struct {
 	MessageTS string `json:"messageTs"`
 }
 
+// ReviewerThreadRef is a thread reply that lists the PRs waiting for the review of the reviewer.
+type ReviewerThreadRef struct {
+	ReviewerLogin string `json:"reviewerLogin"`
+	ChannelID     string `json:"channelId"`
+	MessageTS     string `json:"messageTs"`
+}
+
 type StateArtifactFetcher interface {
 	FetchLatestArtifactByName(
 		ctx context.Context,
-		owner, repo, artifactName, jsonFilePath string,
+		owner, repo, artifactName, branch, jsonFilePath string,
 		target any,
 	) error
 }
 
 func PRToPullRequestRef(pr prparser.PR) models.PullRequestRef {
 	return models.PullRequestRef{
-		Repository: pr.Repository,
-		Number:     *pr.Number,
+		Repository:    pr.Repository,
+		Number:        pr.Number,
+		NodeID:        pr.NodeID,
+		ReminderCount: pr.ReminderCount,
+	}
+}
+
+// CountReminders sets the reminder counts of the PRs of a new reminder: the counts of the PRs that
+// were included in the previous reminder are incremented (they are carried over), while the other PRs
+// are in their first reminder. The previous state may be nil (e.g. on the first run).
+func CountReminders(prs []prparser.PR, previousState *State) []prparser.PR {
+	for i, pr := range prs {
+		prs[i].ReminderCount = 1
+		if previousState == nil {
+			continue
+		}
+		if ref, found := findPullRequestRef(previousState.PullRequests, pr); found {
+			// PRs of states saved before reminders were counted have been in at least one reminder
+			prs[i].ReminderCount = max(ref.ReminderCount, 1) + 1
+		}
+	}
+	return prs
+}
+
+// KeepReminderCounts sets the reminder counts of the PRs from the state when the reminder
+// of the state is updated (the updated message is not a new reminder).
+func KeepReminderCounts(prs []prparser.PR, loadedState State) []prparser.PR {
+	for i, pr := range prs {
+		if ref, found := findPullRequestRef(loadedState.PullRequests, pr); found {
+			prs[i].ReminderCount = ref.ReminderCount
+		}
+	}
+	return prs
+}
+
+// FindRemovedPRRefs returns the PRs of the previous reminder that are not in the new reminder
+// (e.g. merged or closed since, or no longer listed for other reasons).
+func FindRemovedPRRefs(prs []prparser.PR, previousState State) []models.PullRequestRef {
+	return utilities.Filter(previousState.PullRequests, func(ref models.PullRequestRef) bool {
+		return !slices.ContainsFunc(prs, func(pr prparser.PR) bool { return isRefOfPR(ref, pr) })
+	})
+}
+
+func findPullRequestRef(refs []models.PullRequestRef, pr prparser.PR) (models.PullRequestRef, bool) {
+	return utilities.Find(refs, func(ref models.PullRequestRef) bool { return isRefOfPR(ref, pr) })
+}
+
+// Matches the reference to the PR by node ID if available (as it stays the same if the repository
+// is moved), otherwise by the repository and number.
+func isRefOfPR(ref models.PullRequestRef, pr prparser.PR) bool {
+	if ref.NodeID != "" && pr.NodeID != "" {
+		return ref.NodeID == pr.NodeID
 	}
+	return ref.Repository == pr.Repository && ref.Number == pr.Number
 }
 
+// Loads the state from the latest artifact of workflow runs on the branch (see FetchLatestArtifactByName).
+// If the signing key is set, the state must have been signed with it (otherwise ErrInvalidSignature
+// is returned).
 func Load(
 	ctx context.Context,
 	reader StateArtifactFetcher,
 	repository models.Repository,
 	artifactName string,
+	branch string,
 	stateFilePath string,
+	signingKey string,
 ) (*State, error) {
 	var state State
 	if err := reader.FetchLatestArtifactByName(
 		ctx,
 		repository.Owner, repository.Name,
-		artifactName, stateFilePath,
+		artifactName, branch, stateFilePath,
 		&state,
 	); err != nil {
 		return nil, err
 	}
+	if err := state.verifySignature(signingKey); err != nil {
+		return nil, err
+	}
+	state.migrate()
 	return &state, nil
 }
 
+// Migrates a state saved with an older schema version to the current one.
+func (s *State) migrate() {
+	if s.SchemaVersion == 1 {
+		// Node IDs of the PRs are added when the state is saved again after fetching the PRs
+		log.Printf("Migrating state from schema version 1 to 2 (PR node IDs are not available)")
+		s.SchemaVersion = 2
+	}
+}
+
+// GetSlackRefs returns the Slack messages of all workspaces the reminder was sent to (in the order
+// in which they were posted, as the reminder may be split into several messages by repository).
+// States saved with a single workspace only have the SlackMessage field set.
+func (s *State) GetSlackRefs() []SlackRef {
+	if s.NoMessagePosted {
+		return nil
+	}
+	if len(s.SlackMessages) > 0 {
+		return s.SlackMessages
+	}
+	return []SlackRef{s.SlackMessage}
+}
+
+// IsPostedByRun returns true if the messages of the state were posted by the run with the idempotency
+// key, i.e. the run is a re-run of the one that saved the state.
+func (s *State) IsPostedByRun(idempotencyKey string) bool {
+	return idempotencyKey != "" && s.IdempotencyKey == idempotencyKey
+}
+
 func (s *State) Validate() error {
 	if s.SchemaVersion != CurrentSchemaVersion {
 		return fmt.Errorf("unsupported schema version %d, expected %d", s.SchemaVersion, CurrentSchemaVersion)
@@ -72,21 +182,67 @@ func (s *State) Validate() error {
 
 func SavePostState(
 	filePath string,
+	signingKey string,
+	idempotencyKey string,
 	parsedPRs []prparser.PR,
-	messageInfo slackclient.SentMessageInfo,
+	reviewerThreads []ReviewerThreadRef,
+	messageInfos ...slackclient.SentMessageInfo,
 ) error {
 	return savePostState(
 		filePath,
+		signingKey,
+		idempotencyKey,
 		utilities.Map(parsedPRs, PRToPullRequestRef),
-		SlackRef{
-			ChannelID: messageInfo.ChannelID,
-			MessageTS: messageInfo.Timestamp,
-		})
+		utilities.Map(messageInfos, func(messageInfo slackclient.SentMessageInfo) SlackRef {
+			return SlackRef{
+				ChannelID: messageInfo.ChannelID,
+				MessageTS: messageInfo.Timestamp,
+			}
+		}),
+		reviewerThreads,
+	)
 }
 
+// SaveUpdatedState saves the state with the current PRs after updating the messages
+// of a previous state. The creation time and Slack messages of the previous state are
+// kept, so that the age of the messages is tracked from when they were first posted.
+func SaveUpdatedState(filePath, signingKey string, parsedPRs []prparser.PR, previousState State) error {
+	stateToSave := previousState
+	stateToSave.SchemaVersion = CurrentSchemaVersion
+	stateToSave.PullRequests = utilities.Map(parsedPRs, PRToPullRequestRef)
+
+	if err := Save(filePath, signingKey, stateToSave); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	log.Printf("Saved updated state to %s with %d PRs", filePath, len(stateToSave.PullRequests))
+	return nil
+}
+
+// SentBlocksEnvelope is the format of the sent blocks file with metadata of the message
+// (documented in docs/sent-slack-blocks.schema.json).
+type SentBlocksEnvelope struct {
+	GeneratedAt    time.Time         `json:"generatedAt"`
+	RunMode        string            `json:"runMode"`
+	ChannelID      string            `json:"channelId"`
+	Summary        string            `json:"summary"`
+	IdempotencyKey string            `json:"idempotencyKey,omitempty"`
+	Blocks         []json.RawMessage `json:"blocks"`
+}
+
+// SentBlocksMetadata is the metadata of the sent message saved in the envelope.
+type SentBlocksMetadata struct {
+	RunMode        string
+	ChannelID      string
+	Summary        string
+	IdempotencyKey string
+}
+
+// Saves the sent blocks in an envelope with the metadata, or as a bare array of blocks
+// if the metadata is nil (the legacy format).
 func SaveSentSlackBlocks(
 	filePath string,
 	sentBlocks []string,
+	metadata *SentBlocksMetadata,
 ) error {
 	dir := filepath.Dir(filePath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -94,7 +250,7 @@ func SaveSentSlackBlocks(
 	}
 
 	// Parse JSON strings back to raw JSON objects to avoid double-encoding
-	var parsedBlocks []json.RawMessage
+	parsedBlocks := []json.RawMessage{}
 	for i, blockJSON := range sentBlocks {
 		var rawMessage json.RawMessage
 		if err := json.Unmarshal([]byte(blockJSON), &rawMessage); err != nil {
@@ -103,7 +259,18 @@ func SaveSentSlackBlocks(
 		parsedBlocks = append(parsedBlocks, rawMessage)
 	}
 
-	jsonData, err := json.MarshalIndent(parsedBlocks, "", "  ")
+	var content any = parsedBlocks
+	if metadata != nil {
+		content = SentBlocksEnvelope{
+			GeneratedAt:    clock.Now().UTC(),
+			RunMode:        metadata.RunMode,
+			ChannelID:      metadata.ChannelID,
+			Summary:        metadata.Summary,
+			IdempotencyKey: metadata.IdempotencyKey,
+			Blocks:         parsedBlocks,
+		}
+	}
+	jsonData, err := json.MarshalIndent(content, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal sent blocks: %w", err)
 	}
@@ -115,26 +282,74 @@ func SaveSentSlackBlocks(
 	return nil
 }
 
-func savePostState(filePath string, pullRequestRefs []models.PullRequestRef, slackRef SlackRef) error {
+// SaveWebhookPostState saves the state after posting a message via a webhook (e.g. to Google Chat).
+// Webhook messages cannot be updated, so the state has no message references.
+func SaveWebhookPostState(filePath, signingKey string, parsedPRs []prparser.PR) error {
 	stateToSave := State{
 		SchemaVersion: CurrentSchemaVersion,
-		CreatedAt:     time.Now(),
-		SlackMessage:  slackRef,
-		PullRequests:  pullRequestRefs,
+		CreatedAt:     clock.Now(),
+		PullRequests:  utilities.Map(parsedPRs, PRToPullRequestRef),
 	}
+	if err := Save(filePath, signingKey, stateToSave); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	log.Printf("Saved state to %s with %d PRs", filePath, len(parsedPRs))
+	return nil
+}
 
-	if err := Save(filePath, stateToSave); err != nil {
+// SaveNoMessageState saves a state without PRs and messages when no message was posted, so that
+// the runs of the update mode later on have a state to load (and nothing to update).
+func SaveNoMessageState(filePath, signingKey string) error {
+	stateToSave := State{
+		SchemaVersion:   CurrentSchemaVersion,
+		CreatedAt:       clock.Now(),
+		PullRequests:    []models.PullRequestRef{},
+		NoMessagePosted: true,
+	}
+	if err := Save(filePath, signingKey, stateToSave); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	log.Printf("Saved state to %s without a message (no message was posted)", filePath)
+	return nil
+}
+
+func savePostState(
+	filePath, signingKey, idempotencyKey string,
+	pullRequestRefs []models.PullRequestRef,
+	slackRefs []SlackRef,
+	reviewerThreads []ReviewerThreadRef,
+) error {
+	if len(slackRefs) == 0 {
+		return fmt.Errorf("failed to save state: no Slack messages to save")
+	}
+	stateToSave := State{
+		SchemaVersion:   CurrentSchemaVersion,
+		CreatedAt:       clock.Now(),
+		SlackMessage:    slackRefs[0],
+		PullRequests:    pullRequestRefs,
+		ReviewerThreads: reviewerThreads,
+		IdempotencyKey:  idempotencyKey,
+	}
+	if len(slackRefs) > 1 {
+		stateToSave.SlackMessages = slackRefs
+	}
+
+	if err := Save(filePath, signingKey, stateToSave); err != nil {
 		return fmt.Errorf("failed to save state: %w", err)
 	}
 	log.Printf("Saved state to %s with %d PRs", filePath, len(pullRequestRefs))
 	return nil
 }
 
-func Save(filePath string, state State) error {
+// Saves the state to the file, signed with the signing key if it is set.
+func Save(filePath, signingKey string, state State) error {
 	dir := filepath.Dir(filePath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory %s: %w", dir, err)
 	}
+	if err := state.sign(signingKey); err != nil {
+		return err
+	}
 
 	jsonData, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
@@ -10,12 +10,9 @@ import (
 	"testing"
 	"time"
 
-	"github.com/google/go-github/v78/github"
-	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
 	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
 	"github.com/hellej/pr-slack-reminder-action/internal/models"
 	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
-	"github.com/hellej/pr-slack-reminder-action/testhelpers"
 )
 
 func LoadFromFile(filePath string) (*State, error) {
@@ -62,10 +59,7 @@ func createTestState() State {
 
 func createTestPR(number int, owner, repo string) prparser.PR {
 	return prparser.PR{
-		PR: &githubclient.PR{
-			PullRequest: &github.PullRequest{Number: testhelpers.AsPointer(number)},
-			Repository:  models.NewRepository(owner, repo),
-		},
+		PullRequest: models.PullRequest{Repository: models.NewRepository(owner, repo), Number: number},
 	}
 }
 
@@ -87,7 +81,7 @@ func TestStateSaveAndLoadRoundTrip(t *testing.T) {
 		},
 	}
 
-	err := Save(statePath, originalState)
+	err := Save(statePath, "", originalState)
 	if err != nil {
 		t.Fatalf("Save failed: %v", err)
 	}
@@ -210,7 +204,7 @@ func TestSaveSentSlackBlocksProperJSON(t *testing.T) {
 		`{"type":"rich_text","block_id":"open_prs","elements":[{"type":"rich_text_list","elements":[{"type":"rich_text_section","elements":[{"type":"link","url":"https://github.com/owner/repo/pull/1","text":"Test PR","style":{"bold":true}}]}],"style":"bullet"}]}`,
 	}
 
-	err := SaveSentSlackBlocks(filePath, slackBlocksJSON)
+	err := SaveSentSlackBlocks(filePath, slackBlocksJSON, nil)
 	if err != nil {
 		t.Fatalf("SaveSentSlackBlocks failed: %v", err)
 	}
@@ -246,11 +240,45 @@ func TestSaveSentSlackBlocksProperJSON(t *testing.T) {
 	}
 }
 
+func TestSaveSentSlackBlocksEnvelope(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "sent-blocks.json")
+	slackBlocksJSON := []string{`{"type":"rich_text","block_id":"open_prs"}`}
+	metadata := &SentBlocksMetadata{RunMode: "post", ChannelID: "C12345", Summary: "1 open PR is waiting for attention 👀"}
+
+	if err := SaveSentSlackBlocks(filePath, slackBlocksJSON, metadata); err != nil {
+		t.Fatalf("SaveSentSlackBlocks failed: %v", err)
+	}
+
+	fileContent, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read saved file: %v", err)
+	}
+	var envelope SentBlocksEnvelope
+	if err := json.Unmarshal(fileContent, &envelope); err != nil {
+		t.Fatalf("Saved file is not a valid envelope: %v", err)
+	}
+	if envelope.RunMode != "post" || envelope.ChannelID != "C12345" || envelope.Summary != metadata.Summary {
+		t.Errorf("Expected metadata %+v in the envelope, got %+v", *metadata, envelope)
+	}
+	if time.Since(envelope.GeneratedAt) > time.Minute {
+		t.Errorf("Expected generatedAt to be the current time, got %v", envelope.GeneratedAt)
+	}
+	var blocks []map[string]any
+	if err := json.Unmarshal(fileContent, &struct {
+		Blocks *[]map[string]any `json:"blocks"`
+	}{&blocks}); err != nil {
+		t.Fatalf("Failed to parse blocks of the envelope: %v", err)
+	}
+	if len(blocks) != 1 || blocks[0]["block_id"] != "open_prs" {
+		t.Errorf("Expected the block in the envelope, got %v", blocks)
+	}
+}
+
 func TestSaveSentSlackBlocksEmptySlice(t *testing.T) {
 	tempDir := t.TempDir()
 	filePath := filepath.Join(tempDir, "empty-blocks.json")
 
-	err := SaveSentSlackBlocks(filePath, []string{})
+	err := SaveSentSlackBlocks(filePath, []string{}, nil)
 	if err != nil {
 		t.Fatalf("SaveSentSlackBlocks failed with empty slice: %v", err)
 	}
@@ -279,7 +307,7 @@ func TestSaveSentSlackBlocksInvalidJSON(t *testing.T) {
 		`{"type":"rich_text",`, // Incomplete JSON
 	}
 
-	err := SaveSentSlackBlocks(filePath, invalidJSON)
+	err := SaveSentSlackBlocks(filePath, invalidJSON, nil)
 	if err == nil {
 		t.Fatal("Expected error when saving invalid JSON, got nil")
 	}
@@ -294,7 +322,7 @@ func TestSaveDirectoryCreationFailure(t *testing.T) {
 	statePath := filepath.Join(readOnlyDir, "nested", "state.json")
 	state := createTestState()
 
-	err := Save(statePath, state)
+	err := Save(statePath, "", state)
 	if err == nil {
 		t.Fatal("Expected error when creating directory in read-only parent, got nil")
 	}
@@ -311,7 +339,7 @@ func TestSaveSentSlackBlocksDirectoryCreationFailure(t *testing.T) {
 		`{"type":"rich_text","block_id":"test"}`,
 	}
 
-	err := SaveSentSlackBlocks(filePath, slackBlocksJSON)
+	err := SaveSentSlackBlocks(filePath, slackBlocksJSON, nil)
 	if err == nil {
 		t.Fatal("Expected error when creating directory in read-only parent, got nil")
 	}
@@ -326,7 +354,7 @@ func TestSaveFileWriteFailure(t *testing.T) {
 	statePath := filepath.Join(readOnlyDir, "state.json")
 	state := createTestState()
 
-	err := Save(statePath, state)
+	err := Save(statePath, "", state)
 	if err == nil {
 		t.Fatal("Expected error when writing to read-only directory, got nil")
 	}
@@ -343,7 +371,7 @@ func TestSaveSentSlackBlocksFileWriteFailure(t *testing.T) {
 		`{"type":"rich_text","block_id":"test"}`,
 	}
 
-	err := SaveSentSlackBlocks(filePath, slackBlocksJSON)
+	err := SaveSentSlackBlocks(filePath, slackBlocksJSON, nil)
 	if err == nil {
 		t.Fatal("Expected error when writing to read-only directory, got nil")
 	}
@@ -360,7 +388,7 @@ type mockStateArtifactFetcher struct {
 
 func (m *mockStateArtifactFetcher) FetchLatestArtifactByName(
 	ctx context.Context,
-	owner, repo, artifactName, jsonFilePath string,
+	owner, repo, artifactName, branch, jsonFilePath string,
 	target any,
 ) error {
 	if m.fetchError != nil {
@@ -392,7 +420,7 @@ func TestLoadSuccessful(t *testing.T) {
 	mockFetcher := &mockStateArtifactFetcher{state: expectedState}
 	repository := models.NewRepository("owner1", "repo1")
 
-	loadedState, err := Load(context.Background(), mockFetcher, repository, "test-artifact", "state.json")
+	loadedState, err := Load(context.Background(), mockFetcher, repository, "test-artifact", "main", "state.json", "")
 	if err != nil {
 		t.Fatalf("Load failed: %v", err)
 	}
@@ -410,12 +438,41 @@ func TestLoadSuccessful(t *testing.T) {
 	}
 }
 
+func TestLoadMigratesSchemaVersion1(t *testing.T) {
+	stateV1 := &State{
+		SchemaVersion: 1,
+		CreatedAt:     time.Now().UTC(),
+		SlackMessage: SlackRef{
+			ChannelID: "C123456789",
+			MessageTS: "1729123456.123456",
+		},
+		PullRequests: []models.PullRequestRef{
+			{Repository: models.NewRepository("owner1", "repo1"), Number: 1},
+		},
+	}
+
+	mockFetcher := &mockStateArtifactFetcher{state: stateV1}
+	loadedState, err := Load(
+		context.Background(), mockFetcher, models.NewRepository("owner1", "repo1"), "test-artifact", "main", "state.json", "",
+	)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if err := loadedState.Validate(); err != nil {
+		t.Errorf("Expected migrated state to be valid, got error: %v", err)
+	}
+	if loadedState.PullRequests[0].NodeID != "" {
+		t.Errorf("Expected node ID to be empty after migration, got %s", loadedState.PullRequests[0].NodeID)
+	}
+}
+
 func TestLoadFetchError(t *testing.T) {
 	expectedError := errors.New("artifact fetch failed")
 	mockFetcher := &mockStateArtifactFetcher{fetchError: expectedError}
 	repository := models.NewRepository("owner1", "repo1")
 
-	_, err := Load(context.Background(), mockFetcher, repository, "test-artifact", "state.json")
+	_, err := Load(context.Background(), mockFetcher, repository, "test-artifact", "main", "state.json", "")
 	if err == nil {
 		t.Fatal("Expected error from Load, got nil")
 	}
@@ -425,6 +482,60 @@ func TestLoadFetchError(t *testing.T) {
 	}
 }
 
+func TestLoadVerifiesSignature(t *testing.T) {
+	testCases := []struct {
+		name        string
+		savedWith   string
+		loadedWith  string
+		tamper      func(*State)
+		expectError bool
+	}{
+		{name: "signed state with the same key", savedWith: "secret", loadedWith: "secret"},
+		{name: "unsigned state without a key", savedWith: "", loadedWith: ""},
+		{name: "signed state without a key", savedWith: "secret", loadedWith: ""},
+		{name: "unsigned state with a key", savedWith: "", loadedWith: "secret", expectError: true},
+		{name: "signed state with a different key", savedWith: "other", loadedWith: "secret", expectError: true},
+		{
+			name:       "tampered state",
+			savedWith:  "secret",
+			loadedWith: "secret",
+			tamper: func(s *State) {
+				s.SlackMessage.ChannelID = "C999999999"
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			statePath := filepath.Join(t.TempDir(), "state.json")
+			if err := Save(statePath, tc.savedWith, createTestState()); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			// the state is read from the file as the signature must survive the JSON round trip
+			savedState, err := LoadFromFile(statePath)
+			if err != nil {
+				t.Fatalf("LoadFromFile failed: %v", err)
+			}
+			if tc.tamper != nil {
+				tc.tamper(savedState)
+			}
+
+			mockFetcher := &mockStateArtifactFetcher{state: savedState}
+			_, err = Load(
+				context.Background(), mockFetcher, models.NewRepository("owner1", "repo1"),
+				"test-artifact", "main", "state.json", tc.loadedWith,
+			)
+			if tc.expectError && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Expected ErrInvalidSignature, got: %v", err)
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
 func TestSavePostStateSuccessful(t *testing.T) {
 	tempDir := t.TempDir()
 	statePath := filepath.Join(tempDir, "post-state.json")
@@ -439,7 +550,7 @@ func TestSavePostStateSuccessful(t *testing.T) {
 		Timestamp: "1729123456.123456",
 	}
 
-	err := SavePostState(statePath, parsedPRs, messageInfo)
+	err := SavePostState(statePath, "", "", parsedPRs, nil, messageInfo)
 	if err != nil {
 		t.Fatalf("SavePostState failed: %v", err)
 	}
@@ -480,6 +591,94 @@ func TestSavePostStateSuccessful(t *testing.T) {
 	}
 }
 
+func TestIsPostedByRun(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "post-state.json")
+	messageInfo := slackclient.SentMessageInfo{ChannelID: "C123456789", Timestamp: "1729123456.123456"}
+	err := SavePostState(statePath, "", "123456789-2025-01-02", []prparser.PR{createTestPR(1, "owner1", "repo1")}, nil, messageInfo)
+	if err != nil {
+		t.Fatalf("SavePostState failed: %v", err)
+	}
+	loadedState, err := LoadFromFile(statePath)
+	if err != nil {
+		t.Fatalf("Failed to load saved state: %v", err)
+	}
+
+	testCases := []struct {
+		name           string
+		idempotencyKey string
+		expected       bool
+	}{
+		{name: "same run", idempotencyKey: "123456789-2025-01-02", expected: true},
+		{name: "same run on another day", idempotencyKey: "123456789-2025-01-03", expected: false},
+		{name: "another run", idempotencyKey: "987654321-2025-01-02", expected: false},
+		{name: "key not known", idempotencyKey: "", expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := loadedState.IsPostedByRun(tc.idempotencyKey); got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSavePostStateMultipleWorkspaces(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "post-state.json")
+	parsedPRs := []prparser.PR{createTestPR(1, "owner1", "repo1")}
+
+	messageInfos := []slackclient.SentMessageInfo{
+		{ChannelID: "C123456789", Timestamp: "1729123456.123456"},
+		{ChannelID: "C987654321", Timestamp: "1729123456.654321"},
+	}
+
+	if err := SavePostState(statePath, "", "", parsedPRs, nil, messageInfos...); err != nil {
+		t.Fatalf("SavePostState failed: %v", err)
+	}
+
+	loadedState, err := LoadFromFile(statePath)
+	if err != nil {
+		t.Fatalf("Failed to load saved state: %v", err)
+	}
+
+	if loadedState.SlackMessage.ChannelID != messageInfos[0].ChannelID {
+		t.Errorf("SlackMessage should be the first message: got %+v", loadedState.SlackMessage)
+	}
+	slackRefs := loadedState.GetSlackRefs()
+	if len(slackRefs) != len(messageInfos) {
+		t.Fatalf("Expected %d Slack messages, got %d", len(messageInfos), len(slackRefs))
+	}
+	for i, messageInfo := range messageInfos {
+		if slackRefs[i].ChannelID != messageInfo.ChannelID || slackRefs[i].MessageTS != messageInfo.Timestamp {
+			t.Errorf("Slack message %d mismatch: got %+v, want %+v", i, slackRefs[i], messageInfo)
+		}
+	}
+}
+
+func TestSaveNoMessageState(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "no-message-state.json")
+
+	if err := SaveNoMessageState(statePath, ""); err != nil {
+		t.Fatalf("SaveNoMessageState failed: %v", err)
+	}
+
+	loadedState, err := LoadFromFile(statePath)
+	if err != nil {
+		t.Fatalf("Failed to load saved state: %v", err)
+	}
+	if err := loadedState.Validate(); err != nil {
+		t.Errorf("Expected a valid state, got: %v", err)
+	}
+	if !loadedState.NoMessagePosted {
+		t.Error("Expected the state to record that no message was posted")
+	}
+	if len(loadedState.PullRequests) != 0 {
+		t.Errorf("Expected no PRs, got %d", len(loadedState.PullRequests))
+	}
+	if refs := loadedState.GetSlackRefs(); len(refs) != 0 {
+		t.Errorf("Expected no Slack messages, got %+v", refs)
+	}
+}
+
 func TestSavePostStateWriteFailure(t *testing.T) {
 	readOnlyDir := setupReadOnlyDir(t)
 	statePath := filepath.Join(readOnlyDir, "post-state.json")
@@ -490,7 +689,7 @@ func TestSavePostStateWriteFailure(t *testing.T) {
 		Timestamp: "1729123456.123456",
 	}
 
-	err := SavePostState(statePath, parsedPRs, messageInfo)
+	err := SavePostState(statePath, "", "", parsedPRs, nil, messageInfo)
 	if err == nil {
 		t.Fatal("Expected error when writing to read-only directory, got nil")
 	}
@@ -517,3 +716,102 @@ func TestPRToPullRequestRef(t *testing.T) {
 		t.Errorf("Expected Name to be 'test-repo', got %s", ref.Repository.Name)
 	}
 }
+
+func TestPRToPullRequestRefIncludesNodeID(t *testing.T) {
+	pr := createTestPR(123, "test-owner", "test-repo")
+	pr.NodeID = "PR_kwDOABCDEF4AAAAB"
+
+	ref := PRToPullRequestRef(pr)
+
+	if ref.NodeID != "PR_kwDOABCDEF4AAAAB" {
+		t.Errorf("Expected NodeID to be 'PR_kwDOABCDEF4AAAAB', got %s", ref.NodeID)
+	}
+
+	asJSON, err := json.Marshal(PRToPullRequestRef(createTestPR(1, "test-owner", "test-repo")))
+	if err != nil {
+		t.Fatalf("Failed to marshal ref: %v", err)
+	}
+	if strings.Contains(string(asJSON), "nodeId") {
+		t.Errorf("Expected empty node ID to be omitted, got %s", asJSON)
+	}
+}
+
+func TestCountReminders(t *testing.T) {
+	movedPR := createTestPR(4, "test-owner", "renamed-repo")
+	movedPR.NodeID = "PR_moved"
+	previousState := &State{
+		PullRequests: []models.PullRequestRef{
+			{Repository: models.NewRepository("test-owner", "test-repo"), Number: 1, ReminderCount: 2},
+			{Repository: models.NewRepository("test-owner", "test-repo"), Number: 2}, // saved before counting
+			{Repository: models.NewRepository("test-owner", "test-repo"), Number: 4, NodeID: "PR_moved", ReminderCount: 5},
+		},
+	}
+	testCases := []struct {
+		name           string
+		previousState  *State
+		expectedCounts []int
+	}{
+		{name: "no previous state", previousState: nil, expectedCounts: []int{1, 1, 1, 1}},
+		{name: "PRs carried over from previous state", previousState: previousState, expectedCounts: []int{3, 2, 1, 6}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []prparser.PR{
+				createTestPR(1, "test-owner", "test-repo"),
+				createTestPR(2, "test-owner", "test-repo"),
+				createTestPR(3, "test-owner", "test-repo"),
+				movedPR,
+			}
+
+			prs = CountReminders(prs, tc.previousState)
+
+			for i, pr := range prs {
+				if pr.ReminderCount != tc.expectedCounts[i] {
+					t.Errorf("Expected reminder count %d for PR %d, got %d", tc.expectedCounts[i], pr.Number, pr.ReminderCount)
+				}
+				if ref := PRToPullRequestRef(pr); ref.ReminderCount != tc.expectedCounts[i] {
+					t.Errorf("Expected reminder count %d in the ref of PR %d, got %d", tc.expectedCounts[i], pr.Number, ref.ReminderCount)
+				}
+			}
+		})
+	}
+}
+
+func TestFindRemovedPRRefs(t *testing.T) {
+	movedPR := createTestPR(3, "test-owner", "renamed-repo")
+	movedPR.NodeID = "PR_moved"
+	previousState := State{
+		PullRequests: []models.PullRequestRef{
+			{Repository: models.NewRepository("test-owner", "test-repo"), Number: 1},
+			{Repository: models.NewRepository("test-owner", "test-repo"), Number: 2},
+			{Repository: models.NewRepository("test-owner", "test-repo"), Number: 3, NodeID: "PR_moved"},
+		},
+	}
+	prs := []prparser.PR{createTestPR(1, "test-owner", "test-repo"), movedPR}
+
+	removedPRRefs := FindRemovedPRRefs(prs, previousState)
+
+	if len(removedPRRefs) != 1 || removedPRRefs[0].Number != 2 {
+		t.Errorf("Expected only PR 2 to be removed (the moved PR is matched by node ID), got %+v", removedPRRefs)
+	}
+}
+
+func TestKeepReminderCounts(t *testing.T) {
+	loadedState := State{
+		PullRequests: []models.PullRequestRef{
+			{Repository: models.NewRepository("test-owner", "test-repo"), Number: 1, ReminderCount: 3},
+			{Repository: models.NewRepository("test-owner", "test-repo"), Number: 2},
+		},
+	}
+	prs := []prparser.PR{
+		createTestPR(1, "test-owner", "test-repo"),
+		createTestPR(2, "test-owner", "test-repo"),
+	}
+
+	prs = KeepReminderCounts(prs, loadedState)
+
+	if prs[0].ReminderCount != 3 || prs[1].ReminderCount != 0 {
+		t.Errorf("Expected reminder counts 3 and 0 to be kept, got %d and %d", prs[0].ReminderCount, prs[1].ReminderCount)
+	}
+}
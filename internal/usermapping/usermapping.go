@@ -0,0 +1,138 @@
+// Package usermapping suggests the github-user-slack-user-id-mapping input by matching the members
+// of GitHub organizations with the users of a Slack workspace by their emails and names, to bootstrap
+// the configuration of large teams. The suggestions are meant to be reviewed before use, as e.g. the
+// names of different people may match.
+package usermapping
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/slack-go/slack"
+)
+
+type MatchedBy string
+
+const (
+	MatchedByCurrentMapping MatchedBy = "current mapping"
+	MatchedByEmail          MatchedBy = "email"
+	MatchedByName           MatchedBy = "name"
+)
+
+type Suggestion struct {
+	GitHubLogin string
+	SlackUserID string
+	MatchedBy   MatchedBy
+}
+
+type Result struct {
+	Suggestions []Suggestion // sorted by GitHub login
+	Unmatched   []string     // GitHub logins without a matching Slack user, sorted
+}
+
+// Suggest matches the members with the Slack users. The users of the current mapping are kept as is,
+// the others are matched by email, or by name if exactly one Slack user has the name of the member.
+func Suggest(
+	members []githubclient.OrganizationMember, slackUsers []slack.User, currentMapping map[string]string,
+) Result {
+	var result Result
+	var seenLogins []string
+	for _, member := range members {
+		if slices.Contains(seenLogins, member.Login) { // members of several organizations
+			continue
+		}
+		seenLogins = append(seenLogins, member.Login)
+
+		if slackUserID := config.LookupSlackUserID(currentMapping, member.Login); slackUserID != "" {
+			result.Suggestions = append(result.Suggestions, Suggestion{member.Login, slackUserID, MatchedByCurrentMapping})
+		} else if user, ok := findByEmail(slackUsers, member.Email); ok {
+			result.Suggestions = append(result.Suggestions, Suggestion{member.Login, user.ID, MatchedByEmail})
+		} else if user, ok := findByName(slackUsers, member.Name); ok {
+			result.Suggestions = append(result.Suggestions, Suggestion{member.Login, user.ID, MatchedByName})
+		} else {
+			result.Unmatched = append(result.Unmatched, member.Login)
+		}
+	}
+	slices.SortFunc(result.Suggestions, func(a, b Suggestion) int {
+		return compareLogins(a.GitHubLogin, b.GitHubLogin)
+	})
+	slices.SortFunc(result.Unmatched, compareLogins)
+	return result
+}
+
+func compareLogins(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func findByEmail(slackUsers []slack.User, email string) (slack.User, bool) {
+	if email == "" {
+		return slack.User{}, false
+	}
+	index := slices.IndexFunc(slackUsers, func(user slack.User) bool {
+		return strings.EqualFold(user.Profile.Email, email)
+	})
+	if index < 0 {
+		return slack.User{}, false
+	}
+	return slackUsers[index], true
+}
+
+// Names are compared case-insensitively and ignoring extra whitespace. Matches are not
+// suggested if several Slack users have the name.
+func findByName(slackUsers []slack.User, name string) (slack.User, bool) {
+	name = normalizeName(name)
+	if name == "" {
+		return slack.User{}, false
+	}
+	var matches []slack.User
+	for _, user := range slackUsers {
+		names := []string{user.RealName, user.Profile.RealName, user.Profile.DisplayName}
+		if slices.ContainsFunc(names, func(n string) bool { return normalizeName(n) == name }) {
+			matches = append(matches, user)
+		}
+	}
+	if len(matches) != 1 {
+		return slack.User{}, false
+	}
+	return matches[0], true
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Text returns the suggestions in the format of the github-user-slack-user-id-mapping input,
+// grouped by how the users were matched (as comments), followed by the unmatched users.
+func (r Result) Text() string {
+	var b strings.Builder
+	b.WriteString("# Suggested github-user-slack-user-id-mapping, review the matches before use\n")
+	for _, group := range []struct {
+		matchedBy MatchedBy
+		heading   string
+	}{
+		{MatchedByCurrentMapping, "From the current mapping"},
+		{MatchedByEmail, "Matched by email"},
+		{MatchedByName, "Matched by name (check that these are the same people)"},
+	} {
+		suggestions := slices.DeleteFunc(slices.Clone(r.Suggestions), func(s Suggestion) bool {
+			return s.MatchedBy != group.matchedBy
+		})
+		if len(suggestions) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n# %s\n", group.heading)
+		for _, suggestion := range suggestions {
+			fmt.Fprintf(&b, "%s: %s\n", suggestion.GitHubLogin, suggestion.SlackUserID)
+		}
+	}
+	if len(r.Unmatched) > 0 {
+		b.WriteString("\n# No matching Slack user found\n")
+		for _, login := range r.Unmatched {
+			fmt.Fprintf(&b, "# %s\n", login)
+		}
+	}
+	return b.String()
+}
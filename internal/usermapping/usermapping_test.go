@@ -0,0 +1,111 @@
+package usermapping_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/usermapping"
+	"github.com/slack-go/slack"
+)
+
+func TestSuggest(t *testing.T) {
+	slackUser := func(id, realName, email string) slack.User {
+		return slack.User{ID: id, RealName: realName, Profile: slack.UserProfile{RealName: realName, Email: email}}
+	}
+	slackUsers := []slack.User{
+		slackUser("U1", "Alice Anderson", "alice@example.com"),
+		slackUser("U2", "Bob Brown", ""),
+		slackUser("U3", "Sam Smith", ""),
+		slackUser("U4", "Sam Smith", ""),
+		{ID: "U5", Profile: slack.UserProfile{DisplayName: "carol"}},
+	}
+
+	tests := []struct {
+		name              string
+		members           []githubclient.OrganizationMember
+		currentMapping    map[string]string
+		expectedSuggested []usermapping.Suggestion
+		expectedUnmatched []string
+	}{
+		{
+			name: "matched by email case-insensitively",
+			members: []githubclient.OrganizationMember{
+				{Login: "alice-gh", Name: "Someone Else", Email: "Alice@Example.com"},
+			},
+			expectedSuggested: []usermapping.Suggestion{{"alice-gh", "U1", usermapping.MatchedByEmail}},
+		},
+		{
+			name: "matched by real or display name",
+			members: []githubclient.OrganizationMember{
+				{Login: "bob-gh", Name: "bob  brown"},
+				{Login: "carol-gh", Name: "Carol"},
+			},
+			expectedSuggested: []usermapping.Suggestion{
+				{"bob-gh", "U2", usermapping.MatchedByName},
+				{"carol-gh", "U5", usermapping.MatchedByName},
+			},
+		},
+		{
+			name: "ambiguous names and members without a match are unmatched",
+			members: []githubclient.OrganizationMember{
+				{Login: "sam-gh", Name: "Sam Smith"},
+				{Login: "dave-gh", Name: "Dave", Email: "dave@example.com"},
+				{Login: "erin-gh"},
+			},
+			expectedUnmatched: []string{"dave-gh", "erin-gh", "sam-gh"},
+		},
+		{
+			name: "users of the current mapping are kept",
+			members: []githubclient.OrganizationMember{
+				{Login: "alice-gh", Email: "alice@example.com"},
+				{Login: "alice-gh", Email: "alice@example.com"}, // member of several organizations
+			},
+			currentMapping:    map[string]string{"alice-gh": "U9"},
+			expectedSuggested: []usermapping.Suggestion{{"alice-gh", "U9", usermapping.MatchedByCurrentMapping}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := usermapping.Suggest(tt.members, slackUsers, tt.currentMapping)
+
+			if !reflect.DeepEqual(result.Suggestions, tt.expectedSuggested) {
+				t.Errorf("Expected suggestions %+v, got %+v", tt.expectedSuggested, result.Suggestions)
+			}
+			if !reflect.DeepEqual(result.Unmatched, tt.expectedUnmatched) {
+				t.Errorf("Expected unmatched %v, got %v", tt.expectedUnmatched, result.Unmatched)
+			}
+		})
+	}
+}
+
+func TestResultText(t *testing.T) {
+	result := usermapping.Result{
+		Suggestions: []usermapping.Suggestion{
+			{"alice", "U1", usermapping.MatchedByEmail},
+			{"bob", "U2", usermapping.MatchedByName},
+			{"carol", "U3", usermapping.MatchedByEmail},
+		},
+		Unmatched: []string{"dave"},
+	}
+
+	expected := strings.Join([]string{
+		"# Suggested github-user-slack-user-id-mapping, review the matches before use",
+		"",
+		"# Matched by email",
+		"alice: U1",
+		"carol: U3",
+		"",
+		"# Matched by name (check that these are the same people)",
+		"bob: U2",
+		"",
+		"# No matching Slack user found",
+		"# dave",
+		"",
+	}, "\n")
+	if text := result.Text(); text != expected {
+		t.Errorf("Expected text:\n%s\ngot:\n%s", expected, text)
+	}
+}
@@ -0,0 +1,93 @@
+// Package blockids defines the block IDs of the Slack messages sent by the action.
+// The IDs are stable, so consumers of the sent blocks JSON (written to SENT_SLACK_BLOCKS_FILE_PATH)
+// can use them to find the parts of the message. The summary of the message is not a block
+// but the notification text of the message; if there are no PRs, it is shown in the NoPRs block.
+package blockids
+
+import "strings"
+
+const (
+	// Mention of the review captain (on-call user) above the PR lists
+	ReviewCaptain = "review_captain"
+	// How the PRs of the previous reminder were resolved (above the PR lists)
+	ResolvedPRs = "resolved_prs"
+	// Shown instead of the PR lists if there are no open PRs
+	NoPRs = "no_prs_block"
+	// Heading and list of the release PRs (listed before the other PRs)
+	ReleasePRsHeading = "release_prs_heading"
+	ReleasePRs        = "release_prs"
+	// Heading and list of the PRs (if not grouped by repository)
+	PRListHeading = "pr_list_heading"
+	PRList        = "open_prs"
+	// List of the repositories with the numbers of their PRs (message-style: digest), after PRListHeading
+	RepositoryDigest = "repository_digest"
+	// Footer blocks after the PR lists
+	RecentlyMergedHeading       = "recently_merged_heading"
+	RecentlyMerged              = "recently_merged"
+	FailingWorkflowsHeading     = "failing_workflows_heading"
+	FailingWorkflows            = "failing_workflows"
+	MilestoneProgress           = "milestone_progress"
+	SkippedArchivedRepositories = "skipped_archived_repositories"
+	QuietRepositories           = "quiet_repositories"
+	BranchProtectionWarning     = "branch_protection_warning"
+	RunReport                   = "run_report"
+	WorkflowRunLink             = "workflow_run_link"
+	// Mention of the reviewer and the list of the PRs waiting for them in a reviewer thread reply
+	ReviewerThreadHeading = "reviewer_thread_heading"
+	ReviewerThreadPRs     = "reviewer_thread_prs"
+	// Heading and condensed list of the old PRs in the message to the escalation channel
+	EscalationHeading = "escalation_heading"
+	EscalationPRs     = "escalation_prs"
+
+	repositoryHeadingPrefix = PRListHeading + "_"
+	repositoryPRListPrefix  = PRList + "_"
+	repositorySpacingPrefix = "repository_spacing_"
+	repositoryHiddenPrefix  = "hidden_prs_"
+)
+
+// RepositoryHeading returns the ID of the heading of the PRs of the repository
+// (if grouped by repository), e.g. "pr_list_heading_owner/repo".
+func RepositoryHeading(repositoryPath string) string {
+	return repositoryHeadingPrefix + repositoryPath
+}
+
+// RepositoryPRList returns the ID of the list of the PRs of the repository
+// (if grouped by repository), e.g. "open_prs_owner/repo".
+func RepositoryPRList(repositoryPath string) string {
+	return repositoryPRListPrefix + repositoryPath
+}
+
+// RepositorySpacing returns the ID of the empty block after the PRs of the repository
+// (if grouped by repository), e.g. "repository_spacing_owner/repo".
+func RepositorySpacing(repositoryPath string) string {
+	return repositorySpacingPrefix + repositoryPath
+}
+
+// RepositoryHiddenPRs returns the ID of the "and N more…" line after the PRs of the repository
+// (if max-prs-per-repo hides some of them), e.g. "hidden_prs_owner/repo".
+func RepositoryHiddenPRs(repositoryPath string) string {
+	return repositoryHiddenPrefix + repositoryPath
+}
+
+// IsHeading returns true for the IDs of the headings of PR lists.
+func IsHeading(blockID string) bool {
+	return blockID == PRListHeading || blockID == ReleasePRsHeading || blockID == ReviewerThreadHeading ||
+		blockID == EscalationHeading || strings.HasPrefix(blockID, repositoryHeadingPrefix)
+}
+
+// IsPRList returns true for the IDs of PR lists.
+func IsPRList(blockID string) bool {
+	return blockID == PRList || blockID == ReleasePRs || blockID == ReviewerThreadPRs || blockID == EscalationPRs ||
+		strings.HasPrefix(blockID, repositoryPRListPrefix)
+}
+
+// GetRepositoryPath returns the repository path of a repository heading or PR list ID,
+// or an empty string if the ID is not specific to a repository.
+func GetRepositoryPath(blockID string) string {
+	for _, prefix := range []string{repositoryHeadingPrefix, repositoryPRListPrefix, repositorySpacingPrefix, repositoryHiddenPrefix} {
+		if path, found := strings.CutPrefix(blockID, prefix); found {
+			return path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+package blockids_test
+
+import (
+	"testing"
+
+	"github.com/hellej/pr-slack-reminder-action/pkg/blockids"
+)
+
+func TestBlockIDs(t *testing.T) {
+	testCases := []struct {
+		blockID            string
+		expectedHeading    bool
+		expectedPRList     bool
+		expectedRepository string
+	}{
+		{blockID: blockids.PRListHeading, expectedHeading: true},
+		{blockID: blockids.PRList, expectedPRList: true},
+		{blockID: blockids.ReleasePRsHeading, expectedHeading: true},
+		{blockID: blockids.ReleasePRs, expectedPRList: true},
+		{blockID: blockids.ReviewerThreadHeading, expectedHeading: true},
+		{blockID: blockids.ReviewerThreadPRs, expectedPRList: true},
+		{blockID: blockids.EscalationHeading, expectedHeading: true},
+		{blockID: blockids.EscalationPRs, expectedPRList: true},
+		{blockID: blockids.RepositoryHeading("org/repo"), expectedHeading: true, expectedRepository: "org/repo"},
+		{blockID: blockids.RepositoryPRList("org/repo"), expectedPRList: true, expectedRepository: "org/repo"},
+		{blockID: blockids.RepositorySpacing("org/repo"), expectedRepository: "org/repo"},
+		{blockID: blockids.RepositoryHiddenPRs("org/repo"), expectedRepository: "org/repo"},
+		{blockID: blockids.NoPRs},
+		{blockID: blockids.RepositoryDigest},
+		{blockID: blockids.RecentlyMergedHeading},
+		{blockID: blockids.RecentlyMerged},
+		{blockID: blockids.ResolvedPRs},
+		{blockID: blockids.FailingWorkflowsHeading},
+		{blockID: blockids.FailingWorkflows},
+		{blockID: blockids.QuietRepositories},
+		{blockID: blockids.BranchProtectionWarning},
+		{blockID: blockids.WorkflowRunLink},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.blockID, func(t *testing.T) {
+			if blockids.IsHeading(tc.blockID) != tc.expectedHeading {
+				t.Errorf("Expected IsHeading to be %v", tc.expectedHeading)
+			}
+			if blockids.IsPRList(tc.blockID) != tc.expectedPRList {
+				t.Errorf("Expected IsPRList to be %v", tc.expectedPRList)
+			}
+			if repository := blockids.GetRepositoryPath(tc.blockID); repository != tc.expectedRepository {
+				t.Errorf("Expected repository '%s', got '%s'", tc.expectedRepository, repository)
+			}
+		})
+	}
+}
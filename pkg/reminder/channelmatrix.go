@@ -0,0 +1,122 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagebuilder"
+	"github.com/hellej/pr-slack-reminder-action/internal/state"
+	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
+)
+
+// Posts a message to each channel of the channel matrix in parallel, listing the PRs that match the
+// filters of the channel. The PRs (and the rest of the content) are fetched from GitHub only once for
+// all channels. Failing to post to a channel does not stop posting to the others: the state is saved
+// with the messages that were sent, and the errors of all failed channels are returned together.
+func runChannelMatrixPostMode(
+	ctx context.Context,
+	githubClient githubclient.Client,
+	cfg config.Config,
+	getSlackClient func(token string) slackclient.Client,
+	sentMessageHandler func(slackclient.SentMessageInfo) error,
+) error {
+	previousState := loadPreviousState(ctx, githubClient, cfg)
+	fetchCtx, cancel := context.WithTimeout(ctx, prFetchTimeout)
+	defer cancel()
+	fetched, err := fetchOpenPRs(fetchCtx, githubClient, cfg)
+	if err != nil {
+		return err
+	}
+	markMessageBuildingStarted(ctx)
+	parsedPRs := parsePRs(fetched.prs, cfg, previousState)
+	writePRCounts(ctx, cfg, fetched, parsedPRs)
+	if err := appendMetrics(cfg, parsedPRs); err != nil {
+		return err
+	}
+	extras := fetchContentExtras(fetchCtx, githubClient, cfg, fetched.repositories, len(parsedPRs) > 0)
+
+	sentMessageInfos := make([]*slackclient.SentMessageInfo, len(cfg.ChannelMatrix))
+	// the errors of all channels are collected (instead of the first one) and none cancels the others
+	errs := make([]error, len(cfg.ChannelMatrix))
+	var postWaitGroup sync.WaitGroup
+	for i, entry := range cfg.ChannelMatrix {
+		i, entry := i, entry // https://golang.org/doc/faq#closures_and_goroutines
+		postWaitGroup.Add(1)
+		go func() {
+			defer postWaitGroup.Done()
+			sentMessageInfos[i], errs[i] = postToMatrixChannel(
+				ctx, getSlackClient, cfg, entry, filterFetchedPRs(fetched, entry.Filters), extras, previousState,
+			)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("failed to post to channel %s: %w", entry.GetChannel(), errs[i])
+			}
+		}()
+	}
+	postWaitGroup.Wait()
+
+	sent := utilities.Filter(sentMessageInfos, func(info *slackclient.SentMessageInfo) bool { return info != nil })
+	if len(sent) == 0 {
+		if err := errors.Join(errs...); err != nil {
+			return err
+		}
+		return exitWithoutMessage(cfg)
+	}
+	if err := state.SavePostState(
+		cfg.StateFilePath, cfg.StateSigningKey, cfg.IdempotencyKey, parsedPRs, nil,
+		utilities.Map(sent, func(info *slackclient.SentMessageInfo) slackclient.SentMessageInfo { return *info })...,
+	); err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	if err := sentMessageHandler(*sent[0]); err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	return errors.Join(errs...)
+}
+
+// Returns the fetched PRs that match the filters of a channel.
+func filterFetchedPRs(fetched fetchedPRs, filters config.Filters) fetchedPRs {
+	prs := utilities.Filter(fetched.prs, func(pr githubclient.PR) bool { return pr.MatchesFilters(filters) })
+	fetched.filteredPRCount += len(fetched.prs) - len(prs)
+	fetched.prs = prs
+	return fetched
+}
+
+// Posts the message of the PRs to the channel. Returns nil if there is nothing to post to the channel.
+// The PR count trend is not shown, as the state of the previous run has the PRs of all channels.
+func postToMatrixChannel(
+	ctx context.Context,
+	getSlackClient func(token string) slackclient.Client,
+	cfg config.Config,
+	entry config.ChannelMatrixEntry,
+	fetched fetchedPRs,
+	extras contentExtras,
+	previousState *state.State,
+) (*slackclient.SentMessageInfo, error) {
+	target, err := getSlackTarget(ctx, cfg, getSlackClient(cfg.SlackBotToken), config.WorkspaceTarget{
+		SlackBotToken:    cfg.SlackBotToken,
+		SlackChannelID:   entry.SlackChannelID,
+		SlackChannelName: entry.SlackChannelName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	content := getContent(cfg, fetched, parsePRs(fetched.prs, cfg, previousState))
+	addContentExtras(&content, extras)
+	addRunReportSummary(ctx, &content, cfg)
+	if !content.HasPRs() && content.SummaryText == "" {
+		log.Printf("No PRs found for channel %s and no message configured for this case, skipping", entry.GetChannel())
+		return nil, nil
+	}
+	message, summaryText := messagebuilder.BuildMessage(content)
+	sentMessageInfo, err := target.client.SendMessage(ctx, target.channelID, message, summaryText)
+	if err != nil {
+		return nil, err
+	}
+	return &sentMessageInfo, nil
+}
@@ -0,0 +1,39 @@
+package reminder
+
+import (
+	"context"
+	"log"
+	"maps"
+	"slices"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/runreport"
+)
+
+// Returns the config without the Slack user IDs of the mapped users who currently have Do Not Disturb on
+// (respect-dnd), so that they are shown by name instead of being mentioned. If the statuses cannot be
+// fetched, all mapped users are mentioned as usual.
+func withoutUsersOnDND(ctx context.Context, slackClient slackclient.Client, cfg config.Config) config.Config {
+	mapping := cfg.ContentInputs.SlackUserIdByGitHubUsername
+	if len(mapping) == 0 {
+		return cfg
+	}
+	slackClient.SetRequestTimeout(cfg.SlackRequestTimeout)
+	usersOnDND, err := slackClient.GetUsersOnDND(ctx, slices.Compact(slices.Sorted(maps.Values(mapping))))
+	if err != nil {
+		runreport.FromContext(ctx).Add(runreport.KindSlack, "unable to check Do Not Disturb statuses: %v", err)
+		return cfg
+	}
+	if len(usersOnDND) == 0 {
+		return cfg
+	}
+	log.Printf("Not mentioning %d users who have Do Not Disturb on", len(usersOnDND))
+	cfg.ContentInputs.SlackUserIdByGitHubUsername = make(map[string]string, len(mapping))
+	for login, userID := range mapping {
+		if !slices.Contains(usersOnDND, userID) {
+			cfg.ContentInputs.SlackUserIdByGitHubUsername[login] = userID
+		}
+	}
+	return cfg
+}
@@ -0,0 +1,38 @@
+package reminder
+
+import (
+	"context"
+	"log"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagebuilder"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagecontent"
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+	"github.com/hellej/pr-slack-reminder-action/internal/runreport"
+)
+
+// Posts the condensed message of the old PRs to the escalation channel (in the workspace of the reminder)
+// if there are more old PRs than escalation-old-pr-count. The message is not saved to the state, as it
+// is not updated later. Failing to post it is not an error, as the PRs are listed in the reminder anyway.
+func postEscalation(ctx context.Context, slackClient slackclient.Client, cfg config.Config, prs []prparser.PR) {
+	content, exceeded := messagecontent.GetEscalationContent(prs, cfg.Escalation.OldPRCount)
+	if !exceeded {
+		return
+	}
+	channelName := cfg.Escalation.SlackChannelName
+	target, err := getSlackTarget(ctx, cfg, slackClient, config.WorkspaceTarget{
+		SlackBotToken:    cfg.SlackBotToken,
+		SlackChannelName: channelName,
+	})
+	if err != nil {
+		runreport.FromContext(ctx).Add(runreport.KindSlack, "failed to post to escalation channel %s: %v", channelName, err)
+		return
+	}
+	message, summaryText := messagebuilder.BuildEscalationMessage(content)
+	if _, err := target.client.SendMessage(ctx, target.channelID, message, summaryText); err != nil {
+		runreport.FromContext(ctx).Add(runreport.KindSlack, "failed to post to escalation channel %s: %v", channelName, err)
+		return
+	}
+	log.Printf("Posted %d old PRs to the escalation channel %s", len(content.PRs), channelName)
+}
@@ -0,0 +1,125 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/go-github/v78/github"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/slack-go/slack"
+)
+
+// Categories of the errors in the failure notifications (on-failure-webhook)
+const (
+	failureCategoryGitHub  = "GitHub API"
+	failureCategorySlack   = "Slack API"
+	failureCategoryTimeout = "timeout"
+	failureCategoryOther   = "other"
+)
+
+const failureWebhookTimeout = 10 * time.Second
+
+// The JSON posted to on-failure-webhook when a run fails. Slack incoming webhooks show the text,
+// other endpoints can also read the category and the workflow run URL.
+type failureNotification struct {
+	Text           string `json:"text"`
+	Category       string `json:"category"`
+	WorkflowRunURL string `json:"workflowRunUrl,omitempty"`
+}
+
+// runProgress records whether the run started building the message, as only the failures after
+// that are notified.
+type runProgress struct {
+	messageBuildingStarted atomic.Bool
+}
+
+type runProgressKey struct{}
+
+// Marks that the run started building the message (i.e. the PRs were fetched).
+func markMessageBuildingStarted(ctx context.Context) {
+	if progress, ok := ctx.Value(runProgressKey{}).(*runProgress); ok {
+		progress.messageBuildingStarted.Store(true)
+	}
+}
+
+// Runs the reminder and posts a failure notification to on-failure-webhook (if set) if the run fails
+// after it started building the message. Canceled runs are not notified.
+func runNotifyingFailure(ctx context.Context, cfg config.Config, run func(ctx context.Context) error) (err error) {
+	if cfg.OnFailureWebhookURL == "" {
+		return run(ctx)
+	}
+	progress := &runProgress{}
+	defer func() {
+		if err == nil || errors.Is(err, context.Canceled) || !progress.messageBuildingStarted.Load() {
+			return
+		}
+		if notifyErr := postFailureNotification(http.DefaultClient, cfg, err); notifyErr != nil {
+			log.Printf("Warning: failed to post the failure notification: %v", notifyErr)
+		}
+	}()
+	return run(context.WithValue(ctx, runProgressKey{}, progress))
+}
+
+func getFailureCategory(err error) string {
+	var githubErr *github.ErrorResponse
+	var githubRateLimitErr *github.RateLimitError
+	var githubAbuseRateLimitErr *github.AbuseRateLimitError
+	var slackErr slack.SlackErrorResponse
+	var slackRateLimitErr *slack.RateLimitedError
+	var slackStatusErr slack.StatusCodeError
+	switch {
+	case errors.As(err, &githubErr), errors.As(err, &githubRateLimitErr), errors.As(err, &githubAbuseRateLimitErr):
+		return failureCategoryGitHub
+	case errors.As(err, &slackErr), errors.As(err, &slackRateLimitErr), errors.As(err, &slackStatusErr):
+		return failureCategorySlack
+	case errors.Is(err, context.DeadlineExceeded):
+		return failureCategoryTimeout
+	default:
+		return failureCategoryOther
+	}
+}
+
+// Posts the category of the error instead of the error itself, as the webhook may be outside of the
+// organization (the error is logged when the run fails).
+func postFailureNotification(httpClient *http.Client, cfg config.Config, runErr error) error {
+	category := getFailureCategory(runErr)
+	notification := failureNotification{
+		Text:           fmt.Sprintf("⚠️ The PR reminder failed (error category: %s)", category),
+		Category:       category,
+		WorkflowRunURL: cfg.ContentInputs.WorkflowRunURL,
+	}
+	if notification.WorkflowRunURL != "" {
+		notification.Text += ", see the workflow run: " + notification.WorkflowRunURL
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode the failure notification: %w", err)
+	}
+	// the context of the run may be canceled already, e.g. if it timed out
+	ctx, cancel := context.WithTimeout(context.Background(), failureWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.OnFailureWebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create the failure notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send the failure notification: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to send the failure notification: unexpected status %d: %s", resp.StatusCode, respBody)
+	}
+	log.Printf("Posted the failure notification (error category: %s)", category)
+	return nil
+}
@@ -0,0 +1,58 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v78/github"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/testhelpers/mockslackclient"
+	"github.com/slack-go/slack"
+)
+
+func TestGetFailureCategory(t *testing.T) {
+	// The errors of the clients are wrapped on the way up, e.g. when the channel is resolved by name
+	slackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{
+		FindChannelError: slack.SlackErrorResponse{Err: "missing_scope"},
+	})
+	_, channelLookupErr := getSlackTarget(
+		context.Background(),
+		config.Config{SlackRequestTimeout: time.Second},
+		slackclient.NewClient(slackAPI),
+		config.WorkspaceTarget{SlackChannelName: "some-channel-name"},
+	)
+	if channelLookupErr == nil {
+		t.Fatal("Expected resolving the channel to fail")
+	}
+
+	testCases := []struct {
+		name             string
+		err              error
+		expectedCategory string
+	}{
+		{name: "wrapped Slack error", err: channelLookupErr, expectedCategory: failureCategorySlack},
+		{
+			name:             "wrapped GitHub error",
+			err:              fmt.Errorf("error fetching PRs: %w", &github.ErrorResponse{Message: "Not Found"}),
+			expectedCategory: failureCategoryGitHub,
+		},
+		{
+			name:             "timeout",
+			err:              fmt.Errorf("error fetching PRs: %w", context.DeadlineExceeded),
+			expectedCategory: failureCategoryTimeout,
+		},
+		{name: "other error", err: errors.New("something else"), expectedCategory: failureCategoryOther},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if category := getFailureCategory(tc.err); category != tc.expectedCategory {
+				t.Errorf("Expected category '%s', got '%s' (error: %v)", tc.expectedCategory, category, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package reminder
+
+import (
+	"context"
+	"log"
+	"slices"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/clock"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+	"github.com/hellej/pr-slack-reminder-action/internal/prdatacache"
+	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
+)
+
+func saveCachedPRs(cfg config.Config, fetched fetchedPRs) error {
+	return prdatacache.Save(cfg.PRDataCache.FilePath, prdatacache.Data{
+		CreatedAt:            clock.Now(),
+		PRs:                  fetched.prs,
+		Repositories:         fetched.repositories,
+		ArchivedRepositories: fetched.archivedRepositories,
+	})
+}
+
+// Reads the PRs from the PR data cache of the workflow run. The job that wrote the cache fetched the
+// PRs with its own inputs, so only the PRs of the configured repositories that pass the filters (and
+// repository-filters) of this job are kept. The PRs missing from the cache (e.g. excluded by stricter
+// filters of the writing job) cannot be listed.
+func loadCachedPRs(ctx context.Context, githubClient githubclient.Client, cfg config.Config) (fetchedPRs, error) {
+	data, err := prdatacache.Load(
+		ctx, githubClient, cfg.CurrentRepository,
+		cfg.PRDataCache.RunID, cfg.PRDataCache.ArtifactName, cfg.PRDataCache.FilePath,
+	)
+	if err != nil {
+		return fetchedPRs{}, err
+	}
+	isConfigured := func(repo models.Repository) bool { return slices.Contains(cfg.Repositories, repo) }
+	repositories := utilities.Filter(data.Repositories, isConfigured)
+	repositoryPRs := utilities.Filter(data.PRs, func(pr githubclient.PR) bool {
+		return slices.Contains(repositories, pr.Repository)
+	})
+	prs := utilities.Filter(repositoryPRs, func(pr githubclient.PR) bool {
+		return pr.MatchesFilters(cfg.GetFiltersForRepository(pr.Repository))
+	})
+	log.Printf("Using %d of the %d cached PRs", len(prs), len(data.PRs))
+	return fetchedPRs{
+		prs:                  prs,
+		filteredPRCount:      len(repositoryPRs) - len(prs),
+		repositories:         repositories,
+		archivedRepositories: utilities.Filter(data.ArchivedRepositories, isConfigured),
+	}, nil
+}
@@ -0,0 +1,98 @@
+// Package reminder runs the PR reminder as a library, e.g. to embed it in a bot or a scheduled job
+// instead of running it as a GitHub Action. The reminder is configured with the same inputs as the
+// action (see action.yml), which can be given as options instead of environment variables:
+//
+//	r, err := reminder.New(
+//		reminder.WithInput("github-token", githubToken),
+//		reminder.WithInput("slack-bot-token", slackBotToken),
+//		reminder.WithInput("slack-channel-name", "pr-reminders"),
+//		reminder.WithRepositories("owner/repo1", "owner/repo2"),
+//		reminder.WithEnv("GITHUB_REPOSITORY", "owner/repo1"),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	err = r.Run(ctx)
+//
+// The inputs that are not given as options are read from the environment (as in the action).
+package reminder
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/reminderoptions"
+)
+
+// Reminder is a configured reminder, that can be run (several times) with Run.
+type Reminder struct {
+	cfg             config.Config
+	getGitHubClient func(token, tokenForState string) githubclient.Client
+	getSlackClient  func(token string) slackclient.Client
+}
+
+// Option configures the reminder. The API clients are created from the tokens of the inputs.
+type Option func(*reminderoptions.Options)
+
+// WithInput sets the input by its name in action.yml, e.g. "github-token".
+func WithInput(name, value string) Option {
+	return func(o *reminderoptions.Options) {
+		o.Inputs[name] = value
+	}
+}
+
+// WithInputs sets the inputs by their names in action.yml.
+func WithInputs(inputs map[string]string) Option {
+	return func(o *reminderoptions.Options) {
+		maps.Copy(o.Inputs, inputs)
+	}
+}
+
+// WithRepositories sets the repositories (owner/repo) of which the PRs are included.
+func WithRepositories(repositories ...string) Option {
+	return WithInput(config.InputGithubRepositories, strings.Join(repositories, "\n"))
+}
+
+// WithEnv sets an environment variable that the action reads besides the inputs,
+// e.g. GITHUB_REPOSITORY (the default repository) or GITHUB_EVENT_PATH.
+func WithEnv(name, value string) Option {
+	return func(o *reminderoptions.Options) {
+		o.Env[name] = value
+	}
+}
+
+// WithPrintedConfig logs the configuration (with the secrets masked) when the reminder is created.
+func WithPrintedConfig() Option {
+	return func(o *reminderoptions.Options) {
+		o.PrintConfig = true
+	}
+}
+
+// New reads and validates the configuration of the reminder.
+func New(opts ...Option) (*Reminder, error) {
+	o := reminderoptions.Options{
+		Inputs:          map[string]string{},
+		Env:             map[string]string{},
+		GetGitHubClient: githubclient.GetAuthenticatedClient,
+		GetSlackClient:  slackclient.GetAuthenticatedClient,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	cfg, err := config.GetConfigWithOverrides(o.Inputs, o.Env)
+	if err != nil {
+		return nil, fmt.Errorf("configuration error: %v", err)
+	}
+	if o.PrintConfig {
+		cfg.Print()
+	}
+	return &Reminder{
+		cfg:             cfg,
+		getGitHubClient: o.GetGitHubClient,
+		getSlackClient:  o.GetSlackClient,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package reminder_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v78/github"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/reminderoptions"
+	"github.com/hellej/pr-slack-reminder-action/pkg/reminder"
+	"github.com/hellej/pr-slack-reminder-action/testhelpers/mockgithubclient"
+	"github.com/hellej/pr-slack-reminder-action/testhelpers/mockslackclient"
+)
+
+func TestNewWithInputsAsOptions(t *testing.T) {
+	getGitHubClient := mockgithubclient.MakeMockGitHubClientGetter(mockgithubclient.MockGitHubClientOptions{
+		PRs: []*github.PullRequest{{
+			Number:    github.Ptr(1),
+			Title:     github.Ptr("This is a test PR"),
+			User:      &github.User{Login: github.Ptr("stitch"), Name: github.Ptr("Stitch")},
+			CreatedAt: &github.Timestamp{Time: time.Now().Add(-time.Hour)},
+			State:     github.Ptr("open"),
+		}},
+	})
+	mockSlackAPI := mockslackclient.GetMockSlackAPI(mockslackclient.MockSlackClientOptions{})
+
+	r, err := reminder.New(
+		reminder.WithInputs(map[string]string{
+			config.InputGithubToken:      "SOME_TOKEN",
+			config.InputSlackBotToken:    "SOME_TOKEN",
+			config.InputSlackChannelName: "some-channel-name",
+		}),
+		reminder.WithInput(config.InputPRListHeading, "There are <pr_count> open PRs"),
+		reminder.WithRepositories("test-org/test-repo"),
+		reminder.WithEnv(config.EnvGithubRepository, "test-org/test-repo"),
+		reminder.WithEnv(config.EnvStateFilePath, filepath.Join(t.TempDir(), "pr-slack-reminder-state.json")),
+		reminder.WithEnv(config.EnvSentSlackBlocksFilePath, filepath.Join(t.TempDir(), "sent-slack-blocks.json")),
+		reminderoptions.WithGitHubClientGetter(getGitHubClient),
+		reminderoptions.WithSlackClientGetter(mockslackclient.MakeSlackClientGetter(mockSlackAPI)),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := r.Run(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if mockSlackAPI.SentMessage.ChannelID != "C12345678" {
+		t.Errorf("Expected the message to be sent to channel C12345678, got: %v", mockSlackAPI.SentMessage.ChannelID)
+	}
+	if !strings.Contains(mockSlackAPI.SentMessage.Text, "1 open PR") {
+		t.Errorf("Expected the summary to mention 1 open PR, got: %v", mockSlackAPI.SentMessage.Text)
+	}
+}
+
+func TestNewWithInvalidInputs(t *testing.T) {
+	_, err := reminder.New(
+		reminder.WithInput(config.InputSlackBotToken, "SOME_TOKEN"),
+		reminder.WithInput(config.InputSlackChannelName, "some-channel-name"),
+		reminder.WithRepositories("test-org/test-repo"),
+	)
+	if err == nil || !strings.Contains(err.Error(), "configuration error") {
+		t.Errorf("Expected a configuration error for the missing GitHub token, got: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package reminder
+
+import (
+	"context"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagecontent"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+	"github.com/hellej/pr-slack-reminder-action/internal/runreport"
+	"github.com/hellej/pr-slack-reminder-action/internal/state"
+	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
+)
+
+// Fetches the final states of the PRs of the previous reminder that are no longer listed to summarize
+// how they were resolved. The PRs are fetched without the filters, as the filters may have changed.
+// Failing to fetch them is not an error, as the summary is then only left out of the message.
+func getResolvedPRsSummary(
+	ctx context.Context, githubClient githubclient.Client, prs []prparser.PR, previousState state.State,
+) string {
+	removedPRRefs := state.FindRemovedPRRefs(prs, previousState)
+	if len(removedPRRefs) == 0 {
+		return ""
+	}
+	fetchCtx, cancel := context.WithTimeout(ctx, prFetchTimeout)
+	defer cancel()
+	removedPRs, err := githubClient.GetPRs(
+		fetchCtx,
+		removedPRRefs,
+		func(repo models.Repository) config.Filters { return config.Filters{} },
+		config.PRFetchErrorSkip,
+		githubclient.FindOpenPRsOptions{},
+	)
+	if err != nil {
+		runreport.FromContext(ctx).Add(runreport.KindOther, "failed to fetch the PRs of the previous reminder: %v", err)
+		return ""
+	}
+	return messagecontent.GetResolvedPRsSummary(utilities.Map(removedPRs, func(pr githubclient.PR) models.PullRequest {
+		return pr.PullRequest
+	}))
+}
@@ -0,0 +1,1121 @@
+package reminder
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/discordclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/googlechatclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/matrixclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/oncallclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/clock"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/githubevent"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagebuilder"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagecontent"
+	"github.com/hellej/pr-slack-reminder-action/internal/messagepreview"
+	"github.com/hellej/pr-slack-reminder-action/internal/metrics"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+	"github.com/hellej/pr-slack-reminder-action/internal/prparser"
+	"github.com/hellej/pr-slack-reminder-action/internal/runreport"
+	"github.com/hellej/pr-slack-reminder-action/internal/state"
+	"github.com/hellej/pr-slack-reminder-action/internal/utilities"
+	"github.com/slack-go/slack"
+)
+
+// Run fetches the PRs and sends or updates the message(s) as configured. The run stops
+// (e.g. pending Slack API calls are canceled) when the context is canceled.
+// If the run fails after it started building the message, on-failure-webhook is notified (if set).
+func (r *Reminder) Run(ctx context.Context) error {
+	return runNotifyingFailure(ctx, r.cfg, r.run)
+}
+
+func (r *Reminder) run(ctx context.Context) error {
+	cfg := r.cfg
+	// the non-fatal issues of the run are summarized at the end of the run
+	report := runreport.New()
+	ctx = runreport.NewContext(ctx, report)
+	defer report.Log()
+	if !cfg.FixedTime.IsZero() {
+		defer clock.Fix(cfg.FixedTime)()
+	}
+	if err := cfg.WriteOutput(); err != nil {
+		report.Add(runreport.KindOther, "Failed to write the configuration output: %v", err)
+	}
+	githubClient := r.getGitHubClient(cfg.GithubToken, cfg.GithubTokenForState)
+	githubClient.SetBotAccounts(githubclient.BotAccounts{Bots: cfg.BotAuthors, Humans: cfg.HumanBots})
+	githubClient.SetStrictApprovals(cfg.StrictApprovals)
+	githubClient.SetAPICallBudget(cfg.MaxAPICalls)
+	githubClient.SetRepositoryTokens(cfg.RepositoryTokens)
+	if len(cfg.TeamMembers.Teams) > 0 {
+		teamMembers, err := githubClient.FindTeamMembers(ctx, cfg.TeamMembers.Teams)
+		if err != nil {
+			return err
+		}
+		cfg.TeamMembers.Logins = append(slices.Clone(cfg.TeamMembers.Logins), teamMembers...)
+	}
+	if cfg.RunMode == config.RunModeSuggestMapping {
+		return runSuggestMappingMode(ctx, githubClient, r.getSlackClient(cfg.SlackBotToken), cfg)
+	}
+
+	getSlackClient := r.getSlackClient
+	if cfg.DryRun {
+		getSlackClient = getDryRunSlackClient(cfg, getSlackClient)
+	}
+	if err := runWithMessenger(ctx, githubClient, cfg, getSlackClient); err != nil {
+		return err
+	}
+	pruneStateArtifacts(ctx, githubClient, cfg)
+	return nil
+}
+
+func runWithMessenger(
+	ctx context.Context,
+	githubClient githubclient.Client,
+	cfg config.Config,
+	getSlackClient func(token string) slackclient.Client,
+) error {
+	switch cfg.Messenger {
+	case config.MessengerGoogleChat:
+		googleChatClient := googlechatclient.NewClient(http.DefaultClient, cfg.GoogleChatWebhookURL)
+		return runWebhookPostMode(ctx, githubClient, cfg, func(content messagecontent.Content) error {
+			return googleChatClient.SendMessage(messagebuilder.BuildGoogleChatMessage(content))
+		})
+	case config.MessengerDiscord:
+		discordClient := discordclient.NewClient(http.DefaultClient, cfg.DiscordWebhookURL)
+		return runWebhookPostMode(ctx, githubClient, cfg, func(content messagecontent.Content) error {
+			for _, message := range messagebuilder.BuildDiscordMessages(content) {
+				if err := discordClient.SendMessage(message); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case config.MessengerMatrix:
+		matrixClient := matrixclient.NewClient(http.DefaultClient, cfg.Matrix.HomeserverURL, cfg.Matrix.AccessToken)
+		return runWebhookPostMode(ctx, githubClient, cfg, func(content messagecontent.Content) error {
+			return matrixClient.SendMessage(cfg.Matrix.RoomID, messagebuilder.BuildMatrixMessage(content))
+		})
+	}
+
+	if cfg.ValidateUserMapping {
+		validateUserMapping(ctx, githubClient, getSlackClient(cfg.SlackBotToken), cfg)
+	}
+	if cfg.RespectDND {
+		cfg = withoutUsersOnDND(ctx, getSlackClient(cfg.SlackBotToken), cfg)
+	}
+	sentMessageHandler := getSentMessageHandler(cfg)
+	if len(cfg.ChannelMatrix) > 0 {
+		return runChannelMatrixPostMode(ctx, githubClient, cfg, getSlackClient, sentMessageHandler)
+	}
+
+	slackTargets, err := getSlackTargets(ctx, cfg, getSlackClient)
+	if err != nil {
+		return err
+	}
+
+	switch cfg.RunMode {
+	case config.RunModePost:
+		return runPostMode(ctx, githubClient, slackTargets, cfg, sentMessageHandler)
+	case config.RunModeUpdate:
+		return runUpdateMode(ctx, githubClient, slackTargets, cfg, sentMessageHandler)
+	case config.RunModeEvent:
+		return runEventMode(ctx, githubClient, slackTargets, cfg, sentMessageHandler)
+	case config.RunModeSync:
+		return runSyncMode(ctx, githubClient, slackTargets, cfg, sentMessageHandler)
+	default:
+		return fmt.Errorf("unsupported run mode: %s", cfg.RunMode)
+	}
+}
+
+// Returns a client getter of which the clients log the messages instead of sending them, and
+// render them to the HTML preview file (e.g. to be uploaded as an artifact for reviewing the layout).
+func getDryRunSlackClient(
+	cfg config.Config, getSlackClient func(token string) slackclient.Client,
+) func(token string) slackclient.Client {
+	log.Printf("Dry run: the Slack messages are not sent, they are previewed in %s", cfg.PreviewFilePath)
+	preview := messagepreview.New(cfg.PreviewFilePath)
+	return func(token string) slackclient.Client {
+		return slackclient.NewDryRunClient(getSlackClient(token), preview)
+	}
+}
+
+// Deletes the old state artifacts if so configured. Failing to delete them does not fail the run,
+// as the reminder was already sent.
+func pruneStateArtifacts(ctx context.Context, githubClient githubclient.Client, cfg config.Config) {
+	if cfg.PruneStateArtifacts == 0 {
+		return
+	}
+	_, err := githubClient.DeleteOldArtifactsByName(
+		ctx,
+		cfg.CurrentRepository.Owner, cfg.CurrentRepository.Name,
+		cfg.StateArtifactName,
+		cfg.StateArtifactBranch,
+		cfg.PruneStateArtifacts,
+	)
+	if err != nil {
+		runreport.FromContext(ctx).Add(runreport.KindOther, "failed to delete old state artifacts: %v", err)
+	}
+}
+
+// slackTarget is a Slack workspace (client) and the resolved channel to send the message to.
+type slackTarget struct {
+	client    slackclient.Client
+	channelID string
+}
+
+func getSlackTargets(
+	ctx context.Context,
+	cfg config.Config,
+	getSlackClient func(token string) slackclient.Client,
+) ([]slackTarget, error) {
+	return utilities.MapWithError(cfg.GetSlackTargets(), func(target config.WorkspaceTarget) (slackTarget, error) {
+		return getSlackTarget(ctx, cfg, getSlackClient(target.SlackBotToken), target)
+	})
+}
+
+// Resolves the channel of the target with the client of the workspace of the target.
+func getSlackTarget(
+	ctx context.Context,
+	cfg config.Config,
+	slackClient slackclient.Client,
+	target config.WorkspaceTarget,
+) (slackTarget, error) {
+	slackClient.SetRequestTimeout(cfg.SlackRequestTimeout)
+	if err := checkAllowedChannel(ctx, slackClient, target, cfg.AllowedChannelPattern); err != nil {
+		return slackTarget{}, err
+	}
+	if target.SlackChannelID != "" {
+		return slackTarget{client: slackClient, channelID: target.SlackChannelID}, nil
+	}
+	log.Println("Slack channel ID is not set, resolving it by name")
+	channelID, err := slackClient.GetChannelIDByName(ctx, target.SlackChannelName)
+	if err != nil {
+		return slackTarget{}, fmt.Errorf("error getting channel ID by name: %w", err)
+	}
+	return slackTarget{client: slackClient, channelID: channelID}, nil
+}
+
+// Checks that the name of the channel matches the allowed-channel-pattern input (if set) before
+// anything is posted, to avoid posting the PR list to an unintended (e.g. external shared) channel.
+// If the channel is set by ID, its name is fetched from Slack.
+func checkAllowedChannel(
+	ctx context.Context, slackClient slackclient.Client, target config.WorkspaceTarget, pattern string,
+) error {
+	if pattern == "" {
+		return nil
+	}
+	channelName := target.SlackChannelName
+	if target.SlackChannelID != "" {
+		name, err := slackClient.GetChannelNameByID(ctx, target.SlackChannelID)
+		if err != nil {
+			return fmt.Errorf("error checking channel against %s: %w", config.InputAllowedChannelPattern, err)
+		}
+		channelName = name
+	}
+	if !regexp.MustCompile(pattern).MatchString(channelName) { // validated in config
+		return fmt.Errorf(
+			"channel '%s' does not match %s '%s', refusing to post", channelName, config.InputAllowedChannelPattern, pattern,
+		)
+	}
+	return nil
+}
+
+func runPostMode(
+	ctx context.Context,
+	githubClient githubclient.Client,
+	slackTargets []slackTarget,
+	cfg config.Config,
+	sentMessageHandler func(slackclient.SentMessageInfo) error,
+) error {
+	previousState := loadPreviousState(ctx, githubClient, cfg)
+	isRerun := previousState != nil && previousState.IsPostedByRun(cfg.IdempotencyKey)
+	var replyInThreads func(prs []prparser.PR) []prparser.PR
+	// the PR threads were replied to by the run already
+	if cfg.PRThreadMarker != "" && !isRerun {
+		replyInThreads = func(prs []prparser.PR) []prparser.PR {
+			return replyInPRThreads(ctx, slackTargets[0], prs, cfg.PRThreadMarker)
+		}
+	}
+	parsedPRs, content, err := getOpenPRsContent(ctx, githubClient, cfg, previousState, replyInThreads)
+	if err != nil {
+		return err
+	}
+	if isRerun && updateMessagesOfRun(ctx, slackTargets, *previousState, content, cfg, sentMessageHandler) {
+		if !content.HasPRs() && content.SummaryText == "" {
+			return exitWithoutMessage(cfg)
+		}
+		refreshReviewerThreads(ctx, slackTargets, previousState.ReviewerThreads, parsedPRs, cfg)
+		return state.SaveUpdatedState(cfg.StateFilePath, cfg.StateSigningKey, parsedPRs, *previousState)
+	}
+	if !content.HasPRs() && content.SummaryText == "" {
+		return exitWithoutMessage(cfg)
+	}
+	// the trend and the resolved PRs of a re-run would be compared to the reminder itself
+	if previousState != nil && !isRerun {
+		content.AddPRCountTrend(len(previousState.PullRequests))
+		if cfg.ShowResolvedPRs {
+			content.ResolvedPRsSummary = getResolvedPRsSummary(ctx, githubClient, parsedPRs, *previousState)
+		}
+	}
+	if err := sendMessages(ctx, slackTargets, cfg, parsedPRs, content, sentMessageHandler); err != nil {
+		return err
+	}
+	if cfg.Escalation.IsEnabled() {
+		postEscalation(ctx, slackTargets[0].client, cfg, parsedPRs)
+	}
+	return nil
+}
+
+// Updates the messages that an earlier attempt of the run posted (e.g. when the workflow run is re-run
+// after a later step failed), so that re-running the workflow does not post a duplicate reminder.
+// Returns false if the messages were not updated, in which case new ones are posted.
+func updateMessagesOfRun(
+	ctx context.Context,
+	slackTargets []slackTarget,
+	previousState state.State,
+	content messagecontent.Content,
+	cfg config.Config,
+	sentMessageHandler func(slackclient.SentMessageInfo) error,
+) bool {
+	log.Printf("The reminder of this run (%s) was posted already, updating it instead", cfg.IdempotencyKey)
+	slackMessages := getSlackMessagesToUpdate(slackTargets, previousState.GetSlackRefs())
+	if len(slackMessages) == 0 {
+		log.Println("No messages of the run found in the channel(s), posting a new message")
+		return false
+	}
+	if err := updateMessages(ctx, slackMessages, content, cfg, sentMessageHandler); err != nil {
+		log.Printf("Failed to update the message of the run, posting a new one instead: %v", err)
+		return false
+	}
+	return true
+}
+
+// Replies in the threads of existing messages about the PRs in the channel (e.g. CI failure notifications
+// containing the pr-thread-marker) and returns the other PRs to list in the main reminder. Failing to find
+// the threads or to reply is not an error, as the PRs are then listed in the main reminder.
+func replyInPRThreads(ctx context.Context, target slackTarget, prs []prparser.PR, marker string) []prparser.PR {
+	messages, err := target.client.GetRecentMessages(ctx, target.channelID)
+	if err != nil {
+		runreport.FromContext(ctx).Add(runreport.KindSlack, "unable to find PR threads: %v", err)
+		return prs
+	}
+	var remainingPRs []prparser.PR
+	for _, pr := range prs {
+		threadTS := findPRThread(messages, getPRThreadMarker(marker, pr))
+		if threadTS == "" {
+			remainingPRs = append(remainingPRs, pr)
+			continue
+		}
+		if err := target.client.SendReply(ctx, target.channelID, threadTS, getPRThreadReplyText(pr)); err != nil {
+			runreport.FromContext(ctx).Add(runreport.KindSlack, "%v", err)
+			remainingPRs = append(remainingPRs, pr)
+		}
+	}
+	return remainingPRs
+}
+
+// Returns the thread of the latest message containing the marker (empty string if not found).
+func findPRThread(messages []slack.Message, marker string) string {
+	for _, message := range messages {
+		if strings.Contains(message.Text, marker) {
+			return cmp.Or(message.ThreadTimestamp, message.Timestamp)
+		}
+	}
+	return ""
+}
+
+func getPRThreadMarker(marker string, pr prparser.PR) string {
+	return strings.NewReplacer(
+		"<pr_url>", pr.HTMLURL,
+		"<pr_number>", strconv.Itoa(pr.Number),
+	).Replace(marker)
+}
+
+func getPRThreadReplyText(pr prparser.PR) string {
+	return fmt.Sprintf(
+		"⏰ This PR has been open for %s and is still waiting for attention: %s", pr.GetPRAgeText(), pr.HTMLURL,
+	)
+}
+
+// Exits without posting a message when there are no PRs and no no-prs-message. If configured, the state
+// is saved anyway, so that the runs of the update mode later on do not fail to load it.
+func exitWithoutMessage(cfg config.Config) error {
+	log.Println("No PRs found and no message configured for this case, exiting")
+	if !cfg.AlwaysSaveState {
+		return nil
+	}
+	return state.SaveNoMessageState(cfg.StateFilePath, cfg.StateSigningKey)
+}
+
+// Posts the message to a messenger other than Slack (e.g. Google Chat, Discord or Matrix). The messages
+// cannot be updated, so the state is saved only for the PR count trend of the next run.
+func runWebhookPostMode(
+	ctx context.Context,
+	githubClient githubclient.Client,
+	cfg config.Config,
+	sendMessage func(messagecontent.Content) error,
+) error {
+	previousState := loadPreviousState(ctx, githubClient, cfg)
+	parsedPRs, content, err := getOpenPRsContent(ctx, githubClient, cfg, previousState, nil)
+	if err != nil {
+		return err
+	}
+	if !content.HasPRs() && content.SummaryText == "" {
+		return exitWithoutMessage(cfg)
+	}
+	if previousState != nil {
+		content.AddPRCountTrend(len(previousState.PullRequests))
+	}
+	if err := sendMessage(content); err != nil {
+		return err
+	}
+	return state.SaveWebhookPostState(cfg.StateFilePath, cfg.StateSigningKey, parsedPRs)
+}
+
+// Combines the post and update modes: the message of the previous run is updated with the
+// currently open PRs if it is recent enough. Otherwise (or if updating fails), a new message is posted.
+func runSyncMode(
+	ctx context.Context,
+	githubClient githubclient.Client,
+	slackTargets []slackTarget,
+	cfg config.Config,
+	sentMessageHandler func(slackclient.SentMessageInfo) error,
+) error {
+	previousState := loadPreviousState(ctx, githubClient, cfg)
+	parsedPRs, content, err := getOpenPRsContent(ctx, githubClient, cfg, previousState, nil)
+	if err != nil {
+		return err
+	}
+	if previousState != nil {
+		content.AddPRCountTrend(len(previousState.PullRequests))
+	}
+
+	if previousState != nil && isMessageExpired(previousState, cfg) {
+		slackMessages := getSlackMessagesToUpdate(slackTargets, previousState.GetSlackRefs())
+		deleteExpiredMessages(ctx, withReviewerThreads(slackTargets, slackMessages, previousState), cfg)
+	} else if slackMessages := getSlackMessagesToSync(slackTargets, previousState, cfg); len(slackMessages) > 0 {
+		err := updateMessages(ctx, slackMessages, content, cfg, sentMessageHandler)
+		if err == nil {
+			if !content.HasPRs() && content.SummaryText == "" {
+				return exitWithoutMessage(cfg)
+			}
+			refreshReviewerThreads(ctx, slackTargets, previousState.ReviewerThreads, parsedPRs, cfg)
+			return state.SaveUpdatedState(cfg.StateFilePath, cfg.StateSigningKey, parsedPRs, *previousState)
+		}
+		log.Printf("Failed to update the previous message, posting a new one instead: %v", err)
+	}
+
+	if !content.HasPRs() && content.SummaryText == "" {
+		return exitWithoutMessage(cfg)
+	}
+	return sendMessages(ctx, slackTargets, cfg, parsedPRs, content, sentMessageHandler)
+}
+
+// Returns the Slack messages of the previous state to update in sync mode, or none if
+// a new message should be posted instead.
+func getSlackMessagesToSync(
+	slackTargets []slackTarget,
+	previousState *state.State,
+	cfg config.Config,
+) []slackMessageToUpdate {
+	if previousState == nil {
+		log.Println("No previous state found, posting a new message")
+		return nil
+	}
+	if err := previousState.Validate(); err != nil {
+		log.Printf("Previous state is not valid, posting a new message: %v", err)
+		return nil
+	}
+	maxMessageAge := time.Duration(cfg.SyncMaxMessageAgeHours) * time.Hour
+	if clock.Since(previousState.CreatedAt) > maxMessageAge {
+		log.Printf("Previous message is older than %d hours, posting a new message", cfg.SyncMaxMessageAgeHours)
+		return nil
+	}
+	return getSlackMessagesToUpdate(slackTargets, previousState.GetSlackRefs())
+}
+
+// Timeout of fetching the open PRs and the other content of the message from GitHub
+const prFetchTimeout = 60 * time.Second
+
+// Fetches the open PRs (skipping archived repositories if configured) and prepares the message
+// content from them. Metrics of the PRs are appended to the metrics file if one is configured, and
+// the PR counts of the repositories are written to the pr-counts output.
+// Each run of the open PRs is a new reminder, so the PRs carried over from the previous state
+// (if available) have their reminder counts incremented.
+// If replyInThreads is set, the PRs it returns are listed in the content (others are reminded elsewhere).
+func getOpenPRsContent(
+	ctx context.Context,
+	githubClient githubclient.Client,
+	cfg config.Config,
+	previousState *state.State,
+	replyInThreads func(prs []prparser.PR) []prparser.PR,
+) ([]prparser.PR, messagecontent.Content, error) {
+	ctx, cancel := context.WithTimeout(ctx, prFetchTimeout)
+	defer cancel()
+
+	fetched, err := fetchOpenPRs(ctx, githubClient, cfg)
+	if err != nil {
+		return nil, messagecontent.Content{}, err
+	}
+	markMessageBuildingStarted(ctx)
+	parsedPRs := parsePRs(fetched.prs, cfg, previousState)
+	writePRCounts(ctx, cfg, fetched, parsedPRs)
+	if replyInThreads != nil {
+		parsedPRs = replyInThreads(parsedPRs)
+	}
+	if err := appendMetrics(cfg, parsedPRs); err != nil {
+		return nil, messagecontent.Content{}, err
+	}
+	content := getContent(cfg, fetched, parsedPRs)
+	addContentExtras(&content, fetchContentExtras(ctx, githubClient, cfg, fetched.repositories, content.HasPRs()))
+	addRunReportSummary(ctx, &content, cfg)
+	return parsedPRs, content, nil
+}
+
+// fetchedPRs are the open PRs and the repositories from which they were fetched.
+type fetchedPRs struct {
+	prs                  []githubclient.PR
+	filteredPRCount      int                 // the number of the open PRs left out by the filters
+	repositories         []models.Repository // without the skipped archived repositories
+	archivedRepositories []models.Repository
+}
+
+// Fetches the open PRs, or reads them from the PR data cache of the workflow run if so configured.
+// Failing to read the cache is not an error, as the PRs can then be fetched from GitHub instead.
+func fetchOpenPRs(ctx context.Context, githubClient githubclient.Client, cfg config.Config) (fetchedPRs, error) {
+	if cfg.PRDataCache.Mode == config.PRDataCacheRead {
+		fetched, err := loadCachedPRs(ctx, githubClient, cfg)
+		if err == nil {
+			return fetched, nil
+		}
+		runreport.FromContext(ctx).Add(runreport.KindOther, "PR data cache not available, fetching the PRs: %v", err)
+	}
+	fetched, err := fetchOpenPRsFromGitHub(ctx, githubClient, cfg)
+	if err != nil {
+		return fetchedPRs{}, err
+	}
+	if cfg.PRDataCache.Mode == config.PRDataCacheWrite {
+		if err := saveCachedPRs(cfg, fetched); err != nil {
+			return fetchedPRs{}, err
+		}
+	}
+	return fetched, nil
+}
+
+func fetchOpenPRsFromGitHub(
+	ctx context.Context, githubClient githubclient.Client, cfg config.Config,
+) (fetchedPRs, error) {
+	repositories := cfg.Repositories
+	var archivedRepositories []models.Repository
+	if cfg.SkipArchivedRepos {
+		archivedRepositories = githubClient.FindArchivedRepositories(ctx, repositories)
+		for _, repo := range archivedRepositories {
+			runreport.FromContext(ctx).Add(runreport.KindSkippedRepository, "skipping archived repository %s", repo.GetPath())
+		}
+		repositories = utilities.Filter(repositories, func(repo models.Repository) bool {
+			return !slices.Contains(archivedRepositories, repo)
+		})
+	}
+
+	prs, filteredPRCount, err := githubClient.FindOpenPRs(
+		ctx, repositories, cfg.GetFiltersForRepository, githubclient.FindOpenPRsOptions{
+			EnrichTopN:          cfg.EnrichTopN,
+			TruncateKeep:        cfg.TruncateKeep,
+			MinPRsPerRepository: cfg.MinPRsPerRepository,
+		},
+	)
+	if err != nil {
+		return fetchedPRs{}, err
+	}
+	if cfg.ShowFailingChecks {
+		prs = githubClient.AddFailingChecksInfo(ctx, prs)
+	}
+	if cfg.ShowCodeownerApproval {
+		prs = githubClient.AddCodeownerApprovalInfo(ctx, prs)
+	}
+	if cfg.ShowReviewDecision {
+		prs = githubClient.AddReviewDecisionInfo(ctx, prs)
+	}
+	if cfg.ShowReviewEffort {
+		prs = githubClient.AddReviewEffortInfo(ctx, prs)
+	}
+	if cfg.ShowReviewResponseTime {
+		prs = githubClient.AddReviewResponseInfo(ctx, prs)
+	}
+	if cfg.UsesMergeQueue() {
+		prs = githubClient.AddMergeQueueInfo(ctx, prs)
+		queuedPRCount := len(prs)
+		prs = utilities.Filter(prs, func(pr githubclient.PR) bool {
+			return !pr.IsInMergeQueue || !cfg.GetFiltersForRepository(pr.Repository).IgnoreQueued
+		})
+		filteredPRCount += queuedPRCount - len(prs)
+	}
+	return fetchedPRs{
+		prs:                  prs,
+		filteredPRCount:      filteredPRCount,
+		repositories:         repositories,
+		archivedRepositories: archivedRepositories,
+	}, nil
+}
+
+// The PRs of a re-run keep the reminder counts of the state, as the re-run is the same reminder
+// (the PRs not in the state are in their first reminder).
+func parsePRs(prs []githubclient.PR, cfg config.Config, previousState *state.State) []prparser.PR {
+	parsedPRs := prparser.ParsePRs(prs, cfg.ContentInputs, cfg.GetContentClock())
+	if previousState != nil && previousState.IsPostedByRun(cfg.IdempotencyKey) {
+		return state.KeepReminderCounts(state.CountReminders(parsedPRs, nil), *previousState)
+	}
+	return state.CountReminders(parsedPRs, previousState)
+}
+
+func appendMetrics(cfg config.Config, parsedPRs []prparser.PR) error {
+	if cfg.MetricsFilePath == "" {
+		return nil
+	}
+	snapshot := metrics.NewSnapshot(parsedPRs, clock.Now())
+	return metrics.Append(cfg.MetricsFilePath, cfg.MetricsFormat, snapshot)
+}
+
+// Writes the number of the open PRs of each repository to the pr-counts output and (if so configured)
+// to the step summary. Failing to write them is not an error, as the message can still be sent.
+func writePRCounts(ctx context.Context, cfg config.Config, fetched fetchedPRs, parsedPRs []prparser.PR) {
+	writeStepSummary := cfg.PRCountsStepSummary && cfg.StepSummaryFilePath != ""
+	if cfg.GithubOutputFilePath == "" && !writeStepSummary {
+		return
+	}
+	prCounts := metrics.CountPRsByRepository(parsedPRs, utilities.Map(fetched.repositories, models.Repository.GetPath))
+	if cfg.GithubOutputFilePath != "" {
+		if err := metrics.WritePRCountsOutput(cfg.GithubOutputFilePath, prCounts); err != nil {
+			runreport.FromContext(ctx).Add(runreport.KindOther, "Failed to write the PR counts output: %v", err)
+		}
+	}
+	if writeStepSummary {
+		if err := metrics.WritePRCountsStepSummary(cfg.StepSummaryFilePath, prCounts); err != nil {
+			runreport.FromContext(ctx).Add(runreport.KindOther, "Failed to write the PR counts step summary: %v", err)
+		}
+	}
+}
+
+// Prepares the content of the PRs, listing the repositories of which no PRs are listed as quiet if so configured.
+func getContent(cfg config.Config, fetched fetchedPRs, parsedPRs []prparser.PR) messagecontent.Content {
+	content := messagecontent.GetContent(parsedPRs, cfg.ContentInputs)
+	if cfg.ShowFilteredCount {
+		content.AddFilteredPRCount(fetched.filteredPRCount)
+	}
+	content.SkippedArchivedRepositories = utilities.Map(fetched.archivedRepositories, models.Repository.GetPath)
+	if cfg.ContentInputs.ShowQuietRepos != config.QuietReposHide {
+		quietRepositories := utilities.Filter(fetched.repositories, func(repo models.Repository) bool {
+			return !slices.ContainsFunc(fetched.prs, func(pr githubclient.PR) bool { return pr.Repository == repo })
+		})
+		content.AddQuietRepositories(quietRepositories, cfg.ContentInputs)
+	}
+	return content
+}
+
+// contentExtras is the content shown in the message in addition to the PRs, which does not depend
+// on the listed PRs (so it can be fetched once for several messages).
+type contentExtras struct {
+	repositoriesWithoutRequiredReviews []string
+	failingWorkflows                   []messagecontent.FailingWorkflow
+	recentlyMerged                     *messagecontent.RecentlyMerged
+	milestoneProgress                  []messagecontent.MilestoneProgress
+	reviewCaptain                      *messagecontent.ReviewCaptain
+}
+
+// Fetches the content extras as configured. The review captain is only fetched if there are PRs to review.
+func fetchContentExtras(
+	ctx context.Context,
+	githubClient githubclient.Client,
+	cfg config.Config,
+	repositories []models.Repository,
+	hasPRs bool,
+) contentExtras {
+	var extras contentExtras
+	if cfg.AuditBranchProtection {
+		withoutRequiredReviews := githubClient.FindRepositoriesWithoutRequiredReviews(ctx, repositories)
+		for _, repo := range withoutRequiredReviews {
+			log.Printf("Warning: PR reviews are not required on the default branch of repository %s", repo.GetPath())
+		}
+		extras.repositoriesWithoutRequiredReviews = utilities.Map(withoutRequiredReviews, models.Repository.GetPath)
+	}
+	if cfg.ShowFailingWorkflows {
+		failingWorkflows := githubClient.FindFailingWorkflows(ctx, repositories)
+		extras.failingWorkflows = messagecontent.GetFailingWorkflows(failingWorkflows)
+	}
+	if cfg.ShowRecentlyMergedHours > 0 {
+		since := clock.Now().Add(-time.Duration(cfg.ShowRecentlyMergedHours) * time.Hour)
+		mergedPRs := githubClient.FindRecentlyMergedPRs(ctx, repositories, since, cfg.GetFiltersForRepository)
+		extras.recentlyMerged = messagecontent.GetRecentlyMerged(
+			mergedPRs, cfg.ShowRecentlyMergedHours, cfg.ContentInputs.RedactSecretsInTitles,
+		)
+	}
+	if milestones := githubClient.FindMilestones(ctx, repositories, cfg.GetFiltersForRepository); len(milestones) > 0 {
+		extras.milestoneProgress = messagecontent.GetMilestoneProgress(milestones)
+	}
+	if cfg.OnCall.IsEnabled() && hasPRs {
+		extras.reviewCaptain = getReviewCaptain(ctx, cfg.OnCall)
+	}
+	return extras
+}
+
+func addContentExtras(content *messagecontent.Content, extras contentExtras) {
+	content.RepositoriesWithoutRequiredReviews = extras.repositoriesWithoutRequiredReviews
+	content.FailingWorkflows = extras.failingWorkflows
+	content.RecentlyMerged = extras.recentlyMerged
+	content.MilestoneProgress = extras.milestoneProgress
+	if content.HasPRs() {
+		content.ReviewCaptain = extras.reviewCaptain
+	}
+}
+
+// Adds the summary of the non-fatal issues of the run so far to the message if so configured.
+func addRunReportSummary(ctx context.Context, content *messagecontent.Content, cfg config.Config) {
+	if cfg.ShowRunReport {
+		content.RunReportSummary = runreport.FromContext(ctx).Summary()
+	}
+}
+
+// Fetches the current on-call user of the schedule to mention as the review captain.
+// Failing to fetch it is not an error, as the reminder is useful without it too.
+func getReviewCaptain(ctx context.Context, onCall config.OnCallInputs) *messagecontent.ReviewCaptain {
+	onCallClient, err := oncallclient.GetAuthenticatedClient(onCall.Provider, onCall.APIToken)
+	if err != nil {
+		runreport.FromContext(ctx).Add(runreport.KindOther, "%v", err)
+		return nil
+	}
+	user, err := onCallClient.GetOnCallUser(ctx, onCall.ScheduleID)
+	if err != nil {
+		runreport.FromContext(ctx).Add(runreport.KindOther, "unable to get the on-call user: %v", err)
+		return nil
+	}
+	log.Printf("Review captain (on call): %s", user.Name)
+	return &messagecontent.ReviewCaptain{
+		Name:        user.Name,
+		SlackUserID: onCall.SlackUserIdByEmail[user.Email],
+	}
+}
+
+// Loads the state of the previous run if available. Failing to load it is not an error
+// in post and sync modes, as a new message can always be posted instead.
+func loadPreviousState(ctx context.Context, githubClient githubclient.Client, cfg config.Config) *state.State {
+	if cfg.StateArtifactName == "" {
+		return nil
+	}
+	previousState, err := state.Load(
+		ctx,
+		githubClient,
+		cfg.CurrentRepository,
+		cfg.StateArtifactName,
+		cfg.StateArtifactBranch,
+		cfg.StateFilePath,
+		cfg.StateSigningKey,
+	)
+	if err != nil {
+		log.Printf("Previous state not available: %v", err)
+		return nil
+	}
+	return previousState
+}
+
+func sendMessages(
+	ctx context.Context,
+	slackTargets []slackTarget,
+	cfg config.Config,
+	parsedPRs []prparser.PR,
+	content messagecontent.Content,
+	sentMessageHandler func(slackclient.SentMessageInfo) error,
+) error {
+	messages := messagebuilder.BuildMessages(content, cfg.SplitMessagesByRepo)
+
+	// the messages of the repository groups (if split) are posted in order to each target
+	sentMessageInfosByTarget, err := utilities.MapWithError(slackTargets, func(target slackTarget) ([]slackclient.SentMessageInfo, error) {
+		return utilities.MapWithError(messages, func(m messagebuilder.SplitMessage) (slackclient.SentMessageInfo, error) {
+			return target.client.SendMessage(ctx, target.channelID, m.Message, m.SummaryText)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	sentMessageInfos := utilities.FlatMap(sentMessageInfosByTarget)
+	var reviewerThreads []state.ReviewerThreadRef
+	if cfg.ReviewerThreads {
+		reviewerThreads = postReviewerThreads(ctx, slackTargets[0], sentMessageInfos[0].Timestamp, parsedPRs, cfg)
+	}
+
+	if err := state.SavePostState(
+		cfg.StateFilePath, cfg.StateSigningKey, cfg.IdempotencyKey, parsedPRs, reviewerThreads, sentMessageInfos...,
+	); err != nil {
+		return err
+	}
+	return sentMessageHandler(sentMessageInfos[0])
+}
+
+// Replies to the message in a thread per reviewer, listing only the PRs waiting for the review of the
+// reviewer. Failing to reply is not an error, as the PRs are listed in the message anyway.
+func postReviewerThreads(
+	ctx context.Context, target slackTarget, messageTS string, prs []prparser.PR, cfg config.Config,
+) []state.ReviewerThreadRef {
+	var refs []state.ReviewerThreadRef
+	for _, content := range messagecontent.GetReviewerContents(prs, cfg.ContentInputs.SlackUserIdByGitHubUsername) {
+		message, summaryText := messagebuilder.BuildReviewerThreadMessage(content)
+		sentMessageInfo, err := target.client.SendThreadReply(ctx, target.channelID, messageTS, message, summaryText)
+		if err != nil {
+			runreport.FromContext(ctx).Add(
+				runreport.KindSlack, "failed to reply to reviewer %s: %v", content.Reviewer.Login, err,
+			)
+			continue
+		}
+		refs = append(refs, state.ReviewerThreadRef{
+			ReviewerLogin: content.Reviewer.Login,
+			ChannelID:     sentMessageInfo.ChannelID,
+			MessageTS:     sentMessageInfo.Timestamp,
+		})
+	}
+	return refs
+}
+
+// Refreshes the reviewer thread replies of the updated message with the PRs that still wait for the
+// reviewers. No new replies are posted, so reviewers requested later are mentioned under the next message.
+func refreshReviewerThreads(
+	ctx context.Context, slackTargets []slackTarget, refs []state.ReviewerThreadRef, prs []prparser.PR, cfg config.Config,
+) {
+	if !cfg.ReviewerThreads {
+		return
+	}
+	for _, ref := range refs {
+		content := messagecontent.GetReviewerContent(prs, ref.ReviewerLogin, cfg.ContentInputs.SlackUserIdByGitHubUsername)
+		message, summaryText := messagebuilder.BuildReviewerThreadMessage(content)
+		if _, err := slackTargets[0].client.UpdateMessage(
+			ctx, ref.ChannelID, ref.MessageTS, message, summaryText,
+		); err != nil {
+			runreport.FromContext(ctx).Add(
+				runreport.KindSlack, "failed to update the thread of reviewer %s: %v", ref.ReviewerLogin, err,
+			)
+		}
+	}
+}
+
+func runUpdateMode(
+	ctx context.Context,
+	githubClient githubclient.Client,
+	slackTargets []slackTarget,
+	cfg config.Config,
+	sentMessageHandler func(slackclient.SentMessageInfo) error,
+) error {
+	loadedState, err := state.Load(
+		ctx,
+		githubClient,
+		cfg.CurrentRepository,
+		cfg.StateArtifactName,
+		cfg.StateArtifactBranch,
+		cfg.StateFilePath,
+		cfg.StateSigningKey,
+	)
+	if errors.Is(err, githubclient.ErrArtifactNotFound) {
+		return handleMissingState(ctx, githubClient, slackTargets, cfg, sentMessageHandler, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	if len(loadedState.PullRequests) == 0 {
+		log.Println("No PRs to update in state, exiting")
+		return nil
+	}
+	slackMessages := getSlackMessagesToUpdate(slackTargets, loadedState.GetSlackRefs())
+
+	fetchCtx, cancel := context.WithTimeout(ctx, prFetchTimeout)
+	defer cancel()
+	prRefs := getPRRefsToUpdate(loadedState.PullRequests, cfg)
+	prs, err := githubClient.GetPRs(
+		fetchCtx, prRefs, cfg.GetFiltersForStateRepository, cfg.OnPRFetchError, githubclient.FindOpenPRsOptions{
+			EnrichTopN:          cfg.EnrichTopN,
+			TruncateKeep:        cfg.TruncateKeep,
+			MinPRsPerRepository: cfg.MinPRsPerRepository,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	markMessageBuildingStarted(ctx)
+
+	parsedPRs := state.KeepReminderCounts(prparser.ParsePRs(prs, cfg.ContentInputs, cfg.GetContentClock()), *loadedState)
+	content := messagecontent.GetContent(parsedPRs, cfg.ContentInputs)
+	addRunReportSummary(ctx, &content, cfg)
+
+	if isMessageExpired(loadedState, cfg) {
+		deleteExpiredMessages(ctx, withReviewerThreads(slackTargets, slackMessages, loadedState), cfg)
+		if !content.HasPRs() && content.SummaryText == "" {
+			log.Println("No PRs left and no message configured for this case, exiting")
+			return nil
+		}
+		return sendMessages(ctx, slackTargets, cfg, parsedPRs, content, sentMessageHandler)
+	}
+	if err := updateMessages(ctx, slackMessages, content, cfg, sentMessageHandler); err != nil {
+		return err
+	}
+	refreshReviewerThreads(ctx, slackTargets, loadedState.ReviewerThreads, parsedPRs, cfg)
+	return nil
+}
+
+// Handles the update mode run when no state artifact is found (e.g. before the first message of the day
+// has been posted) as configured by the on-missing-state input.
+func handleMissingState(
+	ctx context.Context,
+	githubClient githubclient.Client,
+	slackTargets []slackTarget,
+	cfg config.Config,
+	sentMessageHandler func(slackclient.SentMessageInfo) error,
+	err error,
+) error {
+	switch cfg.OnMissingState {
+	case config.MissingStatePost:
+		log.Printf("State not found (%v), falling back to post mode", err)
+		return runPostMode(ctx, githubClient, slackTargets, cfg, sentMessageHandler)
+	case config.MissingStateSkip:
+		log.Printf("State not found (%v), nothing to update, exiting", err)
+		return nil
+	default:
+		return fmt.Errorf(
+			"failed to load state: %w (run post mode first to save the state, or set %s to '%s' or '%s')",
+			err, config.InputOnMissingState, config.MissingStatePost, config.MissingStateSkip,
+		)
+	}
+}
+
+// Returns the PRs from state to update, dropping the PRs of repositories that are no longer
+// configured if so configured (otherwise they are kept and only the global filters apply to them).
+func getPRRefsToUpdate(prRefs []models.PullRequestRef, cfg config.Config) []models.PullRequestRef {
+	return utilities.Filter(prRefs, func(ref models.PullRequestRef) bool {
+		if cfg.IsConfiguredRepository(ref.Repository) {
+			return true
+		}
+		if cfg.OnUnknownRepo == config.UnknownRepoDrop {
+			log.Printf("Dropping PR %s#%d (repository is no longer configured)", ref.Repository.GetPath(), ref.Number)
+			return false
+		}
+		log.Printf("Keeping PR %s#%d of a repository that is no longer configured", ref.Repository.GetPath(), ref.Number)
+		return true
+	})
+}
+
+// Runs on pull request events (e.g. a pull_request workflow trigger) and posts a Slack message
+// about the triggering PR. The state artifact is expected to be PR specific: if one is found,
+// the message from it is updated instead.
+func runEventMode(
+	ctx context.Context,
+	githubClient githubclient.Client,
+	slackTargets []slackTarget,
+	cfg config.Config,
+	sentMessageHandler func(slackclient.SentMessageInfo) error,
+) error {
+	event, err := githubevent.LoadPullRequestEvent(cfg.GithubEventPath)
+	if err != nil {
+		return fmt.Errorf("failed to read pull request event: %w", err)
+	}
+	log.Printf(
+		"Handling pull request event '%s' for %s#%d",
+		event.Action, event.PullRequest.Repository.GetPath(), event.PullRequest.Number,
+	)
+
+	// the PR specific state is saved by the workflow runs of the events of the PR, i.e. on its branch
+	loadedState, err := state.Load(
+		ctx,
+		githubClient,
+		cfg.CurrentRepository,
+		cfg.StateArtifactName,
+		cmp.Or(cfg.StateArtifactBranch, event.HeadBranch),
+		cfg.StateFilePath,
+		cfg.StateSigningKey,
+	)
+	if err != nil && !errors.Is(err, githubclient.ErrArtifactNotFound) {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, prFetchTimeout)
+	defer cancel()
+	prs, err := githubClient.GetPRs(
+		fetchCtx,
+		[]models.PullRequestRef{event.PullRequest},
+		cfg.GetFiltersForRepository,
+		config.PRFetchErrorFail,
+		githubclient.FindOpenPRsOptions{},
+	)
+	if err != nil {
+		return err
+	}
+	markMessageBuildingStarted(ctx)
+
+	parsedPRs := prparser.ParsePRs(prs, cfg.ContentInputs, cfg.GetContentClock())
+	content := messagecontent.GetContent(parsedPRs, cfg.ContentInputs)
+	addRunReportSummary(ctx, &content, cfg)
+
+	if loadedState != nil {
+		slackMessages := getSlackMessagesToUpdate(slackTargets, loadedState.GetSlackRefs())
+		return updateMessages(ctx, slackMessages, content, cfg, sentMessageHandler)
+	}
+	if !content.HasPRs() {
+		log.Println("The PR of the event was filtered out and no previous message exists, exiting")
+		return nil
+	}
+	return sendMessages(ctx, slackTargets, cfg, parsedPRs, content, sentMessageHandler)
+}
+
+// Returns true if the message of the state is older than the message-ttl-hours input allows.
+func isMessageExpired(loadedState *state.State, cfg config.Config) bool {
+	if cfg.MessageTTLHours == 0 {
+		return false
+	}
+	return clock.Since(loadedState.CreatedAt) > time.Duration(cfg.MessageTTLHours)*time.Hour
+}
+
+// Deletes the expired messages so that the channel does not accumulate old (edited) reminders.
+// Failing to delete a message is not an error, as a new message is posted in any case.
+func deleteExpiredMessages(ctx context.Context, slackMessages []slackMessageToUpdate, cfg config.Config) {
+	log.Printf("Message is older than %d hours, deleting it and posting a new message", cfg.MessageTTLHours)
+	deleteMessages(ctx, slackMessages)
+}
+
+func deleteMessages(ctx context.Context, slackMessages []slackMessageToUpdate) {
+	for _, m := range slackMessages {
+		if err := m.target.client.DeleteMessage(ctx, m.ref.ChannelID, m.ref.MessageTS); err != nil {
+			runreport.FromContext(ctx).Add(runreport.KindSlack, "failed to delete message: %v", err)
+		}
+	}
+}
+
+// Updates the messages of the state with the content. If the reminder was split by repository, the
+// messages of each channel are updated in order and the ones left over (e.g. after the PRs of a
+// repository were closed) are deleted.
+func updateMessages(
+	ctx context.Context,
+	slackMessages []slackMessageToUpdate,
+	content messagecontent.Content,
+	cfg config.Config,
+	sentMessageHandler func(slackclient.SentMessageInfo) error,
+) error {
+	if !content.HasPRs() && content.SummaryText == "" {
+		log.Println("All PRs from state have been filtered out or closed")
+		log.Println("Deleting Slack message as no-prs-message input is not set")
+		deleteMessages(ctx, slackMessages)
+		return nil
+	}
+	if !content.HasPRs() && content.SummaryText != "" {
+		log.Printf("All PRs from state have been filtered out or closed")
+		log.Printf("Updating Slack message with no-prs-message: %s", content.SummaryText)
+	}
+
+	messages := messagebuilder.BuildMessages(content, cfg.SplitMessagesByRepo)
+	messageCountByChannel := map[string]int{}
+	for _, m := range slackMessages {
+		messageCountByChannel[m.ref.ChannelID]++
+	}
+	for channelID, messageCount := range messageCountByChannel {
+		if messageCount < len(messages) {
+			return fmt.Errorf(
+				"the content needs %d messages, but only %d were posted to channel %s",
+				len(messages), messageCount, channelID,
+			)
+		}
+	}
+
+	messageIndexByChannel := map[string]int{}
+	for _, m := range slackMessages {
+		i := messageIndexByChannel[m.ref.ChannelID]
+		messageIndexByChannel[m.ref.ChannelID]++
+		if i >= len(messages) {
+			deleteMessages(ctx, []slackMessageToUpdate{m})
+			continue
+		}
+		sentMessageInfo, err := m.target.client.UpdateMessage(
+			ctx,
+			m.ref.ChannelID,
+			m.ref.MessageTS,
+			messages[i].Message,
+			messages[i].SummaryText,
+		)
+		if err != nil {
+			return err
+		}
+		if err := sentMessageHandler(sentMessageInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type slackMessageToUpdate struct {
+	target slackTarget
+	ref    state.SlackRef
+}
+
+// Returns the reviewer thread replies of the state followed by the messages, so that the replies
+// are deleted before the messages. The replies are in the channel of the only target, as
+// reviewer-threads cannot be used with workspace-targets.
+func withReviewerThreads(
+	targets []slackTarget, slackMessages []slackMessageToUpdate, loadedState *state.State,
+) []slackMessageToUpdate {
+	replies := utilities.Map(loadedState.ReviewerThreads, func(ref state.ReviewerThreadRef) slackMessageToUpdate {
+		return slackMessageToUpdate{
+			target: targets[0],
+			ref:    state.SlackRef{ChannelID: ref.ChannelID, MessageTS: ref.MessageTS},
+		}
+	})
+	return append(replies, slackMessages...)
+}
+
+// Pairs the Slack messages from state with the workspace targets by channel ID, keeping the messages
+// of a channel in the order in which they were posted (several if split by repository).
+// With a single target and message (the common case), they are paired as is.
+func getSlackMessagesToUpdate(targets []slackTarget, refs []state.SlackRef) []slackMessageToUpdate {
+	if len(targets) == 1 && len(refs) == 1 {
+		return []slackMessageToUpdate{{target: targets[0], ref: refs[0]}}
+	}
+	var messages []slackMessageToUpdate
+	for _, target := range targets {
+		targetRefs := utilities.Filter(refs, func(ref state.SlackRef) bool {
+			return ref.ChannelID == target.channelID
+		})
+		if len(targetRefs) == 0 {
+			log.Printf("Warning: no Slack message found in state for channel %s", target.channelID)
+			continue
+		}
+		for _, ref := range targetRefs {
+			messages = append(messages, slackMessageToUpdate{target: target, ref: ref})
+		}
+	}
+	return messages
+}
+
+// Returns a handler function that saves the sent Slack message blocks as a JSON file.
+// This is useful in both dry-run mode of the action and in integration tests.
+func getSentMessageHandler(cfg config.Config) func(slackclient.SentMessageInfo) error {
+	return func(sentMessageInfo slackclient.SentMessageInfo) error {
+		var metadata *state.SentBlocksMetadata
+		if cfg.SentSlackBlocksFormat == config.SentBlocksFormatEnvelope {
+			metadata = &state.SentBlocksMetadata{
+				RunMode:        string(cfg.RunMode),
+				ChannelID:      sentMessageInfo.ChannelID,
+				Summary:        sentMessageInfo.SummaryText,
+				IdempotencyKey: cfg.IdempotencyKey,
+			}
+		}
+		if err := state.SaveSentSlackBlocks(
+			cfg.SentSlackBlocksFilePath, sentMessageInfo.JSONBlocks, metadata,
+		); err != nil {
+			return err
+		}
+		return nil
+	}
+}
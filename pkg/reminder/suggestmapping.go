@@ -0,0 +1,69 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
+	"github.com/hellej/pr-slack-reminder-action/internal/runreport"
+	"github.com/hellej/pr-slack-reminder-action/internal/usermapping"
+)
+
+// Suggests the github-user-slack-user-id-mapping input by matching the members of the organizations
+// that own the repositories with the users of the Slack workspace, and writes the suggestions to a file
+// (e.g. to be uploaded as an artifact). Owners that are not organizations are skipped.
+func runSuggestMappingMode(
+	ctx context.Context, githubClient githubclient.Client, slackClient slackclient.Client, cfg config.Config,
+) error {
+	var members []githubclient.OrganizationMember
+	for _, org := range getRepositoryOwners(cfg.Repositories) {
+		orgMembers, err := githubClient.FindOrganizationMembers(ctx, org)
+		if err != nil {
+			runreport.FromContext(ctx).Add(runreport.KindOther, "skipping the members of %s: %v", org, err)
+			continue
+		}
+		log.Printf("Found %d members in organization %s", len(orgMembers), org)
+		members = append(members, orgMembers...)
+	}
+	if len(members) == 0 {
+		return errors.New("no organization members found to suggest the user mapping for")
+	}
+	slackUsers, err := slackClient.ListUsers(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("Found %d users in the Slack workspace", len(slackUsers))
+
+	result := usermapping.Suggest(members, slackUsers, cfg.ContentInputs.SlackUserIdByGitHubUsername)
+	if dir := filepath.Dir(cfg.SuggestedMappingPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory for the suggested mapping: %w", err)
+		}
+	}
+	if err := os.WriteFile(cfg.SuggestedMappingPath, []byte(result.Text()), 0644); err != nil {
+		return fmt.Errorf("failed to write the suggested mapping: %w", err)
+	}
+	log.Printf(
+		"Wrote the suggested mapping to %s (matched: %d, unmatched: %d)",
+		cfg.SuggestedMappingPath, len(result.Suggestions), len(result.Unmatched),
+	)
+	return nil
+}
+
+func getRepositoryOwners(repositories []models.Repository) []string {
+	var owners []string
+	for _, repository := range repositories {
+		if !slices.Contains(owners, repository.Owner) {
+			owners = append(owners, repository.Owner)
+		}
+	}
+	return owners
+}
@@ -0,0 +1,55 @@
+package reminder
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/runreport"
+)
+
+// Reports the GitHub users and Slack users of the user mapping that are not found (validate-user-mapping),
+// e.g. typos, which otherwise only show as the users being named instead of mentioned. Failing to validate
+// the mapping does not fail the run.
+func validateUserMapping(
+	ctx context.Context, githubClient githubclient.Client, slackClient slackclient.Client, cfg config.Config,
+) {
+	mapping := cfg.ContentInputs.SlackUserIdByGitHubUsername
+	if len(mapping) == 0 {
+		return
+	}
+	report := runreport.FromContext(ctx)
+	input := config.InputSlackUserIdByGitHubUsername
+
+	unknownLogins, err := githubClient.FindUnknownUsers(ctx, slices.Sorted(maps.Keys(mapping)))
+	if err != nil {
+		report.Add(runreport.KindOther, "unable to validate the GitHub users of %s: %v", input, err)
+	}
+	for _, login := range unknownLogins {
+		report.Add(runreport.KindOther, "GitHub user %s of %s not found", login, input)
+	}
+
+	slackClient.SetRequestTimeout(cfg.SlackRequestTimeout)
+	invalidUserIDs, err := slackClient.FindInvalidUserIDs(ctx, slices.Compact(slices.Sorted(maps.Values(mapping))))
+	if err != nil {
+		report.Add(runreport.KindSlack, "unable to validate the Slack users of %s: %v", input, err)
+		return
+	}
+	for _, userID := range invalidUserIDs {
+		var logins []string
+		for login, mappedUserID := range mapping {
+			if mappedUserID == userID {
+				logins = append(logins, login)
+			}
+		}
+		slices.Sort(logins)
+		report.Add(
+			runreport.KindOther, "Slack user %s of %s (%s) not found or deactivated",
+			userID, input, strings.Join(logins, ", "),
+		)
+	}
+}
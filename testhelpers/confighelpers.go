@@ -22,7 +22,9 @@ type TestConfig struct {
 	// RepositoryFilters as a JSON string
 	// e.g. "test-repo: {\"labels\": [\"feature\", \"fix\"]}; test-repo2: {\"ignored-authors\": [\"alice\"]}"
 	RepositoryFiltersRaw string
-	GroupByRepository    bool
+	// WorkspaceTargets as a JSON string
+	WorkspaceTargetsRaw string
+	GroupByRepository   bool
 }
 
 func GetDefaultConfigFull() TestConfig {
@@ -78,21 +80,117 @@ func setEnvFromConfig(t *testing.T, c TestConfig, overrides *map[string]any) {
 	setEnv(t, overrides, config.EnvGithubRepository, c.Repository)
 	setEnv(t, overrides, config.EnvSentSlackBlocksFilePath, c.SentSlackBlocksFilePath)
 	setEnv(t, overrides, config.EnvStateFilePath, c.StateFilePath)
+	setEnv(t, overrides, config.EnvGithubEventPath, c.GithubEventPath)
+	setEnv(t, overrides, config.EnvFixedTime, nil)
+	setEnv(t, overrides, config.EnvGithubRunID, nil)
+	setEnv(t, overrides, config.EnvGithubOutput, nil)
+	setEnv(t, overrides, config.EnvGithubStepSummary, nil)
 
 	setInputEnv(t, overrides, config.InputGithubRepositories, c.Repositories)
 	setInputEnv(t, overrides, config.InputGithubToken, c.GithubToken)
 	setInputEnv(t, overrides, config.InputSlackBotToken, c.SlackBotToken)
 	setInputEnv(t, overrides, config.InputRunMode, string(c.RunMode))
 	setInputEnv(t, overrides, config.InputStateArtifactName, c.StateArtifactName)
+	setInputEnv(t, overrides, config.InputAlwaysSaveState, c.AlwaysSaveState)
+	setInputEnv(t, overrides, config.InputPruneStateArtifacts, nil)
+	setInputEnv(t, overrides, config.InputSyncMaxMessageAgeHours, c.SyncMaxMessageAgeHours)
+	setInputEnv(t, overrides, config.InputMessageTTLHours, c.MessageTTLHours)
 	setInputEnv(t, overrides, config.InputSlackChannelName, c.SlackChannelName)
 	setInputEnv(t, overrides, config.InputSlackChannelID, c.SlackChannelID)
+	setInputEnv(t, overrides, config.InputWorkspaceTargets, c.WorkspaceTargetsRaw)
 	setInputEnv(t, overrides, config.InputSlackUserIdByGitHubUsername, c.ContentInputs.SlackUserIdByGitHubUsername)
+	setInputEnv(t, overrides, config.InputSlackGroupIdByGitHubTeam, c.ContentInputs.SlackGroupIdByGitHubTeam)
 	setInputEnv(t, overrides, config.InputNoPRsMessage, c.ContentInputs.NoPRsMessage)
 	setInputEnv(t, overrides, config.InputPRListHeading, c.ContentInputs.PRListHeading)
 	setInputEnv(t, overrides, config.InputOldPRThresholdHours, c.ContentInputs.OldPRThresholdHours)
+	setInputEnv(t, overrides, config.InputRepositoryOldPRThresholds, nil)
 	setInputEnv(t, overrides, config.InputGlobalFilters, c.GlobalFiltersRaw)
 	setInputEnv(t, overrides, config.InputRepositoryFilters, c.RepositoryFiltersRaw)
 	setInputEnv(t, overrides, config.InputGroupByRepository, c.GroupByRepository)
+	setInputEnv(t, overrides, config.InputShowFailingWorkflows, c.ShowFailingWorkflows)
+	setInputEnv(t, overrides, config.InputShowFailingChecks, c.ShowFailingChecks)
+	setInputEnv(t, overrides, config.InputAuditBranchProtection, c.AuditBranchProtection)
+	setInputEnv(t, overrides, config.InputShowRunReport, nil)
+	setInputEnv(t, overrides, config.InputActionVersionCheck, nil)
+	setInputEnv(t, overrides, config.InputValidateUnknownInputs, nil)
+	setInputEnv(t, overrides, config.InputTruncateKeep, nil)
+	setInputEnv(t, overrides, config.InputMinPRsPerRepo, nil)
+	setInputEnv(t, overrides, config.InputDryRun, nil)
+	setInputEnv(t, overrides, config.InputPreviewFilePath, nil)
+	setInputEnv(t, overrides, config.InputReferenceTime, nil)
+	setInputEnv(t, overrides, config.InputTeamMembers, nil)
+	setInputEnv(t, overrides, config.InputTeamMembersMatch, nil)
+	setInputEnv(t, overrides, config.InputShowReviewDecision, nil)
+	setInputEnv(t, overrides, config.InputStrictApprovals, nil)
+	setInputEnv(t, overrides, config.InputShowMergeQueue, nil)
+	setInputEnv(t, overrides, config.InputReviewerThreads, nil)
+	setInputEnv(t, overrides, config.InputSuggestedMappingFilePath, nil)
+	setInputEnv(t, overrides, config.InputChannelMatrix, nil)
+	setInputEnv(t, overrides, config.InputPRDataCache, nil)
+	setInputEnv(t, overrides, config.InputPRDataCacheFilePath, nil)
+	setInputEnv(t, overrides, config.InputPRDataCacheArtifact, nil)
+	setInputEnv(t, overrides, config.InputEscalationChannel, nil)
+	setInputEnv(t, overrides, config.InputEscalationOldPRCount, nil)
+	setInputEnv(t, overrides, config.InputRespectDND, nil)
+	setInputEnv(t, overrides, config.InputShowFilteredCount, nil)
+	setInputEnv(t, overrides, config.InputPRCountsStepSummary, nil)
+	setInputEnv(t, overrides, config.InputSplitMessagesByRepo, nil)
+	setInputEnv(t, overrides, config.InputShowRecentlyMergedHours, nil)
+	setInputEnv(t, overrides, config.InputShowResolvedPRs, nil)
+	setInputEnv(t, overrides, config.InputShowReviewEffort, nil)
+	setInputEnv(t, overrides, config.InputShowReviewResponseTime, nil)
+	setInputEnv(t, overrides, config.InputShowSilentReviewers, nil)
+	setInputEnv(t, overrides, config.InputValidateUserMapping, nil)
+	setInputEnv(t, overrides, config.InputShowCommentCount, nil)
+	setInputEnv(t, overrides, config.InputRepoHeadingPrefixes, nil)
+	setInputEnv(t, overrides, config.InputIgnoredReviewers, nil)
+	setInputEnv(t, overrides, config.InputMaxAPICalls, nil)
+	setInputEnv(t, overrides, config.InputShowQuickLinks, nil)
+	setInputEnv(t, overrides, config.InputCurrentRepository, nil)
+	setInputEnv(t, overrides, config.InputRepositoryTokens, nil)
+	setInputEnv(t, overrides, config.InputRedactSecretsInTitles, nil)
+	setInputEnv(t, overrides, config.InputMessageStyle, nil)
+	setInputEnv(t, overrides, config.InputOnFailureWebhook, nil)
+	setInputEnv(t, overrides, config.InputSlackGroupMappingFile, nil)
+	setInputEnv(t, overrides, config.InputSlackUserIdMappingFile, nil)
+	setInputEnv(t, overrides, config.InputRepositoryFiltersFile, nil)
+	setInputEnv(t, overrides, config.InputGlobalFiltersFile, nil)
+	setInputEnv(t, overrides, config.InputReleasePRTitlePattern, c.ContentInputs.ReleasePRTitlePattern)
+	setInputEnv(t, overrides, config.InputReleasePRLabels, c.ContentInputs.ReleasePRLabels)
+	setInputEnv(t, overrides, config.InputReviewSLAHours, c.ContentInputs.ReviewSLAHours)
+	setInputEnv(t, overrides, config.InputShowReminderCount, c.ContentInputs.ShowReminderCount)
+	setInputEnv(t, overrides, config.InputHighlightAuthors, nil)
+	setInputEnv(t, overrides, config.InputReviewersIgnore, nil)
+	setInputEnv(t, overrides, config.InputBotAuthors, nil)
+	setInputEnv(t, overrides, config.InputHumanBots, nil)
+	setInputEnv(t, overrides, config.InputShowCodeownerApproval, nil)
+	setInputEnv(t, overrides, config.InputPRThreadMarker, nil)
+	setInputEnv(t, overrides, config.InputGroupBy, nil)
+	setInputEnv(t, overrides, config.InputLabelGroupOrder, nil)
+	setInputEnv(t, overrides, config.InputGroupSort, nil)
+	setInputEnv(t, overrides, config.InputMaxPRsPerRepo, nil)
+	setInputEnv(t, overrides, config.InputShowQuietRepos, nil)
+	setInputEnv(t, overrides, config.InputSlackTimeoutSeconds, nil)
+	setInputEnv(t, overrides, config.InputStateSigningKey, nil)
+	setInputEnv(t, overrides, config.InputStateArtifactBranch, nil)
+	setInputEnv(t, overrides, config.InputSummaryTones, nil)
+	setInputEnv(t, overrides, config.InputSeverityThresholds, nil)
+	setInputEnv(t, overrides, config.InputEnrichTopN, c.EnrichTopN)
+	// Inputs with a non-zero default value are only set if overridden
+	setInputEnv(t, overrides, config.InputSkipArchivedRepos, nil)
+	setInputEnv(t, overrides, config.InputMessenger, nil)
+	setInputEnv(t, overrides, config.InputReviewerLinkStyle, nil)
+	setInputEnv(t, overrides, config.InputOnUnknownRepo, nil)
+	setInputEnv(t, overrides, config.InputOnMissingState, nil)
+	setInputEnv(t, overrides, config.InputOnPRFetchError, nil)
+	setInputEnv(t, overrides, config.InputRepositoryAllowPattern, nil)
+	setInputEnv(t, overrides, config.InputRepositoryDenyPattern, nil)
+	setInputEnv(t, overrides, config.InputAllowedChannelPattern, nil)
+	setInputEnv(t, overrides, config.InputGoogleChatWebhookURL, c.GoogleChatWebhookURL)
+	setInputEnv(t, overrides, config.InputDiscordWebhookURL, c.DiscordWebhookURL)
+	setInputEnv(t, overrides, config.InputMatrixHomeserverURL, c.Matrix.HomeserverURL)
+	setInputEnv(t, overrides, config.InputMatrixAccessToken, c.Matrix.AccessToken)
+	setInputEnv(t, overrides, config.InputMatrixRoomID, c.Matrix.RoomID)
 }
 
 func setEnv(t *testing.T, overrides *map[string]any, envName string, value any) {
@@ -144,6 +242,10 @@ func getValueAsString(
 		strValue = strconv.FormatBool(v)
 	case config.RunMode:
 		strValue = string(v)
+	case config.Messenger:
+		strValue = string(v)
+	case config.ReviewerLinkStyle:
+		strValue = string(v)
 	default:
 		t.Fatalf("unsupported value type for setInputEnv: %T", value)
 	}
@@ -3,18 +3,29 @@ package mockgithubclient
 import (
 	"archive/zip"
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v78/github"
 	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/githubclient"
+	"github.com/hellej/pr-slack-reminder-action/internal/config"
+	"github.com/hellej/pr-slack-reminder-action/internal/models"
 	"github.com/hellej/pr-slack-reminder-action/internal/state"
 )
 
+// ID of the workflow run that uploaded the PR data cache artifact (see PRDataCacheFile)
+const PRDataCacheRunID int64 = 42
+
+const prDataCacheArtifactID int64 = 456
+
 type MockGitHubClientOptions struct {
 	PRsByNumber            map[int]*github.PullRequest
 	ErrByPRNumber          map[int]error
@@ -28,6 +39,41 @@ type MockGitHubClientOptions struct {
 	MockStateForUpdateMode *state.State
 	ListArtifactsError     error
 	DownloadArtifactError  error
+	// Number of older state artifacts (IDs 1, 2, ...) in addition to the one of MockStateForUpdateMode
+	OldStateArtifactCount int
+	// If set, the IDs of the deleted artifacts are appended to it
+	DeletedArtifactIDs   *[]int64
+	ArchivedRepositories []string // names of repositories that are archived
+	// Names of repositories of which the default branch is not protected (others require one approval)
+	UnprotectedRepositories []string
+	// Completed workflow runs on the default branch by repository name (newest first)
+	WorkflowRunsByRepo map[string][]*github.WorkflowRun
+	// Milestones by repository name
+	MilestonesByRepo map[string][]*github.Milestone
+	// Check runs by commit SHA (the head SHA of a PR)
+	CheckRunsBySHA map[string][]*github.CheckRun
+	// Contents of the CODEOWNERS files (.github/CODEOWNERS) by repository name
+	CodeownersByRepo map[string]string
+	// Paths of the changed files by PR number
+	ChangedFilesByPRNumber map[int][]string
+	// Logins of the members of teams by org/team-slug (fetching the members of other teams fails)
+	TeamMembersByTeam map[string][]string
+	// Review decisions (e.g. "APPROVED") of the GraphQL API by the node IDs of PRs
+	ReviewDecisionByNodeID map[string]string
+	// Node IDs of the PRs that are in the merge queue
+	QueuedPRNodeIDs []string
+	// Members of the organizations of the GraphQL API by organization login
+	OrganizationMembers map[string][]githubclient.OrganizationMember
+	// Content of the PR data cache file in the artifact uploaded by the workflow run PRDataCacheRunID
+	PRDataCacheFile []byte
+	// PRs listed when listing the closed PRs (of every repository), e.g. the recently merged PRs
+	ClosedPRs []*github.PullRequest
+	// Stats of the GraphQL API (e.g. the changed files) by the node IDs of PRs
+	PRStatsByNodeID map[string]models.PullRequestStats
+	// First review requests and responses (by "reviewer") in the timelines of the GraphQL API by the node IDs of PRs
+	ReviewResponseByNodeID map[string]models.PullRequestReviewResponse
+	// Logins that do not belong to any GitHub account (all other logins are found)
+	UnknownGitHubUsers []string
 }
 
 func MakeMockGitHubClientGetter(opts MockGitHubClientOptions) func(token, tokenForState string) githubclient.Client {
@@ -41,8 +87,10 @@ func MakeMockGitHubClientGetter(opts MockGitHubClientOptions) func(token, tokenF
 			errorByPRNumber:    opts.ErrByPRNumber,
 			prs:                opts.PRs,
 			prsByRepo:          opts.PRsByRepo,
+			closedPRs:          opts.ClosedPRs,
 			reviewsByPRNumber:  opts.ReviewsByPRNumber,
 			commentsByPRNumber: opts.CommentsByPRNumber,
+			filesByPRNumber:    opts.ChangedFilesByPRNumber,
 			response: &github.Response{
 				Response: &http.Response{
 					StatusCode: opts.ListPRsResponseStatus,
@@ -52,6 +100,7 @@ func MakeMockGitHubClientGetter(opts MockGitHubClientOptions) func(token, tokenF
 		}
 		mockIssueService := &mockIssueService{
 			mockTimelineCommentsByPRNumber: map[int][]*github.IssueComment{},
+			milestonesByRepo:               opts.MilestonesByRepo,
 			response: &github.Response{
 				Response: &http.Response{
 					StatusCode: 200,
@@ -65,6 +114,7 @@ func MakeMockGitHubClientGetter(opts MockGitHubClientOptions) func(token, tokenF
 			},
 			err:                    opts.DownloadArtifactError,
 			mockStateForUpdateMode: opts.MockStateForUpdateMode,
+			prDataCacheFile:        opts.PRDataCacheFile,
 		}
 		mockActionsService := &mockActionsService{
 			response: &github.Response{
@@ -74,8 +124,38 @@ func MakeMockGitHubClientGetter(opts MockGitHubClientOptions) func(token, tokenF
 			},
 			err:                    opts.ListArtifactsError,
 			mockStateForUpdateMode: opts.MockStateForUpdateMode,
+			oldStateArtifactCount:  opts.OldStateArtifactCount,
+			deletedArtifactIDs:     opts.DeletedArtifactIDs,
+			hasPRDataCache:         opts.PRDataCacheFile != nil,
+		}
+		mockRepoService := &mockRepositoriesService{
+			archivedRepositories:    opts.ArchivedRepositories,
+			unprotectedRepositories: opts.UnprotectedRepositories,
+			codeownersByRepo:        opts.CodeownersByRepo,
+		}
+		mockWorkflowRunsService := &mockWorkflowRunsService{workflowRunsByRepo: opts.WorkflowRunsByRepo}
+		mockChecksService := &mockChecksService{checkRunsBySHA: opts.CheckRunsBySHA}
+		mockTeamsService := &mockTeamsService{membersByTeam: opts.TeamMembersByTeam}
+		mockGraphQLService := &mockGraphQLService{
+			prs:                    getAllPRs(opts),
+			reviewDecisionByNodeID: opts.ReviewDecisionByNodeID,
+			queuedPRNodeIDs:        opts.QueuedPRNodeIDs,
+			organizationMembers:    opts.OrganizationMembers,
+			prStatsByNodeID:        opts.PRStatsByNodeID,
+			reviewResponseByNodeID: opts.ReviewResponseByNodeID,
+			unknownUsers:           opts.UnknownGitHubUsers,
 		}
-		return githubclient.NewClient(mockHTTPClient, mockPRService, mockIssueService, mockActionsService)
+		return githubclient.NewClient(githubclient.Services{
+			HTTP:         mockHTTPClient,
+			PullRequests: mockPRService,
+			Issues:       mockIssueService,
+			Actions:      mockActionsService,
+			Repositories: mockRepoService,
+			WorkflowRuns: mockWorkflowRunsService,
+			Checks:       mockChecksService,
+			Teams:        mockTeamsService,
+			GraphQL:      mockGraphQLService,
+		})
 	}
 }
 
@@ -125,8 +205,10 @@ type mockPullRequestService struct {
 	errorByPRNumber    map[int]error
 	prs                []*github.PullRequest
 	prsByRepo          map[string][]*github.PullRequest
+	closedPRs          []*github.PullRequest
 	reviewsByPRNumber  map[int][]*github.PullRequestReview
 	commentsByPRNumber map[int][]*github.PullRequestComment
+	filesByPRNumber    map[int][]string
 	response           *github.Response
 	err                error
 }
@@ -146,6 +228,9 @@ func (m *mockPullRequestService) Get(
 func (m *mockPullRequestService) List(
 	ctx context.Context, owner string, repo string, opts *github.PullRequestListOptions,
 ) ([]*github.PullRequest, *github.Response, error) {
+	if opts != nil && opts.State == "closed" {
+		return m.closedPRs, m.response, m.err
+	}
 	if m.prsByRepo != nil {
 		return m.prsByRepo[repo], m.response, m.err
 	}
@@ -166,12 +251,295 @@ func (m *mockPullRequestService) ListComments(
 	return comments, m.response, m.err
 }
 
+func (m *mockPullRequestService) ListFiles(
+	ctx context.Context, owner string, repo string, number int, opts *github.ListOptions,
+) ([]*github.CommitFile, *github.Response, error) {
+	files := []*github.CommitFile{}
+	for _, filePath := range m.filesByPRNumber[number] {
+		files = append(files, &github.CommitFile{Filename: github.Ptr(filePath)})
+	}
+	return files, m.response, m.err
+}
+
+type mockRepositoriesService struct {
+	archivedRepositories    []string
+	unprotectedRepositories []string
+	codeownersByRepo        map[string]string
+}
+
+func (m *mockRepositoriesService) Get(
+	ctx context.Context, owner string, repo string,
+) (*github.Repository, *github.Response, error) {
+	repository := &github.Repository{
+		Name:          github.Ptr(repo),
+		Archived:      github.Ptr(slices.Contains(m.archivedRepositories, repo)),
+		DefaultBranch: github.Ptr("main"),
+	}
+	return repository, &github.Response{Response: &http.Response{StatusCode: 200}}, nil
+}
+
+func (m *mockRepositoriesService) GetBranchProtection(
+	ctx context.Context, owner, repo, branch string,
+) (*github.Protection, *github.Response, error) {
+	if slices.Contains(m.unprotectedRepositories, repo) {
+		return nil, &github.Response{Response: &http.Response{StatusCode: 404}}, github.ErrBranchNotProtected
+	}
+	protection := &github.Protection{
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 1},
+	}
+	return protection, &github.Response{Response: &http.Response{StatusCode: 200}}, nil
+}
+
+func (m *mockRepositoriesService) GetContents(
+	ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions,
+) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	content, ok := m.codeownersByRepo[repo]
+	if !ok || path != ".github/CODEOWNERS" {
+		return nil, nil, &github.Response{Response: &http.Response{StatusCode: 404}}, errors.New("not found")
+	}
+	file := &github.RepositoryContent{Path: github.Ptr(path), Content: github.Ptr(content)}
+	return file, nil, &github.Response{Response: &http.Response{StatusCode: 200}}, nil
+}
+
+type mockWorkflowRunsService struct {
+	workflowRunsByRepo map[string][]*github.WorkflowRun
+}
+
+func (m *mockWorkflowRunsService) ListRepositoryWorkflowRuns(
+	ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions,
+) (*github.WorkflowRuns, *github.Response, error) {
+	runs := m.workflowRunsByRepo[repo]
+	return &github.WorkflowRuns{
+		TotalCount:   github.Ptr(len(runs)),
+		WorkflowRuns: runs,
+	}, &github.Response{Response: &http.Response{StatusCode: 200}}, nil
+}
+
+type mockChecksService struct {
+	checkRunsBySHA map[string][]*github.CheckRun
+}
+
+func (m *mockChecksService) ListCheckRunsForRef(
+	ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions,
+) (*github.ListCheckRunsResults, *github.Response, error) {
+	runs := m.checkRunsBySHA[ref]
+	return &github.ListCheckRunsResults{
+		Total:     github.Ptr(len(runs)),
+		CheckRuns: runs,
+	}, &github.Response{Response: &http.Response{StatusCode: 200}}, nil
+}
+
+type mockTeamsService struct {
+	membersByTeam map[string][]string
+}
+
+func (m *mockTeamsService) ListTeamMembersBySlug(
+	ctx context.Context, org, slug string, opts *github.TeamListTeamMembersOptions,
+) ([]*github.User, *github.Response, error) {
+	logins, ok := m.membersByTeam[org+"/"+slug]
+	if !ok {
+		return nil, &github.Response{Response: &http.Response{StatusCode: 404}}, errors.New("404 Not Found")
+	}
+	members := make([]*github.User, len(logins))
+	for i, login := range logins {
+		members[i] = &github.User{Login: github.Ptr(login)}
+	}
+	return members, &github.Response{Response: &http.Response{StatusCode: 200}}, nil
+}
+
+// Responds to the queries of PRs by node IDs.
+type mockGraphQLService struct {
+	prs                    []*github.PullRequest // looked up by their node IDs
+	reviewDecisionByNodeID map[string]string
+	queuedPRNodeIDs        []string
+	organizationMembers    map[string][]githubclient.OrganizationMember
+	prStatsByNodeID        map[string]models.PullRequestStats
+	reviewResponseByNodeID map[string]models.PullRequestReviewResponse
+	unknownUsers           []string
+}
+
+func (m *mockGraphQLService) Query(ctx context.Context, query string, variables map[string]any, result any) error {
+	if org, ok := variables["org"].(string); ok {
+		return m.queryOrganizationMembers(org, result)
+	}
+	if strings.Contains(query, "repositoryOwner") {
+		return m.queryUsers(variables, result)
+	}
+	if strings.Contains(query, "headRefOid") {
+		return m.queryPullRequests(variables, result)
+	}
+	type commentCount struct {
+		TotalCount int `json:"totalCount"`
+	}
+	type timeline struct {
+		Nodes []map[string]any `json:"nodes"`
+	}
+	type node struct {
+		ID             string       `json:"id"`
+		ReviewDecision string       `json:"reviewDecision,omitempty"`
+		IsInMergeQueue bool         `json:"isInMergeQueue"`
+		ChangedFiles   int          `json:"changedFiles"`
+		Additions      int          `json:"additions"`
+		Deletions      int          `json:"deletions"`
+		Comments       commentCount `json:"comments"`
+		TimelineItems  timeline     `json:"timelineItems"`
+	}
+	nodes := []node{}
+	for _, id := range variables["ids"].([]string) {
+		stats := m.prStatsByNodeID[id]
+		nodes = append(nodes, node{
+			ID:             id,
+			ReviewDecision: m.reviewDecisionByNodeID[id],
+			IsInMergeQueue: slices.Contains(m.queuedPRNodeIDs, id),
+			ChangedFiles:   stats.ChangedFiles,
+			Additions:      stats.Additions,
+			Deletions:      stats.Deletions,
+			Comments:       commentCount{TotalCount: stats.Comments},
+			TimelineItems:  timeline{Nodes: m.getReviewTimelineItems(id)},
+		})
+	}
+	data, err := json.Marshal(map[string]any{"nodes": nodes})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+// Returns all the PRs of the options (in no particular order).
+func getAllPRs(opts MockGitHubClientOptions) []*github.PullRequest {
+	prs := slices.Concat(opts.PRs, opts.ClosedPRs)
+	for _, pr := range opts.PRsByNumber {
+		prs = append(prs, pr)
+	}
+	for _, repoPRs := range opts.PRsByRepo {
+		prs = append(prs, repoPRs...)
+	}
+	return prs
+}
+
+// Responds to the lookups of PRs by node IDs with the PRs of the options, null for the PRs not found.
+func (m *mockGraphQLService) queryPullRequests(variables map[string]any, result any) error {
+	nodes := []map[string]any{}
+	for _, id := range variables["ids"].([]string) {
+		i := slices.IndexFunc(m.prs, func(pr *github.PullRequest) bool { return pr.GetNodeID() == id })
+		if i < 0 {
+			nodes = append(nodes, nil)
+			continue
+		}
+		pr := m.prs[i]
+		state := strings.ToUpper(pr.GetState())
+		if pr.GetMerged() {
+			state = "MERGED"
+		}
+		var labels, reviewRequests []map[string]any
+		for _, label := range pr.Labels {
+			labels = append(labels, map[string]any{"name": label.GetName()})
+		}
+		for _, user := range pr.RequestedReviewers {
+			reviewRequests = append(reviewRequests, map[string]any{"requestedReviewer": map[string]any{
+				"__typename": cmp.Or(user.GetType(), "User"), "login": user.GetLogin(), "name": user.GetName(),
+			}})
+		}
+		for _, team := range pr.RequestedTeams {
+			reviewRequests = append(reviewRequests, map[string]any{"requestedReviewer": map[string]any{
+				"__typename": "Team", "slug": team.GetSlug(), "name": team.GetName(),
+			}})
+		}
+		node := map[string]any{
+			"id":             id,
+			"number":         pr.GetNumber(),
+			"title":          pr.GetTitle(),
+			"url":            pr.GetHTMLURL(),
+			"state":          state,
+			"merged":         pr.GetMerged(),
+			"isDraft":        pr.GetDraft(),
+			"createdAt":      pr.GetCreatedAt().Time,
+			"updatedAt":      pr.GetUpdatedAt().Time,
+			"labels":         map[string]any{"nodes": labels},
+			"headRefName":    pr.GetHead().GetRef(),
+			"headRefOid":     pr.GetHead().GetSHA(),
+			"reviewRequests": map[string]any{"nodes": reviewRequests},
+		}
+		if user := pr.GetUser(); user != nil {
+			node["author"] = map[string]any{
+				"__typename": cmp.Or(user.GetType(), "User"), "login": user.GetLogin(), "name": user.GetName(),
+			}
+		}
+		if pr.Milestone != nil {
+			node["milestone"] = map[string]any{"title": pr.Milestone.GetTitle()}
+		}
+		nodes = append(nodes, node)
+	}
+	data, err := json.Marshal(map[string]any{"nodes": nodes})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+// Returns the review request and the review of the response (if any) of the PR.
+func (m *mockGraphQLService) getReviewTimelineItems(nodeID string) []map[string]any {
+	response, ok := m.reviewResponseByNodeID[nodeID]
+	if !ok {
+		return nil
+	}
+	items := []map[string]any{{"__typename": "ReviewRequestedEvent", "createdAt": response.RequestedAt}}
+	if response.RespondedAt != nil {
+		items = append(items, map[string]any{
+			"__typename":  "PullRequestReview",
+			"submittedAt": response.RespondedAt,
+			"author":      map[string]string{"__typename": "User", "login": "reviewer"},
+		})
+	}
+	return items
+}
+
+// Responds to the aliased lookups of users (u0, u1, ...) with null for the unknown users.
+func (m *mockGraphQLService) queryUsers(variables map[string]any, result any) error {
+	owners := map[string]any{}
+	for name, login := range variables {
+		alias := "u" + strings.TrimPrefix(name, "l")
+		if slices.Contains(m.unknownUsers, login.(string)) {
+			owners[alias] = nil
+		} else {
+			owners[alias] = map[string]any{"login": login}
+		}
+	}
+	data, err := json.Marshal(owners)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+// Returns all members on one page, or no organization if the members of the organization are not set.
+func (m *mockGraphQLService) queryOrganizationMembers(org string, result any) error {
+	var organization any
+	if members, ok := m.organizationMembers[org]; ok {
+		organization = map[string]any{
+			"membersWithRole": map[string]any{"nodes": members, "pageInfo": map[string]any{"hasNextPage": false}},
+		}
+	}
+	data, err := json.Marshal(map[string]any{"organization": organization})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
 type mockIssueService struct {
 	mockTimelineCommentsByPRNumber map[int][]*github.IssueComment
+	milestonesByRepo               map[string][]*github.Milestone
 	response                       *github.Response
 	err                            error
 }
 
+func (m *mockIssueService) ListMilestones(
+	ctx context.Context, owner string, repo string, opts *github.MilestoneListOptions,
+) ([]*github.Milestone, *github.Response, error) {
+	return m.milestonesByRepo[repo], m.response, m.err
+}
+
 func (m *mockIssueService) ListComments(
 	ctx context.Context, owner string, repo string, number int, opts *github.IssueListCommentsOptions,
 ) ([]*github.IssueComment, *github.Response, error) {
@@ -183,6 +551,9 @@ type mockActionsService struct {
 	response               *github.Response
 	err                    error
 	mockStateForUpdateMode *state.State
+	oldStateArtifactCount  int
+	deletedArtifactIDs     *[]int64
+	hasPRDataCache         bool
 }
 
 func (m *mockActionsService) ListArtifacts(
@@ -198,6 +569,30 @@ func (m *mockActionsService) ListArtifacts(
 			ID:        github.Ptr(int64(123)),
 			Name:      github.Ptr("pr-slack-reminder-state"),
 			CreatedAt: &github.Timestamp{Time: time.Now().Add(-1 * time.Hour)},
+			// the default branch of the mock repositories
+			WorkflowRun: &github.ArtifactWorkflowRun{HeadBranch: github.Ptr("main")},
+		})
+	}
+	if m.hasPRDataCache {
+		artifacts = append(artifacts, &github.Artifact{
+			ID:          github.Ptr(prDataCacheArtifactID),
+			Name:        github.Ptr(config.DefaultPRDataCacheArtifact),
+			CreatedAt:   &github.Timestamp{Time: time.Now().Add(-1 * time.Minute)},
+			WorkflowRun: &github.ArtifactWorkflowRun{ID: github.Ptr(PRDataCacheRunID), HeadBranch: github.Ptr("main")},
+		})
+	}
+	for i := range m.oldStateArtifactCount {
+		artifacts = append(artifacts, &github.Artifact{
+			ID:          github.Ptr(int64(i + 1)),
+			Name:        github.Ptr("pr-slack-reminder-state"),
+			CreatedAt:   &github.Timestamp{Time: time.Now().Add(-time.Duration(i+2) * time.Hour)},
+			WorkflowRun: &github.ArtifactWorkflowRun{HeadBranch: github.Ptr("main")},
+		})
+	}
+
+	if opts != nil && opts.Name != nil {
+		artifacts = slices.DeleteFunc(artifacts, func(artifact *github.Artifact) bool {
+			return artifact.GetName() != *opts.Name
 		})
 	}
 
@@ -213,23 +608,49 @@ func (m *mockActionsService) DownloadArtifact(
 	if m.err != nil {
 		return nil, m.response, m.err
 	}
+	if artifactID == prDataCacheArtifactID {
+		u, _ := url.Parse("https://example.com/mock-pr-data-cache-download-url")
+		return u, m.response, nil
+	}
 	u, _ := url.Parse("https://example.com/mock-download-url")
 	return u, m.response, nil
 }
 
+func (m *mockActionsService) DeleteArtifact(
+	ctx context.Context, owner, repo string, artifactID int64,
+) (*github.Response, error) {
+	if m.deletedArtifactIDs != nil {
+		*m.deletedArtifactIDs = append(*m.deletedArtifactIDs, artifactID)
+	}
+	return m.response, nil
+}
+
 type mockHTTPClient struct {
 	response               *http.Response
 	err                    error
 	mockStateForUpdateMode *state.State
+	prDataCacheFile        []byte
 }
 
-func (m *mockHTTPClient) Get(url string) (*http.Response, error) {
+func (m *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	if m.err != nil {
 		return m.response, m.err
 	}
 
-	if url == "https://example.com/mock-download-url" && m.mockStateForUpdateMode != nil {
-		zipData, err := createMockArtifactZip(m.mockStateForUpdateMode)
+	if req.URL.String() == "https://example.com/mock-pr-data-cache-download-url" {
+		zipData, err := createMockArtifactZip(config.DefaultPRDataCacheFilePath, m.prDataCacheFile)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(zipData))}, nil
+	}
+
+	if req.URL.String() == "https://example.com/mock-download-url" && m.mockStateForUpdateMode != nil {
+		stateJSON, err := json.Marshal(m.mockStateForUpdateMode)
+		if err != nil {
+			return nil, err
+		}
+		zipData, err := createMockArtifactZip("pr-slack-reminder-state.json", stateJSON)
 		if err != nil {
 			return nil, err
 		}
@@ -243,21 +664,16 @@ func (m *mockHTTPClient) Get(url string) (*http.Response, error) {
 	return m.response, m.err
 }
 
-func createMockArtifactZip(mockState *state.State) ([]byte, error) {
+func createMockArtifactZip(fileName string, content []byte) ([]byte, error) {
 	var buf bytes.Buffer
 	zipWriter := zip.NewWriter(&buf)
 
-	file, err := zipWriter.Create("pr-slack-reminder-state.json")
-	if err != nil {
-		return nil, err
-	}
-
-	stateJSON, err := json.Marshal(mockState)
+	file, err := zipWriter.Create(fileName)
 	if err != nil {
 		return nil, err
 	}
 
-	if _, err := file.Write(stateJSON); err != nil {
+	if _, err := file.Write(content); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,12 @@
 package mockslackclient
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sync"
+
 	"github.com/hellej/pr-slack-reminder-action/internal/apiclients/slackclient"
 	"github.com/slack-go/slack"
 )
@@ -11,6 +17,12 @@ type MockSlackClientOptions struct {
 	PostMessageError   error
 	UpdateMessageError error
 	DeleteMessageError error
+	// Messages returned as the history of the channel (newest first)
+	ChannelMessages []slack.Message
+	// Users of the workspace
+	Users []slack.User
+	// Do Not Disturb statuses of the users by user ID
+	DNDStatuses map[string]slack.DNDStatus
 }
 
 // creates the MockSlackAPI (for dependency injection) if nil is provided
@@ -23,6 +35,17 @@ func MakeSlackClientGetter(slackAPI *MockSlackAPI) func(token string) slackclien
 	}
 }
 
+// returns a client for the MockSlackAPI of the given token (e.g. one per Slack workspace)
+func MakeSlackClientGetterByToken(slackAPIByToken map[string]*MockSlackAPI) func(token string) slackclient.Client {
+	return func(token string) slackclient.Client {
+		slackAPI, ok := slackAPIByToken[token]
+		if !ok {
+			panic("No mock Slack API configured for token: " + token)
+		}
+		return slackclient.NewClient(slackAPI)
+	}
+}
+
 func GetMockSlackAPI(opts MockSlackClientOptions) *MockSlackAPI {
 	if opts.SlackChannels == nil {
 		opts.SlackChannels = []*SlackChannel{
@@ -62,6 +85,9 @@ func GetMockSlackAPI(opts MockSlackClientOptions) *MockSlackAPI {
 			Timestamp: "1234567890.123456",
 			Err:       opts.DeleteMessageError,
 		},
+		channelMessages: opts.ChannelMessages,
+		users:           opts.Users,
+		dndStatuses:     opts.DNDStatuses,
 	}
 }
 
@@ -70,20 +96,36 @@ type MockSlackAPI struct {
 	postMessageResponse      PostMessageResponse
 	updateMessageResponse    UpdateMessageResponse
 	deleteMessageResponse    DeleteMessageResponse
+	channelMessages          []slack.Message
+	users                    []slack.User
+	dndStatuses              map[string]slack.DNDStatus
+	mu                       sync.Mutex // messages may be posted to several channels in parallel
 	SentMessage              SentMessage
+	SentMessages             []SentMessage // all successfully sent messages (not replies) in call order
+	SentReplies              []SentReply
 	UpdatedMessage           UpdatedMessage
+	UpdatedMessages          []UpdatedMessage // all successful updates in call order
 	DeletedMessage           DeletedMessage
 }
 
-func (m *MockSlackAPI) GetConversations(params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+func (m *MockSlackAPI) GetConversationsContext(_ context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
 	if m.getConversationsResponse.err != nil {
 		return nil, "", m.getConversationsResponse.err
 	}
 	return m.getConversationsResponse.channels, m.getConversationsResponse.cursor, nil
 }
 
-func (m *MockSlackAPI) PostMessage(
-	channelID string, options ...slack.MsgOption,
+func (m *MockSlackAPI) GetConversationInfoContext(_ context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	for _, channel := range m.getConversationsResponse.channels {
+		if channel.ID == input.ChannelID {
+			return &channel, nil
+		}
+	}
+	return nil, errors.New("channel_not_found")
+}
+
+func (m *MockSlackAPI) PostMessageContext(
+	_ context.Context, channelID string, options ...slack.MsgOption,
 ) (string, string, error) {
 	request, values, err := slack.UnsafeApplyMsgOptions("", "", "", options...)
 
@@ -91,26 +133,33 @@ func (m *MockSlackAPI) PostMessage(
 		panic("Failed to apply message options in mock Slack API: " + err.Error())
 	}
 
-	var sentBlocks BlocksWrapper
-	if blocks, ok := values["blocks"]; ok && len(blocks) > 0 {
-		sentBlocks, err = ParseBlocks([]byte(blocks[0]))
-	}
-
+	sentBlocks, color, err := parseMessageBlocks(values)
 	if err != nil {
 		panic("Failed to parse sent blocks in mock Slack API: " + err.Error())
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if threadTS, ok := values["thread_ts"]; ok && len(threadTS) > 0 {
+		m.SentReplies = append(m.SentReplies, SentReply{
+			ChannelID: channelID, ThreadTS: threadTS[0], Text: values["text"][0], Blocks: sentBlocks,
+		})
+		return channelID, m.postMessageResponse.Timestamp, nil
+	}
+
 	if m.postMessageResponse.Err == nil {
 		m.SentMessage.Request = request
 		m.SentMessage.ChannelID = channelID
 		m.SentMessage.Text = values["text"][0]
 		m.SentMessage.Blocks = sentBlocks
+		m.SentMessage.Color = color
+		m.SentMessages = append(m.SentMessages, m.SentMessage)
 	}
-	return m.postMessageResponse.Channel, m.postMessageResponse.Timestamp, m.postMessageResponse.Err
+	return channelID, m.postMessageResponse.Timestamp, m.postMessageResponse.Err
 }
 
-func (m *MockSlackAPI) UpdateMessage(
-	channelID string, timestamp string, options ...slack.MsgOption,
+func (m *MockSlackAPI) UpdateMessageContext(
+	_ context.Context, channelID string, timestamp string, options ...slack.MsgOption,
 ) (string, string, string, error) {
 	_, values, err := slack.UnsafeApplyMsgOptions("", "", "", options...)
 
@@ -118,11 +167,7 @@ func (m *MockSlackAPI) UpdateMessage(
 		panic("Failed to apply message options in mock Slack API: " + err.Error())
 	}
 
-	var updatedBlocks BlocksWrapper
-	if blocks, ok := values["blocks"]; ok && len(blocks) > 0 {
-		updatedBlocks, err = ParseBlocks([]byte(blocks[0]))
-	}
-
+	updatedBlocks, color, err := parseMessageBlocks(values)
 	if err != nil {
 		panic("Failed to parse updated blocks in mock Slack API: " + err.Error())
 	}
@@ -132,17 +177,67 @@ func (m *MockSlackAPI) UpdateMessage(
 		m.UpdatedMessage.Timestamp = timestamp
 		m.UpdatedMessage.Text = values["text"][0]
 		m.UpdatedMessage.Blocks = updatedBlocks
+		m.UpdatedMessage.Color = color
+		m.UpdatedMessages = append(m.UpdatedMessages, m.UpdatedMessage)
 	}
 	return channelID, timestamp, "updated_timestamp", m.updateMessageResponse.Err
 }
 
-func (m *MockSlackAPI) DeleteMessage(channelID string, timestamp string) (string, string, error) {
+func (m *MockSlackAPI) DeleteMessageContext(_ context.Context, channelID string, timestamp string) (string, string, error) {
 	// Always record the delete attempt, even if it fails
 	m.DeletedMessage.ChannelID = channelID
 	m.DeletedMessage.Timestamp = timestamp
 	return m.deleteMessageResponse.Channel, m.deleteMessageResponse.Timestamp, m.deleteMessageResponse.Err
 }
 
+func (m *MockSlackAPI) GetConversationHistoryContext(
+	_ context.Context, params *slack.GetConversationHistoryParameters,
+) (*slack.GetConversationHistoryResponse, error) {
+	return &slack.GetConversationHistoryResponse{Messages: m.channelMessages}, nil
+}
+
+func (m *MockSlackAPI) GetUsersContext(_ context.Context, _ ...slack.GetUsersOption) ([]slack.User, error) {
+	return m.users, nil
+}
+
+func (m *MockSlackAPI) GetDNDTeamInfoContext(_ context.Context, users []string) (map[string]slack.DNDStatus, error) {
+	statuses := make(map[string]slack.DNDStatus)
+	for _, user := range users {
+		if status, ok := m.dndStatuses[user]; ok {
+			statuses[user] = status
+		}
+	}
+	return statuses, nil
+}
+
+func (m *MockSlackAPI) GetUserInfoContext(_ context.Context, user string) (*slack.User, error) {
+	for _, u := range m.users {
+		if u.ID == user {
+			return &u, nil
+		}
+	}
+	return nil, slack.SlackErrorResponse{Err: "user_not_found"}
+}
+
+// Parses the blocks of the message, sent either as blocks or in a colored attachment.
+func parseMessageBlocks(values url.Values) (BlocksWrapper, string, error) {
+	if blocks, ok := values["blocks"]; ok && len(blocks) > 0 {
+		parsedBlocks, err := ParseBlocks([]byte(blocks[0]))
+		return parsedBlocks, "", err
+	}
+	if attachments, ok := values["attachments"]; ok && len(attachments) > 0 {
+		var parsedAttachments []struct {
+			Color  string  `json:"color"`
+			Blocks []Block `json:"blocks"`
+		}
+		if err := json.Unmarshal([]byte(attachments[0]), &parsedAttachments); err != nil || len(parsedAttachments) == 0 {
+			return BlocksWrapper{}, "", err
+		}
+		return BlocksWrapper{Blocks: parsedAttachments[0].Blocks}, parsedAttachments[0].Color, nil
+	}
+	return BlocksWrapper{}, "", nil
+}
+
 type SlackChannel struct {
 	ID   string
 	Name string
@@ -179,6 +274,7 @@ type SentMessage struct {
 	ChannelID string
 	Blocks    BlocksWrapper
 	Text      string
+	Color     string // of the attachment in which the blocks were sent (if any)
 }
 
 type UpdatedMessage struct {
@@ -186,6 +282,14 @@ type UpdatedMessage struct {
 	Timestamp string
 	Blocks    BlocksWrapper
 	Text      string
+	Color     string // of the attachment in which the blocks were sent (if any)
+}
+
+type SentReply struct {
+	ChannelID string
+	ThreadTS  string
+	Text      string
+	Blocks    BlocksWrapper // empty for plain text replies
 }
 
 type DeletedMessage struct {
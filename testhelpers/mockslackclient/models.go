@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/hellej/pr-slack-reminder-action/pkg/blockids"
 )
 
 // A data model for Blocks that were sent to Slack API.
@@ -14,6 +16,7 @@ type BlocksWrapper struct {
 
 type PRList struct {
 	Heading     string
+	Repository  string // path of the repository if grouped by repository
 	PRListItems []string
 }
 
@@ -41,6 +44,7 @@ func (b BlocksWrapper) GetPRLists() []PRList {
 		var prList PRList
 		if currentHeading != "" && block.IsPRItem() {
 			prList.Heading = currentHeading
+			prList.Repository = blockids.GetRepositoryPath(block.BlockID)
 			var richTextLists []RichTextList // we're expecting an array of one
 			err := json.Unmarshal(block.Elements, &richTextLists)
 			if err != nil {
@@ -63,6 +67,9 @@ func (b BlocksWrapper) GetPRLists() []PRList {
 					if element.UserID != "" {
 						prText += element.UserID
 					}
+					if element.UsergroupID != "" {
+						prText += element.UsergroupID
+					}
 				}
 				prList.PRListItems = append(prList.PRListItems, prText)
 			}
@@ -75,6 +82,18 @@ func (b BlocksWrapper) GetPRLists() []PRList {
 	return prLists
 }
 
+// Returns the paths of the repositories of the PR lists in the order of the lists
+// (if grouped by repository).
+func (b BlocksWrapper) GetPRListRepositories() []string {
+	var repositories []string
+	for _, prList := range b.GetPRLists() {
+		if prList.Repository != "" {
+			repositories = append(repositories, prList.Repository)
+		}
+	}
+	return repositories
+}
+
 func (b BlocksWrapper) GetAllPRItemTexts() []string {
 	var allTexts []string
 	for _, item := range b.GetPRLists() {
@@ -114,6 +133,69 @@ func (b BlocksWrapper) GetPRCount() int {
 	return len(b.GetAllPRItemTexts())
 }
 
+// Returns the text of the rich text block with the ID, or an empty string if there is no such block.
+func (b BlocksWrapper) GetRichTextBlockText(blockID string) string {
+	for _, block := range b.Blocks {
+		if block.Type != "rich_text" || block.BlockID != blockID {
+			continue
+		}
+		var richTextSections []RichTextSection
+		if err := json.Unmarshal(block.Elements, &richTextSections); err != nil {
+			panic(fmt.Sprintf("Unexpected rich_text section array type: %v", err))
+		}
+		text := ""
+		for _, section := range richTextSections {
+			for _, element := range section.Elements {
+				text += element.Text
+			}
+		}
+		return text
+	}
+	return ""
+}
+
+// Returns the texts of the items of the list block (e.g. the lines of the repository digest).
+func (b BlocksWrapper) GetListItemTexts(blockID string) []string {
+	var texts []string
+	for _, block := range b.Blocks {
+		if block.Type != "rich_text" || block.BlockID != blockID {
+			continue
+		}
+		var richTextLists []RichTextList
+		if err := json.Unmarshal(block.Elements, &richTextLists); err != nil {
+			panic(fmt.Sprintf("Unexpected rich_text list array type: %v", err))
+		}
+		for _, list := range richTextLists {
+			for _, section := range list.Elements {
+				text := ""
+				for _, element := range section.Elements {
+					text += element.Text
+				}
+				texts = append(texts, text)
+			}
+		}
+	}
+	return texts
+}
+
+// Returns the texts of the context blocks (e.g. notes and links after the PR lists).
+func (b BlocksWrapper) GetContextTexts() []string {
+	var texts []string
+	for _, block := range b.Blocks {
+		if block.Type != "context" {
+			continue
+		}
+		var elements []TextObject
+		if err := json.Unmarshal(block.Elements, &elements); err != nil {
+			continue
+		}
+		for _, element := range elements {
+			texts = append(texts, element.Text)
+		}
+	}
+	return texts
+}
+
 type Block struct {
 	Type     string          `json:"type"`
 	Text     *TextObject     `json:"text,omitempty"`
@@ -122,11 +204,11 @@ type Block struct {
 }
 
 func (b Block) IsHeading() bool {
-	return b.Type == "rich_text" && (strings.HasPrefix(b.BlockID, "pr_list_heading"))
+	return b.Type == "rich_text" && blockids.IsHeading(b.BlockID)
 }
 
 func (b Block) IsPRItem() bool {
-	return strings.HasPrefix(b.BlockID, "open_prs")
+	return blockids.IsPRList(b.BlockID)
 }
 
 type TextObject struct {
@@ -150,11 +232,12 @@ type RichTextSection struct {
 }
 
 type Element struct {
-	Type   string        `json:"type"`
-	Text   string        `json:"text,omitempty"`
-	URL    string        `json:"url,omitempty"`
-	UserID string        `json:"user_id,omitempty"`
-	Style  *ElementStyle `json:"style,omitempty"`
+	Type        string        `json:"type"`
+	Text        string        `json:"text,omitempty"`
+	URL         string        `json:"url,omitempty"`
+	UserID      string        `json:"user_id,omitempty"`
+	UsergroupID string        `json:"usergroup_id,omitempty"`
+	Style       *ElementStyle `json:"style,omitempty"`
 }
 
 type ElementStyle struct {